@@ -0,0 +1,161 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// promptStore is a fake AI service holding prompts that can be archived
+type promptStore struct {
+	mu      sync.Mutex
+	prompts []Prompt
+	patches []map[string]interface{}
+}
+
+func (s *promptStore) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+
+	if r.URL.Path == "/prompts" {
+		includeArchived := r.URL.Query().Get("includeArchived") == "true"
+		resp := PromptsResponse{Prompts: []Prompt{}}
+		for _, p := range s.prompts {
+			if includeArchived || !p.Archived {
+				resp.Prompts = append(resp.Prompts, p)
+			}
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+		return
+	}
+
+	id := strings.TrimPrefix(r.URL.Path, "/prompts/")
+	for i := range s.prompts {
+		if s.prompts[i].ID != id {
+			continue
+		}
+		if r.Method == http.MethodPatch {
+			var patch map[string]interface{}
+			_ = json.NewDecoder(r.Body).Decode(&patch)
+			s.patches = append(s.patches, patch)
+			s.prompts[i].Archived = patch["archived"] == true
+		}
+		_ = json.NewEncoder(w).Encode(PromptResponse{Prompt: s.prompts[i]})
+		return
+	}
+	w.WriteHeader(http.StatusNotFound)
+	_, _ = w.Write([]byte(`{"error":"not_found","error_description":"Prompt not found"}`))
+}
+
+// promptIDs returns the IDs of prompts, in order
+func promptIDs(prompts []Prompt) string {
+	ids := make([]string, len(prompts))
+	for i, p := range prompts {
+		ids[i] = p.ID
+	}
+	return strings.Join(ids, ",")
+}
+
+func TestClient_ArchivePrompt(t *testing.T) {
+	store := &promptStore{prompts: []Prompt{{ID: "p1"}, {ID: "p2"}}}
+	server := httptest.NewServer(store)
+	defer server.Close()
+
+	client, _ := NewClient(server.URL)
+	ctx := context.Background()
+
+	if err := client.ArchivePrompt(ctx, "p1"); err != nil {
+		t.Fatalf("ArchivePrompt() error = %v", err)
+	}
+	if len(store.patches) != 1 || store.patches[0]["archived"] != true || len(store.patches[0]) != 1 {
+		t.Errorf("ArchivePrompt() sent %v, want {archived: true}", store.patches)
+	}
+
+	prompts, _, err := client.ListPrompts(ctx, nil)
+	if err != nil {
+		t.Fatalf("ListPrompts() error = %v", err)
+	}
+	if got := promptIDs(prompts); got != "p2" {
+		t.Errorf("ListPrompts() = %s, want p2", got)
+	}
+
+	prompts, _, err = client.ListPrompts(ctx, &ListPromptsOptions{IncludeArchived: true})
+	if err != nil {
+		t.Fatalf("ListPrompts() error = %v", err)
+	}
+	if got := promptIDs(prompts); got != "p1,p2" {
+		t.Errorf("ListPrompts() with IncludeArchived = %s, want p1,p2", got)
+	}
+	if !prompts[0].Archived || prompts[1].Archived {
+		t.Errorf("ListPrompts() Archived = %v, %v, want true, false", prompts[0].Archived, prompts[1].Archived)
+	}
+
+	// An archived prompt can still be retrieved directly
+	prompt, err := client.GetPrompt(ctx, "p1")
+	if err != nil {
+		t.Fatalf("GetPrompt() error = %v", err)
+	}
+	if !prompt.Archived {
+		t.Error("GetPrompt() Archived = false, want true")
+	}
+}
+
+func TestClient_UnarchivePrompt(t *testing.T) {
+	store := &promptStore{prompts: []Prompt{{ID: "p1", Archived: true}, {ID: "p2"}}}
+	server := httptest.NewServer(store)
+	defer server.Close()
+
+	client, _ := NewClient(server.URL)
+	ctx := context.Background()
+
+	if err := client.UnarchivePrompt(ctx, "p1"); err != nil {
+		t.Fatalf("UnarchivePrompt() error = %v", err)
+	}
+	// false must be sent, not omitted
+	if archived, ok := store.patches[0]["archived"]; !ok || archived != false {
+		t.Errorf("UnarchivePrompt() sent %v, want {archived: false}", store.patches[0])
+	}
+
+	prompts, _, err := client.ListPrompts(ctx, nil)
+	if err != nil {
+		t.Fatalf("ListPrompts() error = %v", err)
+	}
+	if got := promptIDs(prompts); got != "p1,p2" {
+		t.Errorf("ListPrompts() = %s, want p1,p2", got)
+	}
+}
+
+func TestClient_ArchivePrompt_NotFound(t *testing.T) {
+	server := httptest.NewServer(&promptStore{})
+	defer server.Close()
+
+	client, _ := NewClient(server.URL)
+	err := client.ArchivePrompt(context.Background(), "missing")
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("ArchivePrompt() error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestClient_ListPrompts_DropsArchivedByDefault(t *testing.T) {
+	// A service that ignores includeArchived and returns every prompt
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(PromptsResponse{Prompts: []Prompt{{ID: "p1", Archived: true}, {ID: "p2"}}})
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(server.URL)
+	prompts, _, err := client.ListPrompts(context.Background(), &ListPromptsOptions{Tags: []string{"a"}})
+	if err != nil {
+		t.Fatalf("ListPrompts() error = %v", err)
+	}
+	if got := promptIDs(prompts); got != "p2" {
+		t.Errorf("ListPrompts() = %s, want p2", got)
+	}
+}
@@ -0,0 +1,94 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// mockTokenProvider returns a fixed token or error
+type mockTokenProvider struct {
+	token string
+	err   error
+}
+
+func (m *mockTokenProvider) GetToken(ctx context.Context) (string, error) {
+	return m.token, m.err
+}
+
+func TestWithTokenProvider(t *testing.T) {
+	var authHeaders []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authHeaders = append(authHeaders, r.Header.Get("Authorization"))
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusCreated)
+		}
+		_ = json.NewEncoder(w).Encode(PromptResponse{Prompt: Prompt{ID: "prompt-123", Name: "Test Prompt"}})
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithOptions(server.URL, WithTokenProvider(&mockTokenProvider{token: "test-token"}))
+	if err != nil {
+		t.Fatalf("NewClientWithOptions() error = %v", err)
+	}
+
+	if _, err := client.CreatePrompt(context.Background(), &CreatePromptRequest{Name: "Test Prompt", Template: "Hi"}); err != nil {
+		t.Fatalf("CreatePrompt() error = %v", err)
+	}
+	if _, err := client.GetPrompt(context.Background(), "prompt-123"); err != nil {
+		t.Fatalf("GetPrompt() error = %v", err)
+	}
+
+	if len(authHeaders) != 2 {
+		t.Fatalf("server received %d requests, want 2", len(authHeaders))
+	}
+	for i, got := range authHeaders {
+		if got != "Bearer test-token" {
+			t.Errorf("request %d Authorization = %q, want %q", i+1, got, "Bearer test-token")
+		}
+	}
+}
+
+func TestWithTokenProvider_Error(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+	}))
+	defer server.Close()
+
+	providerErr := errors.New("token service unavailable")
+	client, err := NewClientWithOptions(server.URL, WithTokenProvider(&mockTokenProvider{err: providerErr}))
+	if err != nil {
+		t.Fatalf("NewClientWithOptions() error = %v", err)
+	}
+
+	if _, err := client.CreatePrompt(context.Background(), &CreatePromptRequest{Name: "Test Prompt", Template: "Hi"}); !errors.Is(err, providerErr) {
+		t.Errorf("CreatePrompt() error = %v, want it to wrap %v", err, providerErr)
+	}
+	if _, err := client.GetPrompt(context.Background(), "prompt-123"); !errors.Is(err, providerErr) {
+		t.Errorf("GetPrompt() error = %v, want it to wrap %v", err, providerErr)
+	}
+	if requests != 0 {
+		t.Errorf("server received %d requests, want none", requests)
+	}
+}
+
+func TestWithoutTokenProvider(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "" {
+			t.Errorf("Authorization = %q, want none without a token provider", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(PromptResponse{Prompt: Prompt{ID: "prompt-123"}})
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(server.URL)
+	if _, err := client.GetPrompt(context.Background(), "prompt-123"); err != nil {
+		t.Fatalf("GetPrompt() error = %v", err)
+	}
+}
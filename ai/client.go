@@ -3,14 +3,16 @@
 package ai
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
 	"fmt"
-	"io"
 	"net/http"
 	"net/url"
+	"slices"
 	"strconv"
+	"sync"
 	"time"
 
 	"github.com/atriumn/atriumn-sdk-go/internal/clientutil"
@@ -20,10 +22,31 @@ const (
 	// DefaultTimeout is the default timeout for API requests
 	DefaultTimeout = 10 * time.Second
 
-	// DefaultUserAgent is the user agent sent in requests
-	DefaultUserAgent = "atriumn-ai-client/1.0"
+	// DefaultBatchConcurrency is the default number of requests CreatePrompts runs in parallel
+	DefaultBatchConcurrency = 4
+
+	// DefaultListPromptsMaxResults is the page size ListPrompts requests when
+	// ListPromptsOptions.MaxResults is not set
+	DefaultListPromptsMaxResults = 50
+
+	// MaxListPromptsMaxResults is the largest page size ListPrompts accepts
+	MaxListPromptsMaxResults = 500
 )
 
+// DefaultUserAgent is the user agent sent in requests, naming the client and the SDK version
+var DefaultUserAgent = "atriumn-ai-client/1.0" + " " + clientutil.VersionToken
+
+// TokenProvider defines an interface for retrieving authentication tokens.
+// Implementations should retrieve and return valid bearer tokens for the Atriumn API.
+type TokenProvider interface {
+	GetToken(ctx context.Context) (string, error) // Returns the Bearer token string
+}
+
+// TokenInvalidator is optionally implemented by a TokenProvider that caches
+// tokens. With WithRefreshOn401, the client calls Invalidate when the service
+// rejects a token, so the next GetToken call returns a fresh one.
+type TokenInvalidator = clientutil.TokenInvalidator
+
 // Client is the main API client for Atriumn AI Service.
 // It handles communication with the API endpoints for prompt management.
 type Client struct {
@@ -35,27 +58,40 @@ type Client struct {
 
 	// UserAgent is the user agent sent with each request
 	UserAgent string
+
+	// base holds the token provider and the other shared request settings;
+	// baseClient combines it with the fields above to build and send requests
+	base clientutil.BaseClient
+
+	// clientSideValidation enables request validation before sending
+	clientSideValidation bool
+
+	// batchConcurrency limits the parallel requests issued by batch methods
+	batchConcurrency int
 }
 
 // NewClient creates a new Atriumn AI API client with the specified base URL.
-// It returns an error if the provided URL cannot be parsed.
+// It returns an error if the provided URL cannot be parsed or lacks a scheme or host.
 //
 // Parameters:
 //   - baseURL: The base URL for the Atriumn AI API (required)
 //
 // Returns:
 //   - *Client: A configured AI client instance
-//   - error: An error if the URL cannot be parsed
+//   - error: An error if the URL cannot be parsed or is not absolute
 func NewClient(baseURL string) (*Client, error) {
-	parsedURL, err := url.Parse(baseURL)
+	parsedURL, err := clientutil.ParseBaseURL(baseURL)
 	if err != nil {
-		return nil, fmt.Errorf("invalid base URL: %w", err)
+		return nil, err
 	}
 
 	return &Client{
 		BaseURL:    parsedURL,
 		HTTPClient: &http.Client{Timeout: DefaultTimeout},
 		UserAgent:  DefaultUserAgent,
+		base:       clientutil.BaseClient{Service: "ai", Clock: clientutil.SystemClock},
+
+		batchConcurrency: DefaultBatchConcurrency,
 	}, nil
 }
 
@@ -63,6 +99,13 @@ func NewClient(baseURL string) (*Client, error) {
 // It is used with NewClientWithOptions to customize the client behavior.
 type ClientOption func(*Client)
 
+// shared adapts an option defined once in clientutil for all service clients
+func shared(opt clientutil.Option) ClientOption {
+	return func(c *Client) {
+		opt(&c.base)
+	}
+}
+
 // WithHTTPClient sets the HTTP client for the API client.
 // This can be used to customize timeouts, transport settings, or to inject
 // middleware/interceptors for testing or monitoring.
@@ -92,6 +135,250 @@ func WithUserAgent(userAgent string) ClientOption {
 	}
 }
 
+// WithUserAgentSuffix appends " suffix" to the user agent, keeping the default
+// client and SDK version tokens so requests remain identifiable. It extends the
+// user agent configured so far, so apply it after WithUserAgent when using both.
+//
+// Parameters:
+//   - suffix: The product token to append, such as "my-app/2.3"
+//
+// Returns:
+//   - ClientOption: A functional option to configure the client
+func WithUserAgentSuffix(suffix string) ClientOption {
+	return func(c *Client) {
+		if suffix != "" {
+			c.UserAgent += " " + suffix
+		}
+	}
+}
+
+// WithTokenProvider sets the provider of the bearer tokens sent with each request.
+func WithTokenProvider(tp TokenProvider) ClientOption {
+	return shared(clientutil.SetTokenProvider(tp))
+}
+
+// WithRefreshOn401 retries a request once with a fresh token when the service
+// rejects its token as unauthorized, calling Invalidate first if the
+// TokenProvider implements TokenInvalidator. A second 401 is returned as is.
+func WithRefreshOn401() ClientOption {
+	return shared(clientutil.SetRefreshOn401())
+}
+
+// WithClientSideValidation makes CreatePrompt call CreatePromptRequest.Validate
+// and return its error without contacting the API when the request's variables
+// do not match its template.
+//
+// Returns:
+//   - ClientOption: A functional option to configure the client
+func WithClientSideValidation() ClientOption {
+	return func(c *Client) {
+		c.clientSideValidation = true
+	}
+}
+
+// WithBatchConcurrency sets the maximum number of requests that batch methods
+// such as CreatePrompts send in parallel. Values below 1 are treated as 1.
+//
+// Parameters:
+//   - n: The maximum number of concurrent requests
+//
+// Returns:
+//   - ClientOption: A functional option to configure the client
+func WithBatchConcurrency(n int) ClientOption {
+	return func(c *Client) {
+		if n < 1 {
+			n = 1
+		}
+		c.batchConcurrency = n
+	}
+}
+
+// WithMaxResponseBytes limits how many bytes of an API response body the
+// client reads. Larger responses fail with a "response_too_large" error. The
+// default is 10 MiB; a value of zero or less restores it.
+func WithMaxResponseBytes(n int64) ClientOption {
+	return shared(clientutil.SetMaxResponseBytes(n))
+}
+
+// WithRateLimit throttles the client to rps requests per second on average,
+// allowing bursts of up to burst requests; calls wait for their turn or their
+// context. A rate of zero or less removes the limit.
+func WithRateLimit(rps float64, burst int) ClientOption {
+	return shared(clientutil.SetRateLimit(rps, burst))
+}
+
+// ConcurrencyOption configures WithMaxConcurrentRequests.
+type ConcurrencyOption = clientutil.ConcurrencyOption
+
+// FailFast makes calls made while WithMaxConcurrentRequests has no free slot
+// return ErrTooManyConcurrentRequests at once, instead of waiting for a slot.
+//
+// Returns:
+//   - ConcurrencyOption: An option for WithMaxConcurrentRequests
+func FailFast() ConcurrencyOption {
+	return clientutil.FailFast()
+}
+
+// WithMaxConcurrentRequests allows at most n API calls of the client to be in
+// flight at once; further calls wait for a slot or their context, unless
+// FailFast is given. An n of zero or less removes the limit.
+func WithMaxConcurrentRequests(n int, opts ...ConcurrencyOption) ClientOption {
+	return shared(clientutil.SetMaxConcurrentRequests(n, opts...))
+}
+
+// WithResponseCache caches up to size GET responses that carry an ETag and
+// revalidates them with If-None-Match. A size of zero or less disables the cache.
+func WithResponseCache(size int) ClientOption {
+	return shared(clientutil.SetResponseCache(size))
+}
+
+// WithConnectionPool tunes the connection pool of the client's default HTTP
+// client. Like the TLS options and WithForceHTTP1, it never changes a client
+// passed to WithHTTPClient; zero maxIdle, maxConnsPerHost, or idleTimeout
+// means no limit.
+func WithConnectionPool(maxIdle, maxIdlePerHost, maxConnsPerHost int, idleTimeout time.Duration) ClientOption {
+	return shared(clientutil.SetConnectionPool(maxIdle, maxIdlePerHost, maxConnsPerHost, idleTimeout))
+}
+
+// WithTLSConfig sets the TLS configuration of the client's default HTTP
+// client, for example to present a client certificate. A config with
+// InsecureSkipVerify set is rejected unless WithInsecureSkipVerify is also given.
+func WithTLSConfig(config *tls.Config) ClientOption {
+	return shared(clientutil.SetTLSConfig(config))
+}
+
+// WithRootCAs makes the client's default HTTP client trust the certificates
+// in pool instead of the system roots, overriding the RootCAs of WithTLSConfig.
+func WithRootCAs(pool *x509.CertPool) ClientOption {
+	return shared(clientutil.SetRootCAs(pool))
+}
+
+// WithInsecureSkipVerify disables TLS certificate verification on the client's
+// default HTTP client. It is only fit for local testing; use WithRootCAs to
+// trust a private certificate authority instead.
+func WithInsecureSkipVerify() ClientOption {
+	return shared(clientutil.SetInsecureSkipVerify())
+}
+
+// WithForceHTTP1 makes the client's default HTTP client use only HTTP/1.1,
+// for proxies or middleboxes where HTTP/2 negotiation fails.
+func WithForceHTTP1() ClientOption {
+	return shared(clientutil.SetForceHTTP1())
+}
+
+// MetricsRecorder receives the outcome and latency of every API call, for
+// export to a metrics system such as Prometheus. Implementations must be safe
+// for concurrent use.
+type MetricsRecorder = clientutil.MetricsRecorder
+
+// WithMetricsRecorder reports every API call made by the client to recorder,
+// labeled with the service "ai" and an operation such as "ai.CreatePrompt".
+func WithMetricsRecorder(recorder MetricsRecorder) ClientOption {
+	return shared(clientutil.SetMetricsRecorder(recorder))
+}
+
+// Stats summarizes the API calls made by a client: how many were made, how
+// many failed, and their latency percentiles.
+type Stats = clientutil.Stats
+
+// WithStatsCollection makes the client count its API calls and sample their
+// latencies, so Stats can report them without a metrics system.
+func WithStatsCollection() ClientOption {
+	return shared(clientutil.SetStatsCollection())
+}
+
+// DefaultStatsReservoirSize is the number of call latencies WithStatsCollection
+// samples to estimate percentiles from.
+const DefaultStatsReservoirSize = clientutil.DefaultStatsReservoirSize
+
+// Stats returns the number of API calls made by the client, how many of them
+// returned an error, and their p50, p95, and p99 latencies. Without
+// WithStatsCollection it returns zero Stats.
+func (c *Client) Stats() Stats {
+	return c.base.Stats.Stats()
+}
+
+// Close closes the idle connections of HTTPClient's transport, unless it is
+// the shared http.DefaultTransport. The client remains usable afterwards.
+func (c *Client) Close() error {
+	return c.baseClient().Close()
+}
+
+// Logger receives diagnostic messages from the client, such as warnings that
+// an endpoint is deprecated. *log.Logger satisfies it.
+type Logger = clientutil.Logger
+
+// DeprecationNotice describes a response whose Deprecation or Sunset header
+// announced that its endpoint is going away.
+type DeprecationNotice = clientutil.DeprecationNotice
+
+// WithLogger sets the logger that receives the client's diagnostic messages,
+// such as a warning the first time an endpoint is reported deprecated. The
+// client logs nothing by default.
+func WithLogger(logger Logger) ClientOption {
+	return shared(clientutil.SetLogger(logger))
+}
+
+// WithRequestBodyLogging logs the JSON body of every POST, PUT, and PATCH
+// request to the WithLogger logger, or to the standard library's default
+// logger. Passwords, client secrets, and tokens are replaced with "***".
+func WithRequestBodyLogging() ClientOption {
+	return shared(clientutil.SetRequestBodyLogging())
+}
+
+// WithDeprecationCallback calls fn, once per endpoint per process, when a
+// response carries a Deprecation or Sunset header. fn must be safe for concurrent use.
+func WithDeprecationCallback(fn func(DeprecationNotice)) ClientOption {
+	return shared(clientutil.SetDeprecationCallback(fn))
+}
+
+// Clock tells the time and waits for durations to pass. Supplying a fake
+// implementation with WithClock makes time-dependent behavior testable
+// without real sleeps.
+type Clock = clientutil.Clock
+
+// WithClock replaces the system clock used for rate limiting, retries, and
+// measuring latencies. A nil clock restores the system clock.
+func WithClock(clock Clock) ClientOption {
+	return shared(clientutil.SetClock(clock))
+}
+
+// RetryPolicy configures how the client retries requests that fail
+// transiently. By default, transport errors and 429, 502, 503, and 504
+// responses are retried for idempotent requests only: those with a GET, HEAD,
+// OPTIONS, PUT, or DELETE method, or that carry an Idempotency-Key header.
+// Retryable adds retries on top of those rules.
+type RetryPolicy = clientutil.RetryPolicy
+
+// RetryPredicate reports whether a request should be retried given the response
+// of an attempt, or the error it failed with. The response body can be read in
+// full without affecting the response returned to the caller.
+type RetryPredicate = clientutil.RetryPredicate
+
+// Backoff computes the growing delays between retries
+type Backoff = clientutil.Backoff
+
+// WithRetryPolicy retries requests that fail transiently under policy,
+// waiting for the backoff delay or Retry-After between attempts. Requests
+// whose body cannot be replayed are sent once.
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return shared(clientutil.SetRetryPolicy(policy))
+}
+
+// WithRawErrorBody attaches the full response body to the RawBody field of
+// returned API errors. It is off by default because error bodies may contain
+// sensitive data.
+func WithRawErrorBody() ClientOption {
+	return shared(clientutil.SetRawErrorBody())
+}
+
+// WithRequestTimeout bounds each API call with a per-call context deadline
+// instead of the HTTP client's overall Timeout, which is cleared on a copy of
+// the HTTP client. A shorter deadline on the caller's context still applies.
+func WithRequestTimeout(d time.Duration) ClientOption {
+	return shared(clientutil.SetRequestTimeout(d))
+}
+
 // NewClientWithOptions creates a new client with custom options.
 // It allows for flexible configuration of the client through functional options.
 //
@@ -108,43 +395,59 @@ func NewClientWithOptions(baseURL string, options ...ClientOption) (*Client, err
 		return nil, err
 	}
 
+	defaultHTTPClient := client.HTTPClient
 	for _, option := range options {
 		option(client)
 	}
 
+	client.HTTPClient, _, err = client.base.Configure(client.HTTPClient, defaultHTTPClient)
+	if err != nil {
+		return nil, err
+	}
+
 	return client, nil
 }
 
-// newRequest creates an API request with the specified method, path, and body
-func (c *Client) newRequest(ctx context.Context, method, path string, body interface{}) (*http.Request, error) {
-	u := c.BaseURL.JoinPath(path)
+// baseClient returns the BaseClient that builds and sends c's requests, with
+// c's current BaseURL, HTTPClient, and UserAgent
+func (c *Client) baseClient() *clientutil.BaseClient {
+	b := c.base
+	b.BaseURL = c.BaseURL
+	b.HTTPClient = c.HTTPClient
+	b.UserAgent = c.UserAgent
+	return &b
+}
 
-	var buf io.ReadWriter
-	if body != nil {
-		buf = new(bytes.Buffer)
-		err := json.NewEncoder(buf).Encode(body)
-		if err != nil {
-			return nil, err
-		}
-	}
+// do sends an API request for the named client method and returns the API response
+func (c *Client) do(operation string, req *http.Request, v interface{}) (*http.Response, error) {
+	b := c.baseClient()
+	return b.Do(req, v, b.RequestOptions(operation)...)
+}
 
-	req, err := http.NewRequestWithContext(ctx, method, u.String(), buf)
+// Health checks the health status of the AI API.
+//
+// Parameters:
+//   - ctx: Context for the API request
+//
+// Returns:
+//   - *HealthResponse: The service health status, typically containing a "status" field
+//   - error: An error if the operation fails, which can be:
+//   - apierror.ErrorResponse with codes like:
+//   - "network_error" if the connection fails
+//   - "server_error" if the API server experiences an error
+func (c *Client) Health(ctx context.Context) (*HealthResponse, error) {
+	req, err := c.baseClient().NewRequest(ctx, http.MethodGet, "/health", nil)
 	if err != nil {
 		return nil, err
 	}
 
-	if body != nil {
-		req.Header.Set("Content-Type", "application/json")
+	var resp HealthResponse
+	_, err = c.do("Health", req, &resp)
+	if err != nil {
+		return nil, err
 	}
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("User-Agent", c.UserAgent)
 
-	return req, nil
-}
-
-// do sends an API request and returns the API response
-func (c *Client) do(req *http.Request, v interface{}) (*http.Response, error) {
-	return clientutil.ExecuteRequest(req.Context(), c.HTTPClient, req, v)
+	return &resp, nil
 }
 
 // CreatePrompt creates a new prompt in the Atriumn AI system.
@@ -152,18 +455,27 @@ func (c *Client) do(req *http.Request, v interface{}) (*http.Response, error) {
 // Parameters:
 //   - ctx: Context for the API request
 //   - request: CreatePromptRequest containing prompt details
+//   - opts: Optional call settings, such as WithDryRun
 //
 // Returns:
-//   - *Prompt: The created prompt
-//   - error: An error if the operation fails
-func (c *Client) CreatePrompt(ctx context.Context, request *CreatePromptRequest) (*Prompt, error) {
-	req, err := c.newRequest(ctx, http.MethodPost, "/prompts", request)
+//   - *Prompt: The created prompt, or the prompt that would be created for a dry run
+//   - error: An error if the operation fails, or a validation error if the
+//     client was created with WithClientSideValidation and the request is invalid
+func (c *Client) CreatePrompt(ctx context.Context, request *CreatePromptRequest, opts ...CallOption) (*Prompt, error) {
+	if c.clientSideValidation {
+		if err := request.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid prompt request: %w", err)
+		}
+	}
+
+	req, err := c.baseClient().NewRequest(ctx, http.MethodPost, "/prompts", request)
 	if err != nil {
 		return nil, err
 	}
+	applyCallOptions(req, opts)
 
 	var resp PromptResponse
-	_, err = c.do(req, &resp)
+	_, err = c.do("CreatePrompt", req, &resp)
 	if err != nil {
 		return nil, err
 	}
@@ -171,6 +483,94 @@ func (c *Client) CreatePrompt(ctx context.Context, request *CreatePromptRequest)
 	return &resp.Prompt, nil
 }
 
+// CreateAndRender creates a prompt and renders its template with values, for
+// running a new prompt once it is stored. The values are checked against the
+// request before the prompt is created, so a missing required variable does
+// not leave a prompt behind.
+//
+// Parameters:
+//   - ctx: Context for the API request
+//   - request: CreatePromptRequest containing prompt details
+//   - values: Variable values keyed by variable name
+//
+// Returns:
+//   - *Prompt: The created prompt
+//   - string: The created prompt's template rendered with values
+//   - error: An error if values do not satisfy the request's variables, if the
+//     create fails, or if the created prompt cannot be rendered, in which case
+//     the created prompt is still returned
+func (c *Client) CreateAndRender(ctx context.Context, request *CreatePromptRequest, values map[string]string) (*Prompt, string, error) {
+	if request == nil {
+		return nil, "", errors.New("request is required")
+	}
+	if _, err := RenderTemplate(request.Template, request.Variables, values); err != nil {
+		return nil, "", err
+	}
+
+	prompt, err := c.CreatePrompt(ctx, request)
+	if err != nil {
+		return nil, "", err
+	}
+
+	rendered, err := prompt.Render(values)
+	if err != nil {
+		return prompt, "", err
+	}
+	return prompt, rendered, nil
+}
+
+// CreatePrompts creates several prompts, sending at most the client's batch
+// concurrency (see WithBatchConcurrency) requests at a time. A failed create
+// does not stop the others. Requests not yet started when ctx is canceled
+// fail with the context's error.
+//
+// Parameters:
+//   - ctx: Context for the API requests
+//   - requests: The prompts to create
+//
+// Returns:
+//   - []*Prompt: The created prompts, aligned by index with requests; nil where the create failed
+//   - error: nil if every create succeeded, otherwise a *MultiError whose item
+//     errors carry the index of each failed request
+func (c *Client) CreatePrompts(ctx context.Context, requests []*CreatePromptRequest) ([]*Prompt, error) {
+	prompts := make([]*Prompt, len(requests))
+	errs := make([]error, len(requests))
+
+	concurrency := c.batchConcurrency
+	if concurrency < 1 {
+		concurrency = DefaultBatchConcurrency
+	}
+	sem := make(chan struct{}, concurrency)
+
+	var wg sync.WaitGroup
+	for i, request := range requests {
+		if err := ctx.Err(); err != nil {
+			errs[i] = err
+			continue
+		}
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			errs[i] = ctx.Err()
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int, request *CreatePromptRequest) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			prompts[i], errs[i] = c.CreatePrompt(ctx, request)
+		}(i, request)
+	}
+	wg.Wait()
+
+	multi := &clientutil.MultiError{Total: len(requests)}
+	for i, err := range errs {
+		multi.Add(i, "", err)
+	}
+	return prompts, multi.ErrorOrNil()
+}
+
 // GetPrompt retrieves a prompt by its ID.
 //
 // Parameters:
@@ -182,13 +582,39 @@ func (c *Client) CreatePrompt(ctx context.Context, request *CreatePromptRequest)
 //   - error: An error if the operation fails
 func (c *Client) GetPrompt(ctx context.Context, promptID string) (*Prompt, error) {
 	path := fmt.Sprintf("/prompts/%s", promptID)
-	req, err := c.newRequest(ctx, http.MethodGet, path, nil)
+	req, err := c.baseClient().NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp PromptResponse
+	_, err = c.do("GetPrompt", req, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp.Prompt, nil
+}
+
+// GetPromptVersion retrieves a specific historical version of a prompt.
+//
+// Parameters:
+//   - ctx: Context for the API request
+//   - promptID: ID of the prompt to retrieve
+//   - version: The version number to retrieve
+//
+// Returns:
+//   - *Prompt: The prompt as it was at the requested version
+//   - error: An error if the operation fails, such as "not_found" if the prompt or version doesn't exist
+func (c *Client) GetPromptVersion(ctx context.Context, promptID string, version int64) (*Prompt, error) {
+	path := fmt.Sprintf("/prompts/%s/versions/%d", promptID, version)
+	req, err := c.baseClient().NewRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
 		return nil, err
 	}
 
 	var resp PromptResponse
-	_, err = c.do(req, &resp)
+	_, err = c.do("GetPromptVersion", req, &resp)
 	if err != nil {
 		return nil, err
 	}
@@ -196,33 +622,71 @@ func (c *Client) GetPrompt(ctx context.Context, promptID string) (*Prompt, error
 	return &resp.Prompt, nil
 }
 
-// UpdatePrompt updates an existing prompt.
+// ListPromptVersions retrieves the version history of a prompt.
+//
+// Parameters:
+//   - ctx: Context for the API request
+//   - promptID: ID of the prompt whose versions to list
+//
+// Returns:
+//   - []Prompt: Every stored version of the prompt, newest first
+//   - error: An error if the operation fails, such as "not_found" if the prompt doesn't exist
+func (c *Client) ListPromptVersions(ctx context.Context, promptID string) ([]Prompt, error) {
+	path := fmt.Sprintf("/prompts/%s/versions", promptID)
+	req, err := c.baseClient().NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp PromptsResponse
+	_, err = c.do("ListPromptVersions", req, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Prompts, nil
+}
+
+// UpdatePrompt updates an existing prompt. Without WithExpectedVersion the
+// update overwrites the stored prompt whatever its version.
 //
 // Parameters:
 //   - ctx: Context for the API request
 //   - promptID: ID of the prompt to update
 //   - request: UpdatePromptRequest containing the fields to update
+//   - opts: Optional call settings, such as WithExpectedVersion
 //
 // Returns:
 //   - *Prompt: The updated prompt
-//   - error: An error if the operation fails
-func (c *Client) UpdatePrompt(ctx context.Context, promptID string, request *UpdatePromptRequest) (*Prompt, error) {
+//   - error: An error if the operation fails, matching ErrConflict if the
+//     prompt is no longer at the expected version
+func (c *Client) UpdatePrompt(ctx context.Context, promptID string, request *UpdatePromptRequest, opts ...CallOption) (*Prompt, error) {
 	path := fmt.Sprintf("/prompts/%s", promptID)
-	req, err := c.newRequest(ctx, http.MethodPut, path, request)
+	req, err := c.baseClient().NewRequest(ctx, http.MethodPut, path, request)
 	if err != nil {
 		return nil, err
 	}
+	options := applyCallOptions(req, opts)
 
 	var resp PromptResponse
-	_, err = c.do(req, &resp)
+	_, err = c.do("UpdatePrompt", req, &resp)
 	if err != nil {
+		// The service answers a failed If-Match with 412; report it as the
+		// conflict it is
+		var apiErr *ErrorResponse
+		if options.expectedVersion != nil && errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusPreconditionFailed {
+			apiErr.ErrorCode = "conflict"
+			apiErr.Description = fmt.Sprintf("Prompt %s is no longer at version %d.", promptID, *options.expectedVersion)
+			apiErr.StatusCode = http.StatusConflict
+		}
 		return nil, err
 	}
 
 	return &resp.Prompt, nil
 }
 
-// DeletePrompt deletes a prompt by its ID.
+// DeletePrompt deletes a prompt by its ID. Deletion cannot be undone; use
+// ArchivePrompt to take a prompt out of use reversibly.
 //
 // Parameters:
 //   - ctx: Context for the API request
@@ -232,35 +696,129 @@ func (c *Client) UpdatePrompt(ctx context.Context, promptID string, request *Upd
 //   - error: An error if the operation fails
 func (c *Client) DeletePrompt(ctx context.Context, promptID string) error {
 	path := fmt.Sprintf("/prompts/%s", promptID)
-	req, err := c.newRequest(ctx, http.MethodDelete, path, nil)
+	req, err := c.baseClient().NewRequest(ctx, http.MethodDelete, path, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.do("DeletePrompt", req, nil)
+	return err
+}
+
+// archivePatch is the body sent by ArchivePrompt and UnarchivePrompt.
+type archivePatch struct {
+	Archived bool `json:"archived"`
+}
+
+// ArchivePrompt archives a prompt, a reversible alternative to DeletePrompt.
+// An archived prompt keeps its ID, versions, and content and can still be
+// retrieved with GetPrompt, but ListPrompts leaves it out unless
+// IncludeArchived is set. Archiving an archived prompt has no effect.
+//
+// Parameters:
+//   - ctx: Context for the API request
+//   - promptID: ID of the prompt to archive
+//
+// Returns:
+//   - error: An error if the operation fails, matching ErrNotFound if the
+//     prompt does not exist
+func (c *Client) ArchivePrompt(ctx context.Context, promptID string) error {
+	return c.setPromptArchived(ctx, "ArchivePrompt", promptID, true)
+}
+
+// UnarchivePrompt restores a prompt archived with ArchivePrompt, so
+// ListPrompts includes it again. Unarchiving a prompt that is not archived
+// has no effect.
+//
+// Parameters:
+//   - ctx: Context for the API request
+//   - promptID: ID of the prompt to restore
+//
+// Returns:
+//   - error: An error if the operation fails, matching ErrNotFound if the
+//     prompt does not exist
+func (c *Client) UnarchivePrompt(ctx context.Context, promptID string) error {
+	return c.setPromptArchived(ctx, "UnarchivePrompt", promptID, false)
+}
+
+// setPromptArchived sets the archived flag of a prompt
+func (c *Client) setPromptArchived(ctx context.Context, operation, promptID string, archived bool) error {
+	path := fmt.Sprintf("/prompts/%s", promptID)
+	req, err := c.baseClient().NewRequest(ctx, http.MethodPatch, path, archivePatch{Archived: archived})
 	if err != nil {
 		return err
 	}
 
-	_, err = c.do(req, nil)
+	_, err = c.do(operation, req, nil)
 	return err
 }
 
+// DuplicatePrompt creates a copy of an existing prompt under a new name.
+// The copy carries the source's description, template, model, parameters,
+// variables, and tags; the server assigns it a new ID, version, and timestamps.
+// The source prompt is not modified.
+//
+// Parameters:
+//   - ctx: Context for the API requests
+//   - promptID: ID of the prompt to copy
+//   - newName: Name of the new prompt (required)
+//
+// Returns:
+//   - *Prompt: The newly created prompt
+//   - error: An error if the source prompt cannot be retrieved or the copy cannot be created
+func (c *Client) DuplicatePrompt(ctx context.Context, promptID, newName string) (*Prompt, error) {
+	source, err := c.GetPrompt(ctx, promptID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get source prompt %s: %w", promptID, err)
+	}
+
+	request := &CreatePromptRequest{
+		Name:        newName,
+		Description: source.Description,
+		Template:    source.Template,
+		ModelID:     source.ModelID,
+		Parameters:  copyParameters(source.Parameters),
+		Tags:        append([]string(nil), source.Tags...),
+	}
+	if source.Variables != nil {
+		request.Variables = append([]PromptVariable(nil), source.Variables...)
+	}
+
+	return c.CreatePrompt(ctx, request)
+}
+
 // ListPrompts retrieves a list of prompts with optional filtering and pagination.
 //
 // Parameters:
 //   - ctx: Context for the API request
-//   - options: Optional ListPromptsOptions for filtering and pagination
+//   - options: Optional ListPromptsOptions for filtering and pagination. Pages
+//     hold DefaultListPromptsMaxResults prompts unless MaxResults is set, and
+//     archived prompts are left out unless IncludeArchived is set
 //
 // Returns:
 //   - []Prompt: The list of prompts
 //   - string: The next token for pagination (empty if no more pages)
-//   - error: An error if the operation fails
+//   - error: An error if MaxResults exceeds MaxListPromptsMaxResults or the operation fails
 func (c *Client) ListPrompts(ctx context.Context, options *ListPromptsOptions) ([]Prompt, string, error) {
+	maxResults := DefaultListPromptsMaxResults
+	if options != nil && options.MaxResults > 0 {
+		if options.MaxResults > MaxListPromptsMaxResults {
+			return nil, "", fmt.Errorf("MaxResults must be between 1 and %d, got %d", MaxListPromptsMaxResults, options.MaxResults)
+		}
+		maxResults = options.MaxResults
+	}
+
 	// Create the request with base path
-	req, err := c.newRequest(ctx, http.MethodGet, "/prompts", nil)
+	req, err := c.baseClient().NewRequest(ctx, http.MethodGet, "/prompts", nil)
 	if err != nil {
 		return nil, "", err
 	}
 
+	q := req.URL.Query()
+	q.Set("maxResults", strconv.Itoa(maxResults))
+
 	// Add query parameters if options are provided
 	if options != nil {
-		q := req.URL.Query()
 
 		if options.ModelID != "" {
 			q.Set("modelId", options.ModelID)
@@ -272,23 +830,62 @@ func (c *Client) ListPrompts(ctx context.Context, options *ListPromptsOptions) (
 			}
 		}
 
-		if options.MaxResults > 0 {
-			q.Set("maxResults", strconv.Itoa(options.MaxResults))
+		if options.NameContains != "" {
+			q.Set("nameContains", options.NameContains)
 		}
 
 		if options.NextToken != "" {
 			q.Set("nextToken", options.NextToken)
 		}
 
-		// Set the updated query parameters
-		req.URL.RawQuery = q.Encode()
+		if options.IncludeArchived {
+			q.Set("includeArchived", "true")
+		}
 	}
 
+	// Set the updated query parameters
+	req.URL.RawQuery = q.Encode()
+
 	var resp PromptsResponse
-	_, err = c.do(req, &resp)
+	_, err = c.do("ListPrompts", req, &resp)
 	if err != nil {
 		return nil, "", err
 	}
 
+	// The service leaves archived prompts out unless asked for them; drop any
+	// it returns anyway so callers never see them by accident
+	if options == nil || !options.IncludeArchived {
+		resp.Prompts = slices.DeleteFunc(resp.Prompts, func(p Prompt) bool { return p.Archived })
+	}
+
 	return resp.Prompts, resp.NextToken, nil
 }
+
+// copyParameters returns a deep copy of prompt parameters, including any
+// nested maps and slices decoded from JSON.
+func copyParameters(params map[string]interface{}) map[string]interface{} {
+	if params == nil {
+		return nil
+	}
+	out := make(map[string]interface{}, len(params))
+	for k, v := range params {
+		out[k] = copyParameterValue(v)
+	}
+	return out
+}
+
+// copyParameterValue deep-copies a single JSON-decoded value.
+func copyParameterValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		return copyParameters(v)
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, item := range v {
+			out[i] = copyParameterValue(item)
+		}
+		return out
+	default:
+		return v
+	}
+}
@@ -2,10 +2,19 @@ package ai
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
+
+	"github.com/atriumn/atriumn-sdk-go/internal/apierror"
 )
 
 func TestNewClient(t *testing.T) {
@@ -21,6 +30,36 @@ func TestNewClient(t *testing.T) {
 	}
 }
 
+func TestNewClient_BaseURLValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		baseURL string
+		wantErr string
+	}{
+		{"missing scheme", "api.example.com", "invalid base URL: missing scheme"},
+		{"missing host", "https:///v1", "invalid base URL: missing host"},
+		{"path and trailing slash", "https://api.example.com/v1/", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := NewClient(tt.baseURL)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("NewClient(%q) returned unexpected error: %v", tt.baseURL, err)
+				}
+				if client.BaseURL.String() != tt.baseURL {
+					t.Errorf("NewClient(%q) BaseURL = %q", tt.baseURL, client.BaseURL.String())
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("NewClient(%q) error = %v, want %q", tt.baseURL, err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestNewClientWithOptions(t *testing.T) {
 	customHTTPClient := &http.Client{}
 	customUserAgent := "custom-user-agent"
@@ -106,6 +145,238 @@ func TestClient_CreatePrompt(t *testing.T) {
 	}
 }
 
+func TestClient_CreatePrompt_Conflict(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusConflict)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	_, err = client.CreatePrompt(context.Background(), &CreatePromptRequest{
+		Name:     "Test Prompt",
+		Template: "This is a test prompt",
+	})
+	if !errors.Is(err, ErrConflict) {
+		t.Fatalf("CreatePrompt() error = %v, want ErrConflict", err)
+	}
+
+	apiErr, ok := err.(*apierror.ErrorResponse)
+	if !ok {
+		t.Fatalf("CreatePrompt() error type = %T, want *apierror.ErrorResponse", err)
+	}
+	if apiErr.ErrorCode != "conflict" {
+		t.Errorf("CreatePrompt() ErrorCode = %v, want %v", apiErr.ErrorCode, "conflict")
+	}
+}
+
+func TestClient_CreatePrompt_DryRun(t *testing.T) {
+	var queries []url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.Query())
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"prompt": {"id": "", "name": "Test Prompt", "template": "Hello"}}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	request := &CreatePromptRequest{Name: "Test Prompt", Template: "Hello"}
+	prompt, err := client.CreatePrompt(context.Background(), request, WithDryRun())
+	if err != nil {
+		t.Fatalf("CreatePrompt() error = %v", err)
+	}
+	if prompt.Name != "Test Prompt" {
+		t.Errorf("CreatePrompt() prompt.Name = %v, want %v", prompt.Name, "Test Prompt")
+	}
+
+	if _, err := client.CreatePrompt(context.Background(), request); err != nil {
+		t.Fatalf("CreatePrompt() error = %v", err)
+	}
+	if _, err := client.GetPrompt(context.Background(), "prompt-123"); err != nil {
+		t.Fatalf("GetPrompt() error = %v", err)
+	}
+
+	if len(queries) != 3 {
+		t.Fatalf("server received %d requests, want 3", len(queries))
+	}
+	if got := queries[0].Get("dryRun"); got != "true" {
+		t.Errorf("dry-run CreatePrompt() dryRun = %q, want %q", got, "true")
+	}
+	for i, q := range queries[1:] {
+		if q.Has("dryRun") {
+			t.Errorf("request %d carried dryRun = %q, want none", i+2, q.Get("dryRun"))
+		}
+	}
+}
+
+func TestClient_CreatePrompt_ClientSideValidation(t *testing.T) {
+	called := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(PromptResponse{Prompt: Prompt{ID: "prompt-123"}})
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithOptions(server.URL, WithClientSideValidation())
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	_, err = client.CreatePrompt(context.Background(), &CreatePromptRequest{
+		Name:     "Test Prompt",
+		Template: "Hello {{name}}",
+	})
+	if err == nil {
+		t.Fatal("CreatePrompt() error = nil, want validation error")
+	}
+	if called {
+		t.Error("CreatePrompt() sent an invalid request to the server")
+	}
+
+	_, err = client.CreatePrompt(context.Background(), &CreatePromptRequest{
+		Name:      "Test Prompt",
+		Template:  "Hello {{name}}",
+		Variables: []PromptVariable{{Name: "name"}},
+	})
+	if err != nil {
+		t.Fatalf("CreatePrompt() error = %v", err)
+	}
+	if !called {
+		t.Error("CreatePrompt() did not send a valid request")
+	}
+}
+
+func TestClient_CreatePrompts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var requestBody CreatePromptRequest
+		_ = json.NewDecoder(r.Body).Decode(&requestBody)
+
+		if strings.HasPrefix(requestBody.Name, "bad") {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(PromptResponse{Prompt: Prompt{ID: "id-" + requestBody.Name, Name: requestBody.Name}})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	names := []string{"one", "bad-two", "three", "bad-four", "five"}
+	requests := make([]*CreatePromptRequest, len(names))
+	for i, name := range names {
+		requests[i] = &CreatePromptRequest{Name: name, Template: "t"}
+	}
+
+	prompts, err := client.CreatePrompts(context.Background(), requests)
+	if len(prompts) != len(names) {
+		t.Fatalf("CreatePrompts() returned %d prompts, want %d", len(prompts), len(names))
+	}
+	var multi *MultiError
+	if !errors.As(err, &multi) {
+		t.Fatalf("CreatePrompts() error = %v, want *MultiError", err)
+	}
+	if multi.Total != len(names) || len(multi.Errors) != 2 {
+		t.Fatalf("CreatePrompts() error = %v, want 2 of %d failed", err, len(names))
+	}
+	errs := make([]error, len(names))
+	for _, itemErr := range multi.Errors {
+		errs[itemErr.Index] = itemErr.Err
+	}
+
+	for i, name := range names {
+		if strings.HasPrefix(name, "bad") {
+			if errs[i] == nil || prompts[i] != nil {
+				t.Errorf("CreatePrompts()[%d] = %v, %v, want failure", i, prompts[i], errs[i])
+			}
+			continue
+		}
+		if errs[i] != nil {
+			t.Errorf("CreatePrompts()[%d] error = %v", i, errs[i])
+			continue
+		}
+		if prompts[i].ID != "id-"+name {
+			t.Errorf("CreatePrompts()[%d].ID = %v, want %v", i, prompts[i].ID, "id-"+name)
+		}
+	}
+}
+
+func TestClient_CreatePrompts_BoundedConcurrency(t *testing.T) {
+	var inFlight, maxInFlight int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			max := atomic.LoadInt32(&maxInFlight)
+			if n <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(PromptResponse{Prompt: Prompt{ID: "prompt"}})
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithOptions(server.URL, WithBatchConcurrency(2))
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	requests := make([]*CreatePromptRequest, 8)
+	for i := range requests {
+		requests[i] = &CreatePromptRequest{Name: "p", Template: "t"}
+	}
+
+	if _, err := client.CreatePrompts(context.Background(), requests); err != nil {
+		t.Errorf("CreatePrompts() error = %v", err)
+	}
+	if got := atomic.LoadInt32(&maxInFlight); got != 2 {
+		t.Errorf("CreatePrompts() max concurrent requests = %d, want 2", got)
+	}
+}
+
+func TestClient_CreatePrompts_Canceled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("CreatePrompts() sent a request after the context was canceled")
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	prompts, err := client.CreatePrompts(ctx, []*CreatePromptRequest{{Name: "a"}, {Name: "b"}})
+	var multi *MultiError
+	if !errors.As(err, &multi) || len(multi.Errors) != 2 {
+		t.Fatalf("CreatePrompts() error = %v, want an error for each request", err)
+	}
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("errors.Is(%v, context.Canceled) = false, want true", err)
+	}
+	for i := range prompts {
+		if prompts[i] != nil {
+			t.Errorf("CreatePrompts()[%d] = %v, want nil", i, prompts[i])
+		}
+	}
+}
+
 func TestClient_GetPrompt(t *testing.T) {
 	// Setup test server
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -152,6 +423,134 @@ func TestClient_GetPrompt(t *testing.T) {
 	}
 }
 
+func TestClient_GetPrompt_ResponseCache(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) > 1 {
+			if got := r.Header.Get("If-None-Match"); got != `"rev-1"` {
+				t.Errorf("GetPrompt() If-None-Match = %q, want %q", got, `"rev-1"`)
+			}
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		if got := r.Header.Get("If-None-Match"); got != "" {
+			t.Errorf("first GetPrompt() If-None-Match = %q, want none", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("ETag", `"rev-1"`)
+		_ = json.NewEncoder(w).Encode(PromptResponse{Prompt: Prompt{ID: "prompt-123", Name: "Cached Prompt", Version: 1}})
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithOptions(server.URL, WithResponseCache(16))
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		prompt, err := client.GetPrompt(context.Background(), "prompt-123")
+		if err != nil {
+			t.Fatalf("GetPrompt() call %d error = %v", i+1, err)
+		}
+		if prompt.ID != "prompt-123" || prompt.Name != "Cached Prompt" {
+			t.Errorf("GetPrompt() call %d = %+v, want the cached prompt", i+1, prompt)
+		}
+	}
+	if got := atomic.LoadInt32(&calls); got != 2 {
+		t.Errorf("server calls = %d, want 2", got)
+	}
+}
+
+func TestClient_GetPromptVersion(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("GetPromptVersion() method = %v, want %v", r.Method, http.MethodGet)
+		}
+
+		switch r.URL.Path {
+		case "/prompts/prompt-123/versions/2":
+			prompt := Prompt{
+				ID:       "prompt-123",
+				Name:     "Test Prompt",
+				Template: "Version two with {{variable}}",
+				Version:  2,
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(PromptResponse{Prompt: prompt})
+		default:
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":"not_found","error_description":"version not found"}`))
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	prompt, err := client.GetPromptVersion(context.Background(), "prompt-123", 2)
+	if err != nil {
+		t.Fatalf("GetPromptVersion() error = %v", err)
+	}
+	if prompt.Version != 2 {
+		t.Errorf("GetPromptVersion() prompt.Version = %v, want %v", prompt.Version, 2)
+	}
+	if prompt.Template != "Version two with {{variable}}" {
+		t.Errorf("GetPromptVersion() prompt.Template = %v", prompt.Template)
+	}
+
+	_, err = client.GetPromptVersion(context.Background(), "prompt-123", 9)
+	apiErr, ok := err.(*apierror.ErrorResponse)
+	if !ok {
+		t.Fatalf("GetPromptVersion() error = %v, want *apierror.ErrorResponse", err)
+	}
+	if apiErr.ErrorCode != "not_found" {
+		t.Errorf("GetPromptVersion() ErrorCode = %v, want %v", apiErr.ErrorCode, "not_found")
+	}
+}
+
+func TestClient_ListPromptVersions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/prompts/prompt-123/versions" {
+			t.Errorf("ListPromptVersions() path = %v, want %v", r.URL.Path, "/prompts/prompt-123/versions")
+		}
+		if r.Method != http.MethodGet {
+			t.Errorf("ListPromptVersions() method = %v, want %v", r.Method, http.MethodGet)
+		}
+
+		resp := PromptsResponse{
+			Prompts: []Prompt{
+				{ID: "prompt-123", Template: "v3", Version: 3},
+				{ID: "prompt-123", Template: "v2", Version: 2},
+				{ID: "prompt-123", Template: "v1", Version: 1},
+			},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	versions, err := client.ListPromptVersions(context.Background(), "prompt-123")
+	if err != nil {
+		t.Fatalf("ListPromptVersions() error = %v", err)
+	}
+	if len(versions) != 3 {
+		t.Fatalf("ListPromptVersions() returned %d versions, want 3", len(versions))
+	}
+	for i, want := range []int64{3, 2, 1} {
+		if versions[i].Version != want {
+			t.Errorf("ListPromptVersions()[%d].Version = %v, want %v", i, versions[i].Version, want)
+		}
+	}
+}
+
 func TestClient_UpdatePrompt(t *testing.T) {
 	// Setup test server
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -219,6 +618,57 @@ func TestClient_UpdatePrompt(t *testing.T) {
 	}
 }
 
+func TestClient_UpdatePrompt_ExpectedVersion(t *testing.T) {
+	var ifMatch []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ifMatch = append(ifMatch, r.Header.Get("If-Match"))
+		if r.Header.Get("If-Match") == `"1"` {
+			w.WriteHeader(http.StatusPreconditionFailed)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(PromptResponse{Prompt: Prompt{ID: "prompt-123", Version: 3}})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	name := "Updated Prompt"
+	request := &UpdatePromptRequest{Name: &name}
+
+	prompt, err := client.UpdatePrompt(context.Background(), "prompt-123", request, WithExpectedVersion(2))
+	if err != nil {
+		t.Fatalf("UpdatePrompt() error = %v", err)
+	}
+	if prompt.Version != 3 {
+		t.Errorf("UpdatePrompt() prompt.Version = %v, want %v", prompt.Version, 3)
+	}
+
+	// A stale version is rejected as a conflict
+	_, err = client.UpdatePrompt(context.Background(), "prompt-123", request, WithExpectedVersion(1))
+	if !errors.Is(err, ErrConflict) {
+		t.Fatalf("UpdatePrompt() error = %v, want ErrConflict", err)
+	}
+	var apiErr *ErrorResponse
+	if !errors.As(err, &apiErr) || apiErr.ErrorCode != "conflict" {
+		t.Errorf("UpdatePrompt() error = %v, want code %q", err, "conflict")
+	}
+
+	// Without the option the update is unconditional
+	if _, err := client.UpdatePrompt(context.Background(), "prompt-123", request); err != nil {
+		t.Fatalf("UpdatePrompt() error = %v", err)
+	}
+
+	want := []string{`"2"`, `"1"`, ""}
+	for i := range want {
+		if ifMatch[i] != want[i] {
+			t.Errorf("request %d If-Match = %q, want %q", i+1, ifMatch[i], want[i])
+		}
+	}
+}
+
 func TestClient_DeletePrompt(t *testing.T) {
 	// Setup test server
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -248,6 +698,134 @@ func TestClient_DeletePrompt(t *testing.T) {
 	}
 }
 
+func TestClient_DuplicatePrompt(t *testing.T) {
+	source := Prompt{
+		ID:         "prompt-123",
+		Name:       "Original",
+		Template:   "Hello {{name}}",
+		Parameters: map[string]interface{}{"temperature": 0.2},
+		Variables:  []PromptVariable{{Name: "name", Required: true}},
+		Tags:       []string{"greeting"},
+		Version:    7,
+		CreatedAt:  "2023-01-01T00:00:00Z",
+		UpdatedAt:  "2023-01-05T00:00:00Z",
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/prompts/prompt-123":
+			_ = json.NewEncoder(w).Encode(PromptResponse{Prompt: source})
+		case r.Method == http.MethodPost && r.URL.Path == "/prompts":
+			var raw map[string]interface{}
+			if err := json.NewDecoder(r.Body).Decode(&raw); err != nil {
+				t.Fatalf("Failed to decode request body: %v", err)
+			}
+			for _, field := range []string{"id", "version", "createdAt", "updatedAt"} {
+				if _, ok := raw[field]; ok {
+					t.Errorf("DuplicatePrompt() request body contains server-managed field %q", field)
+				}
+			}
+
+			body, _ := json.Marshal(raw)
+			var request CreatePromptRequest
+			_ = json.Unmarshal(body, &request)
+			_ = json.NewEncoder(w).Encode(PromptResponse{Prompt: Prompt{
+				ID:         "prompt-456",
+				Name:       request.Name,
+				Template:   request.Template,
+				Parameters: request.Parameters,
+				Variables:  request.Variables,
+				Tags:       request.Tags,
+				Version:    1,
+			}})
+		default:
+			t.Errorf("DuplicatePrompt() unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	prompt, err := client.DuplicatePrompt(context.Background(), "prompt-123", "Copy")
+	if err != nil {
+		t.Fatalf("DuplicatePrompt() error = %v", err)
+	}
+
+	if prompt.ID != "prompt-456" || prompt.Version != 1 {
+		t.Errorf("DuplicatePrompt() ID, Version = %v, %v, want prompt-456, 1", prompt.ID, prompt.Version)
+	}
+	if prompt.Name != "Copy" {
+		t.Errorf("DuplicatePrompt() Name = %v, want %v", prompt.Name, "Copy")
+	}
+	if prompt.Template != source.Template {
+		t.Errorf("DuplicatePrompt() Template = %v, want %v", prompt.Template, source.Template)
+	}
+	if len(prompt.Variables) != 1 || prompt.Variables[0] != source.Variables[0] {
+		t.Errorf("DuplicatePrompt() Variables = %v, want %v", prompt.Variables, source.Variables)
+	}
+	if len(prompt.Tags) != 1 || prompt.Tags[0] != "greeting" {
+		t.Errorf("DuplicatePrompt() Tags = %v, want %v", prompt.Tags, source.Tags)
+	}
+	if prompt.Parameters["temperature"] != 0.2 {
+		t.Errorf("DuplicatePrompt() Parameters = %v, want %v", prompt.Parameters, source.Parameters)
+	}
+}
+
+func TestClient_DuplicatePrompt_SourceNotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("DuplicatePrompt() method = %v, want only %v", r.Method, http.MethodGet)
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	_, err = client.DuplicatePrompt(context.Background(), "missing", "Copy")
+	if err == nil {
+		t.Fatal("DuplicatePrompt() error = nil, want error")
+	}
+	var apiErr *apierror.ErrorResponse
+	if !errors.As(err, &apiErr) || apiErr.ErrorCode != "not_found" {
+		t.Errorf("DuplicatePrompt() error = %v, want not_found", err)
+	}
+}
+
+func TestCopyParameters(t *testing.T) {
+	source := map[string]interface{}{
+		"temperature": 0.5,
+		"stop":        []interface{}{"\n"},
+		"nested":      map[string]interface{}{"key": "value"},
+	}
+
+	copied := copyParameters(source)
+	copied["temperature"] = 1.0
+	copied["stop"].([]interface{})[0] = "END"
+	copied["nested"].(map[string]interface{})["key"] = "changed"
+
+	if source["temperature"] != 0.5 {
+		t.Errorf("copyParameters() shares top-level values")
+	}
+	if source["stop"].([]interface{})[0] != "\n" {
+		t.Errorf("copyParameters() shares nested slices")
+	}
+	if source["nested"].(map[string]interface{})["key"] != "value" {
+		t.Errorf("copyParameters() shares nested maps")
+	}
+	if copyParameters(nil) != nil {
+		t.Errorf("copyParameters(nil) = non-nil, want nil")
+	}
+}
+
 func TestClient_ListPrompts(t *testing.T) {
 	// Variables to capture the request
 	var (
@@ -334,6 +912,197 @@ func TestClient_ListPrompts(t *testing.T) {
 	}
 }
 
+func TestClient_ListPrompts_MaxResults(t *testing.T) {
+	tests := []struct {
+		name    string
+		options *ListPromptsOptions
+		want    string
+	}{
+		{name: "nil options", options: nil, want: "50"},
+		{name: "unset", options: &ListPromptsOptions{ModelID: "model-123"}, want: "50"},
+		{name: "negative", options: &ListPromptsOptions{MaxResults: -1}, want: "50"},
+		{name: "explicit", options: &ListPromptsOptions{MaxResults: 25}, want: "25"},
+		{name: "upper bound", options: &ListPromptsOptions{MaxResults: MaxListPromptsMaxResults}, want: "500"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = r.URL.Query().Get("maxResults")
+				w.Header().Set("Content-Type", "application/json")
+				_ = json.NewEncoder(w).Encode(PromptsResponse{})
+			}))
+			defer server.Close()
+
+			client, err := NewClient(server.URL)
+			if err != nil {
+				t.Fatalf("Failed to create client: %v", err)
+			}
+
+			if _, _, err := client.ListPrompts(context.Background(), tt.options); err != nil {
+				t.Fatalf("ListPrompts() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ListPrompts() maxResults = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_ListPrompts_MaxResultsOutOfRange(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	_, _, err = client.ListPrompts(context.Background(), &ListPromptsOptions{MaxResults: MaxListPromptsMaxResults + 1})
+	if err == nil {
+		t.Fatal("ListPrompts() error = nil, want out of range error")
+	}
+	if !strings.Contains(err.Error(), "MaxResults") {
+		t.Errorf("ListPrompts() error = %v, want it to name MaxResults", err)
+	}
+	if requests != 0 {
+		t.Errorf("server received %d requests, want 0", requests)
+	}
+}
+
+func TestClient_ListPrompts_NameContains(t *testing.T) {
+	tests := []struct {
+		name         string
+		options      *ListPromptsOptions
+		wantPresent  bool
+		wantContains string
+	}{
+		{
+			name:         "set",
+			options:      &ListPromptsOptions{NameContains: "greeting", Tags: []string{"a", "b"}},
+			wantPresent:  true,
+			wantContains: "greeting",
+		},
+		{
+			name:    "empty",
+			options: &ListPromptsOptions{Tags: []string{"a", "b"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var query url.Values
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				query = r.URL.Query()
+				w.Header().Set("Content-Type", "application/json")
+				_ = json.NewEncoder(w).Encode(PromptsResponse{})
+			}))
+			defer server.Close()
+
+			client, err := NewClient(server.URL)
+			if err != nil {
+				t.Fatalf("Failed to create client: %v", err)
+			}
+
+			if _, _, err := client.ListPrompts(context.Background(), tt.options); err != nil {
+				t.Fatalf("ListPrompts() error = %v", err)
+			}
+
+			_, present := query["nameContains"]
+			if present != tt.wantPresent {
+				t.Errorf("ListPrompts() nameContains present = %v, want %v", present, tt.wantPresent)
+			}
+			if got := query.Get("nameContains"); got != tt.wantContains {
+				t.Errorf("ListPrompts() nameContains = %v, want %v", got, tt.wantContains)
+			}
+			if tags := query["tags"]; len(tags) != 2 || tags[0] != "a" || tags[1] != "b" {
+				t.Errorf("ListPrompts() tags = %v, want [a b]", tags)
+			}
+		})
+	}
+}
+
+func TestClient_WithRawErrorBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"prompt": "not an object"}`))
+	}))
+	defer server.Close()
+
+	for _, raw := range []bool{false, true} {
+		var options []ClientOption
+		if raw {
+			options = append(options, WithRawErrorBody())
+		}
+		client, err := NewClientWithOptions(server.URL, options...)
+		if err != nil {
+			t.Fatalf("Failed to create client: %v", err)
+		}
+
+		_, err = client.GetPrompt(context.Background(), "prompt-123")
+		var apiErr *apierror.ErrorResponse
+		if !errors.As(err, &apiErr) || apiErr.ErrorCode != "parse_error" {
+			t.Fatalf("GetPrompt() error = %v, want parse_error", err)
+		}
+		if !strings.Contains(apiErr.Description, `"not an object"`) {
+			t.Errorf("GetPrompt() error description = %q, want body snippet", apiErr.Description)
+		}
+		if got := apiErr.RawBody != nil; got != raw {
+			t.Errorf("GetPrompt() RawBody attached = %v, want %v", got, raw)
+		}
+	}
+}
+
+func TestClient_Health(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		body       string
+		wantStatus string
+		wantCode   string
+	}{
+		{name: "ok", statusCode: http.StatusOK, body: `{"status":"ok"}`, wantStatus: "ok"},
+		{name: "server error", statusCode: http.StatusInternalServerError, body: `{}`, wantCode: "server_error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodGet || r.URL.Path != "/health" {
+					t.Errorf("Health() request = %s %s, want GET /health", r.Method, r.URL.Path)
+				}
+				w.WriteHeader(tt.statusCode)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			client, err := NewClient(server.URL)
+			if err != nil {
+				t.Fatalf("Failed to create client: %v", err)
+			}
+
+			health, err := client.Health(context.Background())
+			if tt.wantCode != "" {
+				var apiErr *apierror.ErrorResponse
+				if !errors.As(err, &apiErr) || apiErr.ErrorCode != tt.wantCode {
+					t.Errorf("Health() error = %v, want %v", err, tt.wantCode)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Health() error = %v", err)
+			}
+			if health.Status != tt.wantStatus {
+				t.Errorf("Health() Status = %v, want %v", health.Status, tt.wantStatus)
+			}
+		})
+	}
+}
+
 func TestClient_newRequest(t *testing.T) {
 	client, err := NewClient("https://example.com")
 	if err != nil {
@@ -341,7 +1110,7 @@ func TestClient_newRequest(t *testing.T) {
 	}
 
 	body := map[string]interface{}{"key": "value"}
-	req, err := client.newRequest(context.Background(), http.MethodPost, "/test", body)
+	req, err := client.baseClient().NewRequest(context.Background(), http.MethodPost, "/test", body)
 	if err != nil {
 		t.Fatalf("newRequest() error = %v", err)
 	}
@@ -361,4 +1130,150 @@ func TestClient_newRequest(t *testing.T) {
 	if req.Header.Get("User-Agent") != DefaultUserAgent {
 		t.Errorf("newRequest() User-Agent = %v, want %v", req.Header.Get("User-Agent"), DefaultUserAgent)
 	}
-}
\ No newline at end of file
+}
+func TestClient_UsesBaseClient(t *testing.T) {
+	client, err := NewClientWithOptions("https://api.example.com/v1", WithUserAgent("custom-agent"))
+	if err != nil {
+		t.Fatalf("NewClientWithOptions() error = %v", err)
+	}
+
+	req, err := client.baseClient().NewRequest(context.Background(), http.MethodGet, "/prompts", nil)
+	if err != nil {
+		t.Fatalf("NewRequest() error = %v", err)
+	}
+	if req.URL.String() != "https://api.example.com/v1/prompts" {
+		t.Errorf("NewRequest() URL = %v, want %v", req.URL.String(), "https://api.example.com/v1/prompts")
+	}
+	if req.Header.Get("User-Agent") != "custom-agent" {
+		t.Errorf("NewRequest() User-Agent = %v, want %v", req.Header.Get("User-Agent"), "custom-agent")
+	}
+}
+
+func TestWithConnectionPool(t *testing.T) {
+	client, err := NewClientWithOptions("https://api.example.com/v1", WithConnectionPool(64, 16, 32, 45*time.Second))
+	if err != nil {
+		t.Fatalf("NewClientWithOptions() error = %v", err)
+	}
+
+	transport, ok := client.HTTPClient.Transport.(*http.Transport)
+	if !ok {
+		t.Fatalf("Transport = %T, want *http.Transport", client.HTTPClient.Transport)
+	}
+	if transport.MaxIdleConns != 64 || transport.MaxIdleConnsPerHost != 16 || transport.MaxConnsPerHost != 32 || transport.IdleConnTimeout != 45*time.Second {
+		t.Errorf("transport pool = (%d, %d, %d, %v), want (64, 16, 32, 45s)",
+			transport.MaxIdleConns, transport.MaxIdleConnsPerHost, transport.MaxConnsPerHost, transport.IdleConnTimeout)
+	}
+
+	custom := &http.Client{}
+	client, err = NewClientWithOptions("https://api.example.com/v1", WithHTTPClient(custom), WithConnectionPool(64, 16, 32, 45*time.Second))
+	if err != nil {
+		t.Fatalf("NewClientWithOptions() error = %v", err)
+	}
+	if client.HTTPClient != custom || custom.Transport != nil {
+		t.Errorf("WithConnectionPool modified the client passed to WithHTTPClient")
+	}
+}
+
+func TestWithTLSConfig(t *testing.T) {
+	config := &tls.Config{ServerName: "ai.atriumn.internal"}
+	pool := x509.NewCertPool()
+
+	client, err := NewClientWithOptions("https://api.example.com/v1", WithTLSConfig(config), WithRootCAs(pool))
+	if err != nil {
+		t.Fatalf("NewClientWithOptions() error = %v", err)
+	}
+
+	transport, ok := client.HTTPClient.Transport.(*http.Transport)
+	if !ok {
+		t.Fatalf("Transport = %T, want *http.Transport", client.HTTPClient.Transport)
+	}
+	if transport.TLSClientConfig == nil || transport.TLSClientConfig.ServerName != "ai.atriumn.internal" {
+		t.Fatalf("TLSClientConfig = %+v, want the provided config", transport.TLSClientConfig)
+	}
+	if transport.TLSClientConfig.RootCAs != pool {
+		t.Error("RootCAs is not the pool passed to WithRootCAs")
+	}
+
+	custom := &http.Client{}
+	client, err = NewClientWithOptions("https://api.example.com/v1", WithHTTPClient(custom), WithTLSConfig(config))
+	if err != nil {
+		t.Fatalf("NewClientWithOptions() error = %v", err)
+	}
+	if client.HTTPClient != custom || custom.Transport != nil {
+		t.Errorf("WithTLSConfig modified the client passed to WithHTTPClient")
+	}
+}
+
+func TestWithInsecureSkipVerify(t *testing.T) {
+	insecure := &tls.Config{InsecureSkipVerify: true}
+
+	if _, err := NewClientWithOptions("https://api.example.com/v1", WithTLSConfig(insecure)); !errors.Is(err, ErrInsecureTLSConfig) {
+		t.Fatalf("NewClientWithOptions() error = %v, want ErrInsecureTLSConfig", err)
+	}
+
+	client, err := NewClientWithOptions("https://api.example.com/v1", WithTLSConfig(insecure), WithInsecureSkipVerify())
+	if err != nil {
+		t.Fatalf("NewClientWithOptions() error = %v", err)
+	}
+	if !client.HTTPClient.Transport.(*http.Transport).TLSClientConfig.InsecureSkipVerify {
+		t.Error("InsecureSkipVerify = false, want true after WithInsecureSkipVerify")
+	}
+}
+
+func TestWithUserAgentSuffix(t *testing.T) {
+	client, err := NewClientWithOptions("https://api.example.com/v1", WithUserAgentSuffix("my-app/2.3"))
+	if err != nil {
+		t.Fatalf("NewClientWithOptions() error = %v", err)
+	}
+
+	req, err := client.baseClient().NewRequest(context.Background(), http.MethodGet, "/prompts", nil)
+	if err != nil {
+		t.Fatalf("NewRequest() error = %v", err)
+	}
+	userAgent := req.Header.Get("User-Agent")
+	for _, token := range []string{"atriumn-ai-client/1.0", "atriumn-sdk-go/", "my-app/2.3"} {
+		if !strings.Contains(userAgent, token) {
+			t.Errorf("User-Agent = %q, want it to contain %q", userAgent, token)
+		}
+	}
+	if !strings.HasSuffix(userAgent, " my-app/2.3") {
+		t.Errorf("User-Agent = %q, want suffix %q", userAgent, " my-app/2.3")
+	}
+}
+
+// metricsRecorder records the calls reported by WithMetricsRecorder
+type metricsRecorder struct {
+	requests []string
+}
+
+func (r *metricsRecorder) IncRequest(service, operation, code string) {
+	r.requests = append(r.requests, service+" "+operation+" "+code)
+}
+
+func (r *metricsRecorder) ObserveLatency(service, operation string, d time.Duration) {}
+
+func TestClient_WithMetricsRecorder(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"status":"ok"}`))
+	}))
+	defer server.Close()
+
+	recorder := &metricsRecorder{}
+	client, err := NewClientWithOptions(server.URL, WithMetricsRecorder(recorder), WithStatsCollection())
+	if err != nil {
+		t.Fatalf("NewClientWithOptions returned unexpected error: %v", err)
+	}
+
+	if _, err := client.Health(context.Background()); err != nil {
+		t.Fatalf("Health returned unexpected error: %v", err)
+	}
+
+	want := []string{"ai ai.Health 200"}
+	if strings.Join(recorder.requests, "\n") != strings.Join(want, "\n") {
+		t.Errorf("requests = %q, want %q", recorder.requests, want)
+	}
+	if stats := client.Stats(); stats.Requests != 1 {
+		t.Errorf("Stats().Requests = %d, want 1", stats.Requests)
+	}
+}
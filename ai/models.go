@@ -2,6 +2,12 @@
 // It enables managing prompts and related configurations through a simple, idiomatic Go interface.
 package ai
 
+import "github.com/atriumn/atriumn-sdk-go/internal/health"
+
+// HealthResponse represents the response from the health endpoint.
+// It indicates the current operational status of the AI service.
+type HealthResponse = health.Response
+
 // Prompt represents a prompt configuration in the Atriumn AI system.
 // It contains all the metadata and configuration needed for AI prompts.
 type Prompt struct {
@@ -23,6 +29,8 @@ type Prompt struct {
 	Tags []string `json:"tags,omitempty"`
 	// Version is the current version of the prompt
 	Version int64 `json:"version"`
+	// Archived reports whether the prompt has been archived with ArchivePrompt
+	Archived bool `json:"archived,omitempty"`
 	// CreatedAt is the UTC timestamp when the prompt was created
 	CreatedAt string `json:"createdAt"`
 	// UpdatedAt is the UTC timestamp when the prompt was last updated
@@ -98,8 +106,13 @@ type ListPromptsOptions struct {
 	ModelID string `json:"modelId,omitempty"`
 	// Tags optionally filters prompts by their tags
 	Tags []string `json:"tags,omitempty"`
-	// MaxResults is the maximum number of results to return per page
+	// NameContains optionally filters prompts to those whose name contains this substring
+	NameContains string `json:"nameContains,omitempty"`
+	// MaxResults is the maximum number of results to return per page, from 1 to
+	// MaxListPromptsMaxResults. Zero or less requests DefaultListPromptsMaxResults.
 	MaxResults int `json:"maxResults,omitempty"`
 	// NextToken is the pagination token for retrieving the next set of results
 	NextToken string `json:"nextToken,omitempty"`
+	// IncludeArchived lists archived prompts too; by default they are left out
+	IncludeArchived bool `json:"includeArchived,omitempty"`
 }
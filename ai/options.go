@@ -0,0 +1,52 @@
+package ai
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// CallOption configures a single API call. Options apply only to the call
+// they are passed to.
+type CallOption func(*callOptions)
+
+// callOptions holds the settings applied by CallOption functions.
+type callOptions struct {
+	dryRun          bool
+	expectedVersion *int64
+}
+
+// WithDryRun makes CreatePrompt validate the request without creating
+// anything. The service responds with the resource it would have created.
+func WithDryRun() CallOption {
+	return func(o *callOptions) {
+		o.dryRun = true
+	}
+}
+
+// WithExpectedVersion makes UpdatePrompt apply only if the stored prompt is
+// still at version v, so concurrent editors don't overwrite each other. If the
+// prompt has changed since, the update fails with an error matching
+// ErrConflict.
+func WithExpectedVersion(v int64) CallOption {
+	return func(o *callOptions) {
+		o.expectedVersion = &v
+	}
+}
+
+// applyCallOptions applies opts to req and returns the resulting settings.
+func applyCallOptions(req *http.Request, opts []CallOption) *callOptions {
+	options := &callOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	if options.dryRun {
+		q := req.URL.Query()
+		q.Set("dryRun", "true")
+		req.URL.RawQuery = q.Encode()
+	}
+	if options.expectedVersion != nil {
+		req.Header.Set("If-Match", strconv.Quote(strconv.FormatInt(*options.expectedVersion, 10)))
+	}
+	return options
+}
@@ -0,0 +1,85 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+// createPromptServer stores each created prompt under the ID "prompt-1",
+// counting the creates
+func createPromptServer(t *testing.T, creates *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/prompts" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		atomic.AddInt32(creates, 1)
+
+		var req CreatePromptRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("failed to decode request body: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(PromptResponse{Prompt: Prompt{
+			ID:        "prompt-1",
+			Name:      req.Name,
+			Template:  req.Template,
+			Variables: req.Variables,
+		}})
+	}))
+}
+
+func TestClient_CreateAndRender(t *testing.T) {
+	var creates int32
+	server := createPromptServer(t, &creates)
+	defer server.Close()
+
+	client, _ := NewClient(server.URL)
+	request := &CreatePromptRequest{
+		Name:      "greeting",
+		Template:  "Hello {{name}} from {{team}}",
+		Variables: []PromptVariable{{Name: "name", Required: true}, {Name: "team", DefaultValue: "support"}},
+	}
+
+	prompt, rendered, err := client.CreateAndRender(context.Background(), request, map[string]string{"name": "Ada"})
+	if err != nil {
+		t.Fatalf("CreateAndRender() error = %v", err)
+	}
+	if prompt.ID != "prompt-1" {
+		t.Errorf("prompt ID = %q, want prompt-1", prompt.ID)
+	}
+	if rendered != "Hello Ada from support" {
+		t.Errorf("rendered = %q, want %q", rendered, "Hello Ada from support")
+	}
+	if n := atomic.LoadInt32(&creates); n != 1 {
+		t.Errorf("server received %d creates, want 1", n)
+	}
+}
+
+func TestClient_CreateAndRender_MissingVariableSkipsCreate(t *testing.T) {
+	var creates int32
+	server := createPromptServer(t, &creates)
+	defer server.Close()
+
+	client, _ := NewClient(server.URL)
+	request := &CreatePromptRequest{
+		Name:      "greeting",
+		Template:  "Hello {{name}}",
+		Variables: []PromptVariable{{Name: "name", Required: true}},
+	}
+
+	prompt, _, err := client.CreateAndRender(context.Background(), request, nil)
+	if err == nil || err.Error() != "missing required variables: name" {
+		t.Fatalf("CreateAndRender() error = %v, want missing required variables", err)
+	}
+	if prompt != nil {
+		t.Errorf("prompt = %+v, want nil", prompt)
+	}
+	if n := atomic.LoadInt32(&creates); n != 0 {
+		t.Errorf("server received %d creates, want 0", n)
+	}
+}
@@ -0,0 +1,165 @@
+package ai
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// placeholderPattern matches {{name}} template placeholders, allowing
+// whitespace inside the braces.
+var placeholderPattern = regexp.MustCompile(`\{\{\s*([^{}\s]*)\s*\}\}`)
+
+// RenderOption configures how a prompt template is rendered.
+type RenderOption func(*renderOptions)
+
+// renderOptions holds the settings applied by RenderOption functions.
+type renderOptions struct {
+	strict bool
+}
+
+// WithStrictPlaceholders makes Render fail when the template contains a
+// placeholder that is neither declared in Variables nor given a value.
+// By default such placeholders are left in the output untouched.
+//
+// Returns:
+//   - RenderOption: An option for Prompt.Render and RenderTemplate
+func WithStrictPlaceholders() RenderOption {
+	return func(o *renderOptions) {
+		o.strict = true
+	}
+}
+
+// Render substitutes values into the prompt's template.
+// Each {{name}} placeholder is replaced with values[name], falling back to the
+// variable's DefaultValue when no value is given. Declared optional variables
+// without a value or default render as an empty string.
+//
+// Parameters:
+//   - values: Variable values keyed by variable name
+//   - opts: Optional RenderOption values such as WithStrictPlaceholders
+//
+// Returns:
+//   - string: The rendered template
+//   - error: An error listing required variables that have neither a value nor a
+//     default, or, in strict mode, placeholders that are unknown
+func (p *Prompt) Render(values map[string]string, opts ...RenderOption) (string, error) {
+	return RenderTemplate(p.Template, p.Variables, values, opts...)
+}
+
+// RenderTemplate substitutes values into template the way Prompt.Render does,
+// without a stored prompt, so a template can be tried out without a call to
+// the service.
+//
+// Parameters:
+//   - template: The template containing {{name}} placeholders
+//   - variables: The variables the template declares, with their defaults and
+//     whether they are required
+//   - values: Variable values keyed by variable name
+//   - opts: Optional RenderOption values such as WithStrictPlaceholders
+//
+// Returns:
+//   - string: The rendered template
+//   - error: An error listing required variables that have neither a value nor a
+//     default, or, in strict mode, placeholders that are unknown
+func RenderTemplate(template string, variables []PromptVariable, values map[string]string, opts ...RenderOption) (string, error) {
+	options := &renderOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	resolved := make(map[string]string, len(variables)+len(values))
+	var missing []string
+	for _, v := range variables {
+		if value, ok := values[v.Name]; ok {
+			resolved[v.Name] = value
+		} else if v.DefaultValue != "" {
+			resolved[v.Name] = v.DefaultValue
+		} else if v.Required {
+			missing = append(missing, v.Name)
+		} else {
+			resolved[v.Name] = ""
+		}
+	}
+	for name, value := range values {
+		resolved[name] = value
+	}
+
+	if len(missing) > 0 {
+		return "", fmt.Errorf("missing required variables: %s", strings.Join(missing, ", "))
+	}
+
+	var unknown []string
+	seen := make(map[string]bool)
+	rendered := placeholderPattern.ReplaceAllStringFunc(template, func(match string) string {
+		name := placeholderPattern.FindStringSubmatch(match)[1]
+		if value, ok := resolved[name]; ok {
+			return value
+		}
+		if !seen[name] {
+			seen[name] = true
+			unknown = append(unknown, name)
+		}
+		return match
+	})
+
+	if options.strict && len(unknown) > 0 {
+		return "", fmt.Errorf("unknown template placeholders: %s", strings.Join(unknown, ", "))
+	}
+
+	return rendered, nil
+}
+
+// templatePlaceholders returns the distinct placeholder names used in template,
+// in order of first appearance.
+func templatePlaceholders(template string) []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, match := range placeholderPattern.FindAllStringSubmatch(template, -1) {
+		if name := match[1]; !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// Validate checks that the declared Variables match the {{placeholders}} used
+// in Template. It reports variables that are declared but never used,
+// placeholders that are used but not declared, and empty variable or
+// placeholder names.
+//
+// Returns:
+//   - error: nil if the request is consistent, otherwise an error joining
+//     (see errors.Join) one error per problem found
+func (r *CreatePromptRequest) Validate() error {
+	var errs []error
+
+	declared := make(map[string]bool, len(r.Variables))
+	for i, v := range r.Variables {
+		if v.Name == "" {
+			errs = append(errs, fmt.Errorf("variable %d has an empty name", i))
+			continue
+		}
+		declared[v.Name] = true
+	}
+
+	used := make(map[string]bool)
+	for _, name := range templatePlaceholders(r.Template) {
+		used[name] = true
+		if name == "" {
+			errs = append(errs, errors.New("template contains an empty placeholder {{}}"))
+		} else if !declared[name] {
+			errs = append(errs, fmt.Errorf("placeholder %q is used in the template but not declared", name))
+		}
+	}
+
+	for _, v := range r.Variables {
+		if v.Name != "" && !used[v.Name] {
+			errs = append(errs, fmt.Errorf("variable %q is declared but not used in the template", v.Name))
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,255 @@
+package ai
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPrompt_Render(t *testing.T) {
+	prompt := &Prompt{
+		Template: "Hello {{name}}, welcome to {{ team }}! Goodbye {{name}}.",
+		Variables: []PromptVariable{
+			{Name: "name", Required: true},
+			{Name: "team", DefaultValue: "support"},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		values  map[string]string
+		want    string
+		wantErr string
+	}{
+		{
+			name:   "all values provided",
+			values: map[string]string{"name": "Ada", "team": "sales"},
+			want:   "Hello Ada, welcome to sales! Goodbye Ada.",
+		},
+		{
+			name:   "default applied",
+			values: map[string]string{"name": "Ada"},
+			want:   "Hello Ada, welcome to support! Goodbye Ada.",
+		},
+		{
+			name:    "required missing",
+			values:  map[string]string{"team": "sales"},
+			wantErr: "missing required variables: name",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := prompt.Render(tt.values)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("Render() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Render() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrompt_Render_RequiredWithDefault(t *testing.T) {
+	prompt := &Prompt{
+		Template:  "Language: {{lang}}",
+		Variables: []PromptVariable{{Name: "lang", Required: true, DefaultValue: "en"}},
+	}
+
+	got, err := prompt.Render(nil)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if got != "Language: en" {
+		t.Errorf("Render() = %q, want %q", got, "Language: en")
+	}
+}
+
+func TestPrompt_Render_MultipleMissing(t *testing.T) {
+	prompt := &Prompt{
+		Template:  "{{a}} {{b}}",
+		Variables: []PromptVariable{{Name: "a", Required: true}, {Name: "b", Required: true}},
+	}
+
+	_, err := prompt.Render(map[string]string{})
+	if err == nil || !strings.Contains(err.Error(), "a, b") {
+		t.Errorf("Render() error = %v, want both missing variables listed", err)
+	}
+}
+
+func TestPrompt_Render_UnknownPlaceholder(t *testing.T) {
+	prompt := &Prompt{
+		Template:  "Hi {{name}}, your order {{order_id}} shipped.",
+		Variables: []PromptVariable{{Name: "name"}},
+	}
+
+	got, err := prompt.Render(map[string]string{"name": "Ada"})
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if got != "Hi Ada, your order {{order_id}} shipped." {
+		t.Errorf("Render() = %q, want unknown placeholder untouched", got)
+	}
+
+	_, err = prompt.Render(map[string]string{"name": "Ada"}, WithStrictPlaceholders())
+	if err == nil || !strings.Contains(err.Error(), "order_id") {
+		t.Errorf("Render() strict error = %v, want unknown placeholder reported", err)
+	}
+
+	// An undeclared placeholder that is given a value is not unknown
+	got, err = prompt.Render(map[string]string{"name": "Ada", "order_id": "42"}, WithStrictPlaceholders())
+	if err != nil {
+		t.Fatalf("Render() strict error = %v", err)
+	}
+	if got != "Hi Ada, your order 42 shipped." {
+		t.Errorf("Render() = %q", got)
+	}
+}
+
+func TestRenderTemplate(t *testing.T) {
+	template := "Summarize {{doc}} in {{ length }} words for {{audience}}."
+	variables := []PromptVariable{
+		{Name: "doc", Required: true},
+		{Name: "length", Required: true, DefaultValue: "50"},
+		{Name: "audience"},
+	}
+
+	tests := []struct {
+		name    string
+		values  map[string]string
+		want    string
+		wantErr string
+	}{
+		{
+			name:   "all values provided",
+			values: map[string]string{"doc": "the report", "length": "20", "audience": "engineers"},
+			want:   "Summarize the report in 20 words for engineers.",
+		},
+		{
+			name:   "defaults and optional variables",
+			values: map[string]string{"doc": "the report"},
+			want:   "Summarize the report in 50 words for .",
+		},
+		{
+			name:    "required missing",
+			values:  map[string]string{"length": "20"},
+			wantErr: "missing required variables: doc",
+		},
+		{
+			name:    "nil values",
+			wantErr: "missing required variables: doc",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RenderTemplate(template, variables, tt.values)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("RenderTemplate() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("RenderTemplate() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("RenderTemplate() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderTemplate_UndeclaredPlaceholder(t *testing.T) {
+	got, err := RenderTemplate("Hi {{name}} from {{team}}", nil, map[string]string{"name": "Ada"})
+	if err != nil {
+		t.Fatalf("RenderTemplate() error = %v", err)
+	}
+	if got != "Hi Ada from {{team}}" {
+		t.Errorf("RenderTemplate() = %q, want the unknown placeholder left in place", got)
+	}
+
+	_, err = RenderTemplate("Hi {{name}} from {{team}}", nil, map[string]string{"name": "Ada"}, WithStrictPlaceholders())
+	if err == nil || err.Error() != "unknown template placeholders: team" {
+		t.Errorf("RenderTemplate() strict error = %v, want unknown placeholder team", err)
+	}
+}
+
+func TestCreatePromptRequest_Validate(t *testing.T) {
+	tests := []struct {
+		name      string
+		request   CreatePromptRequest
+		wantErrs  []string
+		wantValid bool
+	}{
+		{
+			name: "consistent",
+			request: CreatePromptRequest{
+				Template:  "Summarize {{text}} in {{ language }}. Keep {{text}} short.",
+				Variables: []PromptVariable{{Name: "text"}, {Name: "language"}},
+			},
+			wantValid: true,
+		},
+		{
+			name: "declared but unused",
+			request: CreatePromptRequest{
+				Template:  "Summarize {{text}}",
+				Variables: []PromptVariable{{Name: "text"}, {Name: "tone"}},
+			},
+			wantErrs: []string{`variable "tone" is declared but not used`},
+		},
+		{
+			name: "used but undeclared",
+			request: CreatePromptRequest{
+				Template:  "Summarize {{text}} for {{audience}}",
+				Variables: []PromptVariable{{Name: "text"}},
+			},
+			wantErrs: []string{`placeholder "audience" is used in the template but not declared`},
+		},
+		{
+			name: "empty names",
+			request: CreatePromptRequest{
+				Template:  "Summarize {{text}} {{}}",
+				Variables: []PromptVariable{{Name: "text"}, {Name: ""}},
+			},
+			wantErrs: []string{"variable 1 has an empty name", "empty placeholder"},
+		},
+		{
+			name: "multiple problems",
+			request: CreatePromptRequest{
+				Template:  "Hello {{name}}",
+				Variables: []PromptVariable{{Name: "user"}},
+			},
+			wantErrs: []string{`placeholder "name"`, `variable "user"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.request.Validate()
+			if tt.wantValid {
+				if err != nil {
+					t.Fatalf("Validate() error = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("Validate() error = nil, want error")
+			}
+			for _, want := range tt.wantErrs {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("Validate() error = %q, want it to contain %q", err, want)
+				}
+			}
+			if joined, ok := err.(interface{ Unwrap() []error }); !ok || len(joined.Unwrap()) != len(tt.wantErrs) {
+				t.Errorf("Validate() error = %q, want %d joined errors", err, len(tt.wantErrs))
+			}
+		})
+	}
+}
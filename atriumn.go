@@ -0,0 +1,294 @@
+// Package atriumn provides a unified client that aggregates the Atriumn
+// service clients (auth, storage, ingest, and ai) behind shared configuration.
+// Each service package remains usable on its own; this package only removes
+// the need to configure four clients separately.
+package atriumn
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/atriumn/atriumn-sdk-go/ai"
+	"github.com/atriumn/atriumn-sdk-go/auth"
+	"github.com/atriumn/atriumn-sdk-go/ingest"
+	"github.com/atriumn/atriumn-sdk-go/internal/clientutil"
+	"github.com/atriumn/atriumn-sdk-go/storage"
+)
+
+// DefaultTimeout is the default timeout of the shared HTTP client
+const DefaultTimeout = 10 * time.Second
+
+// Service identifies one of the Atriumn services aggregated by Client.
+type Service string
+
+const (
+	// ServiceAuth identifies the Auth service
+	ServiceAuth Service = "auth"
+	// ServiceStorage identifies the Storage service
+	ServiceStorage Service = "storage"
+	// ServiceIngest identifies the Ingest service
+	ServiceIngest Service = "ingest"
+	// ServiceAI identifies the AI service
+	ServiceAI Service = "ai"
+)
+
+// Endpoints holds the base URL of each Atriumn service.
+// A service whose URL is empty is not configured and its accessor returns nil.
+type Endpoints struct {
+	// Auth is the base URL of the Atriumn Auth API
+	Auth string
+	// Storage is the base URL of the Atriumn Storage API
+	Storage string
+	// Ingest is the base URL of the Atriumn Ingest API
+	Ingest string
+	// AI is the base URL of the Atriumn AI API
+	AI string
+}
+
+// Client aggregates the individual Atriumn service clients.
+// By default all service clients share a single http.Client, and therefore a
+// single transport and connection pool.
+type Client struct {
+	// HTTPClient is the HTTP client shared by all service clients
+	HTTPClient *http.Client
+
+	auth    *auth.Client
+	storage *storage.Client
+	ingest  *ingest.Client
+	ai      *ai.Client
+}
+
+// TokenProvider supplies the bearer tokens sent by every service client. A
+// provider from auth.NewClientCredentialsTokenProvider satisfies it.
+type TokenProvider = clientutil.TokenProvider
+
+// Logger receives diagnostic messages from every service client, such as
+// warnings that an endpoint is deprecated. *log.Logger satisfies it.
+type Logger = clientutil.Logger
+
+// RetryPolicy configures how every service client retries failed requests.
+type RetryPolicy = clientutil.RetryPolicy
+
+// config collects the settings applied by ClientOption functions.
+type config struct {
+	httpClient        *http.Client
+	serviceHTTPClient map[Service]*http.Client
+	userAgent         string
+	tokenProvider     TokenProvider
+	logger            Logger
+	retryPolicy       *RetryPolicy
+}
+
+// ClientOption is a function that configures a Client.
+// It is used with NewClient to customize the client behavior.
+type ClientOption func(*config)
+
+// WithHTTPClient sets the HTTP client shared by all service clients.
+//
+// Parameters:
+//   - httpClient: The HTTP client to share across services
+//
+// Returns:
+//   - ClientOption: A functional option to configure the client
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return func(c *config) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithServiceHTTPClient overrides the HTTP client for a single service,
+// leaving the other services on the shared client.
+//
+// Parameters:
+//   - service: The service whose HTTP client to override
+//   - httpClient: The HTTP client to use for that service
+//
+// Returns:
+//   - ClientOption: A functional option to configure the client
+func WithServiceHTTPClient(service Service, httpClient *http.Client) ClientOption {
+	return func(c *config) {
+		c.serviceHTTPClient[service] = httpClient
+	}
+}
+
+// WithUserAgent sets the user agent sent by every service client, replacing
+// their default "atriumn-<service>-client" user agents.
+//
+// Parameters:
+//   - userAgent: The user agent string to send with API requests
+//
+// Returns:
+//   - ClientOption: A functional option to configure the client
+func WithUserAgent(userAgent string) ClientOption {
+	return func(c *config) {
+		c.userAgent = userAgent
+	}
+}
+
+// WithTokenProvider sets the token provider every service client obtains its
+// bearer tokens from.
+//
+// Parameters:
+//   - tp: The TokenProvider implementation to use for authentication
+//
+// Returns:
+//   - ClientOption: A functional option to configure the client
+func WithTokenProvider(tp TokenProvider) ClientOption {
+	return func(c *config) {
+		c.tokenProvider = tp
+	}
+}
+
+// WithLogger sets the logger every service client writes diagnostic
+// messages, such as deprecation warnings, to.
+//
+// Parameters:
+//   - logger: The logger that receives the messages
+//
+// Returns:
+//   - ClientOption: A functional option to configure the client
+func WithLogger(logger Logger) ClientOption {
+	return func(c *config) {
+		c.logger = logger
+	}
+}
+
+// WithRetryPolicy makes every service client retry failed requests as policy
+// describes.
+//
+// Parameters:
+//   - policy: The retry policy shared by the service clients
+//
+// Returns:
+//   - ClientOption: A functional option to configure the client
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return func(c *config) {
+		c.retryPolicy = &policy
+	}
+}
+
+// NewClient creates a unified client for the services listed in endpoints.
+//
+// Parameters:
+//   - endpoints: The base URL of each service to configure
+//   - options: A variadic list of ClientOption functions to customize the client
+//
+// Returns:
+//   - *Client: A configured unified client
+//   - error: An error if any configured base URL cannot be parsed
+func NewClient(endpoints Endpoints, options ...ClientOption) (*Client, error) {
+	cfg := &config{serviceHTTPClient: make(map[Service]*http.Client)}
+	for _, option := range options {
+		option(cfg)
+	}
+
+	if cfg.httpClient == nil {
+		cfg.httpClient = &http.Client{
+			Timeout:   DefaultTimeout,
+			Transport: http.DefaultTransport.(*http.Transport).Clone(),
+		}
+	}
+
+	httpClientFor := func(service Service) *http.Client {
+		if hc, ok := cfg.serviceHTTPClient[service]; ok && hc != nil {
+			return hc
+		}
+		return cfg.httpClient
+	}
+
+	client := &Client{HTTPClient: cfg.httpClient}
+
+	// Unset shared settings leave each service client's defaults in place
+	var err error
+	if endpoints.Auth != "" {
+		opts := []auth.ClientOption{
+			auth.WithHTTPClient(httpClientFor(ServiceAuth)),
+			auth.WithTokenProvider(cfg.tokenProvider),
+			auth.WithLogger(cfg.logger),
+		}
+		if cfg.userAgent != "" {
+			opts = append(opts, auth.WithUserAgent(cfg.userAgent))
+		}
+		if cfg.retryPolicy != nil {
+			opts = append(opts, auth.WithRetryPolicy(*cfg.retryPolicy))
+		}
+		client.auth, err = auth.NewClientWithOptions(endpoints.Auth, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("auth: %w", err)
+		}
+	}
+	if endpoints.Storage != "" {
+		opts := []storage.ClientOption{
+			storage.WithHTTPClient(httpClientFor(ServiceStorage)),
+			storage.WithTokenProvider(cfg.tokenProvider),
+			storage.WithLogger(cfg.logger),
+		}
+		if cfg.userAgent != "" {
+			opts = append(opts, storage.WithUserAgent(cfg.userAgent))
+		}
+		if cfg.retryPolicy != nil {
+			opts = append(opts, storage.WithRetryPolicy(*cfg.retryPolicy))
+		}
+		client.storage, err = storage.NewClientWithOptions(endpoints.Storage, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("storage: %w", err)
+		}
+	}
+	if endpoints.Ingest != "" {
+		opts := []ingest.ClientOption{
+			ingest.WithHTTPClient(httpClientFor(ServiceIngest)),
+			ingest.WithTokenProvider(cfg.tokenProvider),
+			ingest.WithLogger(cfg.logger),
+		}
+		if cfg.userAgent != "" {
+			opts = append(opts, ingest.WithUserAgent(cfg.userAgent))
+		}
+		if cfg.retryPolicy != nil {
+			opts = append(opts, ingest.WithRetryPolicy(*cfg.retryPolicy))
+		}
+		client.ingest, err = ingest.NewClientWithOptions(endpoints.Ingest, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("ingest: %w", err)
+		}
+	}
+	if endpoints.AI != "" {
+		opts := []ai.ClientOption{
+			ai.WithHTTPClient(httpClientFor(ServiceAI)),
+			ai.WithTokenProvider(cfg.tokenProvider),
+			ai.WithLogger(cfg.logger),
+		}
+		if cfg.userAgent != "" {
+			opts = append(opts, ai.WithUserAgent(cfg.userAgent))
+		}
+		if cfg.retryPolicy != nil {
+			opts = append(opts, ai.WithRetryPolicy(*cfg.retryPolicy))
+		}
+		client.ai, err = ai.NewClientWithOptions(endpoints.AI, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("ai: %w", err)
+		}
+	}
+
+	return client, nil
+}
+
+// Auth returns the Auth service client, or nil if no Auth endpoint was configured.
+func (c *Client) Auth() *auth.Client {
+	return c.auth
+}
+
+// Storage returns the Storage service client, or nil if no Storage endpoint was configured.
+func (c *Client) Storage() *storage.Client {
+	return c.storage
+}
+
+// Ingest returns the Ingest service client, or nil if no Ingest endpoint was configured.
+func (c *Client) Ingest() *ingest.Client {
+	return c.ingest
+}
+
+// AI returns the AI service client, or nil if no AI endpoint was configured.
+func (c *Client) AI() *ai.Client {
+	return c.ai
+}
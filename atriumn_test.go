@@ -0,0 +1,202 @@
+package atriumn
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/atriumn/atriumn-sdk-go/auth"
+	"github.com/atriumn/atriumn-sdk-go/ingest"
+	"github.com/atriumn/atriumn-sdk-go/internal/clientutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testEndpoints = Endpoints{
+	Auth:    "https://auth.example.com",
+	Storage: "https://storage.example.com",
+	Ingest:  "https://ingest.example.com",
+	AI:      "https://ai.example.com",
+}
+
+func TestNewClient_SharesHTTPClient(t *testing.T) {
+	client, err := NewClient(testEndpoints)
+	require.NoError(t, err)
+
+	require.NotNil(t, client.HTTPClient)
+	assert.Same(t, client.HTTPClient, client.Auth().HTTPClient)
+	assert.Same(t, client.HTTPClient, client.Storage().HTTPClient)
+	assert.Same(t, client.HTTPClient, client.Ingest().HTTPClient)
+	assert.Same(t, client.HTTPClient, client.AI().HTTPClient)
+	assert.Equal(t, DefaultTimeout, client.HTTPClient.Timeout)
+}
+
+func TestNewClient_WithHTTPClient(t *testing.T) {
+	shared := &http.Client{}
+	client, err := NewClient(testEndpoints, WithHTTPClient(shared))
+	require.NoError(t, err)
+
+	assert.Same(t, shared, client.HTTPClient)
+	assert.Same(t, shared, client.Auth().HTTPClient)
+	assert.Same(t, shared, client.AI().HTTPClient)
+}
+
+func TestNewClient_WithServiceHTTPClient(t *testing.T) {
+	ingestHTTPClient := &http.Client{}
+	client, err := NewClient(testEndpoints, WithServiceHTTPClient(ServiceIngest, ingestHTTPClient))
+	require.NoError(t, err)
+
+	assert.Same(t, ingestHTTPClient, client.Ingest().HTTPClient)
+	assert.NotSame(t, ingestHTTPClient, client.HTTPClient)
+	assert.Same(t, client.HTTPClient, client.Auth().HTTPClient)
+	assert.Same(t, client.HTTPClient, client.Storage().HTTPClient)
+	assert.Same(t, client.HTTPClient, client.AI().HTTPClient)
+}
+
+func TestNewClient_PartialEndpoints(t *testing.T) {
+	client, err := NewClient(Endpoints{Ingest: "https://ingest.example.com"})
+	require.NoError(t, err)
+
+	assert.NotNil(t, client.Ingest())
+	assert.Nil(t, client.Auth())
+	assert.Nil(t, client.Storage())
+	assert.Nil(t, client.AI())
+}
+
+func TestNewClient_InvalidEndpoint(t *testing.T) {
+	_, err := NewClient(Endpoints{Storage: ":"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "storage")
+}
+
+// staticTokenProvider returns a fixed token
+type staticTokenProvider struct {
+	token string
+}
+
+func (p *staticTokenProvider) GetToken(ctx context.Context) (string, error) {
+	return p.token, nil
+}
+
+// flakyServer answers each path with 503 Service Unavailable the first time
+// it is requested and with a healthy status afterwards, recording the
+// User-Agent and Authorization headers of every request
+type flakyServer struct {
+	mu            sync.Mutex
+	calls         map[string]int
+	userAgents    []string
+	authorization []string
+}
+
+func (s *flakyServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.calls == nil {
+		s.calls = make(map[string]int)
+	}
+	s.calls[r.URL.Path]++
+	s.userAgents = append(s.userAgents, r.Header.Get("User-Agent"))
+	s.authorization = append(s.authorization, r.Header.Get("Authorization"))
+	if s.calls[r.URL.Path] == 1 {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write([]byte(`{"status":"ok"}`))
+}
+
+// checkAllHealth calls Health on each service client, with every endpoint
+// served by the same server under its own path
+func checkAllHealth(t *testing.T, server *httptest.Server, opts ...ClientOption) map[string]error {
+	t.Helper()
+	client, err := NewClient(Endpoints{
+		Auth:    server.URL + "/auth",
+		Storage: server.URL + "/storage",
+		Ingest:  server.URL + "/ingest",
+		AI:      server.URL + "/ai",
+	}, opts...)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	errs := make(map[string]error)
+	_, errs["auth"] = client.Auth().Health(ctx)
+	_, errs["storage"] = client.Storage().Health(ctx)
+	_, errs["ingest"] = client.Ingest().Health(ctx)
+	_, errs["ai"] = client.AI().Health(ctx)
+	return errs
+}
+
+func TestNewClient_SharedOptions(t *testing.T) {
+	flaky := &flakyServer{}
+	server := httptest.NewServer(flaky)
+	defer server.Close()
+
+	errs := checkAllHealth(t, server,
+		WithUserAgent("my-app/1.0"),
+		WithTokenProvider(&staticTokenProvider{token: "shared-token"}),
+		WithRetryPolicy(RetryPolicy{MaxRetries: 2, Backoff: clientutil.Backoff{Initial: time.Millisecond}}),
+	)
+	for name, err := range errs {
+		assert.NoError(t, err, name)
+	}
+
+	// Each client retried its first, failed request once
+	assert.Len(t, flaky.calls, 4)
+	for path, n := range flaky.calls {
+		assert.Equal(t, 2, n, path)
+	}
+	for i := range flaky.userAgents {
+		assert.Equal(t, "my-app/1.0", flaky.userAgents[i])
+		assert.Equal(t, "Bearer shared-token", flaky.authorization[i])
+	}
+}
+
+func TestNewClient_DefaultsWithoutSharedOptions(t *testing.T) {
+	client, err := NewClient(testEndpoints)
+	require.NoError(t, err)
+	assert.Equal(t, auth.DefaultUserAgent, client.Auth().UserAgent)
+	assert.Equal(t, ingest.DefaultUserAgent, client.Ingest().UserAgent)
+
+	flaky := &flakyServer{}
+	server := httptest.NewServer(flaky)
+	defer server.Close()
+
+	errs := checkAllHealth(t, server)
+	for name, err := range errs {
+		assert.Error(t, err, name)
+	}
+
+	// Without a retry policy or token provider, each client sent one
+	// unauthenticated request
+	for path, n := range flaky.calls {
+		assert.Equal(t, 1, n, path)
+	}
+	for _, authorization := range flaky.authorization {
+		assert.Empty(t, authorization)
+	}
+}
+
+func TestNewClient_SharedOptionsSentWithRequests(t *testing.T) {
+	var userAgent, authorization string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgent = r.Header.Get("User-Agent")
+		authorization = r.Header.Get("Authorization")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"status":"ok"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(Endpoints{Ingest: server.URL},
+		WithUserAgent("my-app/1.0"),
+		WithTokenProvider(&staticTokenProvider{token: "shared-token"}),
+	)
+	require.NoError(t, err)
+
+	_, err = client.Ingest().Health(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "my-app/1.0", userAgent)
+	assert.Equal(t, "Bearer shared-token", authorization)
+}
@@ -0,0 +1,56 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_WithRequestBodyLogging(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token":"at","refresh_token":"rt","token_type":"Bearer"}`))
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	client, err := NewClientWithOptions(server.URL, WithRequestBodyLogging(), WithLogger(log.New(&buf, "", 0)))
+	require.NoError(t, err)
+
+	_, err = client.LoginUser(context.Background(), "ada@example.com", "hunter2")
+	require.NoError(t, err)
+	err = client.ConfirmPasswordReset(context.Background(), "ada@example.com", "123456", "correct-horse")
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2)
+	assert.Contains(t, lines[0], `"password":"***"`)
+	assert.Contains(t, lines[0], `"username":"ada@example.com"`)
+	assert.NotContains(t, lines[0], "hunter2")
+	assert.Contains(t, lines[1], `"new_password":"***"`)
+	assert.Contains(t, lines[1], `"code":"123456"`)
+	assert.NotContains(t, lines[1], "correct-horse")
+}
+
+func TestClient_WithLogger_DoesNotLogBodies(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token":"at","token_type":"Bearer"}`))
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	client, err := NewClientWithOptions(server.URL, WithLogger(log.New(&buf, "", 0)))
+	require.NoError(t, err)
+
+	_, err = client.LoginUser(context.Background(), "ada@example.com", "hunter2")
+	require.NoError(t, err)
+	assert.Empty(t, buf.String())
+}
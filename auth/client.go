@@ -4,13 +4,14 @@
 package auth
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
-	"io"
 	"net/http"
 	"net/url"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/atriumn/atriumn-sdk-go/internal/clientutil"
@@ -20,10 +21,13 @@ const (
 	// DefaultTimeout is the default timeout for API requests
 	DefaultTimeout = 10 * time.Second
 
-	// DefaultUserAgent is the user agent sent in requests
-	DefaultUserAgent = "atriumn-auth-client/1.0"
+	// DefaultJWKSCacheTTL is how long VerifyToken reuses a fetched JWKS
+	DefaultJWKSCacheTTL = time.Hour
 )
 
+// DefaultUserAgent is the user agent sent in requests, naming the client and the SDK version
+var DefaultUserAgent = "atriumn-auth-client/1.0" + " " + clientutil.VersionToken
+
 // Client is the main API client for Atriumn Auth Service.
 // It handles communication with the API endpoints, including
 // authentication, client credential management, and user operations.
@@ -36,27 +40,49 @@ type Client struct {
 
 	// UserAgent is the user agent sent with each request
 	UserAgent string
+
+	// base holds the token provider and the other shared request settings;
+	// baseClient combines it with the fields above to build and send requests
+	base clientutil.BaseClient
+
+	// jwks caches the signing keys used by VerifyToken
+	jwks *jwksCache
+
+	// tokenIssuer and tokenAudience are the iss and aud values VerifyToken requires
+	tokenIssuer   string
+	tokenAudience string
+
+	// endpointOverrides holds the raw WithEndpointOverride values until they are validated
+	endpointOverrides map[string]string
+
+	// endpoints maps endpoint groups to the base URLs that replace BaseURL for them
+	endpoints map[string]*url.URL
+
+	// tenantID is sent in the X-Tenant-ID header unless the request context overrides it
+	tenantID string
 }
 
 // NewClient creates a new Atriumn Auth API client with the specified base URL.
-// It returns an error if the provided URL cannot be parsed.
+// It returns an error if the provided URL cannot be parsed or lacks a scheme or host.
 //
 // Parameters:
 //   - baseURL: The base URL for the Atriumn Auth API (required)
 //
 // Returns:
 //   - *Client: A configured Auth client instance
-//   - error: An error if the URL cannot be parsed
+//   - error: An error if the URL cannot be parsed or is not absolute
 func NewClient(baseURL string) (*Client, error) {
-	parsedURL, err := url.Parse(baseURL)
+	parsedURL, err := clientutil.ParseBaseURL(baseURL)
 	if err != nil {
-		return nil, fmt.Errorf("invalid base URL: %w", err)
+		return nil, err
 	}
 
 	return &Client{
 		BaseURL:    parsedURL,
 		HTTPClient: &http.Client{Timeout: DefaultTimeout},
 		UserAgent:  DefaultUserAgent,
+		base:       clientutil.BaseClient{Service: "auth", Clock: clientutil.SystemClock},
+		jwks:       &jwksCache{ttl: DefaultJWKSCacheTTL},
 	}, nil
 }
 
@@ -64,6 +90,13 @@ func NewClient(baseURL string) (*Client, error) {
 // It is used with NewClientWithOptions to customize the client behavior.
 type ClientOption func(*Client)
 
+// shared adapts an option defined once in clientutil for all service clients
+func shared(opt clientutil.Option) ClientOption {
+	return func(c *Client) {
+		opt(&c.base)
+	}
+}
+
 // WithHTTPClient sets the HTTP client for the API client.
 // This can be used to customize timeouts, transport settings, or to inject
 // middleware/interceptors for testing or monitoring.
@@ -93,6 +126,280 @@ func WithUserAgent(userAgent string) ClientOption {
 	}
 }
 
+// WithUserAgentSuffix appends " suffix" to the user agent, keeping the default
+// client and SDK version tokens so requests remain identifiable. It extends the
+// user agent configured so far, so apply it after WithUserAgent when using both.
+//
+// Parameters:
+//   - suffix: The product token to append, such as "my-app/2.3"
+//
+// Returns:
+//   - ClientOption: A functional option to configure the client
+func WithUserAgentSuffix(suffix string) ClientOption {
+	return func(c *Client) {
+		if suffix != "" {
+			c.UserAgent += " " + suffix
+		}
+	}
+}
+
+// WithTokenProvider sets the provider of the bearer tokens sent with each request.
+func WithTokenProvider(tp TokenProvider) ClientOption {
+	return shared(clientutil.SetTokenProvider(tp))
+}
+
+// WithMaxResponseBytes limits how many bytes of an API response body the
+// client reads. Larger responses fail with a "response_too_large" error. The
+// default is 10 MiB; a value of zero or less restores it.
+func WithMaxResponseBytes(n int64) ClientOption {
+	return shared(clientutil.SetMaxResponseBytes(n))
+}
+
+// WithRateLimit throttles the client to rps requests per second on average,
+// allowing bursts of up to burst requests; calls wait for their turn or their
+// context. A rate of zero or less removes the limit.
+func WithRateLimit(rps float64, burst int) ClientOption {
+	return shared(clientutil.SetRateLimit(rps, burst))
+}
+
+// ConcurrencyOption configures WithMaxConcurrentRequests.
+type ConcurrencyOption = clientutil.ConcurrencyOption
+
+// FailFast makes calls made while WithMaxConcurrentRequests has no free slot
+// return ErrTooManyConcurrentRequests at once, instead of waiting for a slot.
+//
+// Returns:
+//   - ConcurrencyOption: An option for WithMaxConcurrentRequests
+func FailFast() ConcurrencyOption {
+	return clientutil.FailFast()
+}
+
+// WithMaxConcurrentRequests allows at most n API calls of the client to be in
+// flight at once; further calls wait for a slot or their context, unless
+// FailFast is given. An n of zero or less removes the limit.
+func WithMaxConcurrentRequests(n int, opts ...ConcurrencyOption) ClientOption {
+	return shared(clientutil.SetMaxConcurrentRequests(n, opts...))
+}
+
+// WithResponseCache caches up to size GET responses that carry an ETag and
+// revalidates them with If-None-Match. A size of zero or less disables the cache.
+func WithResponseCache(size int) ClientOption {
+	return shared(clientutil.SetResponseCache(size))
+}
+
+// WithConnectionPool tunes the connection pool of the client's default HTTP
+// client. Like the TLS options and WithForceHTTP1, it never changes a client
+// passed to WithHTTPClient; zero maxIdle, maxConnsPerHost, or idleTimeout
+// means no limit.
+func WithConnectionPool(maxIdle, maxIdlePerHost, maxConnsPerHost int, idleTimeout time.Duration) ClientOption {
+	return shared(clientutil.SetConnectionPool(maxIdle, maxIdlePerHost, maxConnsPerHost, idleTimeout))
+}
+
+// WithTLSConfig sets the TLS configuration of the client's default HTTP
+// client, for example to present a client certificate. A config with
+// InsecureSkipVerify set is rejected unless WithInsecureSkipVerify is also given.
+func WithTLSConfig(config *tls.Config) ClientOption {
+	return shared(clientutil.SetTLSConfig(config))
+}
+
+// WithRootCAs makes the client's default HTTP client trust the certificates
+// in pool instead of the system roots, overriding the RootCAs of WithTLSConfig.
+func WithRootCAs(pool *x509.CertPool) ClientOption {
+	return shared(clientutil.SetRootCAs(pool))
+}
+
+// WithInsecureSkipVerify disables TLS certificate verification on the client's
+// default HTTP client. It is only fit for local testing; use WithRootCAs to
+// trust a private certificate authority instead.
+func WithInsecureSkipVerify() ClientOption {
+	return shared(clientutil.SetInsecureSkipVerify())
+}
+
+// WithForceHTTP1 makes the client's default HTTP client use only HTTP/1.1,
+// for proxies or middleboxes where HTTP/2 negotiation fails.
+func WithForceHTTP1() ClientOption {
+	return shared(clientutil.SetForceHTTP1())
+}
+
+// MetricsRecorder receives the outcome and latency of every API call, for
+// export to a metrics system such as Prometheus. Implementations must be safe
+// for concurrent use.
+type MetricsRecorder = clientutil.MetricsRecorder
+
+// WithMetricsRecorder reports every API call made by the client to recorder,
+// labeled with the service "auth" and an operation such as "auth.LoginUser".
+func WithMetricsRecorder(recorder MetricsRecorder) ClientOption {
+	return shared(clientutil.SetMetricsRecorder(recorder))
+}
+
+// Stats summarizes the API calls made by a client: how many were made, how
+// many failed, and their latency percentiles.
+type Stats = clientutil.Stats
+
+// WithStatsCollection makes the client count its API calls and sample their
+// latencies, so Stats can report them without a metrics system.
+func WithStatsCollection() ClientOption {
+	return shared(clientutil.SetStatsCollection())
+}
+
+// DefaultStatsReservoirSize is the number of call latencies WithStatsCollection
+// samples to estimate percentiles from.
+const DefaultStatsReservoirSize = clientutil.DefaultStatsReservoirSize
+
+// Stats returns the number of API calls made by the client, how many of them
+// returned an error, and their p50, p95, and p99 latencies. Without
+// WithStatsCollection it returns zero Stats.
+func (c *Client) Stats() Stats {
+	return c.base.Stats.Stats()
+}
+
+// Close closes the idle connections of HTTPClient's transport, unless it is
+// the shared http.DefaultTransport. The client remains usable afterwards.
+func (c *Client) Close() error {
+	return c.baseClient().Close()
+}
+
+// Logger receives diagnostic messages from the client, such as warnings that
+// an endpoint is deprecated. *log.Logger satisfies it.
+type Logger = clientutil.Logger
+
+// DeprecationNotice describes a response whose Deprecation or Sunset header
+// announced that its endpoint is going away.
+type DeprecationNotice = clientutil.DeprecationNotice
+
+// WithLogger sets the logger that receives the client's diagnostic messages,
+// such as a warning the first time an endpoint is reported deprecated. The
+// client logs nothing by default.
+func WithLogger(logger Logger) ClientOption {
+	return shared(clientutil.SetLogger(logger))
+}
+
+// WithRequestBodyLogging logs the JSON body of every POST, PUT, and PATCH
+// request to the WithLogger logger, or to the standard library's default
+// logger. Passwords, client secrets, and tokens are replaced with "***".
+func WithRequestBodyLogging() ClientOption {
+	return shared(clientutil.SetRequestBodyLogging())
+}
+
+// WithDeprecationCallback calls fn, once per endpoint per process, when a
+// response carries a Deprecation or Sunset header. fn must be safe for concurrent use.
+func WithDeprecationCallback(fn func(DeprecationNotice)) ClientOption {
+	return shared(clientutil.SetDeprecationCallback(fn))
+}
+
+// Clock tells the time and waits for durations to pass. Supplying a fake
+// implementation with WithClock makes time-dependent behavior testable
+// without real sleeps.
+type Clock = clientutil.Clock
+
+// WithClock replaces the system clock used for rate limiting, retries, and
+// measuring latencies. A nil clock restores the system clock.
+func WithClock(clock Clock) ClientOption {
+	return shared(clientutil.SetClock(clock))
+}
+
+// RetryPolicy configures how the client retries requests that fail
+// transiently. By default, transport errors and 429, 502, 503, and 504
+// responses are retried for idempotent requests only: those with a GET, HEAD,
+// OPTIONS, PUT, or DELETE method, or that carry an Idempotency-Key header.
+// Retryable adds retries on top of those rules.
+type RetryPolicy = clientutil.RetryPolicy
+
+// RetryPredicate reports whether a request should be retried given the response
+// of an attempt, or the error it failed with. The response body can be read in
+// full without affecting the response returned to the caller.
+type RetryPredicate = clientutil.RetryPredicate
+
+// Backoff computes the growing delays between retries
+type Backoff = clientutil.Backoff
+
+// WithRetryPolicy retries requests that fail transiently under policy,
+// waiting for the backoff delay or Retry-After between attempts. Requests
+// whose body cannot be replayed are sent once.
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return shared(clientutil.SetRetryPolicy(policy))
+}
+
+// WithEndpointOverride sends requests for some endpoint groups to a different
+// base URL than BaseURL, for deployments where, for example, the admin API is
+// served from a separate hostname. Keys are EndpointGroupAdmin,
+// EndpointGroupOAuth, and EndpointGroupUser; groups without an override, and
+// the health check, use BaseURL. The URLs are validated by NewClientWithOptions.
+//
+// Parameters:
+//   - overrides: Base URLs keyed by endpoint group
+//
+// Returns:
+//   - ClientOption: A functional option to configure the client
+func WithEndpointOverride(overrides map[string]string) ClientOption {
+	return func(c *Client) {
+		if c.endpointOverrides == nil {
+			c.endpointOverrides = make(map[string]string, len(overrides))
+		}
+		for group, u := range overrides {
+			c.endpointOverrides[group] = u
+		}
+	}
+}
+
+// WithJWKSCacheTTL sets how long VerifyToken reuses a fetched JWKS before
+// fetching it again. A token signed by a key missing from the cached JWKS
+// triggers a refetch, at most once a minute, so key rotation does not wait
+// for the TTL.
+//
+// Parameters:
+//   - ttl: How long to cache the JWKS
+//
+// Returns:
+//   - ClientOption: A functional option to configure the client
+func WithJWKSCacheTTL(ttl time.Duration) ClientOption {
+	return func(c *Client) {
+		c.jwks.ttl = ttl
+	}
+}
+
+// WithTokenIssuer makes VerifyToken reject tokens whose iss claim is not issuer.
+// VerifyToken requires it, along with WithTokenAudience.
+//
+// Parameters:
+//   - issuer: The expected token issuer
+//
+// Returns:
+//   - ClientOption: A functional option to configure the client
+func WithTokenIssuer(issuer string) ClientOption {
+	return func(c *Client) {
+		c.tokenIssuer = issuer
+	}
+}
+
+// WithTokenAudience makes VerifyToken reject tokens whose aud claim does not
+// include audience. VerifyToken requires it, along with WithTokenIssuer.
+//
+// Parameters:
+//   - audience: The expected token audience, typically the verifying service's identifier
+//
+// Returns:
+//   - ClientOption: A functional option to configure the client
+func WithTokenAudience(audience string) ClientOption {
+	return func(c *Client) {
+		c.tokenAudience = audience
+	}
+}
+
+// WithRawErrorBody attaches the full response body to the RawBody field of
+// returned API errors. It is off by default because error bodies may contain
+// sensitive data.
+func WithRawErrorBody() ClientOption {
+	return shared(clientutil.SetRawErrorBody())
+}
+
+// WithRequestTimeout bounds each API call with a per-call context deadline
+// instead of the HTTP client's overall Timeout, which is cleared on a copy of
+// the HTTP client. A shorter deadline on the caller's context still applies.
+func WithRequestTimeout(d time.Duration) ClientOption {
+	return shared(clientutil.SetRequestTimeout(d))
+}
+
 // NewClientWithOptions creates a new client with custom options.
 // It allows for flexible configuration of the client through functional options.
 //
@@ -102,17 +409,30 @@ func WithUserAgent(userAgent string) ClientOption {
 //
 // Returns:
 //   - *Client: A configured Auth client instance
-//   - error: An error if the URL cannot be parsed
+//   - error: An error if the URL or an endpoint override cannot be parsed
 func NewClientWithOptions(baseURL string, options ...ClientOption) (*Client, error) {
 	client, err := NewClient(baseURL)
 	if err != nil {
 		return nil, err
 	}
 
+	defaultHTTPClient := client.HTTPClient
 	for _, option := range options {
 		option(client)
 	}
 
+	client.HTTPClient, _, err = client.base.Configure(client.HTTPClient, defaultHTTPClient)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(client.endpointOverrides) > 0 {
+		client.endpoints, err = parseEndpointOverrides(client.endpointOverrides)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return client, nil
 }
 
@@ -121,28 +441,32 @@ func NewClientWithOptions(baseURL string, options ...ClientOption) (*Client, err
 // Parameters:
 //   - ctx: Context for the API request
 //   - req: ClientCredentialCreateRequest containing credential details (required fields: IssuedTo, Scopes)
+//   - opts: Optional call settings, such as WithDryRun
 //
 // Returns:
-//   - *ClientCredentialCreateResponse: The created credential including the client ID and secret
+//   - *ClientCredentialCreateResponse: The created credential including the client ID and secret,
+//     or the validated credential (which may lack a secret) for a dry run
 //   - error: An error if the creation fails, which can be:
 //   - apierror.ErrorResponse with codes like:
 //   - "bad_request" if the request is invalid
 //   - "unauthorized" if authentication fails
 //   - "forbidden" if the caller lacks permissions
 //   - "network_error" if the connection fails
-func (c *Client) CreateClientCredential(ctx context.Context, req ClientCredentialCreateRequest) (*ClientCredentialCreateResponse, error) {
+func (c *Client) CreateClientCredential(ctx context.Context, req ClientCredentialCreateRequest, opts ...CallOption) (*ClientCredentialCreateResponse, error) {
 	httpReq, err := c.newRequest(ctx, "POST", "/admin/credentials", req)
 	if err != nil {
 		return nil, err
 	}
+	dryRun := applyCallOptions(httpReq, opts).dryRun
 
 	var resp ClientCredentialCreateResponse
-	httpResp, err := c.do(httpReq, &resp)
+	httpResp, err := c.do("CreateClientCredential", httpReq, &resp)
 	if err != nil {
 		return nil, err
 	}
 
-	if httpResp.StatusCode != http.StatusCreated {
+	// Nothing is created on a dry run, so the service answers 200 rather than 201
+	if httpResp.StatusCode != http.StatusCreated && !(dryRun && httpResp.StatusCode == http.StatusOK) {
 		return nil, fmt.Errorf("unexpected status code: %d", httpResp.StatusCode)
 	}
 
@@ -168,31 +492,65 @@ func (c *Client) CreateClientCredential(ctx context.Context, req ClientCredentia
 //   - "network_error" if the connection fails
 //   - "server_error" if the API server experiences an error
 func (c *Client) ListClientCredentials(ctx context.Context, issuedToFilter, tenantIDFilter, scopeFilter string, activeOnly, inactiveOnly bool) (*ListClientCredentialsResponse, error) {
+	return c.ListClientCredentialsWithOptions(ctx, &ListClientCredentialsOptions{
+		IssuedTo:     issuedToFilter,
+		TenantID:     tenantIDFilter,
+		Scope:        scopeFilter,
+		ActiveOnly:   activeOnly,
+		InactiveOnly: inactiveOnly,
+	})
+}
+
+// ListClientCredentialsWithOptions lists a page of client credentials with
+// optional filters. Pass the returned NextToken in opts to fetch the next page,
+// or use IterateClientCredentials to walk every page.
+//
+// Parameters:
+//   - ctx: Context for the API request
+//   - opts: Optional filters and pagination (nil lists all credentials)
+//
+// Returns:
+//   - *ListClientCredentialsResponse: A page of matching credentials and optional pagination token
+//   - error: An error if the operation fails, which can be:
+//   - apierror.ErrorResponse with codes like:
+//   - "unauthorized" if authentication fails
+//   - "forbidden" if the caller lacks permissions
+//   - "network_error" if the connection fails
+//   - "server_error" if the API server experiences an error
+func (c *Client) ListClientCredentialsWithOptions(ctx context.Context, opts *ListClientCredentialsOptions) (*ListClientCredentialsResponse, error) {
 	httpReq, err := c.newRequest(ctx, "GET", "/admin/credentials", nil)
 	if err != nil {
 		return nil, err
 	}
 
 	// Add query parameters if they are provided
-	q := httpReq.URL.Query()
-	if issuedToFilter != "" {
-		q.Add("issuedTo", issuedToFilter)
-	}
-	if tenantIDFilter != "" {
-		q.Add("tenantId", tenantIDFilter)
-	}
-	if scopeFilter != "" {
-		q.Add("scope", scopeFilter)
-	}
-	if activeOnly {
-		q.Add("active", "true")
-	} else if inactiveOnly {
-		q.Add("active", "false")
+	if opts != nil {
+		q := url.Values{}
+		if opts.IssuedTo != "" {
+			q.Add("issuedTo", opts.IssuedTo)
+		}
+		if opts.TenantID != "" {
+			q.Add("tenantId", opts.TenantID)
+		}
+		if opts.Scope != "" {
+			q.Add("scope", opts.Scope)
+		}
+		if opts.ActiveOnly {
+			q.Add("active", "true")
+		} else if opts.InactiveOnly {
+			q.Add("active", "false")
+		}
+		if opts.Limit > 0 {
+			q.Add("limit", strconv.Itoa(opts.Limit))
+		}
+		if opts.NextToken != "" {
+			q.Add("nextToken", opts.NextToken)
+		}
+		httpReq.URL.RawQuery = q.Encode()
 	}
-	httpReq.URL.RawQuery = q.Encode()
 
 	var resp ListClientCredentialsResponse
-	_, err = c.do(httpReq, &resp)
+	_, err = c.do("ListClientCredentials", httpReq, &resp)
 	if err != nil {
 		return nil, err
 	}
@@ -200,6 +558,36 @@ func (c *Client) ListClientCredentials(ctx context.Context, issuedToFilter, tena
 	return &resp, nil
 }
 
+// ExportCredentials returns the metadata of every client credential belonging
+// to a tenant, following pagination until all pages have been read. It is
+// intended for backups; secrets are never included because the list endpoint
+// does not return them and ClientCredentialResponse has no field to hold one.
+//
+// Parameters:
+//   - ctx: Context for the API requests
+//   - tenantID: The tenant whose credentials to export (empty exports all visible credentials)
+//
+// Returns:
+//   - []ClientCredentialResponse: All matching credentials, without secrets
+//   - error: An error if any page request fails, which can be:
+//   - apierror.ErrorResponse with codes like:
+//   - "unauthorized" if authentication fails
+//   - "forbidden" if the caller lacks permissions
+//   - "network_error" if the connection fails
+func (c *Client) ExportCredentials(ctx context.Context, tenantID string) ([]ClientCredentialResponse, error) {
+	var credentials []ClientCredentialResponse
+
+	it := c.IterateClientCredentials(&ListClientCredentialsOptions{TenantID: tenantID})
+	for it.Next(ctx) {
+		credentials = append(credentials, it.Credential())
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+
+	return credentials, nil
+}
+
 // GetClientCredential gets a client credential by its ID.
 //
 // Parameters:
@@ -222,7 +610,7 @@ func (c *Client) GetClientCredential(ctx context.Context, id string) (*ClientCre
 	}
 
 	var resp ClientCredentialResponse
-	_, err = c.do(httpReq, &resp)
+	_, err = c.do("GetClientCredential", httpReq, &resp)
 	if err != nil {
 		return nil, err
 	}
@@ -254,7 +642,68 @@ func (c *Client) UpdateClientCredential(ctx context.Context, id string, req Clie
 	}
 
 	var resp ClientCredentialResponse
-	_, err = c.do(httpReq, &resp)
+	_, err = c.do("UpdateClientCredential", httpReq, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// DeactivateClientCredential marks a client credential as inactive without
+// deleting it, so that it can be reactivated if a rotation has to be rolled back.
+//
+// Parameters:
+//   - ctx: Context for the API request
+//   - id: The unique identifier of the credential to deactivate (required)
+//
+// Returns:
+//   - *ClientCredentialResponse: The updated credential details
+//   - error: An error if the operation fails (see UpdateClientCredential)
+func (c *Client) DeactivateClientCredential(ctx context.Context, id string) (*ClientCredentialResponse, error) {
+	active := false
+	return c.UpdateClientCredential(ctx, id, ClientCredentialUpdateRequest{Active: &active})
+}
+
+// ActivateClientCredential marks a client credential as active.
+//
+// Parameters:
+//   - ctx: Context for the API request
+//   - id: The unique identifier of the credential to activate (required)
+//
+// Returns:
+//   - *ClientCredentialResponse: The updated credential details
+//   - error: An error if the operation fails (see UpdateClientCredential)
+func (c *Client) ActivateClientCredential(ctx context.Context, id string) (*ClientCredentialResponse, error) {
+	active := true
+	return c.UpdateClientCredential(ctx, id, ClientCredentialUpdateRequest{Active: &active})
+}
+
+// RotateClientCredentialSecret replaces the secret of a client credential while
+// keeping its client_id, so that dependents only need the new secret.
+// As on creation, the new secret is only returned once and cannot be retrieved later.
+//
+// Parameters:
+//   - ctx: Context for the API request
+//   - id: The unique identifier of the credential to rotate (required)
+//
+// Returns:
+//   - *ClientCredentialCreateResponse: The credential details including the new secret
+//   - error: An error if the operation fails, which can be:
+//   - apierror.ErrorResponse with codes like:
+//   - "not_found" if the credential doesn't exist
+//   - "unauthorized" if authentication fails
+//   - "forbidden" if the caller lacks permissions
+//   - "network_error" if the connection fails
+func (c *Client) RotateClientCredentialSecret(ctx context.Context, id string) (*ClientCredentialCreateResponse, error) {
+	path := fmt.Sprintf("/admin/credentials/%s/rotate", id)
+	httpReq, err := c.newRequest(ctx, "POST", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp ClientCredentialCreateResponse
+	_, err = c.do("RotateClientCredentialSecret", httpReq, &resp)
 	if err != nil {
 		return nil, err
 	}
@@ -282,7 +731,7 @@ func (c *Client) DeleteClientCredential(ctx context.Context, id string) error {
 		return err
 	}
 
-	resp, err := c.do(httpReq, nil)
+	resp, err := c.do("DeleteClientCredential", httpReq, nil)
 	if err != nil {
 		return err
 	}
@@ -294,39 +743,34 @@ func (c *Client) DeleteClientCredential(ctx context.Context, id string) error {
 	return nil
 }
 
-// newRequest creates an API request
-func (c *Client) newRequest(ctx context.Context, method, path string, body interface{}) (*http.Request, error) {
-	// Create the URL for the request
-	u := c.BaseURL.JoinPath(path)
-
-	var buf io.ReadWriter
-	if body != nil {
-		buf = new(bytes.Buffer)
-		err := json.NewEncoder(buf).Encode(body)
-		if err != nil {
-			return nil, err
-		}
-	}
+// baseClient returns the BaseClient that builds and sends c's requests, with
+// c's current BaseURL, HTTPClient, and UserAgent
+func (c *Client) baseClient() *clientutil.BaseClient {
+	b := c.base
+	b.BaseURL = c.BaseURL
+	b.HTTPClient = c.HTTPClient
+	b.UserAgent = c.UserAgent
+	return &b
+}
 
-	req, err := http.NewRequestWithContext(ctx, method, u.String(), buf)
+// newRequest creates an API request, sending it to the endpoint override for
+// the path's endpoint group if one is set, and routing it to the tenant set by
+// WithTenantID or ContextWithTenantID
+func (c *Client) newRequest(ctx context.Context, method, path string, body interface{}) (*http.Request, error) {
+	req, err := c.baseClient().NewRequestWithBase(ctx, c.baseURLFor(path), method, path, body)
 	if err != nil {
 		return nil, err
 	}
-
-	if body != nil {
-		req.Header.Set("Content-Type", "application/json")
-	}
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("User-Agent", c.UserAgent)
-
+	c.setTenantID(req)
 	return req, nil
 }
 
-// do sends an API request and returns the API response.
-// The API response is JSON decoded and stored in the value
+// do sends an API request for the named client method and returns the API
+// response. The API response is JSON decoded and stored in the value
 // pointed to by v, or returned as an error if an API error has occurred.
-func (c *Client) do(req *http.Request, v interface{}) (*http.Response, error) {
-	return clientutil.ExecuteRequest(req.Context(), c.HTTPClient, req, v)
+func (c *Client) do(operation string, req *http.Request, v interface{}) (*http.Response, error) {
+	b := c.baseClient()
+	return b.Do(req, v, b.RequestOptions(operation)...)
 }
 
 // Health checks the health status of the Auth API.
@@ -347,7 +791,7 @@ func (c *Client) Health(ctx context.Context) (*HealthResponse, error) {
 	}
 
 	var resp HealthResponse
-	_, err = c.do(req, &resp)
+	_, err = c.do("Health", req, &resp)
 	if err != nil {
 		return nil, err
 	}
@@ -362,6 +806,7 @@ func (c *Client) Health(ctx context.Context) (*HealthResponse, error) {
 //   - clientID: The client identifier (required)
 //   - clientSecret: The client secret (required)
 //   - scope: Optional space-delimited list of requested permission scopes
+//   - opts: Optional call settings, such as WithResponseHeaders
 //
 // Returns:
 //   - *TokenResponse: The token response containing access_token, token_type, and expires_in
@@ -371,7 +816,7 @@ func (c *Client) Health(ctx context.Context) (*HealthResponse, error) {
 //   - "unauthorized" if authentication fails
 //   - "network_error" if the connection fails
 //   - "server_error" if the API server experiences an error
-func (c *Client) GetClientCredentialsToken(ctx context.Context, clientID, clientSecret, scope string) (*TokenResponse, error) {
+func (c *Client) GetClientCredentialsToken(ctx context.Context, clientID, clientSecret, scope string, opts ...CallOption) (*TokenResponse, error) {
 	req := ClientCredentialsRequest{
 		GrantType:    "client_credentials",
 		ClientID:     clientID,
@@ -384,15 +829,42 @@ func (c *Client) GetClientCredentialsToken(ctx context.Context, clientID, client
 		return nil, err
 	}
 
+	options := applyCallOptions(httpReq, opts)
+
 	var resp TokenResponse
-	_, err = c.do(httpReq, &resp)
+	httpResp, err := c.do("GetClientCredentialsToken", httpReq, &resp)
 	if err != nil {
 		return nil, err
 	}
+	options.storeResponseHeaders(httpResp)
 
 	return &resp, nil
 }
 
+// GetClientCredentialsTokenWithScopes obtains an OAuth token using the client
+// credentials flow, requesting the given scopes. The scopes are joined with
+// single spaces as the OAuth scope parameter requires, so callers need not
+// build the delimited string themselves.
+//
+// Parameters:
+//   - ctx: Context for the API request
+//   - clientID: The client identifier (required)
+//   - clientSecret: The client secret (required)
+//   - scopes: Optional requested permission scopes, none of which may be empty or contain whitespace
+//   - opts: Optional call settings, such as WithResponseHeaders
+//
+// Returns:
+//   - *TokenResponse: The token response containing access_token, token_type, and expires_in
+//   - error: An error if a scope is invalid, without sending a request, or any
+//     error returned by GetClientCredentialsToken
+func (c *Client) GetClientCredentialsTokenWithScopes(ctx context.Context, clientID, clientSecret string, scopes []string, opts ...CallOption) (*TokenResponse, error) {
+	if err := validateScopes(scopes); err != nil {
+		return nil, err
+	}
+
+	return c.GetClientCredentialsToken(ctx, clientID, clientSecret, strings.Join(scopes, " "), opts...)
+}
+
 // SignupUser registers a new user with the provided email and password.
 //
 // Parameters:
@@ -406,7 +878,8 @@ func (c *Client) GetClientCredentialsToken(ctx context.Context, clientID, client
 //   - error: An error if the operation fails, which can be:
 //   - apierror.ErrorResponse with codes like:
 //   - "bad_request" if the email or password is invalid
-//   - "conflict" if the user already exists
+//   - "conflict" if the user already exists; errors.Is(err, ErrConflict) matches
+//     it whatever code the service reports
 //   - "network_error" if the connection fails
 //   - "server_error" if the API server experiences an error
 func (c *Client) SignupUser(ctx context.Context, email, password string, attributes map[string]string) (*UserSignupResponse, error) {
@@ -422,7 +895,7 @@ func (c *Client) SignupUser(ctx context.Context, email, password string, attribu
 	}
 
 	var resp UserSignupResponse
-	_, err = c.do(httpReq, &resp)
+	_, err = c.do("SignupUser", httpReq, &resp)
 	if err != nil {
 		return nil, err
 	}
@@ -455,10 +928,41 @@ func (c *Client) ConfirmSignup(ctx context.Context, username, code string) error
 		return err
 	}
 
-	_, err = c.do(httpReq, nil)
+	_, err = c.do("ConfirmSignup", httpReq, nil)
 	return err
 }
 
+// ConfirmSignupAndLogin confirms a user signup with a verification code and,
+// once confirmed, logs the user in with their password. Login is not attempted
+// if the confirmation fails. The returned error wraps both the API error and
+// ErrSignupConfirmationFailed or ErrLoginAfterConfirmationFailed, so errors.Is
+// tells the failed step apart; after a failed login the account stays
+// confirmed, and LoginUser may be retried on its own.
+//
+// Parameters:
+//   - ctx: Context for the API requests
+//   - username: The email address or username of the account to confirm (required)
+//   - code: The verification code sent to the user during signup (required)
+//   - password: The user's password (required)
+//
+// Returns:
+//   - *TokenResponse: The token response containing access_token, id_token, refresh_token
+//   - error: An error if either step fails, which can be:
+//   - ErrSignupConfirmationFailed wrapping any error returned by ConfirmSignup
+//   - ErrLoginAfterConfirmationFailed wrapping any error returned by LoginUser
+func (c *Client) ConfirmSignupAndLogin(ctx context.Context, username, code, password string) (*TokenResponse, error) {
+	if err := c.ConfirmSignup(ctx, username, code); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrSignupConfirmationFailed, err)
+	}
+
+	token, err := c.LoginUser(ctx, username, password)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrLoginAfterConfirmationFailed, err)
+	}
+
+	return token, nil
+}
+
 // ResendConfirmationCode resends a confirmation code to a user.
 //
 // Parameters:
@@ -486,7 +990,7 @@ func (c *Client) ResendConfirmationCode(ctx context.Context, username string) (*
 	var resp struct {
 		CodeDeliveryDetails *CodeDeliveryDetails `json:"codeDeliveryDetails"`
 	}
-	_, err = c.do(httpReq, &resp)
+	_, err = c.do("ResendConfirmationCode", httpReq, &resp)
 	if err != nil {
 		return nil, err
 	}
@@ -522,7 +1026,7 @@ func (c *Client) LoginUser(ctx context.Context, username, password string) (*Tok
 	}
 
 	var resp TokenResponse
-	_, err = c.do(httpReq, &resp)
+	_, err = c.do("LoginUser", httpReq, &resp)
 	if err != nil {
 		return nil, err
 	}
@@ -552,7 +1056,7 @@ func (c *Client) LogoutUser(ctx context.Context, accessToken string) error {
 		return err
 	}
 
-	_, err = c.do(httpReq, nil)
+	_, err = c.do("LogoutUser", httpReq, nil)
 	return err
 }
 
@@ -581,7 +1085,7 @@ func (c *Client) RequestPasswordReset(ctx context.Context, email string) (*Passw
 	}
 
 	var resp PasswordResetResponse
-	_, err = c.do(httpReq, &resp)
+	_, err = c.do("RequestPasswordReset", httpReq, &resp)
 	if err != nil {
 		return nil, err
 	}
@@ -616,7 +1120,7 @@ func (c *Client) ConfirmPasswordReset(ctx context.Context, email, code, newPassw
 		return err
 	}
 
-	_, err = c.do(httpReq, nil)
+	_, err = c.do("ConfirmPasswordReset", httpReq, nil)
 	return err
 }
 
@@ -642,7 +1146,7 @@ func (c *Client) GetUserProfile(ctx context.Context, accessToken string) (*UserP
 	httpReq.Header.Set("Authorization", "Bearer "+accessToken)
 
 	var resp UserProfileResponse
-	_, err = c.do(httpReq, &resp)
+	_, err = c.do("GetUserProfile", httpReq, &resp)
 	if err != nil {
 		return nil, err
 	}
@@ -2,8 +2,12 @@ package auth
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
@@ -42,6 +46,27 @@ func TestNewClient(t *testing.T) {
 				return err != nil && err.Error() != ""
 			},
 		},
+		{
+			name:    "missing scheme",
+			baseURL: "api.example.com",
+			wantErr: true,
+			errCheck: func(err error) bool {
+				return err.Error() == "invalid base URL: missing scheme"
+			},
+		},
+		{
+			name:    "missing host",
+			baseURL: "https:///v1",
+			wantErr: true,
+			errCheck: func(err error) bool {
+				return err.Error() == "invalid base URL: missing host"
+			},
+		},
+		{
+			name:    "valid URL with path and trailing slash",
+			baseURL: "https://api.example.com/v1/",
+			wantErr: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -97,6 +122,85 @@ func TestClientOptions(t *testing.T) {
 	}
 }
 
+func TestWithConnectionPool(t *testing.T) {
+	client, err := NewClientWithOptions("https://api.example.com", WithConnectionPool(64, 16, 32, 45*time.Second))
+	require.NoError(t, err)
+
+	transport, ok := client.HTTPClient.Transport.(*http.Transport)
+	require.True(t, ok, "Transport = %T, want *http.Transport", client.HTTPClient.Transport)
+	assert.Equal(t, 64, transport.MaxIdleConns)
+	assert.Equal(t, 16, transport.MaxIdleConnsPerHost)
+	assert.Equal(t, 32, transport.MaxConnsPerHost)
+	assert.Equal(t, 45*time.Second, transport.IdleConnTimeout)
+	assert.Equal(t, DefaultTimeout, client.HTTPClient.Timeout)
+}
+
+func TestWithConnectionPool_HTTPClientTakesPrecedence(t *testing.T) {
+	for _, order := range []string{"pool first", "client first"} {
+		t.Run(order, func(t *testing.T) {
+			custom := &http.Client{}
+			options := []ClientOption{WithConnectionPool(64, 16, 32, 45*time.Second), WithHTTPClient(custom)}
+			if order == "client first" {
+				options[0], options[1] = options[1], options[0]
+			}
+
+			client, err := NewClientWithOptions("https://api.example.com", options...)
+			require.NoError(t, err)
+			assert.Same(t, custom, client.HTTPClient)
+			assert.Nil(t, custom.Transport)
+		})
+	}
+}
+
+func TestWithTLSConfig(t *testing.T) {
+	config := &tls.Config{ServerName: "auth.atriumn.internal"}
+	pool := x509.NewCertPool()
+
+	client, err := NewClientWithOptions("https://api.example.com", WithTLSConfig(config), WithRootCAs(pool))
+	require.NoError(t, err)
+
+	transport, ok := client.HTTPClient.Transport.(*http.Transport)
+	require.True(t, ok, "Transport = %T, want *http.Transport", client.HTTPClient.Transport)
+	require.NotNil(t, transport.TLSClientConfig)
+	assert.Equal(t, "auth.atriumn.internal", transport.TLSClientConfig.ServerName)
+	assert.Same(t, pool, transport.TLSClientConfig.RootCAs)
+	assert.False(t, transport.TLSClientConfig.InsecureSkipVerify)
+}
+
+func TestWithTLSConfig_HTTPClientTakesPrecedence(t *testing.T) {
+	custom := &http.Client{}
+	client, err := NewClientWithOptions("https://api.example.com",
+		WithRootCAs(x509.NewCertPool()), WithHTTPClient(custom), WithTLSConfig(&tls.Config{}))
+	require.NoError(t, err)
+	assert.Same(t, custom, client.HTTPClient)
+	assert.Nil(t, custom.Transport)
+}
+
+func TestWithInsecureSkipVerify(t *testing.T) {
+	insecure := &tls.Config{InsecureSkipVerify: true}
+
+	_, err := NewClientWithOptions("https://api.example.com", WithTLSConfig(insecure))
+	assert.ErrorIs(t, err, ErrInsecureTLSConfig)
+
+	client, err := NewClientWithOptions("https://api.example.com", WithTLSConfig(insecure), WithInsecureSkipVerify())
+	require.NoError(t, err)
+	assert.True(t, client.HTTPClient.Transport.(*http.Transport).TLSClientConfig.InsecureSkipVerify)
+}
+
+func TestWithUserAgentSuffix(t *testing.T) {
+	client, err := NewClientWithOptions("https://api.example.com", WithUserAgentSuffix("my-app/2.3"))
+	require.NoError(t, err)
+
+	assert.Equal(t, DefaultUserAgent+" my-app/2.3", client.UserAgent)
+	assert.Contains(t, client.UserAgent, "atriumn-auth-client/1.0")
+	assert.Contains(t, client.UserAgent, "atriumn-sdk-go/")
+	assert.Contains(t, client.UserAgent, "my-app/2.3")
+
+	custom, err := NewClientWithOptions("https://api.example.com", WithUserAgent("custom/1.0"), WithUserAgentSuffix("my-app/2.3"))
+	require.NoError(t, err)
+	assert.Equal(t, "custom/1.0 my-app/2.3", custom.UserAgent)
+}
+
 func TestHealth(t *testing.T) {
 	// Create a test server
 	server, client := setupTestServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -177,6 +281,51 @@ func TestGetClientCredentialsToken(t *testing.T) {
 	}
 }
 
+func TestGetClientCredentialsTokenWithScopes(t *testing.T) {
+	var scope string
+	server, client := setupTestServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req ClientCredentialsRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		scope = req.Scope
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintln(w, `{"access_token": "test-token", "token_type": "Bearer", "expires_in": 3600}`)
+	}))
+	defer server.Close()
+
+	token, err := client.GetClientCredentialsTokenWithScopes(context.Background(), "test-client", "test-secret",
+		[]string{"ingest:read", "ingest:write", "ai:invoke"})
+	require.NoError(t, err)
+	assert.Equal(t, "test-token", token.AccessToken)
+	assert.Equal(t, "ingest:read ingest:write ai:invoke", scope)
+
+	_, err = client.GetClientCredentialsTokenWithScopes(context.Background(), "test-client", "test-secret", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "", scope)
+}
+
+func TestGetClientCredentialsTokenWithScopes_InvalidScope(t *testing.T) {
+	var calls int
+	server, client := setupTestServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+	}))
+	defer server.Close()
+
+	for _, scopes := range [][]string{
+		{"ingest:read", "ingest:write ai:invoke"},
+		{"ingest:read\tai:invoke"},
+		{"ingest:read", ""},
+	} {
+		_, err := client.GetClientCredentialsTokenWithScopes(context.Background(), "test-client", "test-secret", scopes)
+		assert.Error(t, err, "scopes %q", scopes)
+	}
+
+	_, err := client.GetClientCredentialsTokenWithScopes(context.Background(), "test-client", "test-secret",
+		[]string{"ingest:read", "ingest:write ai:invoke"})
+	assert.EqualError(t, err, `scope 1 ("ingest:write ai:invoke") contains whitespace`)
+	assert.Equal(t, 0, calls, "no request should be sent for invalid scopes")
+}
+
 func TestSignupUser(t *testing.T) {
 	server, client := setupTestServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != "POST" {
@@ -217,6 +366,115 @@ func TestSignupUser(t *testing.T) {
 	}
 }
 
+func TestSignupUser_Conflict(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		wantCode string
+	}{
+		{name: "empty body", body: "", wantCode: "conflict"},
+		{name: "service error code", body: `{"error": "user_exists", "error_description": "User already exists"}`, wantCode: "user_exists"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, client := setupTestServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusConflict)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			resp, err := client.SignupUser(context.Background(), "test@example.com", "password123", nil)
+			require.Error(t, err)
+			assert.Nil(t, resp)
+			assert.True(t, errors.Is(err, ErrConflict))
+			assert.True(t, errors.Is(err, apierror.ErrConflict))
+
+			var errorResp *apierror.ErrorResponse
+			require.True(t, errors.As(err, &errorResp))
+			assert.Equal(t, tt.wantCode, errorResp.ErrorCode)
+			assert.Equal(t, http.StatusConflict, errorResp.StatusCode)
+		})
+	}
+}
+
+// confirmAndLoginServer serves the signup confirmation and login endpoints,
+// answering each with the given status and recording the paths requested
+func confirmAndLoginServer(t *testing.T, confirmStatus, loginStatus int, paths *[]string) (*httptest.Server, *Client) {
+	return setupTestServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*paths = append(*paths, r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/auth/signup/confirm":
+			var req ConfirmSignupRequest
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, "test@example.com", req.Username)
+			assert.Equal(t, "123456", req.ConfirmationCode)
+			w.WriteHeader(confirmStatus)
+		case "/auth/login":
+			var req UserLoginRequest
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, "test@example.com", req.Username)
+			assert.Equal(t, "password123", req.Password)
+			w.WriteHeader(loginStatus)
+			if loginStatus == http.StatusOK {
+				_, _ = fmt.Fprintln(w, `{"access_token": "access-token", "token_type": "Bearer", "expires_in": 3600}`)
+			}
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+}
+
+func TestConfirmSignupAndLogin(t *testing.T) {
+	var paths []string
+	server, client := confirmAndLoginServer(t, http.StatusOK, http.StatusOK, &paths)
+	defer server.Close()
+
+	token, err := client.ConfirmSignupAndLogin(context.Background(), "test@example.com", "123456", "password123")
+	require.NoError(t, err)
+	assert.Equal(t, "access-token", token.AccessToken)
+	assert.Equal(t, []string{"/auth/signup/confirm", "/auth/login"}, paths)
+}
+
+func TestConfirmSignupAndLogin_ConfirmationFails(t *testing.T) {
+	var paths []string
+	server, client := confirmAndLoginServer(t, http.StatusBadRequest, http.StatusOK, &paths)
+	defer server.Close()
+
+	token, err := client.ConfirmSignupAndLogin(context.Background(), "test@example.com", "123456", "password123")
+	require.Error(t, err)
+	assert.Nil(t, token)
+	assert.True(t, errors.Is(err, ErrSignupConfirmationFailed))
+	assert.False(t, errors.Is(err, ErrLoginAfterConfirmationFailed))
+
+	var apiErr *apierror.ErrorResponse
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, "bad_request", apiErr.ErrorCode)
+
+	// No login is attempted
+	assert.Equal(t, []string{"/auth/signup/confirm"}, paths)
+}
+
+func TestConfirmSignupAndLogin_LoginFails(t *testing.T) {
+	var paths []string
+	server, client := confirmAndLoginServer(t, http.StatusOK, http.StatusUnauthorized, &paths)
+	defer server.Close()
+
+	token, err := client.ConfirmSignupAndLogin(context.Background(), "test@example.com", "123456", "password123")
+	require.Error(t, err)
+	assert.Nil(t, token)
+	assert.True(t, errors.Is(err, ErrLoginAfterConfirmationFailed))
+	assert.False(t, errors.Is(err, ErrSignupConfirmationFailed))
+
+	var apiErr *apierror.ErrorResponse
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, "unauthorized", apiErr.ErrorCode)
+	assert.Equal(t, []string{"/auth/signup/confirm", "/auth/login"}, paths)
+}
+
 func TestLoginUser(t *testing.T) {
 	server, client := setupTestServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != "POST" {
@@ -608,6 +866,64 @@ func TestCreateClientCredential_Error(t *testing.T) {
 	assert.Equal(t, "Missing required fields", errorResp.Description)
 }
 
+func TestCreateClientCredential_Conflict(t *testing.T) {
+	server, client := setupTestServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusConflict)
+		_, _ = w.Write([]byte(`{"error": "duplicate_credential", "error_description": "Credential already exists"}`))
+	}))
+	defer server.Close()
+
+	resp, err := client.CreateClientCredential(context.Background(), ClientCredentialCreateRequest{
+		IssuedTo: "Test App",
+		Scopes:   []string{"read:users"},
+	})
+
+	require.Error(t, err)
+	require.Nil(t, resp)
+	assert.True(t, errors.Is(err, ErrConflict))
+	errorResp, ok := err.(*apierror.ErrorResponse)
+	require.True(t, ok, "Expected error to be *apierror.ErrorResponse")
+	assert.Equal(t, "duplicate_credential", errorResp.ErrorCode)
+	assert.Equal(t, http.StatusConflict, errorResp.StatusCode)
+}
+
+func TestCreateClientCredential_DryRun(t *testing.T) {
+	var queries []url.Values
+	server, client := setupTestServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.Query())
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("dryRun") == "true" {
+			// Nothing is persisted, so there is no ID or secret
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{"issued_to": "Test App", "scopes": ["read:users"], "active": true}`))
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id": "cred-123", "client_id": "client-123", "client_secret": "secret-abc", "issued_to": "Test App", "scopes": ["read:users"], "active": true}`))
+	}))
+	defer server.Close()
+
+	req := ClientCredentialCreateRequest{
+		IssuedTo: "Test App",
+		Scopes:   []string{"read:users"},
+	}
+
+	resp, err := client.CreateClientCredential(context.Background(), req, WithDryRun())
+	require.NoError(t, err)
+	assert.Empty(t, resp.ClientSecret)
+	assert.Equal(t, "Test App", resp.IssuedTo)
+	assert.Equal(t, []string{"read:users"}, resp.Scopes)
+
+	resp, err = client.CreateClientCredential(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, "secret-abc", resp.ClientSecret)
+
+	require.Len(t, queries, 2)
+	assert.Equal(t, "true", queries[0].Get("dryRun"))
+	assert.False(t, queries[1].Has("dryRun"), "option leaked into a later call")
+}
+
 func TestListClientCredentials_Success(t *testing.T) {
 	server, client := setupTestServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Check request
@@ -957,3 +1273,202 @@ func TestGetClientCredential_NotFound(t *testing.T) {
 	assert.Equal(t, "not_found", errorResp.ErrorCode)
 	assert.Equal(t, "Credential not found", errorResp.Description)
 }
+
+func TestSetClientCredentialActive(t *testing.T) {
+	tests := []struct {
+		name       string
+		call       func(*Client) (*ClientCredentialResponse, error)
+		wantBody   string
+		wantActive bool
+	}{
+		{
+			name: "deactivate",
+			call: func(c *Client) (*ClientCredentialResponse, error) {
+				return c.DeactivateClientCredential(context.Background(), "cred-123")
+			},
+			wantBody:   `{"active":false}`,
+			wantActive: false,
+		},
+		{
+			name: "activate",
+			call: func(c *Client) (*ClientCredentialResponse, error) {
+				return c.ActivateClientCredential(context.Background(), "cred-123")
+			},
+			wantBody:   `{"active":true}`,
+			wantActive: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, client := setupTestServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "PATCH", r.Method)
+				assert.Equal(t, "/admin/credentials/cred-123", r.URL.Path)
+
+				body, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+				assert.JSONEq(t, tt.wantBody, string(body))
+
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = fmt.Fprintf(w, `{"id": "cred-123", "client_id": "client-123", "active": %t}`, tt.wantActive)
+			}))
+			defer server.Close()
+
+			resp, err := tt.call(client)
+			require.NoError(t, err)
+			assert.Equal(t, "cred-123", resp.ID)
+			assert.Equal(t, tt.wantActive, resp.Active)
+		})
+	}
+}
+
+func TestRotateClientCredentialSecret_Success(t *testing.T) {
+	server, client := setupTestServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "/admin/credentials/cred-123/rotate", r.URL.Path)
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"id": "cred-123",
+			"client_id": "client-123",
+			"client_secret": "new-secret",
+			"issued_to": "TestApp",
+			"active": true
+		}`))
+	}))
+	defer server.Close()
+
+	resp, err := client.RotateClientCredentialSecret(context.Background(), "cred-123")
+	require.NoError(t, err)
+	assert.Equal(t, "cred-123", resp.ID)
+	assert.Equal(t, "client-123", resp.ClientID)
+	assert.Equal(t, "new-secret", resp.ClientSecret)
+}
+
+func TestRotateClientCredentialSecret_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		wantCode   string
+	}{
+		{name: "not found", statusCode: http.StatusNotFound, wantCode: "not_found"},
+		{name: "unauthorized", statusCode: http.StatusUnauthorized, wantCode: "unauthorized"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, client := setupTestServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.statusCode)
+			}))
+			defer server.Close()
+
+			resp, err := client.RotateClientCredentialSecret(context.Background(), "cred-123")
+			require.Error(t, err)
+			assert.Nil(t, resp)
+			errorResp, ok := err.(*apierror.ErrorResponse)
+			require.True(t, ok, "Expected error to be *apierror.ErrorResponse")
+			assert.Equal(t, tt.wantCode, errorResp.ErrorCode)
+		})
+	}
+}
+
+func TestExportCredentials_Paging(t *testing.T) {
+	requests := 0
+	server, client := setupTestServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "/admin/credentials", r.URL.Path)
+		assert.Equal(t, "tenant-123", r.URL.Query().Get("tenantId"))
+
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Query().Get("nextToken") {
+		case "":
+			// The server leaks a secret here to prove it never reaches the export
+			_, _ = w.Write([]byte(`{
+				"credentials": [
+					{"id": "cred-1", "client_id": "client-1", "issued_to": "App1", "scopes": ["read:users"], "active": true, "tenant_id": "tenant-123", "client_secret": "leaked-secret"}
+				],
+				"next_token": "page-2"
+			}`))
+		case "page-2":
+			_, _ = w.Write([]byte(`{
+				"credentials": [
+					{"id": "cred-2", "client_id": "client-2", "issued_to": "App2", "scopes": ["write:users"], "active": false, "tenant_id": "tenant-123"}
+				]
+			}`))
+		default:
+			t.Errorf("unexpected nextToken %q", r.URL.Query().Get("nextToken"))
+		}
+	}))
+	defer server.Close()
+
+	credentials, err := client.ExportCredentials(context.Background(), "tenant-123")
+	require.NoError(t, err)
+	assert.Equal(t, 2, requests)
+	require.Len(t, credentials, 2)
+	assert.Equal(t, "cred-1", credentials[0].ID)
+	assert.Equal(t, "cred-2", credentials[1].ID)
+
+	exported, err := json.Marshal(credentials)
+	require.NoError(t, err)
+	assert.NotContains(t, string(exported), "secret")
+}
+
+func TestExportCredentials_Error(t *testing.T) {
+	server, client := setupTestServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	credentials, err := client.ExportCredentials(context.Background(), "tenant-123")
+	assert.Nil(t, credentials)
+	apiErr, ok := err.(*apierror.ErrorResponse)
+	require.True(t, ok)
+	assert.Equal(t, "forbidden", apiErr.ErrorCode)
+}
+
+func TestMaxResponseBytes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, `{"status":"ok"}`)
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithOptions(server.URL, WithMaxResponseBytes(8))
+	require.NoError(t, err)
+
+	_, err = client.Health(context.Background())
+	var apiErr *apierror.ErrorResponse
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, "response_too_large", apiErr.ErrorCode)
+
+	client, err = NewClientWithOptions(server.URL, WithMaxResponseBytes(64))
+	require.NoError(t, err)
+
+	health, err := client.Health(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "ok", health.Status)
+}
+
+// fixedTokenProvider returns a fixed token
+type fixedTokenProvider string
+
+func (p fixedTokenProvider) GetToken(ctx context.Context) (string, error) {
+	return string(p), nil
+}
+
+func TestWithTokenProvider(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer admin-token", r.Header.Get("Authorization"))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"cred-1","scopes":["read"]}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithOptions(server.URL, WithTokenProvider(fixedTokenProvider("admin-token")))
+	require.NoError(t, err)
+
+	cred, err := client.GetClientCredential(context.Background(), "cred-1")
+	require.NoError(t, err)
+	assert.Equal(t, "cred-1", cred.ID)
+}
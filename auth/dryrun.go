@@ -0,0 +1,36 @@
+package auth
+
+import "net/http"
+
+// CallOption configures a single API call. Options apply only to the call
+// they are passed to.
+type CallOption func(*callOptions)
+
+// callOptions holds the settings applied by CallOption functions.
+type callOptions struct {
+	dryRun          bool
+	responseHeaders *http.Header
+}
+
+// WithDryRun makes CreateClientCredential validate the request without creating
+// anything. The service responds with the resource it would have created.
+func WithDryRun() CallOption {
+	return func(o *callOptions) {
+		o.dryRun = true
+	}
+}
+
+// applyCallOptions applies opts to req and returns the resulting settings.
+func applyCallOptions(req *http.Request, opts []CallOption) *callOptions {
+	options := &callOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	if options.dryRun {
+		q := req.URL.Query()
+		q.Set("dryRun", "true")
+		req.URL.RawQuery = q.Encode()
+	}
+	return options
+}
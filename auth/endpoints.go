@@ -0,0 +1,66 @@
+package auth
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Endpoint groups accepted by WithEndpointOverride.
+const (
+	// EndpointGroupAdmin covers the credential management API under /admin
+	EndpointGroupAdmin = "admin"
+
+	// EndpointGroupOAuth covers token issuance (/auth/token) and the JWKS (/.well-known)
+	EndpointGroupOAuth = "oauth"
+
+	// EndpointGroupUser covers the remaining user-facing /auth endpoints, such as
+	// signup, login, logout, password reset, and profile
+	EndpointGroupUser = "user"
+)
+
+// endpointGroup returns the endpoint group that path belongs to, or "" if the
+// path always uses BaseURL.
+func endpointGroup(path string) string {
+	switch {
+	case strings.HasPrefix(path, "/admin/"):
+		return EndpointGroupAdmin
+	case path == "/auth/token", strings.HasPrefix(path, "/.well-known/"):
+		return EndpointGroupOAuth
+	case strings.HasPrefix(path, "/auth/"):
+		return EndpointGroupUser
+	}
+	return ""
+}
+
+// parseEndpointOverrides validates the raw overrides passed to
+// WithEndpointOverride and returns them parsed.
+func parseEndpointOverrides(overrides map[string]string) (map[string]*url.URL, error) {
+	endpoints := make(map[string]*url.URL, len(overrides))
+	for group, raw := range overrides {
+		switch group {
+		case EndpointGroupAdmin, EndpointGroupOAuth, EndpointGroupUser:
+		default:
+			return nil, fmt.Errorf("invalid endpoint override: unknown group %q", group)
+		}
+
+		u, err := url.Parse(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid endpoint override for %q: %w", group, err)
+		}
+		if u.Scheme == "" || u.Host == "" {
+			return nil, fmt.Errorf("invalid endpoint override for %q: %q is not an absolute URL", group, raw)
+		}
+		endpoints[group] = u
+	}
+	return endpoints, nil
+}
+
+// baseURLFor returns the base URL requests to path are sent to: the override
+// for its endpoint group if one is set, otherwise BaseURL.
+func (c *Client) baseURLFor(path string) *url.URL {
+	if u, ok := c.endpoints[endpointGroup(path)]; ok {
+		return u
+	}
+	return c.BaseURL
+}
@@ -0,0 +1,97 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEndpointGroup(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"/admin/credentials", EndpointGroupAdmin},
+		{"/admin/credentials/cred-1/rotate", EndpointGroupAdmin},
+		{"/auth/token", EndpointGroupOAuth},
+		{"/.well-known/jwks.json", EndpointGroupOAuth},
+		{"/auth/login", EndpointGroupUser},
+		{"/auth/me", EndpointGroupUser},
+		{"/health", ""},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, endpointGroup(tt.path), tt.path)
+	}
+}
+
+func TestWithEndpointOverride(t *testing.T) {
+	var defaultPaths, adminPaths []string
+	defaultServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defaultPaths = append(defaultPaths, r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, `{"access_token":"token","token_type":"Bearer","expires_in":3600}`)
+	}))
+	defer defaultServer.Close()
+	adminServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		adminPaths = append(adminPaths, r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, `{"id":"cred-1","client_id":"client-1","issued_to":"svc","scopes":["read"],"created_at":"2026-01-01T00:00:00Z","updated_at":"2026-01-01T00:00:00Z"}`)
+	}))
+	defer adminServer.Close()
+
+	client, err := NewClientWithOptions(defaultServer.URL, WithEndpointOverride(map[string]string{
+		EndpointGroupAdmin: adminServer.URL,
+	}))
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	_, err = client.GetClientCredential(ctx, "cred-1")
+	require.NoError(t, err)
+	_, err = client.LoginUser(ctx, "user@example.com", "password")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"/admin/credentials/cred-1"}, adminPaths)
+	assert.Equal(t, []string{"/auth/login"}, defaultPaths)
+}
+
+func TestWithEndpointOverride_Invalid(t *testing.T) {
+	tests := []struct {
+		name      string
+		overrides map[string]string
+	}{
+		{name: "unknown group", overrides: map[string]string{"billing": "https://billing.example.com"}},
+		{name: "relative URL", overrides: map[string]string{EndpointGroupAdmin: "admin.example.com"}},
+		{name: "unparsable URL", overrides: map[string]string{EndpointGroupOAuth: "://bad"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := NewClientWithOptions("https://auth.example.com", WithEndpointOverride(tt.overrides))
+			assert.Error(t, err)
+			assert.Nil(t, client)
+		})
+	}
+}
+
+func TestNewRequest_UsesBaseClient(t *testing.T) {
+	client, err := NewClientWithOptions("https://auth.example.com",
+		WithUserAgent("custom-agent"),
+		WithEndpointOverride(map[string]string{EndpointGroupAdmin: "https://admin.example.com"}),
+	)
+	require.NoError(t, err)
+
+	req, err := client.newRequest(context.Background(), "GET", "/admin/credentials", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "https://admin.example.com/admin/credentials", req.URL.String())
+	assert.Equal(t, "custom-agent", req.Header.Get("User-Agent"))
+
+	req, err = client.newRequest(context.Background(), "GET", "/auth/me", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "https://auth.example.com/auth/me", req.URL.String())
+}
@@ -0,0 +1,77 @@
+package auth
+
+import (
+	"context"
+	"errors"
+
+	"github.com/atriumn/atriumn-sdk-go/internal/apierror"
+	"github.com/atriumn/atriumn-sdk-go/internal/clientutil"
+)
+
+// ErrorResponse is the error returned for failed API calls. Use errors.As to
+// inspect its code, HTTP status, request ID, retry hint, and field-level
+// validation errors.
+type ErrorResponse = apierror.ErrorResponse
+
+// FieldError is one entry of ErrorResponse.ValidationErrors: a request field
+// the service rejected and the reason.
+type FieldError = apierror.FieldError
+
+// ErrConflict matches, via errors.Is, any error returned for an HTTP 409 Conflict
+// response, such as an attempt to create a resource that already exists.
+var ErrConflict error = apierror.ErrConflict
+
+// ErrNotFound matches, via errors.Is, any error returned for an HTTP 404 Not
+// Found response.
+var ErrNotFound error = apierror.ErrNotFound
+
+// ErrInsecureTLSConfig is returned by NewClientWithOptions when the config
+// passed to WithTLSConfig disables certificate verification without
+// WithInsecureSkipVerify.
+var ErrInsecureTLSConfig error = clientutil.ErrInsecureTLSConfig
+
+// ErrTooManyConcurrentRequests is returned by calls made while the limit set
+// with WithMaxConcurrentRequests and FailFast is reached.
+var ErrTooManyConcurrentRequests error = clientutil.ErrTooManyConcurrentRequests
+
+var (
+	// ErrInvalidToken is returned by VerifyToken when a token is malformed, its
+	// signature does not verify, or its issuer or audience does not match.
+	ErrInvalidToken = errors.New("invalid token")
+
+	// ErrTokenExpired is returned by VerifyToken when a token's exp claim has passed
+	// or its nbf claim has not yet been reached.
+	ErrTokenExpired = errors.New("token expired")
+
+	// ErrUnknownSigningKey is returned by VerifyToken when a token's kid does not
+	// match any key in the auth service's JWKS, even after refetching it.
+	ErrUnknownSigningKey = errors.New("unknown token signing key")
+
+	// ErrVerificationNotConfigured is returned by VerifyToken when the client
+	// was not created with both WithTokenIssuer and WithTokenAudience, without
+	// which a token issued for another service would be accepted.
+	ErrVerificationNotConfigured = errors.New("token verification requires WithTokenIssuer and WithTokenAudience")
+
+	// ErrSignupConfirmationFailed is wrapped by the error ConfirmSignupAndLogin
+	// returns when the confirmation step fails, in which case no login is attempted.
+	ErrSignupConfirmationFailed = errors.New("signup confirmation failed")
+
+	// ErrLoginAfterConfirmationFailed is wrapped by the error ConfirmSignupAndLogin
+	// returns when the signup was confirmed but the login that follows failed.
+	ErrLoginAfterConfirmationFailed = errors.New("login after signup confirmation failed")
+)
+
+// WithRequestID returns a copy of ctx that makes API calls made with it send id
+// as their X-Request-ID header instead of a generated ID. The ID sent is
+// reported in the RequestID field of returned API errors.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return clientutil.WithRequestID(ctx, id)
+}
+
+// WithAuthToken returns a copy of ctx that makes API calls made with it send
+// token as their bearer token instead of one from the client's TokenProvider.
+// Pass the resulting context to the other service clients to make their calls
+// as the user whose access token LoginUser returned.
+func WithAuthToken(ctx context.Context, token string) context.Context {
+	return clientutil.WithAuthToken(ctx, token)
+}
@@ -0,0 +1,49 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetClientCredentialsToken_WithResponseHeaders(t *testing.T) {
+	server, client := setupTestServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Empty(t, r.URL.RawQuery, "WithResponseHeaders must not change the request")
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-RateLimit-Remaining", "9")
+		w.Header().Set("Sunset", "Wed, 31 Dec 2026 23:59:59 GMT")
+		_, _ = w.Write([]byte(`{"access_token":"token-123","token_type":"Bearer","expires_in":3600}`))
+	}))
+	defer server.Close()
+
+	var headers http.Header
+	token, err := client.GetClientCredentialsToken(context.Background(), "client-id", "client-secret", "", WithResponseHeaders(&headers))
+	require.NoError(t, err)
+
+	assert.Equal(t, "token-123", token.AccessToken)
+	assert.Equal(t, "9", headers.Get("X-RateLimit-Remaining"))
+	assert.Equal(t, "Wed, 31 Dec 2026 23:59:59 GMT", headers.Get("Sunset"))
+
+	var scopedHeaders http.Header
+	_, err = client.GetClientCredentialsTokenWithScopes(context.Background(), "client-id", "client-secret",
+		[]string{"ingest:read"}, WithResponseHeaders(&scopedHeaders))
+	require.NoError(t, err)
+	assert.Equal(t, "9", scopedHeaders.Get("X-RateLimit-Remaining"))
+}
+
+func TestGetClientCredentialsToken_WithResponseHeaders_Error(t *testing.T) {
+	server, client := setupTestServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"error":"invalid_client"}`))
+	}))
+	defer server.Close()
+
+	var headers http.Header
+	_, err := client.GetClientCredentialsToken(context.Background(), "client-id", "wrong-secret", "", WithResponseHeaders(&headers))
+	require.Error(t, err)
+	assert.Nil(t, headers)
+}
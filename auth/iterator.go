@@ -0,0 +1,84 @@
+package auth
+
+import "context"
+
+// CredentialIterator walks the client credentials matched by a list request,
+// fetching pages from the service as they are needed. Create one with
+// IterateClientCredentials. It is not safe for concurrent use.
+//
+//	it := client.IterateClientCredentials(&auth.ListClientCredentialsOptions{TenantID: "tenant-123"})
+//	for it.Next(ctx) {
+//		cred := it.Credential()
+//		// ...
+//	}
+//	if err := it.Err(); err != nil {
+//		// Handle error
+//	}
+type CredentialIterator struct {
+	client *Client
+	opts   ListClientCredentialsOptions
+
+	page    []ClientCredentialResponse
+	current ClientCredentialResponse
+	done    bool
+	err     error
+}
+
+// IterateClientCredentials returns an iterator over every client credential
+// matching opts, following pagination until the service returns no NextToken.
+// opts.Limit sets the page size, and opts.NextToken, if set, resumes from a
+// previous list response. No request is sent until the first call to Next.
+//
+// Parameters:
+//   - opts: Optional filters and pagination (nil iterates all credentials)
+//
+// Returns:
+//   - *CredentialIterator: An iterator over the matching credentials
+func (c *Client) IterateClientCredentials(opts *ListClientCredentialsOptions) *CredentialIterator {
+	it := &CredentialIterator{client: c}
+	if opts != nil {
+		it.opts = *opts
+	}
+	return it
+}
+
+// Next advances the iterator to the next credential, fetching the next page
+// when the current one is exhausted. It returns false when every credential
+// has been read or a request fails; check Err to tell the two apart.
+//
+// Parameters:
+//   - ctx: Context for any page request Next sends
+//
+// Returns:
+//   - bool: true if Credential holds the next credential
+func (it *CredentialIterator) Next(ctx context.Context) bool {
+	for len(it.page) == 0 {
+		if it.done || it.err != nil {
+			return false
+		}
+
+		resp, err := it.client.ListClientCredentialsWithOptions(ctx, &it.opts)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		it.page = resp.Credentials
+		it.opts.NextToken = resp.NextToken
+		it.done = resp.NextToken == ""
+	}
+
+	it.current = it.page[0]
+	it.page = it.page[1:]
+	return true
+}
+
+// Credential returns the credential the last successful call to Next advanced to.
+func (it *CredentialIterator) Credential() ClientCredentialResponse {
+	return it.current
+}
+
+// Err returns the error that stopped iteration, or nil if every credential was
+// read or iteration is still in progress.
+func (it *CredentialIterator) Err() error {
+	return it.err
+}
@@ -0,0 +1,107 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/atriumn/atriumn-sdk-go/internal/apierror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListClientCredentialsWithOptions(t *testing.T) {
+	server, client := setupTestServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		assert.Equal(t, "/admin/credentials", r.URL.Path)
+		assert.Equal(t, "tenant-123", q.Get("tenantId"))
+		assert.Equal(t, "true", q.Get("active"))
+		assert.Equal(t, "25", q.Get("limit"))
+		assert.Equal(t, "page-2", q.Get("nextToken"))
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"credentials": [{"id": "cred-3"}], "next_token": "page-3"}`))
+	}))
+	defer server.Close()
+
+	resp, err := client.ListClientCredentialsWithOptions(context.Background(), &ListClientCredentialsOptions{
+		TenantID:   "tenant-123",
+		ActiveOnly: true,
+		Limit:      25,
+		NextToken:  "page-2",
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Credentials, 1)
+	assert.Equal(t, "cred-3", resp.Credentials[0].ID)
+	assert.Equal(t, "page-3", resp.NextToken)
+}
+
+func TestCredentialIterator_MultiplePages(t *testing.T) {
+	var tokens []string
+	server, client := setupTestServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		tokens = append(tokens, q.Get("nextToken"))
+		assert.Equal(t, "2", q.Get("limit"))
+		assert.Equal(t, "read:users", q.Get("scope"))
+
+		w.Header().Set("Content-Type", "application/json")
+		switch q.Get("nextToken") {
+		case "":
+			_, _ = w.Write([]byte(`{"credentials": [{"id": "cred-1"}, {"id": "cred-2"}], "next_token": "page-2"}`))
+		case "page-2":
+			// An empty page with a token does not end iteration
+			_, _ = w.Write([]byte(`{"credentials": [], "next_token": "page-3"}`))
+		case "page-3":
+			_, _ = w.Write([]byte(`{"credentials": [{"id": "cred-3"}]}`))
+		default:
+			t.Errorf("unexpected nextToken %q", q.Get("nextToken"))
+		}
+	}))
+	defer server.Close()
+
+	it := client.IterateClientCredentials(&ListClientCredentialsOptions{Scope: "read:users", Limit: 2})
+	var ids []string
+	for it.Next(context.Background()) {
+		ids = append(ids, it.Credential().ID)
+	}
+	require.NoError(t, it.Err())
+	assert.Equal(t, []string{"cred-1", "cred-2", "cred-3"}, ids)
+	assert.Equal(t, []string{"", "page-2", "page-3"}, tokens)
+
+	// An exhausted iterator sends no further requests
+	assert.False(t, it.Next(context.Background()))
+	assert.Len(t, tokens, 3)
+}
+
+func TestCredentialIterator_SinglePage(t *testing.T) {
+	requests := 0
+	server, client := setupTestServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"credentials": [{"id": "cred-1"}, {"id": "cred-2"}]}`))
+	}))
+	defer server.Close()
+
+	it := client.IterateClientCredentials(nil)
+	var ids []string
+	for it.Next(context.Background()) {
+		ids = append(ids, it.Credential().ID)
+	}
+	require.NoError(t, it.Err())
+	assert.Equal(t, []string{"cred-1", "cred-2"}, ids)
+	assert.Equal(t, 1, requests)
+}
+
+func TestCredentialIterator_Error(t *testing.T) {
+	server, client := setupTestServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	it := client.IterateClientCredentials(nil)
+	assert.False(t, it.Next(context.Background()))
+
+	apiErr, ok := it.Err().(*apierror.ErrorResponse)
+	require.True(t, ok)
+	assert.Equal(t, "forbidden", apiErr.ErrorCode)
+}
@@ -0,0 +1,254 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/atriumn/atriumn-sdk-go/internal/clientutil"
+)
+
+// jwksRefetchInterval is the least time between JWKS fetches made for a
+// token with an unknown kid, or to replace a stale JWKS after a failed fetch,
+// so tokens with made-up kids cannot make VerifyToken fetch once per token.
+const jwksRefetchInterval = time.Minute
+
+// jwksCache holds the RSA signing keys from the most recently fetched JWKS.
+type jwksCache struct {
+	mu          sync.Mutex
+	ttl         time.Duration
+	keys        map[string]*rsa.PublicKey
+	fetchedAt   time.Time // time of the last successful fetch
+	attemptedAt time.Time // time of the last fetch, successful or not
+	failed      bool      // whether the last fetch failed
+	inflight    *jwksFetch
+}
+
+// jwksFetch is a JWKS fetch in progress, shared by every VerifyToken call
+// that needs it. err is set before done is closed.
+type jwksFetch struct {
+	done chan struct{}
+	err  error
+}
+
+// needsFetch reports whether the JWKS should be fetched at now for a token
+// whose kid is known, or not. The caller must hold j.mu.
+func (j *jwksCache) needsFetch(known bool, now time.Time) bool {
+	if j.keys == nil {
+		return true
+	}
+	throttled := now.Sub(j.attemptedAt) < jwksRefetchInterval
+	if now.Sub(j.fetchedAt) >= j.ttl {
+		// A stale JWKS is refetched, unless a recent fetch failed, in which
+		// case its keys keep being used
+		return !j.failed || !throttled
+	}
+	return !known && !throttled
+}
+
+// GetJWKS retrieves the JSON Web Key Set the auth service signs tokens with.
+//
+// Parameters:
+//   - ctx: Context for the API request
+//
+// Returns:
+//   - *JWKS: The published signing keys
+//   - error: An error if the request fails
+func (c *Client) GetJWKS(ctx context.Context) (*JWKS, error) {
+	req, err := c.newRequest(ctx, "GET", "/.well-known/jwks.json", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp JWKS
+	_, err = c.do("GetJWKS", req, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// VerifyToken verifies an RS256-signed token issued by the auth service and
+// returns its claims. The signing key is looked up by the token's kid in the
+// service's JWKS, which is cached (see WithJWKSCacheTTL) and refetched when
+// the kid is unknown, at most once a minute. If a refetch fails, the cached
+// keys keep being used. The exp, nbf, iss, and aud claims are checked, so the
+// client must be created with WithTokenIssuer and WithTokenAudience.
+//
+// Parameters:
+//   - ctx: Context for fetching the JWKS
+//   - token: The compact-serialized JWT to verify
+//
+// Returns:
+//   - *Claims: The token's claims if it is valid
+//   - error: An error if verification fails, which can be:
+//   - ErrInvalidToken if the token is malformed, its signature is invalid, or iss/aud do not match
+//   - ErrTokenExpired if the token has expired or is not yet valid
+//   - ErrUnknownSigningKey if no key in the JWKS matches the token's kid
+//   - ErrVerificationNotConfigured if WithTokenIssuer or WithTokenAudience was not set
+//   - any error returned by GetJWKS
+func (c *Client) VerifyToken(ctx context.Context, token string) (*Claims, error) {
+	if c.tokenIssuer == "" || c.tokenAudience == "" {
+		return nil, ErrVerificationNotConfigured
+	}
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: not a JWT", ErrInvalidToken)
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, fmt.Errorf("%w: header: %v", ErrInvalidToken, err)
+	}
+	if header.Alg != "RS256" {
+		return nil, fmt.Errorf("%w: unsupported algorithm %q", ErrInvalidToken, header.Alg)
+	}
+
+	key, err := c.signingKey(ctx, header.Kid)
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("%w: signature: %v", ErrInvalidToken, err)
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature); err != nil {
+		return nil, fmt.Errorf("%w: signature verification failed", ErrInvalidToken)
+	}
+
+	var claims Claims
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, fmt.Errorf("%w: claims: %v", ErrInvalidToken, err)
+	}
+	if err := decodeSegment(parts[1], &claims.Raw); err != nil {
+		return nil, fmt.Errorf("%w: claims: %v", ErrInvalidToken, err)
+	}
+
+	now := clientutil.ClockOrSystem(c.base.Clock).Now()
+	if claims.ExpiresAt == 0 || !now.Before(claims.ExpiresAt.Time()) {
+		return nil, ErrTokenExpired
+	}
+	if claims.NotBefore != 0 && now.Before(claims.NotBefore.Time()) {
+		return nil, fmt.Errorf("%w: not valid before %s", ErrTokenExpired, claims.NotBefore.Time().Format(time.RFC3339))
+	}
+	if claims.Issuer != c.tokenIssuer {
+		return nil, fmt.Errorf("%w: unexpected issuer %q", ErrInvalidToken, claims.Issuer)
+	}
+	if !claims.Audience.Contains(c.tokenAudience) {
+		return nil, fmt.Errorf("%w: audience does not include %q", ErrInvalidToken, c.tokenAudience)
+	}
+
+	return &claims, nil
+}
+
+// signingKey returns the public key for kid, fetching the JWKS if the cache
+// is empty or stale, or does not contain kid and was not fetched within
+// jwksRefetchInterval. Concurrent calls share one fetch, which is made
+// without holding the cache lock.
+func (c *Client) signingKey(ctx context.Context, kid string) (*rsa.PublicKey, error) {
+	c.jwks.mu.Lock()
+	key, known := c.jwks.keys[kid]
+	if !c.jwks.needsFetch(known, clientutil.ClockOrSystem(c.base.Clock).Now()) {
+		c.jwks.mu.Unlock()
+		if !known {
+			return nil, fmt.Errorf("%w: %q", ErrUnknownSigningKey, kid)
+		}
+		return key, nil
+	}
+
+	// The fetch outlives the context of the call that started it, so that
+	// call giving up does not fail the others waiting on the fetch
+	fetch := c.jwks.inflight
+	if fetch == nil {
+		fetch = &jwksFetch{done: make(chan struct{})}
+		c.jwks.inflight = fetch
+		go c.fetchJWKS(context.WithoutCancel(ctx), fetch)
+	}
+	c.jwks.mu.Unlock()
+	select {
+	case <-fetch.done:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	// After a failed fetch, a key from the previous JWKS is still used
+	c.jwks.mu.Lock()
+	key, known = c.jwks.keys[kid]
+	c.jwks.mu.Unlock()
+	switch {
+	case known:
+		return key, nil
+	case fetch.err != nil:
+		return nil, fetch.err
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownSigningKey, kid)
+	}
+}
+
+// fetchJWKS performs fetch, storing the fetched keys in the cache. On error
+// the cached keys are kept.
+func (c *Client) fetchJWKS(ctx context.Context, fetch *jwksFetch) {
+	jwks, err := c.GetJWKS(ctx)
+	now := clientutil.ClockOrSystem(c.base.Clock).Now()
+
+	c.jwks.mu.Lock()
+	c.jwks.attemptedAt = now
+	c.jwks.failed = err != nil
+	if err == nil {
+		c.jwks.keys = rsaKeys(jwks)
+		c.jwks.fetchedAt = now
+	}
+	c.jwks.inflight = nil
+	c.jwks.mu.Unlock()
+
+	fetch.err = err
+	close(fetch.done)
+}
+
+// rsaKeys converts the RSA signing keys in jwks to public keys indexed by kid.
+// Keys of other types or with invalid parameters are skipped.
+func rsaKeys(jwks *JWKS) map[string]*rsa.PublicKey {
+	keys := make(map[string]*rsa.PublicKey, len(jwks.Keys))
+	for _, k := range jwks.Keys {
+		if k.Kty != "RSA" || (k.Use != "" && k.Use != "sig") {
+			continue
+		}
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			continue
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil || len(e) == 0 || len(e) > 4 {
+			continue
+		}
+		keys[k.Kid] = &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}
+	}
+	return keys
+}
+
+// decodeSegment base64url-decodes a JWT segment and unmarshals it into v.
+func decodeSegment(segment string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
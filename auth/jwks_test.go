@@ -0,0 +1,436 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/atriumn/atriumn-sdk-go/internal/clientutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// jwksServer serves the public halves of keys as a JWKS and counts fetches
+type jwksServer struct {
+	mu      sync.Mutex
+	keys    map[string]*rsa.PrivateKey
+	fetches int
+}
+
+func (s *jwksServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.fetches++
+
+	var jwks JWKS
+	for kid, key := range s.keys {
+		jwks.Keys = append(jwks.Keys, JWK{
+			Kty: "RSA",
+			Kid: kid,
+			Use: "sig",
+			Alg: "RS256",
+			N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		})
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(jwks)
+}
+
+func (s *jwksServer) setKey(kid string, key *rsa.PrivateKey) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.keys[kid] = key
+}
+
+func (s *jwksServer) fetchCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.fetches
+}
+
+func generateKey(t *testing.T) *rsa.PrivateKey {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	return key
+}
+
+// signToken creates an RS256 JWT with the given kid and claims
+func signToken(t *testing.T, key *rsa.PrivateKey, kid string, claims map[string]interface{}) string {
+	t.Helper()
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT", "kid": kid})
+	require.NoError(t, err)
+	payload, err := json.Marshal(claims)
+	require.NoError(t, err)
+
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	require.NoError(t, err)
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func validClaims() map[string]interface{} {
+	return map[string]interface{}{
+		"iss":   "https://auth.example.com",
+		"sub":   "client-123",
+		"aud":   "ingest",
+		"exp":   time.Now().Add(time.Hour).Unix(),
+		"iat":   time.Now().Unix(),
+		"scope": "ingest:write",
+	}
+}
+
+// newVerifier creates a client for server that verifies tokens issued by
+// validClaims, with any further options
+func newVerifier(t *testing.T, serverURL string, opts ...ClientOption) *Client {
+	t.Helper()
+	opts = append([]ClientOption{WithTokenIssuer("https://auth.example.com"), WithTokenAudience("ingest")}, opts...)
+	client, err := NewClientWithOptions(serverURL, opts...)
+	require.NoError(t, err)
+	return client
+}
+
+func TestGetJWKS(t *testing.T) {
+	key := generateKey(t)
+	keys := &jwksServer{keys: map[string]*rsa.PrivateKey{"key-1": key}}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/.well-known/jwks.json", r.URL.Path)
+		keys.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL)
+	require.NoError(t, err)
+
+	jwks, err := client.GetJWKS(context.Background())
+	require.NoError(t, err)
+	require.Len(t, jwks.Keys, 1)
+	assert.Equal(t, "key-1", jwks.Keys[0].Kid)
+	assert.Equal(t, "RSA", jwks.Keys[0].Kty)
+}
+
+func TestVerifyToken(t *testing.T) {
+	key := generateKey(t)
+	otherKey := generateKey(t)
+	keys := &jwksServer{keys: map[string]*rsa.PrivateKey{"key-1": key}}
+	server := httptest.NewServer(keys)
+	defer server.Close()
+
+	client, err := NewClientWithOptions(server.URL,
+		WithTokenIssuer("https://auth.example.com"),
+		WithTokenAudience("ingest"),
+	)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	t.Run("valid token", func(t *testing.T) {
+		claims, err := client.VerifyToken(ctx, signToken(t, key, "key-1", validClaims()))
+		require.NoError(t, err)
+		assert.Equal(t, "client-123", claims.Subject)
+		assert.Equal(t, "https://auth.example.com", claims.Issuer)
+		assert.Equal(t, Audience{"ingest"}, claims.Audience)
+		assert.Equal(t, "ingest:write", claims.Scope)
+		assert.Equal(t, "ingest:write", claims.Raw["scope"])
+	})
+
+	t.Run("audience list", func(t *testing.T) {
+		c := validClaims()
+		c["aud"] = []string{"storage", "ingest"}
+		_, err := client.VerifyToken(ctx, signToken(t, key, "key-1", c))
+		assert.NoError(t, err)
+	})
+
+	t.Run("expired token", func(t *testing.T) {
+		c := validClaims()
+		c["exp"] = time.Now().Add(-time.Minute).Unix()
+		_, err := client.VerifyToken(ctx, signToken(t, key, "key-1", c))
+		assert.ErrorIs(t, err, ErrTokenExpired)
+	})
+
+	t.Run("not yet valid", func(t *testing.T) {
+		c := validClaims()
+		c["nbf"] = time.Now().Add(time.Minute).Unix()
+		_, err := client.VerifyToken(ctx, signToken(t, key, "key-1", c))
+		assert.ErrorIs(t, err, ErrTokenExpired)
+	})
+
+	t.Run("unknown key", func(t *testing.T) {
+		_, err := client.VerifyToken(ctx, signToken(t, otherKey, "key-unknown", validClaims()))
+		assert.ErrorIs(t, err, ErrUnknownSigningKey)
+	})
+
+	t.Run("signed by the wrong key", func(t *testing.T) {
+		_, err := client.VerifyToken(ctx, signToken(t, otherKey, "key-1", validClaims()))
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("wrong issuer", func(t *testing.T) {
+		c := validClaims()
+		c["iss"] = "https://evil.example.com"
+		_, err := client.VerifyToken(ctx, signToken(t, key, "key-1", c))
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("wrong audience", func(t *testing.T) {
+		c := validClaims()
+		c["aud"] = "storage"
+		_, err := client.VerifyToken(ctx, signToken(t, key, "key-1", c))
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("unsigned token", func(t *testing.T) {
+		header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none","kid":"key-1"}`))
+		payload, _ := json.Marshal(validClaims())
+		token := header + "." + base64.RawURLEncoding.EncodeToString(payload) + "."
+		_, err := client.VerifyToken(ctx, token)
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("malformed token", func(t *testing.T) {
+		_, err := client.VerifyToken(ctx, "not-a-jwt")
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+}
+
+func TestVerifyToken_JWKSCaching(t *testing.T) {
+	key := generateKey(t)
+	keys := &jwksServer{keys: map[string]*rsa.PrivateKey{"key-1": key}}
+	server := httptest.NewServer(keys)
+	defer server.Close()
+
+	clock := clientutil.NewFakeClock(time.Now())
+	client := newVerifier(t, server.URL, WithClock(clock))
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		_, err := client.VerifyToken(ctx, signToken(t, key, "key-1", validClaims()))
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 1, keys.fetchCount(), "JWKS should be fetched once and then cached")
+
+	// A rotated-in key is picked up by refetching on the unknown kid, once
+	// the refetch interval has passed since the last fetch
+	rotated := generateKey(t)
+	keys.setKey("key-2", rotated)
+	clock.Advance(jwksRefetchInterval)
+	_, err := client.VerifyToken(ctx, signToken(t, rotated, "key-2", validClaims()))
+	require.NoError(t, err)
+	assert.Equal(t, 2, keys.fetchCount())
+}
+
+func TestVerifyToken_UnknownKidRefetchIsThrottled(t *testing.T) {
+	key := generateKey(t)
+	attacker := generateKey(t)
+	keys := &jwksServer{keys: map[string]*rsa.PrivateKey{"key-1": key}}
+	server := httptest.NewServer(keys)
+	defer server.Close()
+
+	clock := clientutil.NewFakeClock(time.Now())
+	client := newVerifier(t, server.URL, WithClock(clock))
+	ctx := context.Background()
+
+	_, err := client.VerifyToken(ctx, signToken(t, key, "key-1", validClaims()))
+	require.NoError(t, err)
+
+	for i := 0; i < 10; i++ {
+		_, err := client.VerifyToken(ctx, signToken(t, attacker, fmt.Sprintf("made-up-%d", i), validClaims()))
+		assert.ErrorIs(t, err, ErrUnknownSigningKey)
+	}
+	assert.Equal(t, 1, keys.fetchCount(), "unknown kids within the refetch interval should not trigger fetches")
+
+	clock.Advance(jwksRefetchInterval)
+	for i := 0; i < 10; i++ {
+		_, err := client.VerifyToken(ctx, signToken(t, attacker, fmt.Sprintf("other-%d", i), validClaims()))
+		assert.ErrorIs(t, err, ErrUnknownSigningKey)
+	}
+	assert.Equal(t, 2, keys.fetchCount(), "at most one refetch per interval")
+}
+
+func TestVerifyToken_FailedRefetchKeepsCachedKeys(t *testing.T) {
+	key := generateKey(t)
+	keys := &jwksServer{keys: map[string]*rsa.PrivateKey{"key-1": key}}
+	var failing atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if failing.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		keys.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+
+	clock := clientutil.NewFakeClock(time.Now())
+	client := newVerifier(t, server.URL, WithClock(clock), WithJWKSCacheTTL(10*time.Minute))
+	ctx := context.Background()
+	token := signToken(t, key, "key-1", validClaims())
+
+	_, err := client.VerifyToken(ctx, token)
+	require.NoError(t, err)
+
+	// The stale keys are used when the refetch fails
+	failing.Store(true)
+	clock.Advance(11 * time.Minute)
+	_, err = client.VerifyToken(ctx, token)
+	assert.NoError(t, err)
+
+	// An unknown kid still reports the fetch error
+	clock.Advance(jwksRefetchInterval)
+	_, err = client.VerifyToken(ctx, signToken(t, key, "key-2", validClaims()))
+	var apiErr *ErrorResponse
+	assert.ErrorAs(t, err, &apiErr)
+}
+
+func TestVerifyToken_ConcurrentCallsShareOneFetch(t *testing.T) {
+	key := generateKey(t)
+	keys := &jwksServer{keys: map[string]*rsa.PrivateKey{"key-1": key}}
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		keys.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+
+	client := newVerifier(t, server.URL)
+	token := signToken(t, key, "key-1", validClaims())
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := client.VerifyToken(context.Background(), token)
+			assert.NoError(t, err)
+		}()
+	}
+
+	// A caller whose context ends stops waiting for the fetch
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := client.VerifyToken(ctx, token)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	close(release)
+	wg.Wait()
+	assert.Equal(t, 1, keys.fetchCount())
+}
+
+func TestVerifyToken_FractionalNumericDates(t *testing.T) {
+	key := generateKey(t)
+	keys := &jwksServer{keys: map[string]*rsa.PrivateKey{"key-1": key}}
+	server := httptest.NewServer(keys)
+	defer server.Close()
+
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	client := newVerifier(t, server.URL, WithClock(clientutil.NewFakeClock(now)))
+
+	c := validClaims()
+	c["iat"] = float64(now.Unix()) - 0.25
+	c["nbf"] = float64(now.Unix()) - 0.5
+	c["exp"] = float64(now.Unix()) + 0.5
+	claims, err := client.VerifyToken(context.Background(), signToken(t, key, "key-1", c))
+	require.NoError(t, err)
+	assert.Equal(t, now.Add(500*time.Millisecond), claims.ExpiresAt.Time().UTC())
+
+	c["exp"] = float64(now.Unix()) - 0.5
+	_, err = client.VerifyToken(context.Background(), signToken(t, key, "key-1", c))
+	assert.ErrorIs(t, err, ErrTokenExpired)
+}
+
+func TestVerifyToken_RequiresIssuerAndAudience(t *testing.T) {
+	key := generateKey(t)
+	keys := &jwksServer{keys: map[string]*rsa.PrivateKey{"key-1": key}}
+	server := httptest.NewServer(keys)
+	defer server.Close()
+
+	tests := map[string][]ClientOption{
+		"neither":       nil,
+		"issuer only":   {WithTokenIssuer("https://auth.example.com")},
+		"audience only": {WithTokenAudience("ingest")},
+	}
+	for name, opts := range tests {
+		t.Run(name, func(t *testing.T) {
+			client, err := NewClientWithOptions(server.URL, opts...)
+			require.NoError(t, err)
+			_, err = client.VerifyToken(context.Background(), signToken(t, key, "key-1", validClaims()))
+			assert.ErrorIs(t, err, ErrVerificationNotConfigured)
+		})
+	}
+	assert.Zero(t, keys.fetchCount())
+}
+
+func TestVerifyToken_JWKSCacheTTL(t *testing.T) {
+	key := generateKey(t)
+	keys := &jwksServer{keys: map[string]*rsa.PrivateKey{"key-1": key}}
+	server := httptest.NewServer(keys)
+	defer server.Close()
+
+	client := newVerifier(t, server.URL, WithJWKSCacheTTL(0))
+
+	for i := 0; i < 2; i++ {
+		_, err := client.VerifyToken(context.Background(), signToken(t, key, "key-1", validClaims()))
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 2, keys.fetchCount(), "an expired cache should be refetched")
+}
+
+func TestVerifyToken_FakeClock(t *testing.T) {
+	key := generateKey(t)
+	keys := &jwksServer{keys: map[string]*rsa.PrivateKey{"key-1": key}}
+	server := httptest.NewServer(keys)
+	defer server.Close()
+
+	clock := clientutil.NewFakeClock(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	client := newVerifier(t, server.URL, WithJWKSCacheTTL(10*time.Minute), WithClock(clock))
+	ctx := context.Background()
+
+	claims := validClaims()
+	claims["iat"] = clock.Now().Unix()
+	claims["exp"] = clock.Now().Add(30 * time.Minute).Unix()
+	token := signToken(t, key, "key-1", claims)
+
+	_, err := client.VerifyToken(ctx, token)
+	require.NoError(t, err)
+
+	// Within the TTL the cached JWKS is used
+	clock.Advance(9 * time.Minute)
+	_, err = client.VerifyToken(ctx, token)
+	require.NoError(t, err)
+	assert.Equal(t, 1, keys.fetchCount())
+
+	// Past the TTL the JWKS is refetched
+	clock.Advance(2 * time.Minute)
+	_, err = client.VerifyToken(ctx, token)
+	require.NoError(t, err)
+	assert.Equal(t, 2, keys.fetchCount())
+
+	// Past exp the token is rejected
+	clock.Advance(20 * time.Minute)
+	_, err = client.VerifyToken(ctx, token)
+	assert.ErrorIs(t, err, ErrTokenExpired)
+}
+
+func TestVerifyToken_NilClock(t *testing.T) {
+	key := generateKey(t)
+	server := httptest.NewServer(&jwksServer{keys: map[string]*rsa.PrivateKey{"key-1": key}})
+	defer server.Close()
+
+	client := newVerifier(t, server.URL)
+	client.base.Clock = nil
+
+	_, err := client.VerifyToken(context.Background(), signToken(t, key, "key-1", validClaims()))
+	require.NoError(t, err)
+}
@@ -3,6 +3,14 @@
 // and accessing user profiles through a simple, idiomatic Go interface.
 package auth
 
+import (
+	"encoding/json"
+	"math"
+	"time"
+
+	"github.com/atriumn/atriumn-sdk-go/internal/health"
+)
+
 // ErrorResponse is now provided by the internal/apierror package.
 
 // Common API request/response structures
@@ -27,10 +35,7 @@ type TokenResponse struct {
 
 // HealthResponse represents the response from the health endpoint.
 // It indicates the current operational status of the Auth service.
-type HealthResponse struct {
-	// Status indicates the health of the service (e.g., "ok", "error")
-	Status string `json:"status"`
-}
+type HealthResponse = health.Response
 
 // ClientCredentialsRequest represents a client credentials token request.
 // It is used to obtain an OAuth token using the client credentials flow.
@@ -201,9 +206,112 @@ type ClientCredentialCreateResponse struct {
 	ClientSecret string `json:"client_secret"`
 }
 
+// ListClientCredentialsOptions represents optional filters and pagination for
+// ListClientCredentialsWithOptions. Zero values are omitted from the request.
+type ListClientCredentialsOptions struct {
+	// IssuedTo matches credentials with a specific IssuedTo field
+	IssuedTo string
+	// TenantID matches credentials belonging to a specific tenant
+	TenantID string
+	// Scope matches credentials granted a specific scope
+	Scope string
+	// ActiveOnly returns only active credentials
+	ActiveOnly bool
+	// InactiveOnly returns only inactive credentials; ignored if ActiveOnly is set
+	InactiveOnly bool
+	// Limit is the maximum number of credentials to return per page
+	Limit int
+	// NextToken is the pagination token from a previous list response
+	NextToken string
+}
+
 // ListClientCredentialsResponse represents the response from listing client credentials.
 // It contains an array of client credential responses.
 type ListClientCredentialsResponse struct {
 	// Credentials is an array of client credentials without their secrets
 	Credentials []ClientCredentialResponse `json:"credentials"`
+	// NextToken is an optional pagination token for retrieving the next set of results
+	NextToken string `json:"next_token,omitempty"`
+}
+
+// JWK is a single JSON Web Key published by the auth service.
+type JWK struct {
+	// Kty is the key type, such as "RSA"
+	Kty string `json:"kty"`
+	// Kid is the key ID that tokens reference in their header
+	Kid string `json:"kid"`
+	// Use is the intended key use, typically "sig"
+	Use string `json:"use,omitempty"`
+	// Alg is the algorithm the key is used with, such as "RS256"
+	Alg string `json:"alg,omitempty"`
+	// N is the base64url-encoded RSA modulus
+	N string `json:"n,omitempty"`
+	// E is the base64url-encoded RSA public exponent
+	E string `json:"e,omitempty"`
+}
+
+// JWKS is the JSON Web Key Set the auth service signs tokens with.
+type JWKS struct {
+	// Keys contains the published signing keys
+	Keys []JWK `json:"keys"`
+}
+
+// Audience holds a token's aud claim, which may be a single string or a list.
+type Audience []string
+
+// UnmarshalJSON accepts either a JSON string or an array of strings.
+func (a *Audience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = Audience{single}
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+	*a = Audience(list)
+	return nil
+}
+
+// Contains reports whether aud is one of the token's audiences.
+func (a Audience) Contains(aud string) bool {
+	for _, v := range a {
+		if v == aud {
+			return true
+		}
+	}
+	return false
+}
+
+// NumericDate is a JWT time claim: seconds since the Unix epoch, which may
+// have a fractional part.
+type NumericDate float64
+
+// Time returns d as a time.Time.
+func (d NumericDate) Time() time.Time {
+	sec, frac := math.Modf(float64(d))
+	return time.Unix(int64(sec), int64(frac*float64(time.Second)))
+}
+
+// Claims contains the registered claims of a verified token.
+type Claims struct {
+	// Issuer identifies who issued the token
+	Issuer string `json:"iss,omitempty"`
+	// Subject identifies the principal the token was issued to
+	Subject string `json:"sub,omitempty"`
+	// Audience identifies the recipients the token is intended for
+	Audience Audience `json:"aud,omitempty"`
+	// ExpiresAt is the time after which the token is invalid
+	ExpiresAt NumericDate `json:"exp,omitempty"`
+	// NotBefore is the time before which the token is invalid
+	NotBefore NumericDate `json:"nbf,omitempty"`
+	// IssuedAt is the time the token was issued
+	IssuedAt NumericDate `json:"iat,omitempty"`
+	// Scope is the space-delimited list of granted scopes
+	Scope string `json:"scope,omitempty"`
+	// ClientID is the client the token was issued to, for client credentials tokens
+	ClientID string `json:"client_id,omitempty"`
+	// Raw contains every claim in the token, including custom ones
+	Raw map[string]interface{} `json:"-"`
 }
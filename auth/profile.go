@@ -0,0 +1,72 @@
+package auth
+
+import (
+	"strconv"
+	"time"
+)
+
+// AttributeBool returns the user attribute key parsed as a boolean, such as
+// the "true" or "false" of "email_verified".
+//
+// Parameters:
+//   - key: The attribute name
+//
+// Returns:
+//   - bool: The attribute's value
+//   - bool: False if the attribute is absent or is not a boolean
+func (p *UserProfileResponse) AttributeBool(key string) (bool, bool) {
+	value, ok := p.Attributes[key]
+	if !ok {
+		return false, false
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, false
+	}
+	return b, true
+}
+
+// AttributeInt returns the user attribute key parsed as a base 10 integer.
+//
+// Parameters:
+//   - key: The attribute name
+//
+// Returns:
+//   - int64: The attribute's value
+//   - bool: False if the attribute is absent or is not an integer
+func (p *UserProfileResponse) AttributeInt(key string) (int64, bool) {
+	value, ok := p.Attributes[key]
+	if !ok {
+		return 0, false
+	}
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return n, true
+}
+
+// AttributeTime returns the user attribute key parsed as a time. Both Unix
+// timestamps in seconds, the format of attributes like "updated_at", and
+// RFC 3339 times are accepted.
+//
+// Parameters:
+//   - key: The attribute name
+//
+// Returns:
+//   - time.Time: The attribute's value; Unix timestamps are returned in UTC
+//   - bool: False if the attribute is absent or is not a time
+func (p *UserProfileResponse) AttributeTime(key string) (time.Time, bool) {
+	value, ok := p.Attributes[key]
+	if !ok {
+		return time.Time{}, false
+	}
+	if secs, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.Unix(secs, 0).UTC(), true
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
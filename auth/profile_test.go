@@ -0,0 +1,94 @@
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestProfile() *UserProfileResponse {
+	return &UserProfileResponse{
+		Username: "user@example.com",
+		Attributes: map[string]string{
+			"email_verified":   "true",
+			"phone_verified":   "false",
+			"custom:logins":    "42",
+			"custom:balance":   "-7",
+			"updated_at":       "1767268800",
+			"custom:joined_at": "2026-01-01T12:00:00Z",
+			"custom:name":      "John Doe",
+			"custom:ratio":     "1.5",
+		},
+	}
+}
+
+func TestUserProfileResponse_AttributeBool(t *testing.T) {
+	profile := newTestProfile()
+
+	value, ok := profile.AttributeBool("email_verified")
+	assert.True(t, ok)
+	assert.True(t, value)
+
+	value, ok = profile.AttributeBool("phone_verified")
+	assert.True(t, ok)
+	assert.False(t, value)
+
+	_, ok = profile.AttributeBool("missing")
+	assert.False(t, ok, "absent attribute")
+
+	_, ok = profile.AttributeBool("custom:name")
+	assert.False(t, ok, "malformed attribute")
+}
+
+func TestUserProfileResponse_AttributeInt(t *testing.T) {
+	profile := newTestProfile()
+
+	value, ok := profile.AttributeInt("custom:logins")
+	assert.True(t, ok)
+	assert.Equal(t, int64(42), value)
+
+	value, ok = profile.AttributeInt("custom:balance")
+	assert.True(t, ok)
+	assert.Equal(t, int64(-7), value)
+
+	_, ok = profile.AttributeInt("missing")
+	assert.False(t, ok, "absent attribute")
+
+	for _, key := range []string{"custom:name", "custom:ratio", "email_verified"} {
+		value, ok = profile.AttributeInt(key)
+		assert.False(t, ok, "malformed attribute %s", key)
+		assert.Zero(t, value)
+	}
+}
+
+func TestUserProfileResponse_AttributeTime(t *testing.T) {
+	profile := newTestProfile()
+	want := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	value, ok := profile.AttributeTime("updated_at")
+	assert.True(t, ok)
+	assert.True(t, want.Equal(value), "Unix timestamp: got %v", value)
+
+	value, ok = profile.AttributeTime("custom:joined_at")
+	assert.True(t, ok)
+	assert.True(t, want.Equal(value), "RFC 3339: got %v", value)
+
+	_, ok = profile.AttributeTime("missing")
+	assert.False(t, ok, "absent attribute")
+
+	value, ok = profile.AttributeTime("custom:name")
+	assert.False(t, ok, "malformed attribute")
+	assert.True(t, value.IsZero())
+}
+
+func TestUserProfileResponse_NilAttributes(t *testing.T) {
+	profile := &UserProfileResponse{Username: "user@example.com"}
+
+	_, ok := profile.AttributeBool("email_verified")
+	assert.False(t, ok)
+	_, ok = profile.AttributeInt("custom:logins")
+	assert.False(t, ok)
+	_, ok = profile.AttributeTime("updated_at")
+	assert.False(t, ok)
+}
@@ -0,0 +1,110 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/atriumn/atriumn-sdk-go/internal/clientutil"
+)
+
+// DefaultTokenExpiryMargin is how long before a cached token expires that
+// ClientCredentialsTokenProvider fetches a new one, so a token does not expire
+// between being handed out and reaching the service.
+const DefaultTokenExpiryMargin = 30 * time.Second
+
+// TokenProvider supplies bearer tokens for authenticated requests. It matches
+// the TokenProvider interfaces of the ingest, storage, and ai packages, so a
+// provider created here can be passed to their WithTokenProvider options.
+type TokenProvider interface {
+	GetToken(ctx context.Context) (string, error) // Returns the Bearer token string
+}
+
+// ClientCredentialsTokenProvider is a TokenProvider that obtains tokens through
+// the client credentials flow and caches each one until shortly before it
+// expires. It is safe for concurrent use; concurrent callers share one token
+// request. It implements Invalidate, so clients created with WithRefreshOn401
+// discard a token the service rejects.
+type ClientCredentialsTokenProvider struct {
+	client       *Client
+	clientID     string
+	clientSecret string
+	scope        string
+
+	mu        sync.Mutex
+	token     string
+	expiresAt time.Time
+}
+
+// NewClientCredentialsTokenProvider creates an auth client for authBaseURL and
+// returns a caching client credentials token provider backed by it, ready to
+// pass to the WithTokenProvider option of the other service clients. Tokens
+// whose response carries no expires_in are not cached.
+//
+// Parameters:
+//   - authBaseURL: The base URL for the Atriumn Auth API (required)
+//   - clientID: The client identifier (required)
+//   - clientSecret: The client secret (required)
+//   - scope: Optional space-delimited list of requested permission scopes
+//   - opts: Options for the underlying auth client
+//
+// Returns:
+//   - TokenProvider: A *ClientCredentialsTokenProvider
+//   - error: An error if clientID or clientSecret is empty or the auth client cannot be created
+func NewClientCredentialsTokenProvider(authBaseURL, clientID, clientSecret, scope string, opts ...ClientOption) (TokenProvider, error) {
+	if clientID == "" || clientSecret == "" {
+		return nil, errors.New("clientID and clientSecret are required")
+	}
+
+	client, err := NewClientWithOptions(authBaseURL, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ClientCredentialsTokenProvider{
+		client:       client,
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		scope:        scope,
+	}, nil
+}
+
+// GetToken returns the cached access token, requesting a new one from the auth
+// service if none is cached or the cached one is within
+// DefaultTokenExpiryMargin of expiring.
+//
+// Parameters:
+//   - ctx: Context for the token request, if one is needed
+//
+// Returns:
+//   - string: The access token
+//   - error: An error if the token request fails
+func (p *ClientCredentialsTokenProvider) GetToken(ctx context.Context) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := clientutil.ClockOrSystem(p.client.base.Clock).Now()
+	if p.token != "" && now.Before(p.expiresAt) {
+		return p.token, nil
+	}
+
+	resp, err := p.client.GetClientCredentialsToken(ctx, p.clientID, p.clientSecret, p.scope)
+	if err != nil {
+		return "", err
+	}
+
+	p.token = ""
+	if resp.ExpiresIn > 0 {
+		p.token = resp.AccessToken
+		p.expiresAt = resp.ExpiryTime(now).Add(-DefaultTokenExpiryMargin)
+	}
+	return resp.AccessToken, nil
+}
+
+// Invalidate discards the cached token, so the next GetToken requests a new one.
+func (p *ClientCredentialsTokenProvider) Invalidate() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.token = ""
+}
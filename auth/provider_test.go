@@ -0,0 +1,141 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/atriumn/atriumn-sdk-go/internal/clientutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// tokenServer issues a numbered token with the given lifetime for every
+// client credentials request it receives
+func tokenServer(t *testing.T, expiresIn int64, requests *int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/auth/token", r.URL.Path)
+
+		var req ClientCredentialsRequest
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "client_credentials", req.GrantType)
+		assert.Equal(t, "client-id", req.ClientID)
+		assert.Equal(t, "client-secret", req.ClientSecret)
+		assert.Equal(t, "read:content", req.Scope)
+
+		*requests++
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(TokenResponse{
+			AccessToken: fmt.Sprintf("token-%d", *requests),
+			TokenType:   "Bearer",
+			ExpiresIn:   expiresIn,
+		})
+	}))
+}
+
+func TestNewClientCredentialsTokenProvider_Caches(t *testing.T) {
+	requests := 0
+	server := tokenServer(t, 3600, &requests)
+	defer server.Close()
+
+	clock := clientutil.NewFakeClock(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	provider, err := NewClientCredentialsTokenProvider(server.URL, "client-id", "client-secret", "read:content", WithClock(clock))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	token, err := provider.GetToken(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "token-1", token)
+
+	// The token is reused until shortly before it expires
+	clock.Advance(time.Hour - DefaultTokenExpiryMargin - time.Second)
+	token, err = provider.GetToken(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "token-1", token)
+	assert.Equal(t, 1, requests)
+
+	clock.Advance(time.Second)
+	token, err = provider.GetToken(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "token-2", token)
+	assert.Equal(t, 2, requests)
+}
+
+func TestNewClientCredentialsTokenProvider_Invalidate(t *testing.T) {
+	requests := 0
+	server := tokenServer(t, 3600, &requests)
+	defer server.Close()
+
+	provider, err := NewClientCredentialsTokenProvider(server.URL, "client-id", "client-secret", "read:content")
+	require.NoError(t, err)
+
+	invalidator, ok := provider.(clientutil.TokenInvalidator)
+	require.True(t, ok, "provider does not implement Invalidate")
+
+	_, err = provider.GetToken(context.Background())
+	require.NoError(t, err)
+	invalidator.Invalidate()
+
+	token, err := provider.GetToken(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "token-2", token)
+}
+
+func TestNewClientCredentialsTokenProvider_NoExpiry(t *testing.T) {
+	requests := 0
+	server := tokenServer(t, 0, &requests)
+	defer server.Close()
+
+	provider, err := NewClientCredentialsTokenProvider(server.URL, "client-id", "client-secret", "read:content")
+	require.NoError(t, err)
+
+	for i := 1; i <= 2; i++ {
+		token, err := provider.GetToken(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, fmt.Sprintf("token-%d", i), token)
+	}
+}
+
+func TestNewClientCredentialsTokenProvider_AuthorizesServiceClients(t *testing.T) {
+	requests := 0
+	authServer := tokenServer(t, 3600, &requests)
+	defer authServer.Close()
+
+	provider, err := NewClientCredentialsTokenProvider(authServer.URL, "client-id", "client-secret", "read:content")
+	require.NoError(t, err)
+
+	// Any client built on BaseClient accepts the provider
+	base := clientutil.BaseClient{TokenProvider: provider}
+	req := httptest.NewRequest(http.MethodGet, "https://ingest.example.com/content", nil)
+	require.NoError(t, base.Authorize(req))
+	assert.Equal(t, "Bearer token-1", req.Header.Get("Authorization"))
+}
+
+func TestNewClientCredentialsTokenProvider_Errors(t *testing.T) {
+	_, err := NewClientCredentialsTokenProvider("https://auth.example.com", "", "client-secret", "")
+	assert.Error(t, err)
+
+	_, err = NewClientCredentialsTokenProvider("https://auth.example.com", "client-id", "", "")
+	assert.Error(t, err)
+
+	_, err = NewClientCredentialsTokenProvider("not a url", "client-id", "client-secret", "")
+	assert.Error(t, err)
+}
+
+func TestClientCredentialsTokenProvider_RequestError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	provider, err := NewClientCredentialsTokenProvider(server.URL, "client-id", "client-secret", "")
+	require.NoError(t, err)
+
+	token, err := provider.GetToken(context.Background())
+	assert.Error(t, err)
+	assert.Empty(t, token)
+}
@@ -0,0 +1,172 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// scopeUpdateAttempts is how many times AddCredentialScopes and
+// RemoveCredentialScopes read and rewrite a credential whose scopes changed
+// between the read and the write
+const scopeUpdateAttempts = 3
+
+// AddCredentialScopes grants a client credential the given scopes, keeping
+// the ones it already has. Scopes it already has, and duplicates within
+// scopes, are not added twice.
+//
+// The auth API only replaces a credential's whole scope list, so the scopes
+// are read, merged, and written back. When the service sends an ETag with the
+// credential, the write is conditional on it, and a credential changed in the
+// meantime is read and merged again, up to three times in all. Without an
+// ETag, a concurrent change made between the read and the write is lost.
+//
+// Parameters:
+//   - ctx: Context for the API requests
+//   - id: The unique identifier of the credential to update (required)
+//   - scopes: The scopes to add, none of which may be empty or contain whitespace
+//
+// Returns:
+//   - *ClientCredentialResponse: The credential with its resulting scopes
+//   - error: An error if a scope is invalid, without sending a request; an
+//     error matching ErrConflict if the credential kept changing; or any
+//     error returned by GetClientCredential or UpdateClientCredential
+func (c *Client) AddCredentialScopes(ctx context.Context, id string, scopes []string) (*ClientCredentialResponse, error) {
+	if err := validateScopes(scopes); err != nil {
+		return nil, err
+	}
+	return c.updateCredentialScopes(ctx, "AddCredentialScopes", id, func(current []string) []string {
+		return mergeScopes(current, scopes)
+	})
+}
+
+// RemoveCredentialScopes revokes the given scopes from a client credential,
+// keeping its other scopes. Scopes the credential does not have are ignored.
+// Like AddCredentialScopes, it reads, filters, and writes back the scope list.
+//
+// Parameters:
+//   - ctx: Context for the API requests
+//   - id: The unique identifier of the credential to update (required)
+//   - scopes: The scopes to remove, none of which may be empty or contain whitespace
+//
+// Returns:
+//   - *ClientCredentialResponse: The credential with its resulting scopes
+//   - error: An error as described for AddCredentialScopes
+func (c *Client) RemoveCredentialScopes(ctx context.Context, id string, scopes []string) (*ClientCredentialResponse, error) {
+	if err := validateScopes(scopes); err != nil {
+		return nil, err
+	}
+	return c.updateCredentialScopes(ctx, "RemoveCredentialScopes", id, func(current []string) []string {
+		return subtractScopes(current, scopes)
+	})
+}
+
+// updateCredentialScopes replaces the scopes of credential id with
+// change(current), retrying when a conditional write finds the credential
+// changed. If change leaves the scopes as they are, nothing is written.
+func (c *Client) updateCredentialScopes(ctx context.Context, operation, id string, change func(current []string) []string) (*ClientCredentialResponse, error) {
+	path := fmt.Sprintf("/admin/credentials/%s", id)
+
+	for attempt := 1; ; attempt++ {
+		getReq, err := c.newRequest(ctx, "GET", path, nil)
+		if err != nil {
+			return nil, err
+		}
+		var current ClientCredentialResponse
+		getResp, err := c.do(operation, getReq, &current)
+		if err != nil {
+			return nil, err
+		}
+
+		scopes := change(current.Scopes)
+		if equalScopes(scopes, current.Scopes) {
+			return &current, nil
+		}
+
+		patchReq, err := c.newRequest(ctx, "PATCH", path, ClientCredentialUpdateRequest{Scopes: &scopes})
+		if err != nil {
+			return nil, err
+		}
+		etag := getResp.Header.Get("ETag")
+		if etag != "" {
+			patchReq.Header.Set("If-Match", etag)
+		}
+
+		var updated ClientCredentialResponse
+		_, err = c.do(operation, patchReq, &updated)
+		if err == nil {
+			return &updated, nil
+		}
+
+		// The service answers a failed If-Match with 412: read the new scopes
+		// and apply the change to them, or report the conflict it is
+		var apiErr *ErrorResponse
+		if etag == "" || !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusPreconditionFailed {
+			return nil, err
+		}
+		if attempt >= scopeUpdateAttempts {
+			// The service's error comes first so errors.As finds it rather
+			// than the shared ErrConflict sentinel
+			return nil, fmt.Errorf("credential %s changed during %d scope updates: %w: %w", id, scopeUpdateAttempts, err, ErrConflict)
+		}
+	}
+}
+
+// validateScopes rejects scopes that are empty or contain whitespace
+func validateScopes(scopes []string) error {
+	for i, scope := range scopes {
+		if scope == "" {
+			return fmt.Errorf("scope %d is empty", i)
+		}
+		if strings.ContainsAny(scope, " \t\r\n") {
+			return fmt.Errorf("scope %d (%q) contains whitespace", i, scope)
+		}
+	}
+	return nil
+}
+
+// mergeScopes returns current followed by the scopes in added it lacks,
+// without duplicates
+func mergeScopes(current, added []string) []string {
+	merged := make([]string, 0, len(current)+len(added))
+	seen := make(map[string]bool, len(current)+len(added))
+	for _, list := range [][]string{current, added} {
+		for _, scope := range list {
+			if !seen[scope] {
+				seen[scope] = true
+				merged = append(merged, scope)
+			}
+		}
+	}
+	return merged
+}
+
+// subtractScopes returns current without the scopes in removed
+func subtractScopes(current, removed []string) []string {
+	drop := make(map[string]bool, len(removed))
+	for _, scope := range removed {
+		drop[scope] = true
+	}
+	kept := make([]string, 0, len(current))
+	for _, scope := range current {
+		if !drop[scope] {
+			kept = append(kept, scope)
+		}
+	}
+	return kept
+}
+
+// equalScopes reports whether a and b list the same scopes in the same order
+func equalScopes(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,168 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// credentialStore is a fake credential endpoint that versions the stored
+// scopes with an ETag and honors If-Match on updates
+type credentialStore struct {
+	mu       sync.Mutex
+	scopes   []string
+	version  int
+	noETag   bool
+	patches  int
+	ifMatch  []string
+	afterGet func(s *credentialStore) // runs with mu held, after each GET
+}
+
+func (s *credentialStore) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	etag := fmt.Sprintf(`"v%d"`, s.version)
+	switch r.Method {
+	case http.MethodGet:
+		if s.afterGet != nil {
+			defer s.afterGet(s)
+		}
+	case http.MethodPatch:
+		s.patches++
+		s.ifMatch = append(s.ifMatch, r.Header.Get("If-Match"))
+		if match := r.Header.Get("If-Match"); match != "" && match != etag {
+			w.WriteHeader(http.StatusPreconditionFailed)
+			_, _ = w.Write([]byte(`{"error":"precondition_failed"}`))
+			return
+		}
+		var req ClientCredentialUpdateRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		s.scopes = *req.Scopes
+		s.version++
+		etag = fmt.Sprintf(`"v%d"`, s.version)
+	}
+
+	if !s.noETag {
+		w.Header().Set("ETag", etag)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(ClientCredentialResponse{ID: "cred-123", Scopes: s.scopes})
+}
+
+func TestAddCredentialScopes(t *testing.T) {
+	store := &credentialStore{scopes: []string{"read:content"}}
+	server, client := setupTestServer(store)
+	defer server.Close()
+
+	cred, err := client.AddCredentialScopes(context.Background(), "cred-123",
+		[]string{"write:content", "read:content", "write:content", "admin"})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"read:content", "write:content", "admin"}, cred.Scopes)
+	assert.Equal(t, cred.Scopes, store.scopes)
+	assert.Equal(t, []string{`"v0"`}, store.ifMatch)
+}
+
+func TestRemoveCredentialScopes(t *testing.T) {
+	store := &credentialStore{scopes: []string{"read:content", "write:content", "admin"}}
+	server, client := setupTestServer(store)
+	defer server.Close()
+
+	cred, err := client.RemoveCredentialScopes(context.Background(), "cred-123",
+		[]string{"admin", "admin", "not:granted"})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"read:content", "write:content"}, cred.Scopes)
+	assert.Equal(t, cred.Scopes, store.scopes)
+}
+
+func TestCredentialScopes_DeduplicatesStoredScopes(t *testing.T) {
+	store := &credentialStore{scopes: []string{"read:content", "read:content"}}
+	server, client := setupTestServer(store)
+	defer server.Close()
+
+	cred, err := client.AddCredentialScopes(context.Background(), "cred-123", []string{"read:content"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"read:content"}, cred.Scopes)
+}
+
+func TestCredentialScopes_NoChange(t *testing.T) {
+	store := &credentialStore{scopes: []string{"read:content"}}
+	server, client := setupTestServer(store)
+	defer server.Close()
+
+	cred, err := client.AddCredentialScopes(context.Background(), "cred-123", []string{"read:content"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"read:content"}, cred.Scopes)
+
+	_, err = client.RemoveCredentialScopes(context.Background(), "cred-123", []string{"admin"})
+	require.NoError(t, err)
+
+	assert.Zero(t, store.patches, "an unchanged scope list should not be written")
+}
+
+func TestAddCredentialScopes_ConcurrentChange(t *testing.T) {
+	store := &credentialStore{scopes: []string{"read:content"}}
+	// Another writer grants "admin" between the first read and write
+	store.afterGet = func(s *credentialStore) {
+		if s.version == 0 {
+			s.scopes = append(s.scopes, "admin")
+			s.version++
+		}
+	}
+	server, client := setupTestServer(store)
+	defer server.Close()
+
+	cred, err := client.AddCredentialScopes(context.Background(), "cred-123", []string{"write:content"})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"read:content", "admin", "write:content"}, cred.Scopes)
+	assert.Equal(t, []string{`"v0"`, `"v1"`}, store.ifMatch)
+}
+
+func TestAddCredentialScopes_KeepsConflicting(t *testing.T) {
+	store := &credentialStore{scopes: []string{"read:content"}}
+	store.afterGet = func(s *credentialStore) { s.version++ }
+	server, client := setupTestServer(store)
+	defer server.Close()
+
+	_, err := client.AddCredentialScopes(context.Background(), "cred-123", []string{"write:content"})
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrConflict), "error %v should match ErrConflict", err)
+	var apiErr *ErrorResponse
+	require.True(t, errors.As(err, &apiErr), "error %v should wrap the service's response", err)
+	assert.Equal(t, http.StatusPreconditionFailed, apiErr.StatusCode)
+	assert.Equal(t, scopeUpdateAttempts, store.patches)
+	assert.Equal(t, []string{"read:content"}, store.scopes)
+}
+
+func TestAddCredentialScopes_WithoutETag(t *testing.T) {
+	store := &credentialStore{scopes: []string{"read:content"}, noETag: true}
+	server, client := setupTestServer(store)
+	defer server.Close()
+
+	cred, err := client.AddCredentialScopes(context.Background(), "cred-123", []string{"write:content"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"read:content", "write:content"}, cred.Scopes)
+	assert.Equal(t, []string{""}, store.ifMatch, "no If-Match without an ETag")
+}
+
+func TestAddCredentialScopes_InvalidScope(t *testing.T) {
+	store := &credentialStore{scopes: []string{"read:content"}}
+	server, client := setupTestServer(store)
+	defer server.Close()
+
+	_, err := client.AddCredentialScopes(context.Background(), "cred-123", []string{"write content"})
+	require.Error(t, err)
+	_, err = client.RemoveCredentialScopes(context.Background(), "cred-123", []string{""})
+	require.Error(t, err)
+	assert.Zero(t, store.patches)
+}
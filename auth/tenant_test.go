@@ -0,0 +1,87 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// tenantServer answers login and signup requests, recording the X-Tenant-ID
+// header of each and whether it was present
+func tenantServer(tenantIDs *[]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := "<none>"
+		if values := r.Header.Values(TenantIDHeader); len(values) > 0 {
+			id = values[0]
+		}
+		*tenantIDs = append(*tenantIDs, id)
+
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/auth/login":
+			_, _ = w.Write([]byte(`{"access_token": "access-token"}`))
+		case "/auth/signup":
+			_, _ = w.Write([]byte(`{"user_id": "user-123"}`))
+		}
+	}))
+}
+
+func TestWithTenantID(t *testing.T) {
+	var tenantIDs []string
+	server := tenantServer(&tenantIDs)
+	defer server.Close()
+
+	client, err := NewClientWithOptions(server.URL, WithTenantID("tenant-a"))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	_, err = client.LoginUser(ctx, "user@example.com", "password123")
+	require.NoError(t, err)
+	_, err = client.SignupUser(ctx, "user@example.com", "password123", nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"tenant-a", "tenant-a"}, tenantIDs)
+}
+
+func TestContextWithTenantID_Overrides(t *testing.T) {
+	var tenantIDs []string
+	server := tenantServer(&tenantIDs)
+	defer server.Close()
+
+	client, err := NewClientWithOptions(server.URL, WithTenantID("tenant-a"))
+	require.NoError(t, err)
+
+	ctx := ContextWithTenantID(context.Background(), "tenant-b")
+	_, err = client.LoginUser(ctx, "user@example.com", "password123")
+	require.NoError(t, err)
+	_, err = client.SignupUser(ctx, "user@example.com", "password123", nil)
+	require.NoError(t, err)
+
+	// An empty override sends no header
+	_, err = client.LoginUser(ContextWithTenantID(context.Background(), ""), "user@example.com", "password123")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"tenant-b", "tenant-b", "<none>"}, tenantIDs)
+}
+
+func TestTenantID_NotSetByDefault(t *testing.T) {
+	var tenantIDs []string
+	server := tenantServer(&tenantIDs)
+	defer server.Close()
+
+	client, err := NewClient(server.URL)
+	require.NoError(t, err)
+
+	_, err = client.LoginUser(context.Background(), "user@example.com", "password123")
+	require.NoError(t, err)
+
+	// The context alone selects a tenant when the client has none
+	_, err = client.LoginUser(ContextWithTenantID(context.Background(), "tenant-b"), "user@example.com", "password123")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"<none>", "tenant-b"}, tenantIDs)
+}
@@ -0,0 +1,47 @@
+package auth
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ExpiryTime returns the absolute time at which the access token expires,
+// given the time the token response was received.
+//
+// Parameters:
+//   - receivedAt: When the token response was received
+//
+// Returns:
+//   - time.Time: receivedAt plus ExpiresIn seconds
+func (t *TokenResponse) ExpiryTime(receivedAt time.Time) time.Time {
+	return receivedAt.Add(time.Duration(t.ExpiresIn) * time.Second)
+}
+
+// ParsedClaims decodes the payload of the access token when it is a JWT.
+// The signature is NOT verified; verification is the server's job, and the
+// claims must not be trusted for authorization decisions.
+//
+// Returns:
+//   - map[string]interface{}: The token's claims, such as "exp" and "scope"
+//   - error: An error if the access token is not a JWT or its payload cannot be decoded
+func (t *TokenResponse) ParsedClaims() (map[string]interface{}, error) {
+	parts := strings.Split(t.AccessToken, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("access token is not a JWT")
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode JWT payload: %w", err)
+	}
+
+	var claims map[string]interface{}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, fmt.Errorf("failed to parse JWT claims: %w", err)
+	}
+	return claims, nil
+}
@@ -0,0 +1,49 @@
+package auth
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenResponse_ExpiryTime(t *testing.T) {
+	receivedAt := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	token := &TokenResponse{ExpiresIn: 3600}
+
+	assert.Equal(t, receivedAt.Add(time.Hour), token.ExpiryTime(receivedAt))
+}
+
+func TestTokenResponse_ParsedClaims(t *testing.T) {
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"RS256","typ":"JWT"}`))
+	payload := base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"client-123","exp":1767268800,"scope":"ingest"}`))
+	token := &TokenResponse{AccessToken: header + "." + payload + ".signature"}
+
+	claims, err := token.ParsedClaims()
+	require.NoError(t, err)
+	assert.Equal(t, "client-123", claims["sub"])
+	assert.Equal(t, float64(1767268800), claims["exp"])
+	assert.Equal(t, "ingest", claims["scope"])
+}
+
+func TestTokenResponse_ParsedClaims_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{name: "opaque token", token: "2YotnFZFEjr1zCsicMWpAA"},
+		{name: "invalid base64 payload", token: "header.!!!.signature"},
+		{name: "non-JSON payload", token: "header." + base64.RawURLEncoding.EncodeToString([]byte("not json")) + ".signature"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token := &TokenResponse{AccessToken: tt.token}
+			claims, err := token.ParsedClaims()
+			assert.Error(t, err)
+			assert.Nil(t, claims)
+		})
+	}
+}
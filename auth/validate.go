@@ -0,0 +1,42 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Validate checks a credential create request before it is sent. IssuedTo and
+// Scopes must be non-empty. If allowedScopes is non-empty, every requested
+// scope must also appear in it, which catches typos such as "read:user" for
+// "read:users"; pass nil to skip that check.
+//
+// Parameters:
+//   - allowedScopes: Optional list of the scopes that may be requested
+//
+// Returns:
+//   - error: nil if the request is valid, otherwise an error joining
+//     (see errors.Join) one error per problem found
+func (r ClientCredentialCreateRequest) Validate(allowedScopes []string) error {
+	var errs []error
+
+	if r.IssuedTo == "" {
+		errs = append(errs, errors.New("issued_to is required"))
+	}
+	if len(r.Scopes) == 0 {
+		errs = append(errs, errors.New("at least one scope is required"))
+	}
+
+	if len(allowedScopes) > 0 {
+		allowed := make(map[string]bool, len(allowedScopes))
+		for _, scope := range allowedScopes {
+			allowed[scope] = true
+		}
+		for _, scope := range r.Scopes {
+			if !allowed[scope] {
+				errs = append(errs, fmt.Errorf("scope %q is not allowed", scope))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,70 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientCredentialCreateRequest_Validate(t *testing.T) {
+	allowed := []string{"read:users", "write:users"}
+
+	tests := []struct {
+		name     string
+		request  ClientCredentialCreateRequest
+		allowed  []string
+		wantErrs []string
+	}{
+		{
+			name:    "valid",
+			request: ClientCredentialCreateRequest{IssuedTo: "app", Scopes: []string{"read:users"}},
+			allowed: allowed,
+		},
+		{
+			name:     "empty issued_to",
+			request:  ClientCredentialCreateRequest{Scopes: []string{"read:users"}},
+			allowed:  allowed,
+			wantErrs: []string{"issued_to is required"},
+		},
+		{
+			name:     "empty scopes",
+			request:  ClientCredentialCreateRequest{IssuedTo: "app"},
+			allowed:  allowed,
+			wantErrs: []string{"at least one scope is required"},
+		},
+		{
+			name:     "unknown scope",
+			request:  ClientCredentialCreateRequest{IssuedTo: "app", Scopes: []string{"read:users", "read:user"}},
+			allowed:  allowed,
+			wantErrs: []string{`scope "read:user" is not allowed`},
+		},
+		{
+			name:    "no allow-list skips membership check",
+			request: ClientCredentialCreateRequest{IssuedTo: "app", Scopes: []string{"anything"}},
+		},
+		{
+			name:     "no allow-list still requires fields",
+			request:  ClientCredentialCreateRequest{},
+			wantErrs: []string{"issued_to is required", "at least one scope is required"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.request.Validate(tt.allowed)
+			if len(tt.wantErrs) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+
+			require.Error(t, err)
+			for _, want := range tt.wantErrs {
+				assert.Contains(t, err.Error(), want)
+			}
+			joined, ok := err.(interface{ Unwrap() []error })
+			require.True(t, ok, "Expected a joined error")
+			assert.Len(t, joined.Unwrap(), len(tt.wantErrs))
+		})
+	}
+}
@@ -43,10 +43,12 @@ func main() {
 		log.Fatalf("Failed to get file info: %v", err)
 	}
 
-	// Determine content type based on file extension
-	// In a production application, you might want to use a more robust method
-	// such as http.DetectContentType or a library like github.com/gabriel-vasile/mimetype
-	contentType := getContentType(filePath)
+	// Determine the content type from the file's leading bytes, falling back
+	// to its extension. The file is rewound afterwards.
+	contentType, err := ingest.DetectContentType(filePath, file)
+	if err != nil {
+		log.Fatalf("Failed to detect content type: %v", err)
+	}
 
 	fmt.Printf("Uploading file: %s (Size: %d bytes, Type: %s)\n", filepath.Base(filePath), fileInfo.Size(), contentType)
 
@@ -90,29 +92,3 @@ func main() {
 	fmt.Printf("Upload complete! Status: %d\n", resp.StatusCode)
 	fmt.Printf("Content item ID: %s\n", uploadResponse.ContentID)
 }
-
-// getContentType returns a MIME type based on the file extension
-func getContentType(filename string) string {
-	ext := filepath.Ext(filename)
-	switch ext {
-	case ".pdf":
-		return "application/pdf"
-	case ".txt":
-		return "text/plain"
-	case ".json":
-		return "application/json"
-	case ".csv":
-		return "text/csv"
-	case ".png":
-		return "image/png"
-	case ".jpg", ".jpeg":
-		return "image/jpeg"
-	case ".gif":
-		return "image/gif"
-	case ".mp4":
-		return "video/mp4"
-	default:
-		// Default to binary data if we can't determine the type
-		return "application/octet-stream"
-	}
-}
\ No newline at end of file
@@ -0,0 +1,66 @@
+package ingest
+
+import (
+	"context"
+	"testing"
+)
+
+func TestClient_WithAuthToken(t *testing.T) {
+	var tokens []string
+	server := tokenCheckingServer(t, &tokens)
+	defer server.Close()
+
+	provider := &rotatingTokenProvider{token: "valid-token"}
+	client, _ := NewClientWithOptions(server.URL, WithTokenProvider(provider))
+
+	// The token carried by the context replaces the provider's
+	ctx := WithAuthToken(context.Background(), "user-token")
+	if _, err := client.GetContentItem(ctx, "content-123"); err == nil {
+		t.Fatal("GetContentItem with a rejected context token succeeded")
+	}
+
+	// Without one, the provider's token is sent
+	if _, err := client.GetContentItem(context.Background(), "content-123"); err != nil {
+		t.Fatalf("GetContentItem returned unexpected error: %v", err)
+	}
+
+	if len(tokens) != 2 || tokens[0] != "Bearer user-token" || tokens[1] != "Bearer valid-token" {
+		t.Errorf("server saw tokens %q, want the context token and then the provider's", tokens)
+	}
+}
+
+func TestClient_WithAuthToken_NoProvider(t *testing.T) {
+	var tokens []string
+	server := tokenCheckingServer(t, &tokens)
+	defer server.Close()
+
+	client, _ := NewClient(server.URL)
+
+	ctx := WithAuthToken(context.Background(), "valid-token")
+	if _, err := client.GetContentItem(ctx, "content-123"); err != nil {
+		t.Fatalf("GetContentItem returned unexpected error: %v", err)
+	}
+	if len(tokens) != 1 || tokens[0] != "Bearer valid-token" {
+		t.Errorf("server saw tokens %q, want the context token", tokens)
+	}
+}
+
+func TestClient_WithAuthToken_NotRefreshedOn401(t *testing.T) {
+	var tokens []string
+	server := tokenCheckingServer(t, &tokens)
+	defer server.Close()
+
+	provider := &rotatingTokenProvider{token: "valid-token"}
+	client, _ := NewClientWithOptions(server.URL, WithTokenProvider(provider), WithRefreshOn401())
+
+	ctx := WithAuthToken(context.Background(), "expired-user-token")
+	if _, err := client.GetContentItem(ctx, "content-123"); err == nil {
+		t.Fatal("GetContentItem with a rejected context token succeeded")
+	}
+	if len(tokens) != 1 {
+		t.Errorf("server saw tokens %q, want a single request", tokens)
+	}
+	if provider.invalidated != 0 {
+		t.Errorf("Invalidate called %d times, want 0", provider.invalidated)
+	}
+}
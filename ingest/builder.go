@@ -0,0 +1,53 @@
+package ingest
+
+// NewIngestURLRequest creates an IngestURLRequest for the given tenant and URL.
+// Optional fields are set with the chainable With and AddMetadata methods;
+// fields that are never set stay empty or nil and are omitted from the JSON body.
+//
+// Parameters:
+//   - tenantID: The tenant identifier (may be empty)
+//   - url: The web address to scrape and ingest (required)
+//
+// Returns:
+//   - *IngestURLRequest: A request ready to pass to IngestURL or further customize
+func NewIngestURLRequest(tenantID, url string) *IngestURLRequest {
+	return &IngestURLRequest{TenantID: tenantID, URL: url}
+}
+
+// WithUserID sets the user who owns the content and returns the request.
+func (r *IngestURLRequest) WithUserID(userID string) *IngestURLRequest {
+	r.UserID = userID
+	return r
+}
+
+// WithSourceSubType sets the hint about the nature of the URL (e.g., "linkedin_profile")
+// and returns the request.
+func (r *IngestURLRequest) WithSourceSubType(sourceSubType string) *IngestURLRequest {
+	r.SourceSubType = &sourceSubType
+	return r
+}
+
+// WithUserNotes sets free-form notes about the content and returns the request.
+func (r *IngestURLRequest) WithUserNotes(notes string) *IngestURLRequest {
+	r.UserNotes = &notes
+	return r
+}
+
+// WithMetadata replaces the request's metadata with a copy of metadata and
+// returns the request.
+func (r *IngestURLRequest) WithMetadata(metadata map[string]string) *IngestURLRequest {
+	r.Metadata = nil
+	for k, v := range metadata {
+		r.AddMetadata(k, v)
+	}
+	return r
+}
+
+// AddMetadata sets a single metadata key and returns the request.
+func (r *IngestURLRequest) AddMetadata(key, value string) *IngestURLRequest {
+	if r.Metadata == nil {
+		r.Metadata = make(map[string]string)
+	}
+	r.Metadata[key] = value
+	return r
+}
@@ -0,0 +1,58 @@
+package ingest
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestNewIngestURLRequest(t *testing.T) {
+	tests := []struct {
+		name    string
+		request *IngestURLRequest
+		want    string
+	}{
+		{
+			name:    "required fields only",
+			request: NewIngestURLRequest("tenant-123", "https://example.com"),
+			want:    `{"tenantId":"tenant-123","url":"https://example.com"}`,
+		},
+		{
+			name: "all fields",
+			request: NewIngestURLRequest("tenant-123", "https://example.com").
+				WithUserID("user-456").
+				WithSourceSubType("linkedin_profile").
+				WithUserNotes("").
+				WithMetadata(map[string]string{"source": "crawler"}).
+				AddMetadata("priority", "high"),
+			want: `{"tenantId":"tenant-123","userId":"user-456","url":"https://example.com",` +
+				`"sourceSubType":"linkedin_profile","metadata":{"priority":"high","source":"crawler"},"userNotes":""}`,
+		},
+		{
+			name:    "single metadata key",
+			request: NewIngestURLRequest("", "https://example.com").AddMetadata("k", "v"),
+			want:    `{"url":"https://example.com","metadata":{"k":"v"}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.Marshal(tt.request)
+			if err != nil {
+				t.Fatalf("json.Marshal returned unexpected error: %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("json.Marshal = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIngestURLRequest_WithMetadataCopies(t *testing.T) {
+	metadata := map[string]string{"source": "crawler"}
+	request := NewIngestURLRequest("tenant-123", "https://example.com").WithMetadata(metadata)
+
+	request.AddMetadata("extra", "value")
+	if _, ok := metadata["extra"]; ok {
+		t.Error("AddMetadata modified the map passed to WithMetadata")
+	}
+}
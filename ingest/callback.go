@@ -0,0 +1,105 @@
+package ingest
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// CallbackSignatureHeader carries the hex-encoded HMAC-SHA256 signature of a callback
+	CallbackSignatureHeader = "X-Atriumn-Signature"
+
+	// CallbackTimestampHeader carries the Unix time, in seconds, at which a callback was signed
+	CallbackTimestampHeader = "X-Atriumn-Timestamp"
+
+	// CallbackTolerance is the maximum age, or clock skew, accepted for a callback timestamp
+	CallbackTolerance = 5 * time.Minute
+)
+
+var (
+	// ErrInvalidCallbackSignature is returned when a callback's signature is missing or does not match
+	ErrInvalidCallbackSignature = errors.New("invalid callback signature")
+
+	// ErrStaleCallback is returned when a callback's timestamp is outside CallbackTolerance
+	ErrStaleCallback = errors.New("callback timestamp outside tolerance")
+)
+
+// callbackNow returns the current time; it is replaceable in tests.
+var callbackNow = time.Now
+
+// VerifyCallbackSignature authenticates a processing callback sent to a
+// CallbackURL. The service signs each callback with HMAC-SHA256 over the
+// timestamp, a ".", and the raw body, keyed by the shared secret. Callbacks
+// signed more than CallbackTolerance away from the current time are rejected
+// to prevent replay.
+//
+// Parameters:
+//   - header: The headers of the incoming callback request
+//   - body: The raw, unparsed request body
+//   - secret: The shared callback signing secret
+//
+// Returns:
+//   - error: nil if the callback is authentic, otherwise ErrInvalidCallbackSignature
+//     or ErrStaleCallback (possibly wrapped)
+func VerifyCallbackSignature(header http.Header, body []byte, secret string) error {
+	timestamp := header.Get(CallbackTimestampHeader)
+	signature := header.Get(CallbackSignatureHeader)
+	if timestamp == "" || signature == "" {
+		return fmt.Errorf("%w: missing %s or %s header", ErrInvalidCallbackSignature, CallbackSignatureHeader, CallbackTimestampHeader)
+	}
+
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w: malformed timestamp %q", ErrInvalidCallbackSignature, timestamp)
+	}
+
+	expected := callbackSignature(timestamp, body, secret)
+	provided, err := hex.DecodeString(signature)
+	if err != nil || !hmac.Equal(provided, expected) {
+		return ErrInvalidCallbackSignature
+	}
+
+	age := callbackNow().Sub(time.Unix(seconds, 0))
+	if age > CallbackTolerance || age < -CallbackTolerance {
+		return ErrStaleCallback
+	}
+
+	return nil
+}
+
+// callbackSignature computes the HMAC-SHA256 of "timestamp.body".
+func callbackSignature(timestamp string, body []byte, secret string) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return mac.Sum(nil)
+}
+
+// ParseCallbackPayload decodes the body of a processing callback into the
+// content item it describes. Verify the signature first with
+// VerifyCallbackSignature.
+//
+// Parameters:
+//   - body: The raw callback request body
+//
+// Returns:
+//   - *ContentItem: The content item reported by the callback
+//   - error: An error if the body is not valid JSON or does not identify a content item
+func ParseCallbackPayload(body []byte) (*ContentItem, error) {
+	var item ContentItem
+	if err := json.Unmarshal(body, &item); err != nil {
+		return nil, fmt.Errorf("failed to parse callback payload: %w", err)
+	}
+	if item.ID == "" {
+		return nil, errors.New("callback payload is missing the content item id")
+	}
+	return &item, nil
+}
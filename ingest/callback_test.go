@@ -0,0 +1,103 @@
+package ingest
+
+import (
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// signedCallbackHeader returns headers signing body at the given time.
+func signedCallbackHeader(body []byte, secret string, at time.Time) http.Header {
+	timestamp := strconv.FormatInt(at.Unix(), 10)
+	header := make(http.Header)
+	header.Set(CallbackTimestampHeader, timestamp)
+	header.Set(CallbackSignatureHeader, hex.EncodeToString(callbackSignature(timestamp, body, secret)))
+	return header
+}
+
+func TestVerifyCallbackSignature(t *testing.T) {
+	fixed := time.Unix(1700000000, 0)
+	callbackNow = func() time.Time { return fixed }
+	defer func() { callbackNow = time.Now }()
+
+	body := []byte(`{"id":"content-123","status":"COMPLETED"}`)
+	secret := "callback-secret"
+
+	tests := []struct {
+		name    string
+		header  http.Header
+		body    []byte
+		wantErr error
+	}{
+		{
+			name:   "valid signature",
+			header: signedCallbackHeader(body, secret, fixed.Add(-time.Minute)),
+			body:   body,
+		},
+		{
+			name:    "tampered body",
+			header:  signedCallbackHeader(body, secret, fixed),
+			body:    []byte(`{"id":"content-123","status":"FAILED"}`),
+			wantErr: ErrInvalidCallbackSignature,
+		},
+		{
+			name:    "wrong secret",
+			header:  signedCallbackHeader(body, "other-secret", fixed),
+			body:    body,
+			wantErr: ErrInvalidCallbackSignature,
+		},
+		{
+			name:    "stale timestamp",
+			header:  signedCallbackHeader(body, secret, fixed.Add(-CallbackTolerance-time.Second)),
+			body:    body,
+			wantErr: ErrStaleCallback,
+		},
+		{
+			name:    "future timestamp",
+			header:  signedCallbackHeader(body, secret, fixed.Add(CallbackTolerance+time.Second)),
+			body:    body,
+			wantErr: ErrStaleCallback,
+		},
+		{
+			name:    "missing headers",
+			header:  http.Header{},
+			body:    body,
+			wantErr: ErrInvalidCallbackSignature,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := VerifyCallbackSignature(tt.header, tt.body, secret)
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Errorf("VerifyCallbackSignature returned unexpected error: %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("VerifyCallbackSignature error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseCallbackPayload(t *testing.T) {
+	item, err := ParseCallbackPayload([]byte(`{"id":"content-123","status":"COMPLETED","sourceType":"TEXT"}`))
+	if err != nil {
+		t.Fatalf("ParseCallbackPayload returned unexpected error: %v", err)
+	}
+	if item.ID != "content-123" || item.Status != "COMPLETED" {
+		t.Errorf("ParseCallbackPayload = %+v, want content-123 COMPLETED", item)
+	}
+
+	if _, err := ParseCallbackPayload([]byte(`not json`)); err == nil {
+		t.Error("ParseCallbackPayload should fail on invalid JSON")
+	}
+	if _, err := ParseCallbackPayload([]byte(`{"status":"COMPLETED"}`)); err == nil {
+		t.Error("ParseCallbackPayload should fail when the id is missing")
+	}
+}
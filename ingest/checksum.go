@@ -0,0 +1,107 @@
+package ingest
+
+import (
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+)
+
+const (
+	// ContentMD5Header carries the base64-encoded MD5 digest of an upload
+	ContentMD5Header = "Content-MD5"
+
+	// ChecksumSHA256Header carries the base64-encoded SHA-256 digest of an S3 upload
+	ChecksumSHA256Header = "x-amz-checksum-sha256"
+)
+
+// ErrChecksumNeedsSeeker is returned when a checksum option without a
+// precomputed value is used with content that cannot be rewound.
+var ErrChecksumNeedsSeeker = errors.New("computing an upload checksum requires an io.ReadSeeker; pass a precomputed value for streams")
+
+// uploadChecksum is a checksum header requested by WithContentMD5 or WithChecksumSHA256
+type uploadChecksum struct {
+	header  string
+	value   string
+	newHash func() hash.Hash
+}
+
+// WithContentMD5 sends the Content-MD5 header with an upload, so the storage
+// service rejects content corrupted in transit. If value is empty, the digest
+// is computed from the content, which must then be an io.ReadSeeker; it is
+// rewound to its starting position before the upload. For streams that cannot
+// be rewound, pass the base64-encoded MD5 digest computed beforehand.
+//
+// Parameters:
+//   - value: The base64-encoded MD5 digest, or "" to compute it
+//
+// Returns:
+//   - TransferOption: An option for UploadToURL or UploadToURLWithResult
+func WithContentMD5(value string) TransferOption {
+	return func(o *transferOptions) {
+		o.checksums = append(o.checksums, uploadChecksum{header: ContentMD5Header, value: value, newHash: md5.New})
+	}
+}
+
+// WithChecksumSHA256 sends the x-amz-checksum-sha256 header with an upload,
+// so S3 rejects content whose SHA-256 digest does not match. If value is
+// empty, the digest is computed from the content, which must then be an
+// io.ReadSeeker; it is rewound to its starting position before the upload. For
+// streams that cannot be rewound, pass the base64-encoded digest computed
+// beforehand.
+//
+// Parameters:
+//   - value: The base64-encoded SHA-256 digest, or "" to compute it
+//
+// Returns:
+//   - TransferOption: An option for UploadToURL or UploadToURLWithResult
+func WithChecksumSHA256(value string) TransferOption {
+	return func(o *transferOptions) {
+		o.checksums = append(o.checksums, uploadChecksum{header: ChecksumSHA256Header, value: value, newHash: sha256.New})
+	}
+}
+
+// setChecksumHeaders sets the requested checksum headers on req, computing
+// missing values from content.
+func setChecksumHeaders(req *http.Request, checksums []uploadChecksum, content io.Reader) error {
+	for _, checksum := range checksums {
+		value := checksum.value
+		if value == "" {
+			var err error
+			if value, err = computeChecksum(content, checksum.newHash()); err != nil {
+				return fmt.Errorf("failed to compute %s: %w", checksum.header, err)
+			}
+		}
+		req.Header.Set(checksum.header, value)
+	}
+	return nil
+}
+
+// computeChecksum returns the base64-encoded digest of the rest of content,
+// leaving content at the position it started from
+func computeChecksum(content io.Reader, h hash.Hash) (string, error) {
+	// Hash the reader a countingReader wraps so the digest's bytes aren't counted
+	if cr, ok := content.(*countingReader); ok {
+		content = cr.r
+	}
+	seeker, ok := content.(io.ReadSeeker)
+	if !ok {
+		return "", ErrChecksumNeedsSeeker
+	}
+
+	start, err := seeker.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return "", err
+	}
+	if _, err := io.Copy(h, seeker); err != nil {
+		return "", err
+	}
+	if _, err := seeker.Seek(start, io.SeekStart); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(h.Sum(nil)), nil
+}
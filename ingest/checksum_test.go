@@ -0,0 +1,112 @@
+package ingest
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// Digests of "hello world", base64-encoded
+const (
+	helloWorldMD5    = "XrY7u+Ae7tCTyyK7j1rNww=="
+	helloWorldSHA256 = "uU0nuZNNPgilLlLX2n2r+sSE7+N6U4DukIj3rOLvzek="
+)
+
+// checksumServer records the headers and body of the upload it receives
+func checksumServer(t *testing.T, header *http.Header, body *string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*header = r.Header.Clone()
+		data, _ := io.ReadAll(r.Body)
+		*body = string(data)
+		w.WriteHeader(http.StatusOK)
+	}))
+}
+
+func TestClient_UploadToURL_ComputedChecksums(t *testing.T) {
+	var header http.Header
+	var body string
+	server := checksumServer(t, &header, &body)
+	defer server.Close()
+
+	client, _ := NewClient("https://api.example.com")
+	resp, err := client.UploadToURL(context.Background(), server.URL, "text/plain", strings.NewReader("hello world"),
+		WithContentMD5(""), WithChecksumSHA256(""))
+	if err != nil {
+		t.Fatalf("UploadToURL returned unexpected error: %v", err)
+	}
+	_ = resp.Body.Close()
+
+	if got := header.Get(ContentMD5Header); got != helloWorldMD5 {
+		t.Errorf("Content-MD5 = %q, want %q", got, helloWorldMD5)
+	}
+	if got := header.Get(ChecksumSHA256Header); got != helloWorldSHA256 {
+		t.Errorf("x-amz-checksum-sha256 = %q, want %q", got, helloWorldSHA256)
+	}
+	if body != "hello world" {
+		t.Errorf("uploaded body = %q, want the full content after hashing", body)
+	}
+}
+
+func TestClient_UploadToURLWithResult_ComputedChecksum(t *testing.T) {
+	var header http.Header
+	var body string
+	server := checksumServer(t, &header, &body)
+	defer server.Close()
+
+	// Hashing starts from the reader's current position, which is restored
+	content := strings.NewReader("skip:hello world")
+	_, _ = content.Seek(5, io.SeekStart)
+
+	client, _ := NewClient("https://api.example.com")
+	result, err := client.UploadToURLWithResult(context.Background(), server.URL, "text/plain", content, WithChecksumSHA256(""))
+	if err != nil {
+		t.Fatalf("UploadToURLWithResult returned unexpected error: %v", err)
+	}
+	if got := header.Get(ChecksumSHA256Header); got != helloWorldSHA256 {
+		t.Errorf("x-amz-checksum-sha256 = %q, want %q", got, helloWorldSHA256)
+	}
+	if body != "hello world" || result.BytesWritten != 11 {
+		t.Errorf("uploaded %q (%d bytes counted), want %q (11 bytes)", body, result.BytesWritten, "hello world")
+	}
+}
+
+func TestClient_UploadToURL_PrecomputedChecksum(t *testing.T) {
+	var header http.Header
+	var body string
+	server := checksumServer(t, &header, &body)
+	defer server.Close()
+
+	client, _ := NewClient("https://api.example.com")
+	stream := io.MultiReader(strings.NewReader("hello "), strings.NewReader("world"))
+	resp, err := client.UploadToURL(context.Background(), server.URL, "text/plain", stream, WithChecksumSHA256(helloWorldSHA256))
+	if err != nil {
+		t.Fatalf("UploadToURL returned unexpected error: %v", err)
+	}
+	_ = resp.Body.Close()
+
+	if got := header.Get(ChecksumSHA256Header); got != helloWorldSHA256 {
+		t.Errorf("x-amz-checksum-sha256 = %q, want %q", got, helloWorldSHA256)
+	}
+}
+
+func TestClient_UploadToURL_ChecksumNeedsSeeker(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+	}))
+	defer server.Close()
+
+	client, _ := NewClient("https://api.example.com")
+	stream := io.MultiReader(strings.NewReader("hello world"))
+	_, err := client.UploadToURL(context.Background(), server.URL, "text/plain", stream, WithContentMD5(""))
+	if !errors.Is(err, ErrChecksumNeedsSeeker) {
+		t.Errorf("UploadToURL error = %v, want ErrChecksumNeedsSeeker", err)
+	}
+	if requests != 0 {
+		t.Errorf("server received %d requests, want none", requests)
+	}
+}
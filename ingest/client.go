@@ -5,17 +5,22 @@ package ingest
 
 import (
 	"bytes"
+	"compress/gzip"
 	"context"
-	"encoding/json"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
 	"fmt"
 	"io"
+	"maps"
 	"mime/multipart"
 	"net/http"
 	"net/url"
-	"os"
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/atriumn/atriumn-sdk-go/internal/apierror"
 	"github.com/atriumn/atriumn-sdk-go/internal/clientutil"
 )
 
@@ -23,16 +28,35 @@ const (
 	// DefaultTimeout is the default timeout for API requests
 	DefaultTimeout = 10 * time.Second
 
-	// DefaultUserAgent is the user agent sent in requests
-	DefaultUserAgent = "atriumn-ingest-client/1.0"
+	// DefaultMaxResponseBytes is the default limit on API responses and content read into memory (10 MiB)
+	DefaultMaxResponseBytes = clientutil.DefaultMaxResponseBytes
+
+	// DefaultBatchConcurrency is the default number of requests batch methods run in parallel
+	DefaultBatchConcurrency = 4
+
+	// DefaultUploadTimeout is the default limit on an upload to a pre-signed URL
+	// whose context has no deadline of its own
+	DefaultUploadTimeout = 60 * time.Second
+
+	// CompressionThreshold is the smallest JSON body, in bytes, that is gzip-encoded
+	// when request compression is enabled
+	CompressionThreshold = 1024
 )
 
+// DefaultUserAgent is the user agent sent in requests, naming the client and the SDK version
+var DefaultUserAgent = "atriumn-ingest-client/1.0" + " " + clientutil.VersionToken
+
 // TokenProvider defines an interface for retrieving authentication tokens.
 // Implementations should retrieve and return valid bearer tokens for the Atriumn API.
 type TokenProvider interface {
 	GetToken(ctx context.Context) (string, error) // Returns the Bearer token string
 }
 
+// TokenInvalidator is optionally implemented by a TokenProvider that caches
+// tokens. With WithRefreshOn401, the client calls Invalidate when the service
+// rejects a token, so the next GetToken call returns a fresh one.
+type TokenInvalidator = clientutil.TokenInvalidator
+
 // Client is the main API client for Atriumn Ingest Service.
 // It handles communication with the API endpoints for content ingestion
 // and retrieval operations.
@@ -46,29 +70,67 @@ type Client struct {
 	// UserAgent is the user agent sent with each request
 	UserAgent string
 
-	// tokenProvider provides authentication tokens for API requests
-	tokenProvider TokenProvider
+	// base holds the token provider and the other shared request settings;
+	// baseClient combines it with the fields above to build and send requests
+	base clientutil.BaseClient
+
+	// MaxResponseBytes limits how many bytes of an API response body, or of content
+	// read by GetContentBytes, are read into memory
+	MaxResponseBytes int64
+
+	// transferTransport, if set, carries requests to pre-signed URLs, so they
+	// share the TLS and protocol settings of the default HTTP client
+	transferTransport http.RoundTripper
+
+	// uploadTimeout bounds uploads to pre-signed URLs whose context has no deadline
+	uploadTimeout time.Duration
+
+	// clientSideValidation enables request validation before sending
+	clientSideValidation bool
+
+	// batchConcurrency limits the parallel requests issued by batch methods
+	batchConcurrency int
+
+	// downloadURLs caches pre-signed download URLs when enabled
+	downloadURLs *downloadURLCache
+
+	// metadataCache caches GetContentItem results when enabled
+	metadataCache *metadataCache
+
+	// compressRequests gzip-encodes large JSON request bodies
+	compressRequests bool
+
+	// defaultTenantID and defaultUserID fill empty TenantID and UserID request fields
+	defaultTenantID string
+	defaultUserID   string
+
+	// defaultMetadata is merged under the Metadata of ingest requests
+	defaultMetadata map[string]string
 }
 
 // NewClient creates a new Atriumn Ingest API client with the specified base URL.
-// It returns an error if the provided URL cannot be parsed.
+// It returns an error if the provided URL cannot be parsed or lacks a scheme or host.
 //
 // Parameters:
 //   - baseURL: The base URL for the Atriumn Ingest API (required)
 //
 // Returns:
 //   - *Client: A configured Ingest client instance
-//   - error: An error if the URL cannot be parsed
+//   - error: An error if the URL cannot be parsed or is not absolute
 func NewClient(baseURL string) (*Client, error) {
-	parsedURL, err := url.Parse(baseURL)
+	parsedURL, err := clientutil.ParseBaseURL(baseURL)
 	if err != nil {
-		return nil, fmt.Errorf("invalid base URL: %w", err)
+		return nil, err
 	}
 
 	return &Client{
-		BaseURL:    parsedURL,
-		HTTPClient: &http.Client{Timeout: DefaultTimeout},
-		UserAgent:  DefaultUserAgent,
+		BaseURL:          parsedURL,
+		HTTPClient:       &http.Client{Timeout: DefaultTimeout},
+		UserAgent:        DefaultUserAgent,
+		base:             clientutil.BaseClient{Service: "ingest", Clock: clientutil.SystemClock},
+		MaxResponseBytes: DefaultMaxResponseBytes,
+		uploadTimeout:    DefaultUploadTimeout,
+		batchConcurrency: DefaultBatchConcurrency,
 	}, nil
 }
 
@@ -76,6 +138,13 @@ func NewClient(baseURL string) (*Client, error) {
 // It is used with NewClientWithOptions to customize the client behavior.
 type ClientOption func(*Client)
 
+// shared adapts an option defined once in clientutil for all service clients
+func shared(opt clientutil.Option) ClientOption {
+	return func(c *Client) {
+		opt(&c.base)
+	}
+}
+
 // WithHTTPClient sets the HTTP client for the API client.
 // This can be used to customize timeouts, transport settings, or to inject
 // middleware/interceptors for testing or monitoring.
@@ -105,17 +174,376 @@ func WithUserAgent(userAgent string) ClientOption {
 	}
 }
 
-// WithTokenProvider sets the token provider for the API client.
-// The token provider is used to obtain authentication tokens for API requests.
+// WithUserAgentSuffix appends " suffix" to the user agent, keeping the default
+// client and SDK version tokens so requests remain identifiable. It extends the
+// user agent configured so far, so apply it after WithUserAgent when using both.
 //
 // Parameters:
-//   - tp: The TokenProvider implementation to use for authentication
+//   - suffix: The product token to append, such as "my-app/2.3"
 //
 // Returns:
 //   - ClientOption: A functional option to configure the client
+func WithUserAgentSuffix(suffix string) ClientOption {
+	return func(c *Client) {
+		if suffix != "" {
+			c.UserAgent += " " + suffix
+		}
+	}
+}
+
+// WithTokenProvider sets the provider of the bearer tokens sent with each request.
 func WithTokenProvider(tp TokenProvider) ClientOption {
+	return shared(clientutil.SetTokenProvider(tp))
+}
+
+// WithRefreshOn401 retries a request once with a fresh token when the service
+// rejects its token as unauthorized, calling Invalidate first if the
+// TokenProvider implements TokenInvalidator. A second 401 is returned as is.
+func WithRefreshOn401() ClientOption {
+	return shared(clientutil.SetRefreshOn401())
+}
+
+// WithDefaultTenantID sets the tenant ID sent by IngestText, IngestURL,
+// RequestFileUpload, and RequestTextUpload when the request's TenantID is empty.
+// A TenantID set on the request always takes precedence.
+//
+// Parameters:
+//   - id: The tenant ID to use by default
+//
+// Returns:
+//   - ClientOption: A functional option to configure the client
+func WithDefaultTenantID(id string) ClientOption {
+	return func(c *Client) {
+		c.defaultTenantID = id
+	}
+}
+
+// WithDefaultUserID sets the user ID sent by IngestText, IngestURL,
+// RequestFileUpload, and RequestTextUpload when the request's UserID is empty.
+// A UserID set on the request always takes precedence.
+//
+// Parameters:
+//   - id: The user ID to use by default
+//
+// Returns:
+//   - ClientOption: A functional option to configure the client
+func WithDefaultUserID(id string) ClientOption {
+	return func(c *Client) {
+		c.defaultUserID = id
+	}
+}
+
+// WithDefaultMetadata sets metadata sent by IngestText, IngestURL,
+// RequestFileUpload, and RequestTextUpload in addition to the request's own,
+// such as tags naming the environment and application. A key set in the
+// request's Metadata always takes precedence. The request's map is not
+// modified.
+//
+// Parameters:
+//   - metadata: The metadata keys and values to send by default
+//
+// Returns:
+//   - ClientOption: A functional option to configure the client
+func WithDefaultMetadata(metadata map[string]string) ClientOption {
+	return func(c *Client) {
+		c.defaultMetadata = maps.Clone(metadata)
+	}
+}
+
+// WithMaxResponseBytes sets the maximum size of API response bodies, and of
+// content that GetContentBytes will read into memory. Larger API responses fail
+// with a "response_too_large" error and larger content with "content_too_large"
+// rather than being buffered.
+//
+// Parameters:
+//   - n: The maximum number of bytes to read
+//
+// Returns:
+//   - ClientOption: A functional option to configure the client
+func WithMaxResponseBytes(n int64) ClientOption {
+	return func(c *Client) {
+		c.MaxResponseBytes = n
+	}
+}
+
+// WithRateLimit throttles the client to rps requests per second on average,
+// allowing bursts of up to burst requests; calls wait for their turn or their
+// context. A rate of zero or less removes the limit.
+func WithRateLimit(rps float64, burst int) ClientOption {
+	return shared(clientutil.SetRateLimit(rps, burst))
+}
+
+// ConcurrencyOption configures WithMaxConcurrentRequests.
+type ConcurrencyOption = clientutil.ConcurrencyOption
+
+// FailFast makes calls made while WithMaxConcurrentRequests has no free slot
+// return ErrTooManyConcurrentRequests at once, instead of waiting for a slot.
+//
+// Returns:
+//   - ConcurrencyOption: An option for WithMaxConcurrentRequests
+func FailFast() ConcurrencyOption {
+	return clientutil.FailFast()
+}
+
+// WithMaxConcurrentRequests allows at most n API calls of the client to be in
+// flight at once; further calls wait for a slot or their context, unless
+// FailFast is given. An n of zero or less removes the limit.
+func WithMaxConcurrentRequests(n int, opts ...ConcurrencyOption) ClientOption {
+	return shared(clientutil.SetMaxConcurrentRequests(n, opts...))
+}
+
+// WithResponseCache caches up to size GET responses that carry an ETag and
+// revalidates them with If-None-Match. A size of zero or less disables the cache.
+func WithResponseCache(size int) ClientOption {
+	return shared(clientutil.SetResponseCache(size))
+}
+
+// WithConnectionPool tunes the connection pool of the client's default HTTP
+// client. Like the TLS options and WithForceHTTP1, it never changes a client
+// passed to WithHTTPClient; zero maxIdle, maxConnsPerHost, or idleTimeout
+// means no limit.
+func WithConnectionPool(maxIdle, maxIdlePerHost, maxConnsPerHost int, idleTimeout time.Duration) ClientOption {
+	return shared(clientutil.SetConnectionPool(maxIdle, maxIdlePerHost, maxConnsPerHost, idleTimeout))
+}
+
+// WithTLSConfig sets the TLS configuration of the client's default HTTP
+// client, for example to present a client certificate. A config with
+// InsecureSkipVerify set is rejected unless WithInsecureSkipVerify is also given.
+func WithTLSConfig(config *tls.Config) ClientOption {
+	return shared(clientutil.SetTLSConfig(config))
+}
+
+// WithRootCAs makes the client's default HTTP client trust the certificates
+// in pool instead of the system roots, overriding the RootCAs of WithTLSConfig.
+func WithRootCAs(pool *x509.CertPool) ClientOption {
+	return shared(clientutil.SetRootCAs(pool))
+}
+
+// WithInsecureSkipVerify disables TLS certificate verification on the client's
+// default HTTP client. It is only fit for local testing; use WithRootCAs to
+// trust a private certificate authority instead.
+func WithInsecureSkipVerify() ClientOption {
+	return shared(clientutil.SetInsecureSkipVerify())
+}
+
+// WithForceHTTP1 makes the client's default HTTP client use only HTTP/1.1,
+// for proxies or middleboxes where HTTP/2 negotiation fails.
+func WithForceHTTP1() ClientOption {
+	return shared(clientutil.SetForceHTTP1())
+}
+
+// MetricsRecorder receives the outcome and latency of every API call, for
+// export to a metrics system such as Prometheus. Implementations must be safe
+// for concurrent use.
+type MetricsRecorder = clientutil.MetricsRecorder
+
+// WithMetricsRecorder reports every API call made by the client to recorder,
+// labeled with the service "ingest" and an operation such as "ingest.RequestFileUpload".
+func WithMetricsRecorder(recorder MetricsRecorder) ClientOption {
+	return shared(clientutil.SetMetricsRecorder(recorder))
+}
+
+// Stats summarizes the API calls made by a client: how many were made, how
+// many failed, and their latency percentiles.
+type Stats = clientutil.Stats
+
+// WithStatsCollection makes the client count its API calls and sample their
+// latencies, so Stats can report them without a metrics system.
+func WithStatsCollection() ClientOption {
+	return shared(clientutil.SetStatsCollection())
+}
+
+// DefaultStatsReservoirSize is the number of call latencies WithStatsCollection
+// samples to estimate percentiles from.
+const DefaultStatsReservoirSize = clientutil.DefaultStatsReservoirSize
+
+// Stats returns the number of API calls made by the client, how many of them
+// returned an error, and their p50, p95, and p99 latencies. Without
+// WithStatsCollection it returns zero Stats.
+func (c *Client) Stats() Stats {
+	return c.base.Stats.Stats()
+}
+
+// Close closes the idle connections of HTTPClient's transport, unless it is
+// the shared http.DefaultTransport. The client remains usable afterwards.
+func (c *Client) Close() error {
+	return c.baseClient().Close()
+}
+
+// Logger receives diagnostic messages from the client, such as warnings that
+// an endpoint is deprecated. *log.Logger satisfies it.
+type Logger = clientutil.Logger
+
+// DeprecationNotice describes a response whose Deprecation or Sunset header
+// announced that its endpoint is going away.
+type DeprecationNotice = clientutil.DeprecationNotice
+
+// WithLogger sets the logger that receives the client's diagnostic messages,
+// such as a warning the first time an endpoint is reported deprecated. The
+// client logs nothing by default.
+func WithLogger(logger Logger) ClientOption {
+	return shared(clientutil.SetLogger(logger))
+}
+
+// WithRequestBodyLogging logs the JSON body of every POST, PUT, and PATCH
+// request to the WithLogger logger, or to the standard library's default
+// logger. Passwords, client secrets, and tokens are replaced with "***".
+func WithRequestBodyLogging() ClientOption {
+	return shared(clientutil.SetRequestBodyLogging())
+}
+
+// WithDeprecationCallback calls fn, once per endpoint per process, when a
+// response carries a Deprecation or Sunset header. fn must be safe for concurrent use.
+func WithDeprecationCallback(fn func(DeprecationNotice)) ClientOption {
+	return shared(clientutil.SetDeprecationCallback(fn))
+}
+
+// Clock tells the time and waits for durations to pass. Supplying a fake
+// implementation with WithClock makes time-dependent behavior testable
+// without real sleeps.
+type Clock = clientutil.Clock
+
+// WithClock replaces the system clock used for rate limiting, retries, and
+// measuring latencies. A nil clock restores the system clock.
+func WithClock(clock Clock) ClientOption {
+	return shared(clientutil.SetClock(clock))
+}
+
+// RetryPolicy configures how the client retries requests that fail
+// transiently. By default, transport errors and 429, 502, 503, and 504
+// responses are retried for idempotent requests only: those with a GET, HEAD,
+// OPTIONS, PUT, or DELETE method, or that carry an Idempotency-Key header.
+// Retryable adds retries on top of those rules.
+type RetryPolicy = clientutil.RetryPolicy
+
+// RetryPredicate reports whether a request should be retried given the response
+// of an attempt, or the error it failed with. The response body can be read in
+// full without affecting the response returned to the caller.
+type RetryPredicate = clientutil.RetryPredicate
+
+// Backoff computes the growing delays between retries
+type Backoff = clientutil.Backoff
+
+// WithRetryPolicy retries requests that fail transiently under policy,
+// waiting for the backoff delay or Retry-After between attempts. Requests
+// whose body cannot be replayed are sent once.
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return shared(clientutil.SetRetryPolicy(policy))
+}
+
+// WithClientSideValidation makes IngestURL call IngestURLRequest.Validate and
+// return its error without contacting the API when the URL is not an absolute
+// http or https URL.
+//
+// Returns:
+//   - ClientOption: A functional option to configure the client
+func WithClientSideValidation() ClientOption {
+	return func(c *Client) {
+		c.clientSideValidation = true
+	}
+}
+
+// WithBatchConcurrency sets the maximum number of requests that batch methods
+// such as GetContentItems and DeleteContentItems send in parallel. Values below 1 are treated as 1.
+//
+// Parameters:
+//   - n: The maximum number of concurrent requests
+//
+// Returns:
+//   - ClientOption: A functional option to configure the client
+func WithBatchConcurrency(n int) ClientOption {
+	return func(c *Client) {
+		if n < 1 {
+			n = 1
+		}
+		c.batchConcurrency = n
+	}
+}
+
+// WithDownloadURLCache makes GetContentDownloadURL reuse a previously issued
+// pre-signed URL for the same content item until it is within margin of its
+// expiry. URLs whose expiry the service does not report are never cached, and
+// calls whose context carries a token from WithAuthToken bypass the cache.
+//
+// Parameters:
+//   - margin: How long before expiry a cached URL is replaced with a fresh one
+//
+// Returns:
+//   - ClientOption: A functional option to configure the client
+func WithDownloadURLCache(margin time.Duration) ClientOption {
 	return func(c *Client) {
-		c.tokenProvider = tp
+		c.downloadURLs = newDownloadURLCache(margin)
+	}
+}
+
+// WithMetadataCache makes GetContentItem serve a content item fetched in the
+// last ttl from memory instead of asking the service again, for callers that
+// read the same items repeatedly. At most maxEntries items are kept, the least
+// recently used being dropped first; below 1, DefaultMetadataCacheEntries is
+// used. Only full items are cached, not those fetched with fields.
+//
+// An item is dropped from the cache when it is changed or deleted through this
+// client, but changes made elsewhere are not seen until the item expires. Pass
+// WithNoCache to GetContentItemWithOptions to bypass the cache for one call.
+// WaitForProcessing, and calls whose context carries a token from
+// WithAuthToken, always bypass it. A ttl of zero or less disables caching.
+//
+// Parameters:
+//   - ttl: How long a fetched content item is served from the cache
+//   - maxEntries: The maximum number of content items cached
+//
+// Returns:
+//   - ClientOption: A functional option to configure the client
+func WithMetadataCache(ttl time.Duration, maxEntries int) ClientOption {
+	return func(c *Client) {
+		if ttl <= 0 {
+			c.metadataCache = nil
+			return
+		}
+		c.metadataCache = newMetadataCache(ttl, maxEntries)
+	}
+}
+
+// WithRawErrorBody attaches the full response body to the RawBody field of
+// returned API errors. It is off by default because error bodies may contain
+// sensitive data.
+func WithRawErrorBody() ClientOption {
+	return shared(clientutil.SetRawErrorBody())
+}
+
+// WithRequestCompression gzip-encodes JSON request bodies of at least
+// CompressionThreshold bytes on POST, PUT and PATCH calls and sets
+// Content-Encoding: gzip. Smaller bodies and pre-signed uploads are sent as is.
+//
+// Returns:
+//   - ClientOption: A functional option to configure the client
+func WithRequestCompression() ClientOption {
+	return func(c *Client) {
+		c.compressRequests = true
+	}
+}
+
+// WithRequestTimeout bounds each API call with a per-call context deadline
+// instead of the HTTP client's overall Timeout, which is cleared on a copy of
+// the HTTP client. A shorter deadline on the caller's context still applies.
+func WithRequestTimeout(d time.Duration) ClientOption {
+	return shared(clientutil.SetRequestTimeout(d))
+}
+
+// WithUploadTimeout sets how long an upload to a pre-signed URL, such as one
+// sent by UploadToURL or UploadFile, may take when its context has no deadline.
+// A deadline on the caller's context always governs instead, so long uploads
+// can be given more time than the default. Zero or less removes the limit,
+// leaving the upload bounded only by its context.
+//
+// Parameters:
+//   - d: The maximum duration of an upload (default DefaultUploadTimeout)
+//
+// Returns:
+//   - ClientOption: A functional option to configure the client
+func WithUploadTimeout(d time.Duration) ClientOption {
+	return func(c *Client) {
+		c.uploadTimeout = d
 	}
 }
 
@@ -135,17 +563,24 @@ func NewClientWithOptions(baseURL string, options ...ClientOption) (*Client, err
 		return nil, err
 	}
 
+	defaultHTTPClient := client.HTTPClient
 	for _, option := range options {
 		option(client)
 	}
 
+	client.HTTPClient, client.transferTransport, err = client.base.Configure(client.HTTPClient, defaultHTTPClient)
+	if err != nil {
+		return nil, err
+	}
+
 	return client, nil
 }
 
 // IngestText ingests text content through the Atriumn Ingest API.
 //
 // Deprecated: This method is incompatible with the new upload model. Use RequestTextUpload to get a pre-signed URL,
-// then perform an HTTP PUT request directly to that URL with the text content.
+// then perform an HTTP PUT request directly to that URL with the text content, or
+// use UploadText, which performs both steps.
 //
 // Parameters:
 //   - ctx: Context for the API request
@@ -160,13 +595,20 @@ func NewClientWithOptions(baseURL string, options ...ClientOption) (*Client, err
 //   - "forbidden" if the caller lacks permissions
 //   - "network_error" if the connection fails
 func (c *Client) IngestText(ctx context.Context, request *IngestTextRequest) (*IngestResponse, error) {
+	if request != nil {
+		r := *request
+		c.applyDefaultIDs(&r.TenantID, &r.UserID)
+		c.applyDefaultMetadata(&r.Metadata)
+		request = &r
+	}
+
 	httpReq, err := c.newRequest(ctx, "POST", "/ingest/text", request)
 	if err != nil {
 		return nil, err
 	}
 
 	var resp IngestResponse
-	_, err = c.do(httpReq, &resp)
+	_, err = c.do("IngestText", httpReq, &resp)
 	if err != nil {
 		return nil, err
 	}
@@ -179,6 +621,7 @@ func (c *Client) IngestText(ctx context.Context, request *IngestTextRequest) (*I
 // Parameters:
 //   - ctx: Context for the API request
 //   - request: IngestURLRequest containing the URL to scrape and metadata (required)
+//   - opts: Optional call settings, such as WithIdempotencyKey
 //
 // Returns:
 //   - *IngestURLResponse: An asynchronous response with ID and status (PENDING/QUEUED)
@@ -188,14 +631,32 @@ func (c *Client) IngestText(ctx context.Context, request *IngestTextRequest) (*I
 //   - "unauthorized" if authentication fails
 //   - "forbidden" if the caller lacks permissions
 //   - "network_error" if the connection fails
-func (c *Client) IngestURL(ctx context.Context, request *IngestURLRequest) (*IngestURLResponse, error) {
+//   - a validation error if the client was created with WithClientSideValidation
+//     and the URL is invalid
+func (c *Client) IngestURL(ctx context.Context, request *IngestURLRequest, opts ...CallOption) (*IngestURLResponse, error) {
+	if c.clientSideValidation {
+		if err := request.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid ingest URL request: %w", err)
+		}
+	}
+
+	if request != nil {
+		r := *request
+		c.applyDefaultIDs(&r.TenantID, &r.UserID)
+		c.applyDefaultMetadata(&r.Metadata)
+		request = &r
+	}
+
 	httpReq, err := c.newRequest(ctx, "POST", "/ingest/url", request)
 	if err != nil {
 		return nil, err
 	}
+	if err := setIdempotencyKey(httpReq, opts); err != nil {
+		return nil, err
+	}
 
 	var resp IngestURLResponse
-	_, err = c.do(httpReq, &resp)
+	_, err = c.do("IngestURL", httpReq, &resp)
 	if err != nil {
 		return nil, err
 	}
@@ -273,21 +734,18 @@ func (c *Client) IngestFile(ctx context.Context, tenantID string, filename strin
 	req.Header.Set("Content-Type", writer.FormDataContentType())
 	req.Header.Set("User-Agent", c.UserAgent)
 	req.Header.Set("Accept", "application/json")
+	if err := clientutil.SetRequestID(req); err != nil {
+		return nil, err
+	}
 
 	// Add Authorization header if TokenProvider is configured
-	if c.tokenProvider != nil {
-		token, tokenErr := c.tokenProvider.GetToken(ctx)
-		if tokenErr != nil {
-			return nil, fmt.Errorf("failed to get token from provider: %w", tokenErr)
-		}
-		if token != "" {
-			req.Header.Set("Authorization", "Bearer "+token)
-		}
+	if err := c.baseClient().Authorize(req); err != nil {
+		return nil, err
 	}
 
 	// Send request and process response
 	var resp IngestResponse
-	_, err = c.do(req, &resp)
+	_, err = c.do("IngestFile", req, &resp)
 	if err != nil {
 		return nil, err
 	}
@@ -300,6 +758,7 @@ func (c *Client) IngestFile(ctx context.Context, tenantID string, filename strin
 // Parameters:
 //   - ctx: Context for the API request
 //   - request: RequestFileUploadRequest containing file metadata (required fields: Filename, ContentType)
+//   - opts: Optional call settings, such as WithIdempotencyKey
 //
 // Returns:
 //   - *RequestFileUploadResponse: The response containing the pre-signed URL for direct S3 upload
@@ -310,17 +769,27 @@ func (c *Client) IngestFile(ctx context.Context, tenantID string, filename strin
 //   - "forbidden" if the caller lacks permissions
 //   - "network_error" if the connection fails
 //   - "server_error" if generating the upload URL fails
-func (c *Client) RequestFileUpload(ctx context.Context, request *RequestFileUploadRequest) (*RequestFileUploadResponse, error) {
+func (c *Client) RequestFileUpload(ctx context.Context, request *RequestFileUploadRequest, opts ...CallOption) (*RequestFileUploadResponse, error) {
+	if request != nil {
+		r := *request
+		c.applyDefaultIDs(&r.TenantID, &r.UserID)
+		c.applyDefaultMetadata(&r.Metadata)
+		request = &r
+	}
+
 	// Use the internal newRequest helper to create the POST request
 	// The path should now be `/ingest/file` based on service refactor. Double-check service route.
 	httpReq, err := c.newRequest(ctx, "POST", "/ingest/file", request) // Pass the RequestFileUploadRequest struct directly
 	if err != nil {
 		return nil, fmt.Errorf("failed to create file upload request: %w", err)
 	}
+	if err := setIdempotencyKey(httpReq, opts); err != nil {
+		return nil, fmt.Errorf("failed to create file upload request: %w", err)
+	}
 
 	// Execute the request using the internal 'do' helper, expecting RequestFileUploadResponse
 	var resp RequestFileUploadResponse
-	_, err = c.do(httpReq, &resp) // Pass pointer to the response struct
+	_, err = c.do("RequestFileUpload", httpReq, &resp) // Pass pointer to the response struct
 	if err != nil {
 		return nil, err // Error handling (including 4xx/5xx) is done within c.do
 	}
@@ -334,6 +803,7 @@ func (c *Client) RequestFileUpload(ctx context.Context, request *RequestFileUplo
 // Parameters:
 //   - ctx: Context for the API request
 //   - request: RequestTextUploadRequest containing text metadata (required field: ContentType)
+//   - opts: Optional call settings, such as WithIdempotencyKey
 //
 // Returns:
 //   - *RequestTextUploadResponse: The response containing the pre-signed URL for direct S3 upload
@@ -344,14 +814,24 @@ func (c *Client) RequestFileUpload(ctx context.Context, request *RequestFileUplo
 //   - "forbidden" if the caller lacks permissions
 //   - "network_error" if the connection fails
 //   - "server_error" if generating the upload URL fails
-func (c *Client) RequestTextUpload(ctx context.Context, request *RequestTextUploadRequest) (*RequestTextUploadResponse, error) {
+func (c *Client) RequestTextUpload(ctx context.Context, request *RequestTextUploadRequest, opts ...CallOption) (*RequestTextUploadResponse, error) {
+	if request != nil {
+		r := *request
+		c.applyDefaultIDs(&r.TenantID, &r.UserID)
+		c.applyDefaultMetadata(&r.Metadata)
+		request = &r
+	}
+
 	httpReq, err := c.newRequest(ctx, "POST", "/ingest/text", request)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create text upload request: %w", err)
 	}
+	if err := setIdempotencyKey(httpReq, opts); err != nil {
+		return nil, fmt.Errorf("failed to create text upload request: %w", err)
+	}
 
 	var resp RequestTextUploadResponse
-	_, err = c.do(httpReq, &resp)
+	_, err = c.do("RequestTextUpload", httpReq, &resp)
 	if err != nil {
 		return nil, err
 	}
@@ -359,13 +839,17 @@ func (c *Client) RequestTextUpload(ctx context.Context, request *RequestTextUplo
 	return &resp, nil
 }
 
-// UploadToURL uploads content directly to a pre-signed URL.
+// UploadToURL uploads content directly to a pre-signed URL. Canceling ctx
+// aborts the upload promptly, even while fileReader is blocked in a Read. If
+// ctx has no deadline, the upload is bounded by the client's upload timeout
+// (see WithUploadTimeout). Close the returned response's body to release it.
 //
 // Parameters:
 //   - ctx: Context for the API request
 //   - uploadURL: The pre-signed S3 URL to upload to (required)
 //   - contentType: The MIME type of the content being uploaded (required)
 //   - fileReader: An io.Reader providing the content to upload (required)
+//   - opts: Optional TransferOption values such as WithProgress, WithTotalSize, or WithChecksumSHA256
 //
 // Returns:
 //   - *http.Response: The raw HTTP response from the upload operation
@@ -373,35 +857,93 @@ func (c *Client) RequestTextUpload(ctx context.Context, request *RequestTextUplo
 //   - Network errors if the connection fails
 //   - S3-specific errors if the upload is rejected
 //   - Context cancellation errors
-func (c *Client) UploadToURL(ctx context.Context, uploadURL string, contentType string, fileReader io.Reader) (*http.Response, error) {
+//   - ErrChecksumNeedsSeeker if a checksum must be computed from content that cannot be rewound
+func (c *Client) UploadToURL(ctx context.Context, uploadURL string, contentType string, fileReader io.Reader, opts ...TransferOption) (*http.Response, error) {
+	options := newTransferOptions(opts)
+
+	// The upload timeout is a context deadline rather than an http.Client
+	// Timeout, so it never outlasts or cuts short a deadline set by the caller
+	cancel := context.CancelFunc(func() {})
+	if _, ok := ctx.Deadline(); !ok && c.uploadTimeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, c.uploadTimeout)
+	}
+
+	// Determine the size before any wrapping hides the underlying reader type
+	size := contentLength(fileReader)
+	if size < 0 {
+		size = options.totalSize
+	}
+
+	source := func() io.Reader {
+		if options.progress != nil {
+			return NewProgressReader(fileReader, size, options.progress)
+		}
+		return fileReader
+	}
+
+	// A slow source must not hold up cancellation, so it is read through a
+	// pipe that the context closes. Empty bodies are left for the transport to omit.
+	body := source()
+	var pipedBody *contextBody
+	if size != 0 {
+		pipedBody = newContextBody(ctx, body)
+		body = pipedBody
+	}
+
 	// Create a new HTTP request with the provided upload URL
-	req, err := http.NewRequestWithContext(ctx, "PUT", uploadURL, fileReader)
+	req, err := http.NewRequestWithContext(ctx, "PUT", uploadURL, body)
 	if err != nil {
+		cancel()
 		return nil, fmt.Errorf("failed to create upload request: %w", err)
 	}
 
+	// The contextBody wrapper hides a seekable source from net/http, so let
+	// the transport rewind it itself when it resends the body, as it does
+	// after a 307 or 308 redirect
+	if seeker, ok := fileReader.(io.Seeker); ok && pipedBody != nil {
+		if start, err := seeker.Seek(0, io.SeekCurrent); err == nil {
+			req.GetBody = func() (io.ReadCloser, error) {
+				// The previous attempt's copy must stop reading before the
+				// offset it reads from is moved
+				_ = pipedBody.Close()
+				pipedBody.wait()
+				if _, err := seeker.Seek(start, io.SeekStart); err != nil {
+					return nil, err
+				}
+				pipedBody = newContextBody(ctx, source())
+				return pipedBody, nil
+			}
+		}
+	}
+
 	// Set the Content-Type header to the specified value
 	req.Header.Set("Content-Type", contentType)
 
-	// Set Content-Length if we can determine it from the fileReader (if it's an *os.File)
-	if file, ok := fileReader.(*os.File); ok {
-		fileInfo, err := file.Stat()
-		if err == nil {
-			req.ContentLength = fileInfo.Size()
-		}
+	// Set any requested checksum headers, computing them before the body is read
+	if err := setChecksumHeaders(req, options.checksums, fileReader); err != nil {
+		cancel()
+		return nil, err
 	}
 
-	// Use the standard HTTP client instead of c.HTTPClient to avoid auth header conflicts
-	// for direct S3 uploads with pre-signed URLs
-	standardClient := &http.Client{
-		Timeout: 60 * time.Second, // Set a reasonable timeout
+	// Set Content-Length if we can determine it from the fileReader (e.g. an *os.File)
+	if size >= 0 {
+		req.ContentLength = size
 	}
 
+	// Use the standard HTTP client instead of c.HTTPClient to avoid auth header conflicts
+	// for direct S3 uploads with pre-signed URLs. It has no Timeout of its own;
+	// the request's context bounds the connection, the body write, and the response.
+	standardClient := &http.Client{Transport: c.transferTransport}
+
 	resp, err := standardClient.Do(req)
 	if err != nil {
+		cancel()
 		return nil, fmt.Errorf("failed to upload to URL: %w", err)
 	}
 
+	// The response body stays readable until the caller closes it
+	resp.Body = &cancelOnCloseBody{ReadCloser: resp.Body, cancel: cancel}
+
 	// Check for non-2xx status codes and return appropriate error
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
 		defer func() {
@@ -420,54 +962,146 @@ func (c *Client) UploadToURL(ctx context.Context, uploadURL string, contentType
 	return resp, nil
 }
 
-// newRequest creates an API request with the specified method, path and body
+// baseClient returns the BaseClient that builds and sends c's requests, with
+// c's current BaseURL, HTTPClient, and UserAgent
+func (c *Client) baseClient() *clientutil.BaseClient {
+	b := c.base
+	b.BaseURL = c.BaseURL
+	b.HTTPClient = c.HTTPClient
+	b.UserAgent = c.UserAgent
+	b.MaxResponseBytes = c.MaxResponseBytes
+	return &b
+}
+
+// newRequest creates an API request with the specified method, path and body,
+// gzip-encoding the body if request compression applies to it
 func (c *Client) newRequest(ctx context.Context, method, path string, body interface{}) (*http.Request, error) {
-	u := c.BaseURL.JoinPath(path)
-
-	var buf io.ReadWriter
-	if body != nil {
-		buf = new(bytes.Buffer)
-		err := json.NewEncoder(buf).Encode(body)
-		if err != nil {
-			return nil, err
+	req, err := c.baseClient().NewRequest(ctx, method, path, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if body != nil && c.shouldCompress(method, int(req.ContentLength)) {
+		if err := compressRequestBody(req); err != nil {
+			return nil, fmt.Errorf("failed to compress request body: %w", err)
 		}
 	}
 
-	req, err := http.NewRequestWithContext(ctx, method, u.String(), buf)
+	return req, nil
+}
+
+// applyDefaultIDs fills empty tenant and user IDs with the client's defaults.
+// Callers pass fields of a copy of the request so the caller's value is not modified.
+func (c *Client) applyDefaultIDs(tenantID, userID *string) {
+	if *tenantID == "" {
+		*tenantID = c.defaultTenantID
+	}
+	if *userID == "" {
+		*userID = c.defaultUserID
+	}
+}
+
+// applyDefaultMetadata replaces *metadata with a new map holding the client's
+// default metadata overlaid with *metadata. Callers pass a field of a copy of
+// the request so the caller's map is not modified.
+func (c *Client) applyDefaultMetadata(metadata *map[string]string) {
+	if len(c.defaultMetadata) == 0 {
+		return
+	}
+	merged := maps.Clone(c.defaultMetadata)
+	maps.Copy(merged, *metadata)
+	*metadata = merged
+}
+
+// shouldCompress reports whether a JSON body of size bytes sent with method
+// should be gzip-encoded
+func (c *Client) shouldCompress(method string, size int) bool {
+	if !c.compressRequests || size < CompressionThreshold {
+		return false
+	}
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch:
+		return true
+	}
+	return false
+}
+
+// compressRequestBody replaces the body of req with its gzip encoding and sets
+// Content-Encoding: gzip
+func compressRequestBody(req *http.Request) error {
+	data, err := io.ReadAll(req.Body)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	if body != nil {
-		req.Header.Set("Content-Type", "application/json")
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(data); err != nil {
+		return err
+	}
+	if err := zw.Close(); err != nil {
+		return err
 	}
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("User-Agent", c.UserAgent)
 
-	// Add Authorization header if TokenProvider is configured
-	if c.tokenProvider != nil {
-		token, tokenErr := c.tokenProvider.GetToken(ctx)
-		if tokenErr != nil {
-			return nil, fmt.Errorf("failed to get token from provider: %w", tokenErr)
-		}
-		if token != "" {
-			req.Header.Set("Authorization", "Bearer "+token)
-		}
+	compressed := buf.Bytes()
+	req.Body = io.NopCloser(bytes.NewReader(compressed))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(compressed)), nil
 	}
+	req.ContentLength = int64(len(compressed))
+	req.Header.Set("Content-Encoding", "gzip")
+	return nil
+}
 
-	return req, nil
+// do sends an API request for the named client method and returns the API response
+func (c *Client) do(operation string, req *http.Request, v interface{}) (*http.Response, error) {
+	b := c.baseClient()
+	return b.Do(req, v, b.RequestOptions(operation)...)
 }
 
-// do sends an API request and returns the API response
-func (c *Client) do(req *http.Request, v interface{}) (*http.Response, error) {
-	return clientutil.ExecuteRequest(req.Context(), c.HTTPClient, req, v)
+// stream sends an API request for the named client method and returns the
+// unread body of a successful response
+func (c *Client) stream(operation string, req *http.Request) (io.ReadCloser, error) {
+	b := c.baseClient()
+	return b.Stream(req, b.RequestOptions(operation)...)
+}
+
+// Health checks the health status of the Ingest API.
+//
+// Parameters:
+//   - ctx: Context for the API request
+//
+// Returns:
+//   - *HealthResponse: The service health status, typically containing a "status" field
+//   - error: An error if the operation fails, which can be:
+//   - apierror.ErrorResponse with codes like:
+//   - "network_error" if the connection fails
+//   - "server_error" if the API server experiences an error
+func (c *Client) Health(ctx context.Context) (*HealthResponse, error) {
+	req, err := c.newRequest(ctx, "GET", "/health", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp HealthResponse
+	_, err = c.do("Health", req, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
 }
 
 // GetContentItem retrieves a specific content item by its ID.
+// If fields are given, the service is asked to return only those fields
+// (e.g. "id", "status") and the remaining ContentItem fields are left empty.
+// With WithMetadataCache, a full item fetched within the TTL is returned from
+// the cache without a request.
 //
 // Parameters:
 //   - ctx: Context for the API request
 //   - id: The unique identifier of the content item to retrieve (required)
+//   - fields: Optional JSON field names to return; none returns the full item
 //
 // Returns:
 //   - *ContentItem: The content item details if found
@@ -477,30 +1111,174 @@ func (c *Client) do(req *http.Request, v interface{}) (*http.Response, error) {
 //   - "unauthorized" if authentication fails
 //   - "forbidden" if the caller lacks permissions
 //   - "network_error" if the connection fails
-func (c *Client) GetContentItem(ctx context.Context, id string) (*ContentItem, error) {
+func (c *Client) GetContentItem(ctx context.Context, id string, fields ...string) (*ContentItem, error) {
+	return c.GetContentItemWithOptions(ctx, id, fields)
+}
+
+// GetContentItemWithOptions retrieves a specific content item by its ID like
+// GetContentItem, additionally accepting call options such as WithNoCache and
+// WithResponseHeaders. Headers are not stored for an item served from the
+// metadata cache.
+//
+// Parameters:
+//   - ctx: Context for the API request
+//   - id: The unique identifier of the content item to retrieve (required)
+//   - fields: Optional JSON field names to return; nil returns the full item
+//   - opts: Optional call options
+//
+// Returns:
+//   - *ContentItem: The content item details if found
+//   - error: An error as described for GetContentItem
+func (c *Client) GetContentItemWithOptions(ctx context.Context, id string, fields []string, opts ...CallOption) (*ContentItem, error) {
+	options := newCallOptions(opts)
+
+	// Only full items are cached, so field projections always go to the
+	// service, and items fetched with a caller's own token are not shared
+	// with other callers
+	cache := c.metadataCache
+	if len(fields) > 0 || clientutil.AuthTokenFromContext(ctx) != "" {
+		cache = nil
+	}
+	var generation uint64
+	if cache != nil {
+		if !options.noCache {
+			if item := cache.get(id, clientutil.ClockOrSystem(c.base.Clock).Now()); item != nil {
+				return item, nil
+			}
+		}
+		generation = cache.currentGeneration()
+	}
+
 	path := fmt.Sprintf("/content/%s", id)
 	httpReq, err := c.newRequest(ctx, "GET", path, nil)
 	if err != nil {
 		return nil, err
 	}
 
+	if len(fields) > 0 {
+		q := httpReq.URL.Query()
+		q.Set("fields", strings.Join(fields, ","))
+		httpReq.URL.RawQuery = q.Encode()
+	}
+
 	var resp ContentItem
-	_, err = c.do(httpReq, &resp)
+	httpResp, err := c.do("GetContentItem", httpReq, &resp)
 	if err != nil {
 		return nil, err
 	}
+	options.storeResponseHeaders(httpResp)
 
+	if cache != nil {
+		cache.put(id, &resp, generation, clientutil.ClockOrSystem(c.base.Clock).Now())
+	}
 	return &resp, nil
 }
 
+// GetContentItemByS3Key retrieves the content item stored under an S3 key, for
+// callers that know where content is stored but not its ID.
+//
+// Parameters:
+//   - ctx: Context for the API request
+//   - s3Key: The S3 key of the content item (required)
+//
+// Returns:
+//   - *ContentItem: The content item stored under the key
+//   - error: An error if the operation fails, which can be:
+//   - "not_found" (matching ErrNotFound) if no content item has the key
+//   - "ambiguous_s3_key" (matching ErrAmbiguousS3Key) if several content items have the key
+//   - "unauthorized" if authentication fails
+//   - "network_error" if the connection fails
+func (c *Client) GetContentItemByS3Key(ctx context.Context, s3Key string) (*ContentItem, error) {
+	httpReq, err := c.newRequest(ctx, "GET", "/content", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Two results are enough to tell a unique match from an ambiguous one
+	q := httpReq.URL.Query()
+	q.Set("s3Key", s3Key)
+	q.Set("limit", "2")
+	httpReq.URL.RawQuery = q.Encode()
+
+	var resp ListContentResponse
+	if _, err := c.do("GetContentItemByS3Key", httpReq, &resp); err != nil {
+		return nil, err
+	}
+
+	switch len(resp.Items) {
+	case 0:
+		return nil, &apierror.ErrorResponse{
+			ErrorCode:   "not_found",
+			Description: fmt.Sprintf("No content item has S3 key %q.", s3Key),
+			StatusCode:  http.StatusNotFound,
+		}
+	case 1:
+		return &resp.Items[0], nil
+	default:
+		return nil, &apierror.ErrorResponse{
+			ErrorCode:   "ambiguous_s3_key",
+			Description: fmt.Sprintf("More than one content item has S3 key %q.", s3Key),
+		}
+	}
+}
+
+// ContentItemExists reports whether a content item exists without fetching
+// its metadata. It issues a HEAD request to /content/{id}, falling back to GET
+// if the service does not support HEAD.
+//
+// Parameters:
+//   - ctx: Context for the API request
+//   - id: The unique identifier of the content item to check (required)
+//
+// Returns:
+//   - bool: true if the content item exists, false if the service reports it not found
+//   - error: An error if the check itself fails, which can be:
+//   - apierror.ErrorResponse with codes like:
+//   - "unauthorized" if authentication fails
+//   - "forbidden" if the caller lacks permissions
+//   - "network_error" if the connection fails
+func (c *Client) ContentItemExists(ctx context.Context, id string) (bool, error) {
+	path := fmt.Sprintf("/content/%s", id)
+
+	err := c.probe(ctx, http.MethodHead, path)
+	var apiErr *apierror.ErrorResponse
+	if errors.As(err, &apiErr) &&
+		(apiErr.StatusCode == http.StatusMethodNotAllowed || apiErr.StatusCode == http.StatusNotImplemented) {
+		err = c.probe(ctx, http.MethodGet, path)
+	}
+
+	if err != nil {
+		if isNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// probe sends a request to path and discards the response body.
+func (c *Client) probe(ctx context.Context, method, path string) error {
+	httpReq, err := c.newRequest(ctx, method, path, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.do("ContentItemExists", httpReq, nil)
+	return err
+}
+
 // ListContentItems lists content items with optional filters.
 //
+// Deprecated: Use ListContentItemsWithOptions, which takes typed status and
+// source type filters.
+//
 // Parameters:
 //   - ctx: Context for the API request
 //   - statusFilter: Optional filter to match content items with a specific status (e.g., "COMPLETED")
 //   - sourceTypeFilter: Optional filter to match content items with a specific source type (e.g., "TEXT", "URL", "FILE")
 //   - limit: Optional maximum number of items to return
 //   - nextToken: Optional pagination token from a previous list response
+//   - callOpts: Optional call settings, such as WithResponseHeaders
 //
 // Returns:
 //   - *ListContentResponse: A list of content items and optional pagination token
@@ -510,38 +1288,87 @@ func (c *Client) GetContentItem(ctx context.Context, id string) (*ContentItem, e
 //   - "unauthorized" if authentication fails
 //   - "forbidden" if the caller lacks permissions
 //   - "network_error" if the connection fails
-func (c *Client) ListContentItems(ctx context.Context, statusFilter *string, sourceTypeFilter *string, limit *int, nextToken *string) (*ListContentResponse, error) {
-	httpReq, err := c.newRequest(ctx, "GET", "/content", nil)
-	if err != nil {
-		return nil, err
-	}
-
-	// Add query parameters if they are provided
-	q := httpReq.URL.Query()
+func (c *Client) ListContentItems(ctx context.Context, statusFilter *string, sourceTypeFilter *string, limit *int, nextToken *string, callOpts ...CallOption) (*ListContentResponse, error) {
+	opts := &ListContentItemsOptions{}
 	if statusFilter != nil {
-		q.Add("status", *statusFilter)
+		opts.Status = ContentStatus(*statusFilter)
 	}
 	if sourceTypeFilter != nil {
-		q.Add("sourceType", *sourceTypeFilter)
+		opts.SourceType = SourceType(*sourceTypeFilter)
 	}
 	if limit != nil {
-		q.Add("limit", strconv.Itoa(*limit))
+		opts.Limit = *limit
 	}
 	if nextToken != nil {
-		q.Add("nextToken", *nextToken)
+		opts.NextToken = *nextToken
+	}
+	return c.ListContentItemsWithOptions(ctx, opts, callOpts...)
+}
+
+// ListContentItemsWithOptions lists content items with optional typed filters.
+//
+// Parameters:
+//   - ctx: Context for the API request
+//   - opts: Optional filters and pagination (nil lists all content items)
+//   - callOpts: Optional call settings, such as WithResponseHeaders
+//
+// Returns:
+//   - *ListContentResponse: A list of content items and optional pagination token
+//   - error: An error if the operation fails, which can be:
+//   - apierror.ErrorResponse with codes like:
+//   - "bad_request" if the query parameters are invalid
+//   - "unauthorized" if authentication fails
+//   - "forbidden" if the caller lacks permissions
+//   - "network_error" if the connection fails
+func (c *Client) ListContentItemsWithOptions(ctx context.Context, opts *ListContentItemsOptions, callOpts ...CallOption) (*ListContentResponse, error) {
+	httpReq, err := c.newRequest(ctx, "GET", "/content", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Add query parameters if they are provided
+	if opts != nil {
+		q := httpReq.URL.Query()
+		if opts.Status != "" {
+			q.Add("status", string(opts.Status))
+		}
+		if opts.SourceType != "" {
+			q.Add("sourceType", string(opts.SourceType))
+		}
+		if opts.Limit > 0 {
+			q.Add("limit", strconv.Itoa(opts.Limit))
+		}
+		if opts.NextToken != "" {
+			q.Add("nextToken", opts.NextToken)
+		}
+		if opts.TenantID != "" {
+			q.Add("tenantId", opts.TenantID)
+		}
+		if opts.UserID != "" {
+			q.Add("userId", opts.UserID)
+		}
+		if !opts.CreatedAfter.IsZero() {
+			q.Add("createdAfter", opts.CreatedAfter.UTC().Format(time.RFC3339))
+		}
+		if !opts.CreatedBefore.IsZero() {
+			q.Add("createdBefore", opts.CreatedBefore.UTC().Format(time.RFC3339))
+		}
+		httpReq.URL.RawQuery = q.Encode()
 	}
-	httpReq.URL.RawQuery = q.Encode()
 
 	var resp ListContentResponse
-	_, err = c.do(httpReq, &resp)
+	httpResp, err := c.do("ListContentItemsWithOptions", httpReq, &resp)
 	if err != nil {
 		return nil, err
 	}
+	newCallOptions(callOpts).storeResponseHeaders(httpResp)
 
 	return &resp, nil
 }
 
 // GetContentDownloadURL retrieves a pre-signed URL that can be used to download the content.
+// If the client was created with WithDownloadURLCache, a cached URL that is not
+// close to expiry is returned without contacting the API.
 //
 // Parameters:
 //   - ctx: Context for the API request
@@ -556,6 +1383,17 @@ func (c *Client) ListContentItems(ctx context.Context, statusFilter *string, sou
 //   - "forbidden" if the caller lacks permissions
 //   - "network_error" if the connection fails
 func (c *Client) GetContentDownloadURL(ctx context.Context, contentID string) (*DownloadURLResponse, error) {
+	// URLs fetched with a caller's own token are not shared with other callers
+	cache := c.downloadURLs
+	if clientutil.AuthTokenFromContext(ctx) != "" {
+		cache = nil
+	}
+	if cache != nil {
+		if cached := cache.get(contentID, clientutil.ClockOrSystem(c.base.Clock).Now()); cached != nil {
+			return cached, nil
+		}
+	}
+
 	path := fmt.Sprintf("/content/%s/download-url", contentID)
 
 	req, err := c.newRequest(ctx, "GET", path, nil)
@@ -564,10 +1402,15 @@ func (c *Client) GetContentDownloadURL(ctx context.Context, contentID string) (*
 	}
 
 	var resp DownloadURLResponse
-	_, err = c.do(req, &resp)
+	_, err = c.do("GetContentDownloadURL", req, &resp)
 	if err != nil {
 		return nil, err
 	}
+	resp.retrievedAt = clientutil.ClockOrSystem(c.base.Clock).Now()
+
+	if cache != nil {
+		cache.put(contentID, &resp)
+	}
 
 	return &resp, nil
 }
@@ -596,7 +1439,64 @@ func (c *Client) UpdateContentItem(ctx context.Context, id string, req *UpdateCo
 	}
 
 	var resp ContentItem
-	_, err = c.do(httpReq, &resp)
+	_, err = c.do("UpdateContentItem", httpReq, &resp)
+	c.invalidateMetadata(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// MergePatchContentType is the media type of JSON merge patch (RFC 7396)
+// request bodies, as sent by SetContentMetadata.
+const MergePatchContentType = "application/merge-patch+json"
+
+// metadataPatch is the JSON merge patch body sent by SetContentMetadata. A nil
+// value removes the key.
+type metadataPatch struct {
+	Metadata map[string]*string `json:"metadata"`
+}
+
+// SetContentMetadata changes individual metadata keys of a content item without
+// resending the whole map, so concurrent changes to other keys are not lost.
+// The change is sent as a JSON merge patch: upserted keys carry their new
+// values and deleted keys are sent as null. A key in both upserts and deletes
+// is deleted.
+//
+// Parameters:
+//   - ctx: Context for the API request
+//   - id: The unique identifier of the content item to update (required)
+//   - upserts: Metadata keys to add or overwrite, with their values
+//   - deletes: Metadata keys to remove
+//
+// Returns:
+//   - *ContentItem: The updated content item if successful
+//   - error: An error if the operation fails, which can be:
+//   - apierror.ErrorResponse with codes like:
+//   - "not_found" if the content item doesn't exist
+//   - "bad_request" if the patch is invalid
+//   - "unauthorized" if authentication fails
+//   - "network_error" if the connection fails
+func (c *Client) SetContentMetadata(ctx context.Context, id string, upserts map[string]string, deletes []string) (*ContentItem, error) {
+	patch := metadataPatch{Metadata: make(map[string]*string, len(upserts)+len(deletes))}
+	for key, value := range upserts {
+		patch.Metadata[key] = &value
+	}
+	for _, key := range deletes {
+		patch.Metadata[key] = nil
+	}
+
+	path := fmt.Sprintf("/content/%s", id)
+	httpReq, err := c.newRequest(ctx, "PATCH", path, patch)
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", MergePatchContentType)
+
+	var resp ContentItem
+	_, err = c.do("SetContentMetadata", httpReq, &resp)
+	c.invalidateMetadata(id)
 	if err != nil {
 		return nil, err
 	}
@@ -604,11 +1504,13 @@ func (c *Client) UpdateContentItem(ctx context.Context, id string, req *UpdateCo
 	return &resp, nil
 }
 
-// DeleteContentItem deletes a content item by its ID.
+// DeleteContentItem deletes a content item by its ID. The service soft-deletes
+// the record unless WithPurge is given.
 //
 // Parameters:
 //   - ctx: Context for the API request
 //   - id: The unique identifier of the content item to delete (required)
+//   - opts: Optional call settings, such as WithPurge
 //
 // Returns:
 //   - error: An error if the operation fails, which can be:
@@ -617,17 +1519,55 @@ func (c *Client) UpdateContentItem(ctx context.Context, id string, req *UpdateCo
 //   - "unauthorized" if authentication fails
 //   - "forbidden" if the caller lacks permissions
 //   - "network_error" if the connection fails
-func (c *Client) DeleteContentItem(ctx context.Context, id string) error {
+func (c *Client) DeleteContentItem(ctx context.Context, id string, opts ...CallOption) error {
 	path := fmt.Sprintf("/content/%s", id)
 	httpReq, err := c.newRequest(ctx, "DELETE", path, nil)
 	if err != nil {
 		return err
 	}
+	newCallOptions(opts).setPurge(httpReq)
 
-	_, err = c.do(httpReq, nil)
+	_, err = c.do("DeleteContentItem", httpReq, nil)
+	c.invalidateMetadata(id)
 	return err
 }
 
+// DeleteContentItemWithResult deletes a content item by its ID and returns the
+// deleted item when the service includes it in the response, for example to
+// audit its size and S3 key. A 204 No Content response yields a nil item.
+//
+// Parameters:
+//   - ctx: Context for the API request
+//   - id: The unique identifier of the content item to delete (required)
+//   - opts: Optional call settings, such as WithPurge
+//
+// Returns:
+//   - *ContentItem: The deleted content item, or nil if the service returned no body
+//   - error: An error if the operation fails, which can be:
+//   - apierror.ErrorResponse with codes like:
+//   - "not_found" if the content item doesn't exist
+//   - "unauthorized" if authentication fails
+//   - "forbidden" if the caller lacks permissions
+//   - "network_error" if the connection fails
+func (c *Client) DeleteContentItemWithResult(ctx context.Context, id string, opts ...CallOption) (*ContentItem, error) {
+	path := fmt.Sprintf("/content/%s", id)
+	httpReq, err := c.newRequest(ctx, "DELETE", path, nil)
+	if err != nil {
+		return nil, err
+	}
+	newCallOptions(opts).setPurge(httpReq)
+
+	// item stays nil unless the response has a body to decode
+	var item *ContentItem
+	_, err = c.do("DeleteContentItemWithResult", httpReq, &item)
+	c.invalidateMetadata(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return item, nil
+}
+
 // GetTextContent retrieves the raw text content of a TEXT type content item.
 //
 // Parameters:
@@ -651,7 +1591,7 @@ func (c *Client) GetTextContent(ctx context.Context, id string) (*GetTextContent
 	}
 
 	var resp GetTextContentResponse
-	_, err = c.do(httpReq, &resp)
+	_, err = c.do("GetTextContent", httpReq, &resp)
 	if err != nil {
 		return nil, err
 	}
@@ -659,6 +1599,35 @@ func (c *Client) GetTextContent(ctx context.Context, id string) (*GetTextContent
 	return &resp, nil
 }
 
+// StreamTextContent retrieves the raw text content of a TEXT type content item
+// as a stream, without holding it in memory. Use it instead of GetTextContent
+// for large documents. The caller must close the returned reader.
+//
+// Parameters:
+//   - ctx: Context for the API request and for reading the stream
+//   - id: The unique identifier of the content item (required)
+//
+// Returns:
+//   - io.ReadCloser: The text content
+//   - error: An error if the operation fails, which can be:
+//   - apierror.ErrorResponse with codes like:
+//   - "not_found" if the content item doesn't exist
+//   - "bad_request" if the content item is not of type TEXT
+//   - "unauthorized" if authentication fails
+//   - "forbidden" if the caller lacks permissions
+//   - "network_error" if the connection fails
+func (c *Client) StreamTextContent(ctx context.Context, id string) (io.ReadCloser, error) {
+	path := fmt.Sprintf("/content/%s/text", id)
+	httpReq, err := c.newRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+	// Ask for the text itself rather than the JSON envelope GetTextContent reads
+	httpReq.Header.Set("Accept", "text/plain")
+
+	return c.stream("StreamTextContent", httpReq)
+}
+
 // UpdateTextContent updates the raw text content of a TEXT type content item.
 //
 // Parameters:
@@ -681,6 +1650,44 @@ func (c *Client) UpdateTextContent(ctx context.Context, id string, req *UpdateTe
 		return err
 	}
 
-	_, err = c.do(httpReq, nil)
+	_, err = c.do("UpdateTextContent", httpReq, nil)
+	c.invalidateMetadata(id)
+	return err
+}
+
+// UpdateTextContentStream replaces the raw text content of a TEXT type content
+// item with the contents of r, sent as the request body as it is read. Unlike
+// UpdateTextContent, the text is neither held in a string nor JSON-encoded,
+// which suits large documents.
+//
+// Parameters:
+//   - ctx: Context for the API request
+//   - id: The unique identifier of the content item to update (required)
+//   - contentType: The media type of the text, such as "text/markdown"; empty means "text/plain; charset=utf-8"
+//   - r: The new text content (required)
+//
+// Returns:
+//   - error: An error if the operation fails, which can be:
+//   - apierror.ErrorResponse with codes like:
+//   - "not_found" if the content item doesn't exist
+//   - "bad_request" if the content item is not of type TEXT
+//   - "unauthorized" if authentication fails
+//   - "network_error" if the connection fails
+func (c *Client) UpdateTextContentStream(ctx context.Context, id string, contentType string, r io.Reader) error {
+	path := fmt.Sprintf("/content/%s/text", id)
+	httpReq, err := c.newRequest(ctx, "PUT", path, nil)
+	if err != nil {
+		return err
+	}
+
+	if contentType == "" {
+		contentType = "text/plain; charset=utf-8"
+	}
+	httpReq.Body = io.NopCloser(r)
+	httpReq.ContentLength = -1
+	httpReq.Header.Set("Content-Type", contentType)
+
+	_, err = c.do("UpdateTextContentStream", httpReq, nil)
+	c.invalidateMetadata(id)
 	return err
 }
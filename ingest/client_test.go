@@ -11,7 +11,9 @@ import (
 	"net/http/httptest"
 	"net/url"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/atriumn/atriumn-sdk-go/internal/apierror"
 )
@@ -101,6 +103,36 @@ func TestNewClient(t *testing.T) {
 	}
 }
 
+func TestNewClient_BaseURLValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		baseURL string
+		wantErr string
+	}{
+		{"missing scheme", "api.example.com", "invalid base URL: missing scheme"},
+		{"missing host", "https:///v1", "invalid base URL: missing host"},
+		{"path and trailing slash", "https://api.example.com/v1/", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := NewClient(tt.baseURL)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("NewClient(%q) returned unexpected error: %v", tt.baseURL, err)
+				}
+				if client.BaseURL.String() != tt.baseURL {
+					t.Errorf("NewClient(%q) BaseURL = %q", tt.baseURL, client.BaseURL.String())
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("NewClient(%q) error = %v, want %q", tt.baseURL, err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestNewClientWithOptions(t *testing.T) {
 	httpClient := &http.Client{}
 	tokenProvider := &MockTokenProvider{token: "test-token"}
@@ -122,8 +154,119 @@ func TestNewClientWithOptions(t *testing.T) {
 	if client.UserAgent != userAgent {
 		t.Errorf("NewClientWithOptions UserAgent = %q, want %q", client.UserAgent, userAgent)
 	}
-	if client.tokenProvider != tokenProvider {
-		t.Errorf("NewClientWithOptions tokenProvider = %v, want %v", client.tokenProvider, tokenProvider)
+	if client.base.TokenProvider != tokenProvider {
+		t.Errorf("NewClientWithOptions tokenProvider = %v, want %v", client.base.TokenProvider, tokenProvider)
+	}
+}
+
+func TestClient_WithConnectionPool(t *testing.T) {
+	client, err := NewClientWithOptions("https://api.example.com", WithConnectionPool(64, 16, 32, 45*time.Second))
+	if err != nil {
+		t.Fatalf("NewClientWithOptions() error = %v", err)
+	}
+
+	transport, ok := client.HTTPClient.Transport.(*http.Transport)
+	if !ok {
+		t.Fatalf("Transport = %T, want *http.Transport", client.HTTPClient.Transport)
+	}
+	if transport.MaxIdleConns != 64 {
+		t.Errorf("MaxIdleConns = %d, want 64", transport.MaxIdleConns)
+	}
+	if transport.MaxIdleConnsPerHost != 16 {
+		t.Errorf("MaxIdleConnsPerHost = %d, want 16", transport.MaxIdleConnsPerHost)
+	}
+	if transport.MaxConnsPerHost != 32 {
+		t.Errorf("MaxConnsPerHost = %d, want 32", transport.MaxConnsPerHost)
+	}
+	if transport.IdleConnTimeout != 45*time.Second {
+		t.Errorf("IdleConnTimeout = %v, want 45s", transport.IdleConnTimeout)
+	}
+	if client.HTTPClient.Timeout != DefaultTimeout {
+		t.Errorf("Timeout = %v, want %v", client.HTTPClient.Timeout, DefaultTimeout)
+	}
+}
+
+func TestClient_WithConnectionPool_HTTPClientTakesPrecedence(t *testing.T) {
+	for _, order := range []string{"pool first", "client first"} {
+		t.Run(order, func(t *testing.T) {
+			custom := &http.Client{}
+			options := []ClientOption{WithConnectionPool(64, 16, 32, 45*time.Second), WithHTTPClient(custom)}
+			if order == "client first" {
+				options[0], options[1] = options[1], options[0]
+			}
+
+			client, err := NewClientWithOptions("https://api.example.com", options...)
+			if err != nil {
+				t.Fatalf("NewClientWithOptions() error = %v", err)
+			}
+			if client.HTTPClient != custom {
+				t.Errorf("HTTPClient was replaced, want the client passed to WithHTTPClient")
+			}
+			if custom.Transport != nil {
+				t.Errorf("custom Transport = %T, want it left unset", custom.Transport)
+			}
+		})
+	}
+}
+
+func TestClient_WithUserAgentSuffix(t *testing.T) {
+	var gotUserAgent string
+	server := setupTestServer(t, http.StatusOK, `{"status":"ok"}`, func(r *http.Request) {
+		gotUserAgent = r.Header.Get("User-Agent")
+	})
+	defer server.Close()
+
+	client, err := NewClientWithOptions(server.URL, WithUserAgentSuffix("my-app/2.3"))
+	if err != nil {
+		t.Fatalf("NewClientWithOptions returned unexpected error: %v", err)
+	}
+	if _, err := client.Health(context.Background()); err != nil {
+		t.Fatalf("Health returned unexpected error: %v", err)
+	}
+
+	want := DefaultUserAgent + " my-app/2.3"
+	if gotUserAgent != want {
+		t.Errorf("User-Agent = %q, want %q", gotUserAgent, want)
+	}
+	for _, token := range []string{"atriumn-ingest-client/1.0", "atriumn-sdk-go/", "my-app/2.3"} {
+		if !strings.Contains(gotUserAgent, token) {
+			t.Errorf("User-Agent = %q, want it to contain %q", gotUserAgent, token)
+		}
+	}
+}
+
+// idleCloserTransport is a custom transport that records CloseIdleConnections calls
+type idleCloserTransport struct {
+	http.RoundTripper
+	closed bool
+}
+
+func (t *idleCloserTransport) CloseIdleConnections() {
+	t.closed = true
+}
+
+func TestClient_Close(t *testing.T) {
+	client, err := NewClient("https://api.example.com")
+	if err != nil {
+		t.Fatalf("NewClient returned unexpected error: %v", err)
+	}
+	if err := client.Close(); err != nil {
+		t.Errorf("Close on a fresh client returned %v, want nil", err)
+	}
+	if err := client.Close(); err != nil {
+		t.Errorf("second Close returned %v, want nil", err)
+	}
+
+	transport := &idleCloserTransport{RoundTripper: http.DefaultTransport}
+	client, err = NewClientWithOptions("https://api.example.com", WithHTTPClient(&http.Client{Transport: transport}))
+	if err != nil {
+		t.Fatalf("NewClientWithOptions returned unexpected error: %v", err)
+	}
+	if err := client.Close(); err != nil {
+		t.Errorf("Close returned %v, want nil", err)
+	}
+	if !transport.closed {
+		t.Error("Close did not close the transport's idle connections")
 	}
 }
 
@@ -246,6 +389,34 @@ func TestClient_IngestURL(t *testing.T) {
 	}
 }
 
+func TestClient_IngestURL_FullResponse(t *testing.T) {
+	expectedResponse := `{"id":"test-id","status":"QUEUED","tenantId":"tenant-123","userId":"user-456","timestamp":"2023-04-01T12:34:56Z"}`
+
+	server := setupTestServer(t, http.StatusAccepted, expectedResponse, nil)
+	defer server.Close()
+
+	client, err := NewClient(server.URL)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	resp, err := client.IngestURL(context.Background(), &IngestURLRequest{URL: "https://example.com/document.pdf"})
+	if err != nil {
+		t.Fatalf("IngestURL returned unexpected error: %v", err)
+	}
+
+	want := IngestURLResponse{
+		ID:        "test-id",
+		Status:    "QUEUED",
+		TenantID:  "tenant-123",
+		UserID:    "user-456",
+		Timestamp: "2023-04-01T12:34:56Z",
+	}
+	if *resp != want {
+		t.Errorf("IngestURL response = %+v, want %+v", *resp, want)
+	}
+}
+
 func TestClient_IngestFile(t *testing.T) {
 	expectedResponse := `{"id":"test-id","status":"pending","tenantId":"tenant-123","userId":"user-456","timestamp":"2023-04-01T12:34:56Z"}`
 
@@ -563,6 +734,45 @@ func TestClient_GetContentItem(t *testing.T) {
 	}
 }
 
+func TestClient_GetContentItem_Fields(t *testing.T) {
+	tests := []struct {
+		name       string
+		fields     []string
+		wantFields string
+		wantSet    bool
+	}{
+		{name: "fields requested", fields: []string{"id", "status"}, wantFields: "id,status", wantSet: true},
+		{name: "no fields", fields: nil, wantSet: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var query url.Values
+			server := setupTestServer(t, http.StatusOK, `{"id":"content-123","status":"PROCESSING"}`, func(r *http.Request) {
+				query = r.URL.Query()
+			})
+			defer server.Close()
+
+			client, _ := NewClient(server.URL)
+			item, err := client.GetContentItem(context.Background(), "content-123", tt.fields...)
+			if err != nil {
+				t.Fatalf("GetContentItem returned unexpected error decoding a partial response: %v", err)
+			}
+
+			_, set := query["fields"]
+			if set != tt.wantSet || query.Get("fields") != tt.wantFields {
+				t.Errorf("fields query = %q (set %v), want %q (set %v)", query.Get("fields"), set, tt.wantFields, tt.wantSet)
+			}
+			if item.ID != "content-123" || item.Status != "PROCESSING" {
+				t.Errorf("GetContentItem = %+v, want id and status populated", item)
+			}
+			if item.TenantID != "" || item.Metadata != nil {
+				t.Errorf("GetContentItem populated fields absent from the response: %+v", item)
+			}
+		})
+	}
+}
+
 func TestClient_GetContentItem_NotFound(t *testing.T) {
 	errorResponse := `{"error":"not_found","error_description":"Content item not found"}`
 
@@ -2388,3 +2598,336 @@ func TestClient_UpdateTextContent_BadRequest(t *testing.T) {
 		t.Errorf("Expected error code bad_request, got %s", apiErr.ErrorCode)
 	}
 }
+
+func TestClient_Health(t *testing.T) {
+	server := setupTestServer(t, http.StatusOK, `{"status": "ok"}`, func(r *http.Request) {
+		if r.Method != "GET" || r.URL.Path != "/health" {
+			t.Errorf("Health request = %s %s, want GET /health", r.Method, r.URL.Path)
+		}
+	})
+	defer server.Close()
+
+	client, _ := NewClient(server.URL)
+	health, err := client.Health(context.Background())
+	if err != nil {
+		t.Fatalf("Health returned unexpected error: %v", err)
+	}
+	if health.Status != "ok" {
+		t.Errorf("Health status = %q, want ok", health.Status)
+	}
+}
+
+func TestClient_Health_ServerError(t *testing.T) {
+	server := setupTestServer(t, http.StatusInternalServerError, `{}`, nil)
+	defer server.Close()
+
+	client, _ := NewClient(server.URL)
+	health, err := client.Health(context.Background())
+	if health != nil || !isAPIError(err, "server_error") {
+		t.Errorf("Health = %v, %v, want server_error", health, err)
+	}
+}
+
+func TestClient_RequestID(t *testing.T) {
+	var received string
+	server := setupTestServer(t, http.StatusNotFound, `{"error":"not_found"}`, func(r *http.Request) {
+		received = r.Header.Get("X-Request-ID")
+	})
+	defer server.Close()
+
+	client, err := NewClient(server.URL)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	ctx := WithRequestID(context.Background(), "support-ticket-42")
+	_, err = client.GetContentItem(ctx, "missing")
+
+	var apiErr *apierror.ErrorResponse
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Expected *apierror.ErrorResponse, got %T", err)
+	}
+	if received != "support-ticket-42" {
+		t.Errorf("X-Request-ID header = %q, want %q", received, "support-ticket-42")
+	}
+	if apiErr.RequestID != "support-ticket-42" {
+		t.Errorf("error RequestID = %q, want %q", apiErr.RequestID, "support-ticket-42")
+	}
+}
+
+func TestClient_WithRateLimit(t *testing.T) {
+	server := setupTestServer(t, http.StatusOK, `{"id":"content-1"}`, nil)
+	defer server.Close()
+
+	client, err := NewClientWithOptions(server.URL, WithRateLimit(50, 1))
+	if err != nil {
+		t.Fatalf("NewClientWithOptions returned unexpected error: %v", err)
+	}
+
+	// The first call uses the burst; the other four wait 1/50s each
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		if _, err := client.GetContentItem(context.Background(), "content-1"); err != nil {
+			t.Fatalf("GetContentItem returned unexpected error: %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 80*time.Millisecond {
+		t.Errorf("5 calls took %v, want at least 80ms", elapsed)
+	}
+
+	slow, _ := NewClientWithOptions(server.URL, WithRateLimit(0.1, 1))
+	if _, err := slow.GetContentItem(context.Background(), "content-1"); err != nil {
+		t.Fatalf("GetContentItem returned unexpected error: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(20*time.Millisecond, cancel)
+	_, err = slow.GetContentItem(ctx, "content-1")
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("GetContentItem error = %v, want context.Canceled", err)
+	}
+}
+
+func TestClient_RateLimitedRetryAfter(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "7")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(server.URL)
+	_, err := client.GetContentItem(context.Background(), "content-1")
+
+	var apiErr *ErrorResponse
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("GetContentItem error = %v, want *ErrorResponse", err)
+	}
+	if apiErr.ErrorCode != "rate_limited" {
+		t.Errorf("ErrorCode = %q, want %q", apiErr.ErrorCode, "rate_limited")
+	}
+	if apiErr.RetryAfter != 7*time.Second {
+		t.Errorf("RetryAfter = %v, want %v", apiErr.RetryAfter, 7*time.Second)
+	}
+}
+
+func TestClient_GetContentItemByS3Key(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		wantID   string
+		wantCode string
+	}{
+		{"single match", `{"items":[{"id":"content-1","s3Key":"uploads/a.txt"}]}`, "content-1", ""},
+		{"no match", `{"items":[]}`, "", "not_found"},
+		{"ambiguous", `{"items":[{"id":"content-1"},{"id":"content-2"}]}`, "", "ambiguous_s3_key"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := setupTestServer(t, http.StatusOK, tt.body, func(r *http.Request) {
+				if r.URL.Path != "/content" {
+					t.Errorf("path = %q, want %q", r.URL.Path, "/content")
+				}
+				if got := r.URL.Query().Get("s3Key"); got != "uploads/a.txt" {
+					t.Errorf("s3Key = %q, want %q", got, "uploads/a.txt")
+				}
+			})
+			defer server.Close()
+
+			client, _ := NewClient(server.URL)
+			item, err := client.GetContentItemByS3Key(context.Background(), "uploads/a.txt")
+
+			if tt.wantCode == "" {
+				if err != nil {
+					t.Fatalf("GetContentItemByS3Key returned unexpected error: %v", err)
+				}
+				if item.ID != tt.wantID {
+					t.Errorf("item.ID = %q, want %q", item.ID, tt.wantID)
+				}
+				return
+			}
+
+			var apiErr *ErrorResponse
+			if !errors.As(err, &apiErr) || apiErr.ErrorCode != tt.wantCode {
+				t.Fatalf("GetContentItemByS3Key error = %v, want code %q", err, tt.wantCode)
+			}
+			if got := errors.Is(err, ErrAmbiguousS3Key); got != (tt.wantCode == "ambiguous_s3_key") {
+				t.Errorf("errors.Is(err, ErrAmbiguousS3Key) = %v", got)
+			}
+			if got := errors.Is(err, ErrNotFound); got != (tt.wantCode == "not_found") {
+				t.Errorf("errors.Is(err, ErrNotFound) = %v", got)
+			}
+		})
+	}
+}
+
+func TestClient_SetContentMetadata(t *testing.T) {
+	tests := []struct {
+		name    string
+		upserts map[string]string
+		deletes []string
+		want    string
+	}{
+		{"upserts only", map[string]string{"owner": "ops", "tier": "gold"}, nil, `{"metadata":{"owner":"ops","tier":"gold"}}`},
+		{"deletes only", nil, []string{"legacy"}, `{"metadata":{"legacy":null}}`},
+		{"mixed", map[string]string{"owner": "ops"}, []string{"legacy", "tmp"}, `{"metadata":{"legacy":null,"owner":"ops","tmp":null}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body []byte
+			server := setupTestServer(t, http.StatusOK, `{"id":"content-1"}`, func(r *http.Request) {
+				if r.Method != "PATCH" || r.URL.Path != "/content/content-1" {
+					t.Errorf("request = %s %s, want PATCH /content/content-1", r.Method, r.URL.Path)
+				}
+				if got := r.Header.Get("Content-Type"); got != MergePatchContentType {
+					t.Errorf("Content-Type = %q, want %q", got, MergePatchContentType)
+				}
+				body, _ = io.ReadAll(r.Body)
+			})
+			defer server.Close()
+
+			client, _ := NewClient(server.URL)
+			item, err := client.SetContentMetadata(context.Background(), "content-1", tt.upserts, tt.deletes)
+			if err != nil {
+				t.Fatalf("SetContentMetadata returned unexpected error: %v", err)
+			}
+			if item.ID != "content-1" {
+				t.Errorf("item.ID = %q, want %q", item.ID, "content-1")
+			}
+			if got := strings.TrimSpace(string(body)); got != tt.want {
+				t.Errorf("patch body = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_StreamTextContent(t *testing.T) {
+	text := strings.Repeat("large document line\n", 1000)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept") != "text/plain" {
+			t.Errorf("Accept = %q, want %q", r.Header.Get("Accept"), "text/plain")
+		}
+		switch r.URL.Path {
+		case "/content/text-1/text":
+			w.Header().Set("Content-Type", "text/plain")
+			_, _ = io.WriteString(w, text)
+		case "/content/file-1/text":
+			w.WriteHeader(http.StatusBadRequest)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(server.URL)
+
+	body, err := client.StreamTextContent(context.Background(), "text-1")
+	if err != nil {
+		t.Fatalf("StreamTextContent returned unexpected error: %v", err)
+	}
+	got, err := io.ReadAll(body)
+	_ = body.Close()
+	if err != nil {
+		t.Fatalf("reading stream: %v", err)
+	}
+	if string(got) != text {
+		t.Errorf("stream returned %d bytes, want %d", len(got), len(text))
+	}
+
+	if _, err := client.StreamTextContent(context.Background(), "missing"); !isAPIError(err, "not_found") {
+		t.Errorf("StreamTextContent(missing) error = %v, want not_found", err)
+	}
+	if _, err := client.StreamTextContent(context.Background(), "file-1"); !isAPIError(err, "bad_request") {
+		t.Errorf("StreamTextContent(file-1) error = %v, want bad_request", err)
+	}
+}
+
+func TestClient_UpdateTextContentStream(t *testing.T) {
+	text := strings.Repeat("# Heading\n\nParagraph.\n", 500)
+
+	var gotType string
+	var gotBody []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" {
+			t.Errorf("method = %s, want PUT", r.Method)
+		}
+		if r.URL.Path != "/content/text-1/text" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		gotType = r.Header.Get("Content-Type")
+		gotBody, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(server.URL)
+
+	// io.MultiReader hides the length, so the body is streamed
+	r := io.MultiReader(strings.NewReader(text[:100]), strings.NewReader(text[100:]))
+	if err := client.UpdateTextContentStream(context.Background(), "text-1", "text/markdown", r); err != nil {
+		t.Fatalf("UpdateTextContentStream returned unexpected error: %v", err)
+	}
+	if gotType != "text/markdown" {
+		t.Errorf("Content-Type = %q, want %q", gotType, "text/markdown")
+	}
+	if string(gotBody) != text {
+		t.Errorf("body = %d bytes, want %d", len(gotBody), len(text))
+	}
+
+	err := client.UpdateTextContentStream(context.Background(), "missing", "", strings.NewReader("x"))
+	if !isAPIError(err, "not_found") {
+		t.Errorf("UpdateTextContentStream(missing) error = %v, want not_found", err)
+	}
+}
+
+// metricsRecorder records the metrics it receives
+type metricsRecorder struct {
+	mu        sync.Mutex
+	requests  []string
+	latencies int
+}
+
+func (r *metricsRecorder) IncRequest(service, operation, code string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.requests = append(r.requests, service+" "+operation+" "+code)
+}
+
+func (r *metricsRecorder) ObserveLatency(service, operation string, d time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.latencies++
+}
+
+func TestClient_WithMetricsRecorder(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req RequestFileUploadRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		if req.Filename == "bad.txt" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"content-1","uploadUrl":"https://s3.example.com/upload"}`))
+	}))
+	defer server.Close()
+
+	recorder := &metricsRecorder{}
+	client, _ := NewClientWithOptions(server.URL, WithMetricsRecorder(recorder))
+
+	for _, name := range []string{"ok.txt", "bad.txt"} {
+		_, _ = client.RequestFileUpload(context.Background(), &RequestFileUploadRequest{Filename: name, ContentType: "text/plain"})
+	}
+
+	want := []string{
+		"ingest ingest.RequestFileUpload 200",
+		"ingest ingest.RequestFileUpload 400",
+	}
+	if strings.Join(recorder.requests, "\n") != strings.Join(want, "\n") {
+		t.Errorf("requests = %q, want %q", recorder.requests, want)
+	}
+	if recorder.latencies != 2 {
+		t.Errorf("latency observations = %d, want 2", recorder.latencies)
+	}
+}
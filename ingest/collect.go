@@ -0,0 +1,118 @@
+package ingest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/atriumn/atriumn-sdk-go/internal/apierror"
+	"github.com/atriumn/atriumn-sdk-go/internal/clientutil"
+)
+
+const (
+	// DefaultCollectMaxItems caps the items CollectAllContentItems gathers when
+	// WithMaxItems is not given
+	DefaultCollectMaxItems = 100000
+
+	// collectRateLimitRetries is how many times CollectAllContentItems retries
+	// a page rejected with 429 Too Many Requests
+	collectRateLimitRetries = 5
+)
+
+// CollectOption configures a single CollectAllContentItems call.
+type CollectOption func(*collectOptions)
+
+// collectOptions holds the settings applied by CollectOption functions.
+type collectOptions struct {
+	maxItems int
+}
+
+// WithMaxItems caps the number of items CollectAllContentItems gathers.
+//
+// Parameters:
+//   - n: The most items to collect; zero or less uses DefaultCollectMaxItems
+//
+// Returns:
+//   - CollectOption: An option for CollectAllContentItems
+func WithMaxItems(n int) CollectOption {
+	return func(o *collectOptions) {
+		o.maxItems = n
+	}
+}
+
+// CollectAllContentItems lists every content item matching opts, following
+// the pagination tokens until the last page, and returns them together. Each
+// page request waits for the client's rate limiter if one was configured with
+// WithRateLimit. A page rejected with 429 Too Many Requests is retried up to
+// five times, after the delay the service asks for or an exponential backoff.
+//
+// To bound memory use, at most DefaultCollectMaxItems items are collected, or
+// the cap given with WithMaxItems. When more items match, the items up to the
+// cap are returned with an error matching ErrCollectLimitReached.
+//
+// Parameters:
+//   - ctx: Context for the API requests
+//   - opts: Optional filters, page size, and starting token (nil lists all content items)
+//   - collectOpts: Optional CollectOption values such as WithMaxItems
+//
+// Returns:
+//   - []ContentItem: The content items, in the order the pages returned them
+//   - error: An error if the scan stops early, returned along with the items
+//     collected so far, which can be:
+//   - an error matching ErrCollectLimitReached if more items match than the cap allows
+//   - any error returned by ListContentItemsWithOptions
+func (c *Client) CollectAllContentItems(ctx context.Context, opts *ListContentItemsOptions, collectOpts ...CollectOption) ([]ContentItem, error) {
+	var page ListContentItemsOptions
+	if opts != nil {
+		page = *opts
+	}
+	var options collectOptions
+	for _, opt := range collectOpts {
+		opt(&options)
+	}
+	maxItems := options.maxItems
+	if maxItems <= 0 {
+		maxItems = DefaultCollectMaxItems
+	}
+
+	var items []ContentItem
+	for {
+		resp, err := c.listContentPage(ctx, &page)
+		if err != nil {
+			return items, err
+		}
+		items = append(items, resp.Items...)
+
+		if len(items) > maxItems || (len(items) == maxItems && resp.NextToken != "") {
+			return items[:maxItems], fmt.Errorf("%w: stopped after %d items", ErrCollectLimitReached, maxItems)
+		}
+		if resp.NextToken == "" {
+			return items, nil
+		}
+		page.NextToken = resp.NextToken
+	}
+}
+
+// listContentPage fetches one page of content items for CollectAllContentItems,
+// retrying it while the service rejects it with 429 Too Many Requests
+func (c *Client) listContentPage(ctx context.Context, page *ListContentItemsOptions) (*ListContentResponse, error) {
+	var backoff clientutil.Backoff
+	for attempt := 0; ; attempt++ {
+		resp, err := c.ListContentItemsWithOptions(ctx, page)
+
+		var apiErr *apierror.ErrorResponse
+		if err == nil || attempt >= collectRateLimitRetries ||
+			!errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusTooManyRequests {
+			return resp, err
+		}
+
+		delay := apiErr.RetryAfter
+		if delay <= 0 {
+			delay = backoff.Duration(attempt)
+		}
+		if err := clientutil.ClockOrSystem(c.base.Clock).Sleep(ctx, delay); err != nil {
+			return nil, fmt.Errorf("waiting to list content items: %w", err)
+		}
+	}
+}
@@ -0,0 +1,162 @@
+package ingest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/atriumn/atriumn-sdk-go/internal/clientutil"
+)
+
+// pagedContentServer serves pages of content items keyed by nextToken; the
+// first page has no token. It counts the list requests in calls.
+func pagedContentServer(t *testing.T, calls *int32, pages map[string]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(calls, 1)
+		if r.URL.Path != "/content" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		page, ok := pages[r.URL.Query().Get("nextToken")]
+		if !ok {
+			t.Errorf("unexpected nextToken %q", r.URL.Query().Get("nextToken"))
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(page))
+	}))
+}
+
+func TestClient_CollectAllContentItems(t *testing.T) {
+	var calls int32
+	server := pagedContentServer(t, &calls, map[string]string{
+		"":       `{"items":[{"id":"c1"},{"id":"c2"}],"nextToken":"page-2"}`,
+		"page-2": `{"items":[{"id":"c3"},{"id":"c4"}],"nextToken":"page-3"}`,
+		"page-3": `{"items":[{"id":"c5"}]}`,
+	})
+	defer server.Close()
+
+	client, _ := NewClient(server.URL)
+	opts := &ListContentItemsOptions{Status: ContentStatusCompleted, Limit: 2}
+	items, err := client.CollectAllContentItems(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("CollectAllContentItems returned unexpected error: %v", err)
+	}
+
+	var ids []string
+	for _, item := range items {
+		ids = append(ids, item.ID)
+	}
+	if fmt.Sprint(ids) != "[c1 c2 c3 c4 c5]" {
+		t.Errorf("CollectAllContentItems IDs = %v, want [c1 c2 c3 c4 c5]", ids)
+	}
+	if got := atomic.LoadInt32(&calls); got != 3 {
+		t.Errorf("server received %d requests, want 3", got)
+	}
+	if opts.NextToken != "" {
+		t.Errorf("CollectAllContentItems modified the caller's options: NextToken = %q", opts.NextToken)
+	}
+}
+
+func TestClient_CollectAllContentItems_RateLimited(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&calls, 1)
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case n == 2:
+			w.Header().Set("Retry-After", "3")
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte(`{"error":"rate_limited"}`))
+		case r.URL.Query().Get("nextToken") == "":
+			_, _ = w.Write([]byte(`{"items":[{"id":"c1"}],"nextToken":"page-2"}`))
+		default:
+			_, _ = w.Write([]byte(`{"items":[{"id":"c2"}]}`))
+		}
+	}))
+	defer server.Close()
+
+	clock := clientutil.NewFakeClock(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	client, _ := NewClientWithOptions(server.URL, WithClock(clock))
+	items, err := client.CollectAllContentItems(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("CollectAllContentItems returned unexpected error: %v", err)
+	}
+	if len(items) != 2 || items[1].ID != "c2" {
+		t.Errorf("CollectAllContentItems = %+v, want c1 and c2", items)
+	}
+	if got := atomic.LoadInt32(&calls); got != 3 {
+		t.Errorf("server received %d requests, want 3", got)
+	}
+	if sleeps := clock.Sleeps(); len(sleeps) != 1 || sleeps[0] != 3*time.Second {
+		t.Errorf("clock sleeps = %v, want the 3s the service asked for", sleeps)
+	}
+}
+
+func TestClient_CollectAllContentItems_RateLimitedTooOften(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	clock := clientutil.NewFakeClock(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	client, _ := NewClientWithOptions(server.URL, WithClock(clock))
+	_, err := client.CollectAllContentItems(context.Background(), nil)
+
+	var apiErr *ErrorResponse
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("CollectAllContentItems error = %v, want the 429 error", err)
+	}
+	if got := atomic.LoadInt32(&calls); got != collectRateLimitRetries+1 {
+		t.Errorf("server received %d requests, want %d", got, collectRateLimitRetries+1)
+	}
+}
+
+func TestClient_CollectAllContentItems_MaxItems(t *testing.T) {
+	pages := map[string]string{
+		"":       `{"items":[{"id":"c1"},{"id":"c2"}],"nextToken":"page-2"}`,
+		"page-2": `{"items":[{"id":"c3"},{"id":"c4"}],"nextToken":"page-3"}`,
+		"page-3": `{"items":[{"id":"c5"}]}`,
+	}
+
+	tests := []struct {
+		name      string
+		maxItems  int
+		wantItems int
+		wantCalls int32
+		wantErr   bool
+	}{
+		{"cap inside a page", 3, 3, 2, true},
+		{"cap at a page boundary with more pages", 4, 4, 2, true},
+		{"cap equal to the total", 5, 5, 3, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int32
+			server := pagedContentServer(t, &calls, pages)
+			defer server.Close()
+
+			client, _ := NewClient(server.URL)
+			items, err := client.CollectAllContentItems(context.Background(), nil, WithMaxItems(tt.maxItems))
+			if tt.wantErr != errors.Is(err, ErrCollectLimitReached) {
+				t.Fatalf("CollectAllContentItems error = %v, want ErrCollectLimitReached: %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatalf("CollectAllContentItems returned unexpected error: %v", err)
+			}
+			if len(items) != tt.wantItems {
+				t.Errorf("CollectAllContentItems returned %d items, want %d", len(items), tt.wantItems)
+			}
+			if got := atomic.LoadInt32(&calls); got != tt.wantCalls {
+				t.Errorf("server received %d requests, want %d", got, tt.wantCalls)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package ingest
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// newDecodingServer returns a server that records the Content-Encoding header
+// and decodes the (possibly gzipped) request body into got
+func newDecodingServer(t *testing.T, encoding *string, got *IngestTextRequest) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*encoding = r.Header.Get("Content-Encoding")
+
+		var body io.Reader = r.Body
+		if *encoding == "gzip" {
+			zr, err := gzip.NewReader(r.Body)
+			if err != nil {
+				t.Errorf("request body is not valid gzip: %v", err)
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			defer zr.Close()
+			body = zr
+		}
+		if err := json.NewDecoder(body).Decode(got); err != nil {
+			t.Errorf("failed to decode request body: %v", err)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte(`{"id":"content-1","status":"PENDING"}`))
+	}))
+}
+
+func TestClient_RequestCompression(t *testing.T) {
+	var encoding string
+	var got IngestTextRequest
+	server := newDecodingServer(t, &encoding, &got)
+	defer server.Close()
+
+	client, err := NewClientWithOptions(server.URL, WithRequestCompression())
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	req := &IngestTextRequest{TenantID: "tenant-1", Content: strings.Repeat("large text ", 500)}
+	if _, err := client.IngestText(context.Background(), req); err != nil {
+		t.Fatalf("IngestText returned unexpected error: %v", err)
+	}
+
+	if encoding != "gzip" {
+		t.Errorf("Content-Encoding = %q, want %q", encoding, "gzip")
+	}
+	if got.Content != req.Content || got.TenantID != req.TenantID {
+		t.Errorf("decoded request did not round-trip: got %+v", got)
+	}
+}
+
+func TestClient_RequestCompression_SmallBody(t *testing.T) {
+	var encoding string
+	var got IngestTextRequest
+	server := newDecodingServer(t, &encoding, &got)
+	defer server.Close()
+
+	client, err := NewClientWithOptions(server.URL, WithRequestCompression())
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	req := &IngestTextRequest{TenantID: "tenant-1", Content: "short"}
+	if _, err := client.IngestText(context.Background(), req); err != nil {
+		t.Fatalf("IngestText returned unexpected error: %v", err)
+	}
+
+	if encoding != "" {
+		t.Errorf("Content-Encoding = %q, want none for a small body", encoding)
+	}
+	if got.Content != "short" {
+		t.Errorf("Content = %q, want %q", got.Content, "short")
+	}
+}
+
+func TestClient_RequestCompression_Disabled(t *testing.T) {
+	var encoding string
+	var got IngestTextRequest
+	server := newDecodingServer(t, &encoding, &got)
+	defer server.Close()
+
+	client, err := NewClient(server.URL)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	req := &IngestTextRequest{TenantID: "tenant-1", Content: strings.Repeat("large text ", 500)}
+	if _, err := client.IngestText(context.Background(), req); err != nil {
+		t.Fatalf("IngestText returned unexpected error: %v", err)
+	}
+
+	if encoding != "" {
+		t.Errorf("Content-Encoding = %q, want none when compression is disabled", encoding)
+	}
+}
+
+func TestClient_NewRequest_UsesBaseClient(t *testing.T) {
+	client, err := NewClientWithOptions("https://api.example.com",
+		WithTokenProvider(&MockTokenProvider{token: "abc"}),
+		WithRequestCompression(),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	req, err := client.newRequest(context.Background(), "POST", "/ingest/text",
+		&IngestTextRequest{Content: strings.Repeat("large text ", 500)})
+	if err != nil {
+		t.Fatalf("newRequest returned unexpected error: %v", err)
+	}
+	if got := req.Header.Get("Authorization"); got != "Bearer abc" {
+		t.Errorf("Authorization = %q, want %q", got, "Bearer abc")
+	}
+	if got := req.Header.Get("Content-Encoding"); got != "gzip" {
+		t.Errorf("Content-Encoding = %q, want %q", got, "gzip")
+	}
+
+	// The compressed body can be re-read, as retries and redirects require
+	body, err := req.GetBody()
+	if err != nil {
+		t.Fatalf("GetBody returned unexpected error: %v", err)
+	}
+	zr, err := gzip.NewReader(body)
+	if err != nil {
+		t.Fatalf("GetBody did not return gzip data: %v", err)
+	}
+	var got IngestTextRequest
+	if err := json.NewDecoder(zr).Decode(&got); err != nil {
+		t.Fatalf("failed to decode request body: %v", err)
+	}
+	if !strings.HasPrefix(got.Content, "large text") {
+		t.Errorf("Content = %q, want the original text", got.Content)
+	}
+}
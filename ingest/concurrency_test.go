@@ -0,0 +1,131 @@
+package ingest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// blockingServer holds every request until release receives a value, sending
+// the ID of each request to arrived as it comes in
+func blockingServer(t *testing.T) (server *httptest.Server, arrived <-chan string, release chan<- struct{}) {
+	arrivedc := make(chan string, 10)
+	releasec := make(chan struct{}, 10)
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := strings.TrimPrefix(r.URL.Path, "/content/")
+		arrivedc <- id
+		<-releasec
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"id":%q,"status":"COMPLETED"}`, id)
+	}))
+	return server, arrivedc, releasec
+}
+
+// receive returns the next value from ch, failing the test if none arrives in time
+func receive(t *testing.T, ch <-chan string) string {
+	t.Helper()
+	select {
+	case v := <-ch:
+		return v
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for a request")
+		return ""
+	}
+}
+
+func TestClient_WithMaxConcurrentRequests_Blocks(t *testing.T) {
+	server, arrived, release := blockingServer(t)
+	defer server.Close()
+
+	client, _ := NewClientWithOptions(server.URL, WithMaxConcurrentRequests(2))
+
+	var wg sync.WaitGroup
+	get := func(id string) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.GetContentItem(context.Background(), id); err != nil {
+				t.Errorf("GetContentItem(%s) error = %v", id, err)
+			}
+		}()
+	}
+
+	get("content-1")
+	get("content-2")
+	receive(t, arrived)
+	receive(t, arrived)
+
+	get("content-3")
+	select {
+	case id := <-arrived:
+		t.Fatalf("request for %s reached the server while 2 calls were in flight", id)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	release <- struct{}{}
+	if id := receive(t, arrived); id != "content-3" {
+		t.Errorf("request after a slot freed = %s, want content-3", id)
+	}
+	release <- struct{}{}
+	release <- struct{}{}
+	wg.Wait()
+
+	if n := client.base.ConcurrencyLimiter.InFlight(); n != 0 {
+		t.Errorf("InFlight() = %d after all calls returned, want 0", n)
+	}
+}
+
+func TestClient_WithMaxConcurrentRequests_ContextCanceled(t *testing.T) {
+	server, arrived, release := blockingServer(t)
+	defer server.Close()
+
+	client, _ := NewClientWithOptions(server.URL, WithMaxConcurrentRequests(1))
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, _ = client.GetContentItem(context.Background(), "content-1")
+	}()
+	receive(t, arrived)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := client.GetContentItem(ctx, "content-2")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("GetContentItem error = %v, want it to wrap context.DeadlineExceeded", err)
+	}
+
+	release <- struct{}{}
+	<-done
+}
+
+func TestClient_WithMaxConcurrentRequests_FailFast(t *testing.T) {
+	server, arrived, release := blockingServer(t)
+	defer server.Close()
+
+	client, _ := NewClientWithOptions(server.URL, WithMaxConcurrentRequests(1, FailFast()))
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, _ = client.GetContentItem(context.Background(), "content-1")
+	}()
+	receive(t, arrived)
+
+	if _, err := client.GetContentItem(context.Background(), "content-2"); !errors.Is(err, ErrTooManyConcurrentRequests) {
+		t.Errorf("GetContentItem error = %v, want ErrTooManyConcurrentRequests", err)
+	}
+
+	release <- struct{}{}
+	<-done
+	release <- struct{}{}
+	if _, err := client.GetContentItem(context.Background(), "content-3"); err != nil {
+		t.Errorf("GetContentItem after the slot freed error = %v", err)
+	}
+}
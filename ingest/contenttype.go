@@ -0,0 +1,127 @@
+package ingest
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strings"
+)
+
+// sniffLen is the number of leading bytes http.DetectContentType considers
+const sniffLen = 512
+
+// genericContentType is what http.DetectContentType reports for content it
+// cannot identify
+const genericContentType = "application/octet-stream"
+
+// extensionContentTypes maps common file extensions to MIME types. It takes
+// precedence over the mime package, whose table depends on the system.
+var extensionContentTypes = map[string]string{
+	".csv":  "text/csv",
+	".gif":  "image/gif",
+	".htm":  "text/html",
+	".html": "text/html",
+	".jpeg": "image/jpeg",
+	".jpg":  "image/jpeg",
+	".json": "application/json",
+	".md":   "text/markdown",
+	".mp3":  "audio/mpeg",
+	".mp4":  "video/mp4",
+	".pdf":  "application/pdf",
+	".png":  "image/png",
+	".txt":  "text/plain",
+	".webp": "image/webp",
+	".xml":  "application/xml",
+	".zip":  "application/zip",
+}
+
+// DetectContentType determines the MIME type of a file from its first 512
+// bytes, read from peek, using http.DetectContentType. When the content alone
+// only identifies it generically, as text/plain or application/octet-stream,
+// the more specific type mapped from filename's extension is used if there is
+// one, so a JSON file is reported as application/json. If peek is an
+// io.Seeker it is rewound to where it was; otherwise the bytes read from it
+// are consumed.
+//
+// Parameters:
+//   - filename: The file's name, used for its extension
+//   - peek: The file's content (nil detects from the extension only)
+//
+// Returns:
+//   - string: The MIME type, or "application/octet-stream" if it cannot be determined
+//   - error: An error if peek cannot be read or rewound
+func DetectContentType(filename string, peek io.Reader) (string, error) {
+	var sniffed string
+	if peek != nil {
+		seeker, seekable := peek.(io.Seeker)
+		var offset int64
+		if seekable {
+			var err error
+			if offset, err = seeker.Seek(0, io.SeekCurrent); err != nil {
+				return "", fmt.Errorf("failed to read content for type detection: %w", err)
+			}
+		}
+
+		buf := make([]byte, sniffLen)
+		n, err := io.ReadFull(peek, buf)
+		if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+			return "", fmt.Errorf("failed to read content for type detection: %w", err)
+		}
+		if seekable {
+			if _, err := seeker.Seek(offset, io.SeekStart); err != nil {
+				return "", fmt.Errorf("failed to rewind content after type detection: %w", err)
+			}
+		}
+		if n > 0 {
+			sniffed = http.DetectContentType(buf[:n])
+		}
+	}
+
+	if sniffed != "" && sniffed != genericContentType && !strings.HasPrefix(sniffed, "text/plain") {
+		return sniffed, nil
+	}
+	if byExtension := extensionContentType(filename); byExtension != "" {
+		return byExtension, nil
+	}
+	if sniffed != "" {
+		return sniffed, nil
+	}
+	return genericContentType, nil
+}
+
+// extensionContentType returns the MIME type mapped from filename's extension,
+// or "" if the extension is unknown
+func extensionContentType(filename string) string {
+	ext := strings.ToLower(filepath.Ext(filename))
+	if ext == "" {
+		return ""
+	}
+	if contentType, ok := extensionContentTypes[ext]; ok {
+		return contentType
+	}
+	return mime.TypeByExtension(ext)
+}
+
+// detectUploadContentType runs DetectContentType on content for UploadFile.
+// A content reader that cannot be rewound is returned wrapped so the bytes
+// read for detection are still uploaded.
+func detectUploadContentType(filename string, content io.Reader) (string, io.Reader, error) {
+	if _, ok := content.(io.Seeker); ok {
+		contentType, err := DetectContentType(filename, content)
+		return contentType, content, err
+	}
+
+	buf := make([]byte, sniffLen)
+	n, err := io.ReadFull(content, buf)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return "", nil, fmt.Errorf("failed to read content for type detection: %w", err)
+	}
+	contentType, err := DetectContentType(filename, bytes.NewReader(buf[:n]))
+	if err != nil {
+		return "", nil, err
+	}
+	return contentType, io.MultiReader(bytes.NewReader(buf[:n]), content), nil
+}
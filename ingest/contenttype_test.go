@@ -0,0 +1,115 @@
+package ingest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR\x00\x00\x00\x01\x00\x00\x00\x01\x08\x02\x00\x00\x00")
+
+func TestDetectContentType(t *testing.T) {
+	tests := []struct {
+		name     string
+		filename string
+		content  io.Reader
+		want     string
+	}{
+		{"text file", "notes", strings.NewReader("hello world"), "text/plain; charset=utf-8"},
+		{"text file with extension", "notes.txt", strings.NewReader("hello world"), "text/plain"},
+		{"PNG by magic bytes", "upload.bin", bytes.NewReader(pngHeader), "image/png"},
+		{"PNG with misleading extension", "image.txt", bytes.NewReader(pngHeader), "image/png"},
+		{"unknown binary", "blob", bytes.NewReader([]byte{0x00, 0x01, 0x02, 0xfe, 0xff}), "application/octet-stream"},
+		{"JSON refined by extension", "data.json", strings.NewReader(`{"a":1}`), "application/json"},
+		{"extension only", "report.PDF", nil, "application/pdf"},
+		{"extension only, empty content", "table.csv", strings.NewReader(""), "text/csv"},
+		{"nothing to go on", "blob", nil, "application/octet-stream"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DetectContentType(tt.filename, tt.content)
+			if err != nil {
+				t.Fatalf("DetectContentType returned unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("DetectContentType = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDetectContentType_RewindsSeeker(t *testing.T) {
+	content := strings.NewReader("skip:" + strings.Repeat("x", 1000))
+	if _, err := content.Seek(5, io.SeekStart); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := DetectContentType("data", content); err != nil {
+		t.Fatalf("DetectContentType returned unexpected error: %v", err)
+	}
+	if rest, _ := io.ReadAll(content); string(rest) != strings.Repeat("x", 1000) {
+		t.Errorf("reader not rewound to its original offset; %d bytes remain", len(rest))
+	}
+}
+
+type failingReader struct{}
+
+func (failingReader) Read([]byte) (int, error) { return 0, errors.New("disk error") }
+
+func TestDetectContentType_ReadError(t *testing.T) {
+	if _, err := DetectContentType("data.txt", failingReader{}); err == nil {
+		t.Fatal("DetectContentType should return the read error")
+	}
+}
+
+func TestClient_UploadFile_DetectsContentType(t *testing.T) {
+	tests := []struct {
+		name    string
+		content io.Reader
+	}{
+		{"seekable", bytes.NewReader(pngHeader)},
+		{"not seekable", bytes.NewBuffer(append([]byte(nil), pngHeader...))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requested RequestFileUploadRequest
+			var uploadedType string
+			var uploaded []byte
+			var server *httptest.Server
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/ingest/file":
+					_ = json.NewDecoder(r.Body).Decode(&requested)
+					w.Header().Set("Content-Type", "application/json")
+					_ = json.NewEncoder(w).Encode(map[string]string{"id": "content-123", "uploadUrl": server.URL + "/upload"})
+				case "/upload":
+					uploadedType = r.Header.Get("Content-Type")
+					uploaded, _ = io.ReadAll(r.Body)
+				}
+			}))
+			defer server.Close()
+
+			client, _ := NewClient(server.URL)
+			request := &RequestFileUploadRequest{Filename: "pixel"}
+			resp, err := client.UploadFile(context.Background(), request, tt.content, nil)
+			if err != nil {
+				t.Fatalf("UploadFile returned unexpected error: %v", err)
+			}
+			if requested.ContentType != "image/png" || uploadedType != "image/png" {
+				t.Errorf("content type requested %q and uploaded %q, want image/png", requested.ContentType, uploadedType)
+			}
+			if !bytes.Equal(uploaded, pngHeader) || resp.BytesUploaded != int64(len(pngHeader)) {
+				t.Errorf("uploaded %d bytes, want the %d bytes of the content", len(uploaded), len(pngHeader))
+			}
+			if request.ContentType != "" {
+				t.Error("UploadFile should not modify the caller's request")
+			}
+		})
+	}
+}
@@ -0,0 +1,203 @@
+package ingest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// identityServer records the decoded body of the last request, such as its
+// tenantId, userId, and metadata
+func identityServer(t *testing.T, got *map[string]interface{}) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*got = nil
+		if err := json.NewDecoder(r.Body).Decode(got); err != nil {
+			t.Errorf("failed to decode request body: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = fmt.Fprint(w, `{"id":"content-1","contentId":"content-1","status":"PENDING"}`)
+	}))
+}
+
+func TestClient_DefaultTenantAndUserID(t *testing.T) {
+	type call func(c *Client, tenantID, userID string) error
+	calls := map[string]call{
+		"IngestText": func(c *Client, tenantID, userID string) error {
+			_, err := c.IngestText(context.Background(), &IngestTextRequest{TenantID: tenantID, UserID: userID, Content: "x"})
+			return err
+		},
+		"IngestURL": func(c *Client, tenantID, userID string) error {
+			_, err := c.IngestURL(context.Background(), &IngestURLRequest{TenantID: tenantID, UserID: userID, URL: "https://example.com"})
+			return err
+		},
+		"RequestFileUpload": func(c *Client, tenantID, userID string) error {
+			_, err := c.RequestFileUpload(context.Background(), &RequestFileUploadRequest{TenantID: tenantID, UserID: userID, Filename: "a.txt"})
+			return err
+		},
+		"RequestTextUpload": func(c *Client, tenantID, userID string) error {
+			_, err := c.RequestTextUpload(context.Background(), &RequestTextUploadRequest{TenantID: tenantID, UserID: userID, ContentType: "text/plain"})
+			return err
+		},
+	}
+
+	tests := []struct {
+		name                     string
+		tenantID, userID         string
+		wantTenantID, wantUserID string
+	}{
+		{"defaults fill empty fields", "", "", "default-tenant", "default-user"},
+		{"explicit values win", "tenant-1", "user-1", "tenant-1", "user-1"},
+		{"mixed", "tenant-1", "", "tenant-1", "default-user"},
+	}
+
+	for name, fn := range calls {
+		for _, tt := range tests {
+			t.Run(name+"/"+tt.name, func(t *testing.T) {
+				var got map[string]interface{}
+				server := identityServer(t, &got)
+				defer server.Close()
+
+				client, _ := NewClientWithOptions(server.URL,
+					WithDefaultTenantID("default-tenant"),
+					WithDefaultUserID("default-user"),
+				)
+				if err := fn(client, tt.tenantID, tt.userID); err != nil {
+					t.Fatalf("%s returned unexpected error: %v", name, err)
+				}
+
+				if got["tenantId"] != tt.wantTenantID {
+					t.Errorf("tenantId = %v, want %q", got["tenantId"], tt.wantTenantID)
+				}
+				if got["userId"] != tt.wantUserID {
+					t.Errorf("userId = %v, want %q", got["userId"], tt.wantUserID)
+				}
+			})
+		}
+	}
+}
+
+func TestClient_DefaultIDsDoNotModifyRequest(t *testing.T) {
+	var got map[string]interface{}
+	server := identityServer(t, &got)
+	defer server.Close()
+
+	client, _ := NewClientWithOptions(server.URL, WithDefaultTenantID("default-tenant"))
+	req := &IngestURLRequest{URL: "https://example.com"}
+	if _, err := client.IngestURL(context.Background(), req); err != nil {
+		t.Fatalf("IngestURL returned unexpected error: %v", err)
+	}
+	if req.TenantID != "" {
+		t.Errorf("caller's request TenantID = %q, want it left empty", req.TenantID)
+	}
+}
+
+func TestClient_NoDefaultIDs(t *testing.T) {
+	var got map[string]interface{}
+	server := identityServer(t, &got)
+	defer server.Close()
+
+	client, _ := NewClient(server.URL)
+	if _, err := client.RequestTextUpload(context.Background(), &RequestTextUploadRequest{ContentType: "text/plain"}); err != nil {
+		t.Fatalf("RequestTextUpload returned unexpected error: %v", err)
+	}
+	if _, ok := got["tenantId"]; ok {
+		t.Errorf("tenantId = %v, want it omitted", got["tenantId"])
+	}
+	if _, ok := got["userId"]; ok {
+		t.Errorf("userId = %v, want it omitted", got["userId"])
+	}
+}
+
+func TestClient_DefaultMetadata(t *testing.T) {
+	type call func(c *Client, metadata map[string]string) error
+	calls := map[string]call{
+		"IngestText": func(c *Client, metadata map[string]string) error {
+			_, err := c.IngestText(context.Background(), &IngestTextRequest{Content: "x", Metadata: metadata})
+			return err
+		},
+		"IngestURL": func(c *Client, metadata map[string]string) error {
+			_, err := c.IngestURL(context.Background(), &IngestURLRequest{URL: "https://example.com", Metadata: metadata})
+			return err
+		},
+		"RequestFileUpload": func(c *Client, metadata map[string]string) error {
+			_, err := c.RequestFileUpload(context.Background(), &RequestFileUploadRequest{Filename: "a.txt", Metadata: metadata})
+			return err
+		},
+		"RequestTextUpload": func(c *Client, metadata map[string]string) error {
+			_, err := c.RequestTextUpload(context.Background(), &RequestTextUploadRequest{ContentType: "text/plain", Metadata: metadata})
+			return err
+		},
+	}
+
+	tests := []struct {
+		name     string
+		metadata map[string]string
+		want     map[string]interface{}
+	}{
+		{"nil request map", nil, map[string]interface{}{"env": "prod", "app": "uploader"}},
+		{"request keys added", map[string]string{"source": "web"}, map[string]interface{}{"env": "prod", "app": "uploader", "source": "web"}},
+		{"request keys win", map[string]string{"env": "staging"}, map[string]interface{}{"env": "staging", "app": "uploader"}},
+	}
+
+	for name, fn := range calls {
+		for _, tt := range tests {
+			t.Run(name+"/"+tt.name, func(t *testing.T) {
+				var got map[string]interface{}
+				server := identityServer(t, &got)
+				defer server.Close()
+
+				client, _ := NewClientWithOptions(server.URL,
+					WithDefaultMetadata(map[string]string{"env": "prod", "app": "uploader"}),
+				)
+				if err := fn(client, tt.metadata); err != nil {
+					t.Fatalf("%s returned unexpected error: %v", name, err)
+				}
+
+				metadata, _ := got["metadata"].(map[string]interface{})
+				if fmt.Sprint(metadata) != fmt.Sprint(tt.want) {
+					t.Errorf("metadata = %v, want %v", metadata, tt.want)
+				}
+			})
+		}
+	}
+}
+
+func TestClient_DefaultMetadataDoesNotModifyRequest(t *testing.T) {
+	var got map[string]interface{}
+	server := identityServer(t, &got)
+	defer server.Close()
+
+	defaults := map[string]string{"env": "prod"}
+	client, _ := NewClientWithOptions(server.URL, WithDefaultMetadata(defaults))
+	defaults["env"] = "changed"
+
+	req := &IngestURLRequest{URL: "https://example.com", Metadata: map[string]string{"source": "web"}}
+	if _, err := client.IngestURL(context.Background(), req); err != nil {
+		t.Fatalf("IngestURL returned unexpected error: %v", err)
+	}
+	if len(req.Metadata) != 1 {
+		t.Errorf("caller's request Metadata = %v, want only source", req.Metadata)
+	}
+	if metadata := got["metadata"].(map[string]interface{}); metadata["env"] != "prod" {
+		t.Errorf("metadata env = %v, want prod as configured", metadata["env"])
+	}
+}
+
+func TestClient_NoDefaultMetadata(t *testing.T) {
+	var got map[string]interface{}
+	server := identityServer(t, &got)
+	defer server.Close()
+
+	client, _ := NewClient(server.URL)
+	if _, err := client.RequestTextUpload(context.Background(), &RequestTextUploadRequest{ContentType: "text/plain"}); err != nil {
+		t.Fatalf("RequestTextUpload returned unexpected error: %v", err)
+	}
+	if _, ok := got["metadata"]; ok {
+		t.Errorf("metadata = %v, want it omitted", got["metadata"])
+	}
+}
@@ -0,0 +1,125 @@
+package ingest
+
+import (
+	"context"
+	"net/http"
+	"sync"
+
+	"github.com/atriumn/atriumn-sdk-go/internal/clientutil"
+)
+
+// DeleteOption configures a DeleteContentItems call.
+type DeleteOption func(*deleteOptions)
+
+// deleteOptions holds the settings applied by DeleteOption functions.
+type deleteOptions struct {
+	ignoreNotFound bool
+}
+
+// WithIgnoreNotFound treats a "not_found" response as a successful delete,
+// since the content item is already gone.
+//
+// Returns:
+//   - DeleteOption: An option for DeleteContentItems
+func WithIgnoreNotFound() DeleteOption {
+	return func(o *deleteOptions) {
+		o.ignoreNotFound = true
+	}
+}
+
+// WithPurge makes DeleteContentItem and DeleteContentItemWithResult ask the
+// service to hard-delete the content item along with its derived artifacts,
+// such as extracted text and thumbnails, instead of soft-deleting the record.
+// A purged item cannot be restored.
+func WithPurge() CallOption {
+	return func(o *callOptions) {
+		o.purge = true
+	}
+}
+
+// setPurge adds the purge=true query parameter to req if options ask for it.
+func (o *callOptions) setPurge(req *http.Request) {
+	if o.purge {
+		q := req.URL.Query()
+		q.Set("purge", "true")
+		req.URL.RawQuery = q.Encode()
+	}
+}
+
+// DeleteContentItems deletes several content items, sending at most the
+// client's batch concurrency (see WithBatchConcurrency) requests at a time.
+// A failed delete does not stop the others. Items not yet started when ctx is
+// canceled fail with the context's error.
+//
+// Parameters:
+//   - ctx: Context for the API requests
+//   - ids: The unique identifiers of the content items to delete
+//   - opts: Optional DeleteOption values such as WithIgnoreNotFound
+//
+// Returns:
+//   - error: nil if every delete succeeded, otherwise a *MultiError with an
+//     item error, identified by content ID, for each ID whose delete failed
+func (c *Client) DeleteContentItems(ctx context.Context, ids []string, opts ...DeleteOption) error {
+	options := &deleteOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	concurrency := c.batchConcurrency
+	if concurrency < 1 {
+		concurrency = DefaultBatchConcurrency
+	}
+	sem := make(chan struct{}, concurrency)
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs = make(map[string]error)
+		seen = make(map[string]bool, len(ids))
+	)
+	record := func(id string, err error) {
+		mu.Lock()
+		errs[id] = err
+		mu.Unlock()
+	}
+
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		if err := ctx.Err(); err != nil {
+			record(id, err)
+			continue
+		}
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			record(id, ctx.Err())
+			continue
+		}
+
+		wg.Add(1)
+		go func(id string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			err := c.DeleteContentItem(ctx, id)
+			if err == nil || (options.ignoreNotFound && isNotFound(err)) {
+				return
+			}
+			record(id, err)
+		}(id)
+	}
+	wg.Wait()
+
+	multi := &clientutil.MultiError{Total: len(seen)}
+	for i, id := range ids {
+		if err, ok := errs[id]; ok {
+			multi.Add(i, id, err)
+			delete(errs, id)
+		}
+	}
+	return multi.ErrorOrNil()
+}
@@ -0,0 +1,217 @@
+package ingest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/atriumn/atriumn-sdk-go/internal/apierror"
+)
+
+func TestClient_DeleteContentItemWithResult(t *testing.T) {
+	server := setupTestServer(t, http.StatusOK, `{"id":"content-123","status":"DELETED","s3Key":"tenant/content-123","size":2048}`, func(r *http.Request) {
+		if r.Method != "DELETE" {
+			t.Errorf("DeleteContentItemWithResult method = %s, want DELETE", r.Method)
+		}
+		if r.URL.Path != "/content/content-123" {
+			t.Errorf("DeleteContentItemWithResult path = %s, want /content/content-123", r.URL.Path)
+		}
+	})
+	defer server.Close()
+
+	client, _ := NewClient(server.URL)
+	item, err := client.DeleteContentItemWithResult(context.Background(), "content-123")
+	if err != nil {
+		t.Fatalf("DeleteContentItemWithResult returned unexpected error: %v", err)
+	}
+	if item == nil {
+		t.Fatal("DeleteContentItemWithResult returned nil item, want the deleted item")
+	}
+	if item.ID != "content-123" || item.S3Key != "tenant/content-123" || item.Size != 2048 {
+		t.Errorf("DeleteContentItemWithResult item = %+v, want ID content-123, S3Key tenant/content-123, Size 2048", item)
+	}
+}
+
+func TestClient_DeleteContentItemWithResult_NoContent(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(server.URL)
+	item, err := client.DeleteContentItemWithResult(context.Background(), "content-123")
+	if err != nil {
+		t.Fatalf("DeleteContentItemWithResult returned unexpected error: %v", err)
+	}
+	if item != nil {
+		t.Errorf("DeleteContentItemWithResult item = %+v, want nil", item)
+	}
+}
+
+func TestClient_DeleteContentItemWithResult_NotFound(t *testing.T) {
+	server := setupTestServer(t, http.StatusNotFound, `{"error":"not_found","error_description":"Content item not found"}`, nil)
+	defer server.Close()
+
+	client, _ := NewClient(server.URL)
+	item, err := client.DeleteContentItemWithResult(context.Background(), "missing")
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("DeleteContentItemWithResult error = %v, want ErrNotFound", err)
+	}
+	if item != nil {
+		t.Errorf("DeleteContentItemWithResult item = %+v, want nil", item)
+	}
+}
+
+func TestClient_DeleteContentItems(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "DELETE" {
+			t.Errorf("DeleteContentItems method = %s, want DELETE", r.Method)
+		}
+		switch strings.TrimPrefix(r.URL.Path, "/content/") {
+		case "gone":
+			w.WriteHeader(http.StatusNotFound)
+		case "broken":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(server.URL)
+	ids := []string{"a", "b", "gone", "broken", "c"}
+
+	errs := itemErrors(t, client.DeleteContentItems(context.Background(), ids, WithIgnoreNotFound()))
+	if len(errs) != 1 {
+		t.Fatalf("DeleteContentItems returned %d errors, want 1: %v", len(errs), errs)
+	}
+	if !isAPIError(errs["broken"], "server_error") {
+		t.Errorf("errs[broken] = %v, want server_error", errs["broken"])
+	}
+
+	// Without WithIgnoreNotFound the 404 is reported
+	err := client.DeleteContentItems(context.Background(), ids)
+	errs = itemErrors(t, err)
+	if len(errs) != 2 || !isAPIError(errs["gone"], "not_found") {
+		t.Errorf("DeleteContentItems errors = %v, want not_found for gone and server_error for broken", errs)
+	}
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("errors.Is(%v, ErrNotFound) = false, want true", err)
+	}
+	if want := "2 of 5 operations failed: gone: not_found"; !strings.HasPrefix(err.Error(), want) {
+		t.Errorf("DeleteContentItems error = %q, want prefix %q", err.Error(), want)
+	}
+}
+
+func TestClient_DeleteContentItems_BoundedConcurrency(t *testing.T) {
+	var inFlight, maxInFlight int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			max := atomic.LoadInt32(&maxInFlight)
+			if n <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client, _ := NewClientWithOptions(server.URL, WithBatchConcurrency(3))
+	ids := []string{"1", "2", "3", "4", "5", "6", "7", "8", "9", "10"}
+
+	if err := client.DeleteContentItems(context.Background(), ids); err != nil {
+		t.Fatalf("DeleteContentItems returned errors: %v", err)
+	}
+	if got := atomic.LoadInt32(&maxInFlight); got != 3 {
+		t.Errorf("max concurrent deletes = %d, want 3", got)
+	}
+}
+
+func TestClient_DeleteContentItems_Canceled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("DeleteContentItems sent a request after the context was canceled")
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(server.URL)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	errs := itemErrors(t, client.DeleteContentItems(ctx, []string{"a", "b"}))
+	if errs["a"] != context.Canceled || errs["b"] != context.Canceled {
+		t.Errorf("DeleteContentItems errors = %v, want context.Canceled for each ID", errs)
+	}
+}
+
+// itemErrors returns the item errors of a *MultiError by ID.
+func itemErrors(t *testing.T, err error) map[string]error {
+	t.Helper()
+	errs := make(map[string]error)
+	if err == nil {
+		return errs
+	}
+	var multi *MultiError
+	if !errors.As(err, &multi) {
+		t.Fatalf("error = %v, want *MultiError", err)
+	}
+	for _, itemErr := range multi.Errors {
+		errs[itemErr.ID] = itemErr.Err
+	}
+	return errs
+}
+
+// isAPIError reports whether err is an API error with the given code.
+func isAPIError(err error, code string) bool {
+	var apiErr *apierror.ErrorResponse
+	return errors.As(err, &apiErr) && apiErr.ErrorCode == code
+}
+
+func TestClient_DeleteContentItem_Purge(t *testing.T) {
+	tests := []struct {
+		name      string
+		delete    func(c *Client) error
+		wantQuery string
+	}{
+		{"DeleteContentItem", func(c *Client) error {
+			return c.DeleteContentItem(context.Background(), "content-123")
+		}, ""},
+		{"DeleteContentItem with WithPurge", func(c *Client) error {
+			return c.DeleteContentItem(context.Background(), "content-123", WithPurge())
+		}, "purge=true"},
+		{"DeleteContentItemWithResult", func(c *Client) error {
+			_, err := c.DeleteContentItemWithResult(context.Background(), "content-123")
+			return err
+		}, ""},
+		{"DeleteContentItemWithResult with WithPurge", func(c *Client) error {
+			_, err := c.DeleteContentItemWithResult(context.Background(), "content-123", WithPurge())
+			return err
+		}, "purge=true"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := setupTestServer(t, http.StatusNoContent, "", func(r *http.Request) {
+				if r.URL.Path != "/content/content-123" {
+					t.Errorf("path = %s, want /content/content-123", r.URL.Path)
+				}
+				if r.URL.RawQuery != tt.wantQuery {
+					t.Errorf("query = %q, want %q", r.URL.RawQuery, tt.wantQuery)
+				}
+			})
+			defer server.Close()
+
+			client, _ := NewClient(server.URL)
+			if err := tt.delete(client); err != nil {
+				t.Fatalf("%s returned unexpected error: %v", tt.name, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package ingest
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestClient_DeprecationWarning(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Deprecation", "true")
+		w.Header().Set("Sunset", "Wed, 30 Dec 2026 23:59:59 GMT")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"items":[]}`))
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	var notices []DeprecationNotice
+	client, _ := NewClientWithOptions(server.URL,
+		WithLogger(log.New(&buf, "", 0)),
+		WithDeprecationCallback(func(n DeprecationNotice) { notices = append(notices, n) }),
+	)
+
+	for i := 0; i < 3; i++ {
+		if _, err := client.ListContentItemsWithOptions(context.Background(), nil); err != nil {
+			t.Fatalf("ListContentItemsWithOptions returned unexpected error: %v", err)
+		}
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("logged %d lines, want a single warning: %q", len(lines), buf.String())
+	}
+	want := "atriumn: ingest.ListContentItemsWithOptions (GET /content) is deprecated and will be removed after 2026-12-30T23:59:59Z"
+	if lines[0] != want {
+		t.Errorf("warning = %q, want %q", lines[0], want)
+	}
+	if len(notices) != 1 || notices[0].Operation != "ingest.ListContentItemsWithOptions" {
+		t.Errorf("deprecation callback received %+v, want one notice for ingest.ListContentItemsWithOptions", notices)
+	}
+}
@@ -0,0 +1,144 @@
+package ingest
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/atriumn/atriumn-sdk-go/internal/apierror"
+)
+
+// DownloadContent downloads a content item's raw bytes into w. It obtains a
+// pre-signed URL with GetContentDownloadURL and streams the body from it.
+//
+// Parameters:
+//   - ctx: Context for the API request and the download
+//   - id: The unique identifier of the content item to download (required)
+//   - w: Destination for the content bytes (required)
+//   - opts: Optional TransferOption values such as WithProgress
+//
+// Returns:
+//   - int64: The number of bytes written to w
+//   - error: An error if the operation fails, which can be:
+//   - any error returned by GetContentDownloadURL
+//   - a download error if the pre-signed URL request fails or returns a non-2xx status
+func (c *Client) DownloadContent(ctx context.Context, id string, w io.Writer, opts ...TransferOption) (int64, error) {
+	urlResp, err := c.GetContentDownloadURL(ctx, id)
+	if err != nil {
+		return 0, err
+	}
+
+	resp, err := c.openDownloadURL(ctx, urlResp.DownloadURL)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	options := newTransferOptions(opts)
+	total := options.totalSize
+	if total < 0 {
+		total = resp.ContentLength
+	}
+
+	dst := w
+	var progress *ProgressWriter
+	if options.progress != nil {
+		progress = NewProgressWriter(w, total, options.progress)
+		dst = progress
+	}
+
+	n, err := io.Copy(dst, resp.Body)
+	if err != nil {
+		return n, fmt.Errorf("failed to download content: %w", err)
+	}
+	if progress != nil {
+		progress.Complete()
+	}
+
+	return n, nil
+}
+
+// GetContentBytes fetches a content item's metadata and reads its full content
+// into memory. It is intended for small files; content larger than the client's
+// MaxResponseBytes is rejected. Use DownloadContent to stream larger content.
+//
+// Parameters:
+//   - ctx: Context for the API requests and the download
+//   - id: The unique identifier of the content item to fetch (required)
+//
+// Returns:
+//   - []byte: The raw content bytes
+//   - *ContentItem: The content item's metadata
+//   - error: An error if the operation fails, which can be:
+//   - any error returned by GetContentItem or GetContentDownloadURL
+//   - a download error if the pre-signed URL request fails or returns a non-2xx status
+//   - apierror.ErrorResponse with code "content_too_large" if the content exceeds MaxResponseBytes
+func (c *Client) GetContentBytes(ctx context.Context, id string) ([]byte, *ContentItem, error) {
+	item, err := c.GetContentItem(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	urlResp, err := c.GetContentDownloadURL(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resp, err := c.openDownloadURL(ctx, urlResp.DownloadURL)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	limit := c.MaxResponseBytes
+	if limit <= 0 {
+		limit = DefaultMaxResponseBytes
+	}
+	tooLarge := &apierror.ErrorResponse{
+		ErrorCode:   "content_too_large",
+		Description: fmt.Sprintf("Content %s exceeds the maximum of %d bytes", id, limit),
+	}
+	if resp.ContentLength > limit {
+		return nil, item, tooLarge
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, limit+1))
+	if err != nil {
+		return nil, item, fmt.Errorf("failed to download content: %w", err)
+	}
+	if int64(len(data)) > limit {
+		return nil, item, tooLarge
+	}
+
+	return data, item, nil
+}
+
+// openDownloadURL issues a GET against a pre-signed download URL and returns
+// the response with an unread body. Like UploadToURL, it uses a plain HTTP
+// client so that no Authorization header is sent to the storage service.
+func (c *Client) openDownloadURL(ctx context.Context, downloadURL string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", downloadURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create download request: %w", err)
+	}
+
+	standardClient := &http.Client{
+		Transport: c.transferTransport,
+		Timeout:   60 * time.Second,
+	}
+
+	resp, err := standardClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download from URL: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		defer func() { _ = resp.Body.Close() }()
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("download failed with status %d: %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,91 @@
+package ingest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/atriumn/atriumn-sdk-go/internal/apierror"
+)
+
+// contentBytesServer serves a content item, its download URL, and its bytes.
+// When chunked is true the content is streamed without a Content-Length.
+func contentBytesServer(t *testing.T, payload string, chunked bool) *httptest.Server {
+	t.Helper()
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/content/content-123":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = fmt.Fprint(w, `{"id":"content-123","status":"COMPLETED","contentType":"text/plain"}`)
+		case "/content/content-123/download-url":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = fmt.Fprintf(w, `{"downloadUrl":"%s/object"}`, server.URL)
+		case "/object":
+			if chunked {
+				_, _ = w.Write([]byte(payload[:1]))
+				w.(http.Flusher).Flush()
+				_, _ = w.Write([]byte(payload[1:]))
+				return
+			}
+			w.Header().Set("Content-Length", fmt.Sprint(len(payload)))
+			_, _ = w.Write([]byte(payload))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	return server
+}
+
+func TestClient_GetContentBytes(t *testing.T) {
+	payload := "small file contents"
+	server := contentBytesServer(t, payload, false)
+	defer server.Close()
+
+	client, _ := NewClient(server.URL)
+	data, item, err := client.GetContentBytes(context.Background(), "content-123")
+	if err != nil {
+		t.Fatalf("GetContentBytes returned unexpected error: %v", err)
+	}
+	if string(data) != payload {
+		t.Errorf("GetContentBytes data = %q, want %q", data, payload)
+	}
+	if item == nil || item.ID != "content-123" || item.Status != "COMPLETED" {
+		t.Errorf("GetContentBytes item = %+v, want content-123 metadata", item)
+	}
+}
+
+func TestClient_GetContentBytes_OverCap(t *testing.T) {
+	// The cap also applies to the metadata responses, so keep it above their size
+	payload := strings.Repeat("z", 256)
+
+	for _, chunked := range []bool{false, true} {
+		t.Run(fmt.Sprintf("chunked=%v", chunked), func(t *testing.T) {
+			server := contentBytesServer(t, payload, chunked)
+			defer server.Close()
+
+			client, _ := NewClientWithOptions(server.URL, WithMaxResponseBytes(128))
+			data, _, err := client.GetContentBytes(context.Background(), "content-123")
+			if data != nil {
+				t.Errorf("GetContentBytes data = %d bytes, want nil", len(data))
+			}
+
+			var apiErr *apierror.ErrorResponse
+			if !errors.As(err, &apiErr) || apiErr.ErrorCode != "content_too_large" {
+				t.Fatalf("GetContentBytes error = %v, want content_too_large", err)
+			}
+		})
+	}
+
+	// Content exactly at the cap is accepted
+	server := contentBytesServer(t, payload, true)
+	defer server.Close()
+	client, _ := NewClientWithOptions(server.URL, WithMaxResponseBytes(int64(len(payload))))
+	if _, _, err := client.GetContentBytes(context.Background(), "content-123"); err != nil {
+		t.Errorf("GetContentBytes at the cap returned error: %v", err)
+	}
+}
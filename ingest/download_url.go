@@ -0,0 +1,68 @@
+package ingest
+
+import (
+	"sync"
+	"time"
+)
+
+// Expiry returns the time at which the download URL stops working. ExpiresAt
+// is used if set; otherwise ExpiresIn is counted from when the response was
+// received. The boolean is false if the service reported neither.
+func (r *DownloadURLResponse) Expiry() (time.Time, bool) {
+	if r.ExpiresAt != "" {
+		if t, err := time.Parse(time.RFC3339, r.ExpiresAt); err == nil {
+			return t, true
+		}
+	}
+	if r.ExpiresIn > 0 && !r.retrievedAt.IsZero() {
+		return r.retrievedAt.Add(time.Duration(r.ExpiresIn) * time.Second), true
+	}
+	return time.Time{}, false
+}
+
+// IsExpired reports whether the download URL has expired at now. A URL whose
+// expiry is unknown is never reported as expired.
+func (r *DownloadURLResponse) IsExpired(now time.Time) bool {
+	expiry, ok := r.Expiry()
+	return ok && !now.Before(expiry)
+}
+
+// downloadURLCache holds pre-signed download URLs keyed by content ID.
+type downloadURLCache struct {
+	mu      sync.Mutex
+	margin  time.Duration
+	entries map[string]DownloadURLResponse
+}
+
+// newDownloadURLCache creates a cache that drops URLs margin before they expire.
+func newDownloadURLCache(margin time.Duration) *downloadURLCache {
+	return &downloadURLCache{margin: margin, entries: make(map[string]DownloadURLResponse)}
+}
+
+// get returns a copy of the cached URL for contentID, or nil if there is none
+// or it is within the margin of expiring.
+func (c *downloadURLCache) get(contentID string, now time.Time) *DownloadURLResponse {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[contentID]
+	if !ok {
+		return nil
+	}
+	if entry.IsExpired(now.Add(c.margin)) {
+		delete(c.entries, contentID)
+		return nil
+	}
+	return &entry
+}
+
+// put stores a copy of resp if its expiry is known.
+func (c *downloadURLCache) put(contentID string, resp *DownloadURLResponse) {
+	if _, ok := resp.Expiry(); !ok {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[contentID] = *resp
+}
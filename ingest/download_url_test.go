@@ -0,0 +1,143 @@
+package ingest
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/atriumn/atriumn-sdk-go/internal/clientutil"
+)
+
+func TestDownloadURLResponse_DecodeExpiry(t *testing.T) {
+	server := setupTestServer(t, http.StatusOK,
+		`{"downloadUrl":"https://example.com/dl","expiresAt":"2026-01-02T03:04:05Z","expiresIn":900}`, nil)
+	defer server.Close()
+
+	client, err := NewClient(server.URL)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	resp, err := client.GetContentDownloadURL(context.Background(), "content-1")
+	if err != nil {
+		t.Fatalf("GetContentDownloadURL returned unexpected error: %v", err)
+	}
+	if resp.ExpiresAt != "2026-01-02T03:04:05Z" {
+		t.Errorf("ExpiresAt = %q, want %q", resp.ExpiresAt, "2026-01-02T03:04:05Z")
+	}
+	if resp.ExpiresIn != 900 {
+		t.Errorf("ExpiresIn = %d, want 900", resp.ExpiresIn)
+	}
+
+	expiry, ok := resp.Expiry()
+	if !ok {
+		t.Fatal("Expiry() reported unknown expiry")
+	}
+	if want := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC); !expiry.Equal(want) {
+		t.Errorf("Expiry() = %v, want %v (ExpiresAt takes precedence)", expiry, want)
+	}
+}
+
+func TestDownloadURLResponse_IsExpired(t *testing.T) {
+	expiry := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	issued := expiry.Add(-10 * time.Minute)
+
+	tests := []struct {
+		name string
+		resp DownloadURLResponse
+		now  time.Time
+		want bool
+	}{
+		{"ExpiresAt before", DownloadURLResponse{ExpiresAt: "2026-01-02T03:04:05Z"}, expiry.Add(-time.Nanosecond), false},
+		{"ExpiresAt exactly", DownloadURLResponse{ExpiresAt: "2026-01-02T03:04:05Z"}, expiry, true},
+		{"ExpiresAt after", DownloadURLResponse{ExpiresAt: "2026-01-02T03:04:05Z"}, expiry.Add(time.Second), true},
+		{"ExpiresIn before", DownloadURLResponse{ExpiresIn: 600, retrievedAt: issued}, expiry.Add(-time.Nanosecond), false},
+		{"ExpiresIn exactly", DownloadURLResponse{ExpiresIn: 600, retrievedAt: issued}, expiry, true},
+		{"unknown expiry", DownloadURLResponse{}, expiry.Add(time.Hour), false},
+		{"unparseable ExpiresAt", DownloadURLResponse{ExpiresAt: "soon"}, expiry, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.resp.IsExpired(tt.now); got != tt.want {
+				t.Errorf("IsExpired(%v) = %v, want %v", tt.now, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_GetContentDownloadURL_Cache(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&calls, 1)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"downloadUrl":"https://example.com/dl/%d","expiresIn":600}`, n)
+	}))
+	defer server.Close()
+
+	clock := clientutil.NewFakeClock(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	client, err := NewClientWithOptions(server.URL, WithDownloadURLCache(time.Minute), WithClock(clock))
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	ctx := context.Background()
+	first, err := client.GetContentDownloadURL(ctx, "content-1")
+	if err != nil {
+		t.Fatalf("GetContentDownloadURL returned unexpected error: %v", err)
+	}
+
+	// Within the validity window (minus the margin), the cached URL is reused.
+	clock.Advance(8 * time.Minute)
+	second, err := client.GetContentDownloadURL(ctx, "content-1")
+	if err != nil {
+		t.Fatalf("GetContentDownloadURL returned unexpected error: %v", err)
+	}
+	if second.DownloadURL != first.DownloadURL {
+		t.Errorf("cached DownloadURL = %q, want %q", second.DownloadURL, first.DownloadURL)
+	}
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Errorf("server calls = %d, want 1", got)
+	}
+
+	// Inside the margin, a fresh URL is fetched.
+	clock.Advance(time.Minute + time.Second)
+	third, err := client.GetContentDownloadURL(ctx, "content-1")
+	if err != nil {
+		t.Fatalf("GetContentDownloadURL returned unexpected error: %v", err)
+	}
+	if third.DownloadURL == first.DownloadURL {
+		t.Errorf("expected a fresh URL after expiry, got cached %q", third.DownloadURL)
+	}
+	if got := atomic.LoadInt32(&calls); got != 2 {
+		t.Errorf("server calls = %d, want 2", got)
+	}
+}
+
+func TestClient_GetContentDownloadURL_CacheSkipsUnknownExpiry(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"downloadUrl":"https://example.com/dl"}`)
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithOptions(server.URL, WithDownloadURLCache(time.Minute))
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.GetContentDownloadURL(context.Background(), "content-1"); err != nil {
+			t.Fatalf("GetContentDownloadURL returned unexpected error: %v", err)
+		}
+	}
+	if got := atomic.LoadInt32(&calls); got != 2 {
+		t.Errorf("server calls = %d, want 2", got)
+	}
+}
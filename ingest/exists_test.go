@@ -0,0 +1,78 @@
+package ingest
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClient_ContentItemExists(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		want       bool
+		wantErr    string
+	}{
+		{name: "exists", statusCode: http.StatusOK, want: true},
+		{name: "not found", statusCode: http.StatusNotFound, want: false},
+		{name: "unauthorized", statusCode: http.StatusUnauthorized, wantErr: "unauthorized"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodHead {
+					t.Errorf("ContentItemExists method = %s, want HEAD", r.Method)
+				}
+				if r.URL.Path != "/content/content-123" {
+					t.Errorf("ContentItemExists path = %s, want /content/content-123", r.URL.Path)
+				}
+				w.WriteHeader(tt.statusCode)
+			}))
+			defer server.Close()
+
+			client, _ := NewClient(server.URL)
+			got, err := client.ContentItemExists(context.Background(), "content-123")
+
+			if tt.wantErr != "" {
+				if !isAPIError(err, tt.wantErr) {
+					t.Errorf("ContentItemExists error = %v, want %s", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ContentItemExists returned unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ContentItemExists = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_ContentItemExists_FallsBackToGet(t *testing.T) {
+	var methods []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.Method)
+		if r.Method == http.MethodHead {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"content-123"}`))
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(server.URL)
+	got, err := client.ContentItemExists(context.Background(), "content-123")
+	if err != nil {
+		t.Fatalf("ContentItemExists returned unexpected error: %v", err)
+	}
+	if !got {
+		t.Error("ContentItemExists = false, want true")
+	}
+	if len(methods) != 2 || methods[0] != http.MethodHead || methods[1] != http.MethodGet {
+		t.Errorf("ContentItemExists methods = %v, want [HEAD GET]", methods)
+	}
+}
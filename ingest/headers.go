@@ -0,0 +1,20 @@
+package ingest
+
+import "net/http"
+
+// WithResponseHeaders copies the headers of the call's final response into h
+// when the call succeeds, for headers the decoded result does not carry, such
+// as rate limit counters or deprecation notices. h is left unchanged when the
+// call fails.
+func WithResponseHeaders(h *http.Header) CallOption {
+	return func(o *callOptions) {
+		o.responseHeaders = h
+	}
+}
+
+// storeResponseHeaders copies the headers of resp where WithResponseHeaders asked
+func (o *callOptions) storeResponseHeaders(resp *http.Response) {
+	if o.responseHeaders != nil && resp != nil {
+		*o.responseHeaders = resp.Header.Clone()
+	}
+}
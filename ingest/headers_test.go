@@ -0,0 +1,61 @@
+package ingest
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClient_ListContentItems_WithResponseHeaders(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-RateLimit-Remaining", "41")
+		w.Header().Set("Deprecation", "true")
+		_, _ = w.Write([]byte(`{"items":[{"id":"content-123"}]}`))
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(server.URL)
+
+	var headers http.Header
+	resp, err := client.ListContentItemsWithOptions(context.Background(), nil, WithResponseHeaders(&headers))
+	if err != nil {
+		t.Fatalf("ListContentItemsWithOptions returned unexpected error: %v", err)
+	}
+	if len(resp.Items) != 1 {
+		t.Errorf("ListContentItemsWithOptions returned %d items, want 1", len(resp.Items))
+	}
+	if got := headers.Get("X-RateLimit-Remaining"); got != "41" {
+		t.Errorf("X-RateLimit-Remaining = %q, want %q", got, "41")
+	}
+	if got := headers.Get("Deprecation"); got != "true" {
+		t.Errorf("Deprecation = %q, want %q", got, "true")
+	}
+
+	var deprecatedHeaders http.Header
+	if _, err := client.ListContentItems(context.Background(), nil, nil, nil, nil, WithResponseHeaders(&deprecatedHeaders)); err != nil {
+		t.Fatalf("ListContentItems returned unexpected error: %v", err)
+	}
+	if got := deprecatedHeaders.Get("X-RateLimit-Remaining"); got != "41" {
+		t.Errorf("ListContentItems X-RateLimit-Remaining = %q, want %q", got, "41")
+	}
+}
+
+func TestClient_WithResponseHeaders_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(server.URL)
+
+	var headers http.Header
+	if _, err := client.ListContentItemsWithOptions(context.Background(), nil, WithResponseHeaders(&headers)); err == nil {
+		t.Fatal("ListContentItemsWithOptions should fail on a 429 response")
+	}
+	if headers != nil {
+		t.Errorf("headers = %v, want them left unset on failure", headers)
+	}
+}
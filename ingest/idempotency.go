@@ -0,0 +1,62 @@
+package ingest
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/atriumn/atriumn-sdk-go/internal/clientutil"
+)
+
+// IdempotencyKeyHeader is the request header carrying an idempotency key.
+// The service treats POSTs with the same key as a single operation, so a
+// retried request does not create a duplicate content item.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// CallOption configures a single API call. WithIdempotencyKey applies to the
+// ingest-creating methods IngestURL, RequestFileUpload, and RequestTextUpload;
+// WithNoCache to GetContentItemWithOptions; WithPurge to DeleteContentItem and
+// DeleteContentItemWithResult; WithResponseHeaders to the methods that accept
+// a CallOption.
+type CallOption func(*callOptions)
+
+// callOptions holds the settings applied by CallOption functions.
+type callOptions struct {
+	idempotencyKey  string
+	responseHeaders *http.Header
+	noCache         bool
+	purge           bool
+}
+
+// newCallOptions applies opts and returns the resulting settings.
+func newCallOptions(opts []CallOption) *callOptions {
+	options := &callOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+	return options
+}
+
+// WithIdempotencyKey sets the idempotency key sent with the call. Pass the same
+// key when retrying a call yourself so the service recognises the retry. If no
+// key is given, a random one is generated for the call, which covers retries
+// made while sending that single call.
+func WithIdempotencyKey(key string) CallOption {
+	return func(o *callOptions) {
+		o.idempotencyKey = key
+	}
+}
+
+// setIdempotencyKey applies opts and sets the resulting idempotency key on req,
+// generating a new key if none was provided.
+func setIdempotencyKey(req *http.Request, opts []CallOption) error {
+	key := newCallOptions(opts).idempotencyKey
+	if key == "" {
+		var err error
+		key, err = clientutil.NewUUID()
+		if err != nil {
+			return fmt.Errorf("failed to generate idempotency key: %w", err)
+		}
+	}
+	req.Header.Set(IdempotencyKeyHeader, key)
+	return nil
+}
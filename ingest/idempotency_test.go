@@ -0,0 +1,122 @@
+package ingest
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"sync"
+	"testing"
+)
+
+// retryingTransport re-sends a request once when the first attempt fails with
+// 503, the way a retry policy would.
+type retryingTransport struct{}
+
+func (retryingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := http.DefaultTransport.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusServiceUnavailable {
+		return resp, err
+	}
+	_ = resp.Body.Close()
+
+	retry := req.Clone(req.Context())
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		retry.Body = body
+	}
+	return http.DefaultTransport.RoundTrip(retry)
+}
+
+// keyRecordingServer records the idempotency key of every request and fails
+// each odd-numbered request with 503.
+func keyRecordingServer(t *testing.T, keys *[]string) *httptest.Server {
+	t.Helper()
+	var mu sync.Mutex
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		*keys = append(*keys, r.Header.Get(IdempotencyKeyHeader))
+		attempt := len(*keys)
+		mu.Unlock()
+
+		if attempt%2 == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = fmt.Fprint(w, `{"id":"content-1","contentId":"content-1","status":"PENDING"}`)
+	}))
+}
+
+func TestClient_IdempotencyKey_StableAcrossRetries(t *testing.T) {
+	calls := map[string]func(c *Client, opts ...CallOption) error{
+		"IngestURL": func(c *Client, opts ...CallOption) error {
+			_, err := c.IngestURL(context.Background(), &IngestURLRequest{TenantID: "t", URL: "https://example.com"}, opts...)
+			return err
+		},
+		"RequestFileUpload": func(c *Client, opts ...CallOption) error {
+			_, err := c.RequestFileUpload(context.Background(), &RequestFileUploadRequest{Filename: "a.txt", ContentType: "text/plain"}, opts...)
+			return err
+		},
+		"RequestTextUpload": func(c *Client, opts ...CallOption) error {
+			_, err := c.RequestTextUpload(context.Background(), &RequestTextUploadRequest{ContentType: "text/plain"}, opts...)
+			return err
+		},
+	}
+	uuidPattern := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+	for name, call := range calls {
+		t.Run(name+"/generated", func(t *testing.T) {
+			var keys []string
+			server := keyRecordingServer(t, &keys)
+			defer server.Close()
+
+			client, _ := NewClientWithOptions(server.URL, WithHTTPClient(&http.Client{Transport: retryingTransport{}}))
+			if err := call(client); err != nil {
+				t.Fatalf("%s returned unexpected error: %v", name, err)
+			}
+			if err := call(client); err != nil {
+				t.Fatalf("%s returned unexpected error: %v", name, err)
+			}
+
+			if len(keys) != 4 {
+				t.Fatalf("server saw %d requests, want 4", len(keys))
+			}
+			if !uuidPattern.MatchString(keys[0]) {
+				t.Errorf("generated key %q is not a v4 UUID", keys[0])
+			}
+			if keys[0] != keys[1] || keys[2] != keys[3] {
+				t.Errorf("key changed across retries: %v", keys)
+			}
+			if keys[0] == keys[2] {
+				t.Errorf("separate calls reused key %q", keys[0])
+			}
+		})
+
+		t.Run(name+"/explicit", func(t *testing.T) {
+			var keys []string
+			server := keyRecordingServer(t, &keys)
+			defer server.Close()
+
+			client, _ := NewClient(server.URL)
+			// The first attempt fails; the caller retries with the same key
+			if err := call(client, WithIdempotencyKey("op-123")); err == nil {
+				t.Fatalf("%s: expected the first attempt to fail", name)
+			}
+			if err := call(client, WithIdempotencyKey("op-123")); err != nil {
+				t.Fatalf("%s returned unexpected error: %v", name, err)
+			}
+
+			for i, key := range keys {
+				if key != "op-123" {
+					t.Errorf("request %d key = %q, want %q", i, key, "op-123")
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package ingest
+
+import (
+	"context"
+	"sync"
+)
+
+// GetContentItems retrieves several content items, sending at most the
+// client's batch concurrency (see WithBatchConcurrency) GetContentItem
+// requests at a time. The ingest API has no batch read endpoint, so each item
+// is fetched individually. A failed fetch, such as a "not_found" for a deleted
+// item, does not stop the others. Items not yet started when ctx is canceled
+// fail with the context's error. Duplicate IDs are fetched once.
+//
+// Parameters:
+//   - ctx: Context for the API requests
+//   - ids: The unique identifiers of the content items to retrieve
+//
+// Returns:
+//   - map[string]*ContentItem: The content items fetched, keyed by ID
+//   - map[string]error: The error for each ID whose fetch failed, keyed by ID;
+//     empty if every fetch succeeded
+func (c *Client) GetContentItems(ctx context.Context, ids []string) (map[string]*ContentItem, map[string]error) {
+	concurrency := c.batchConcurrency
+	if concurrency < 1 {
+		concurrency = DefaultBatchConcurrency
+	}
+	sem := make(chan struct{}, concurrency)
+
+	var (
+		wg    sync.WaitGroup
+		mu    sync.Mutex
+		items = make(map[string]*ContentItem, len(ids))
+		errs  = make(map[string]error)
+		seen  = make(map[string]bool, len(ids))
+	)
+	record := func(id string, item *ContentItem, err error) {
+		mu.Lock()
+		if err != nil {
+			errs[id] = err
+		} else {
+			items[id] = item
+		}
+		mu.Unlock()
+	}
+
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		if err := ctx.Err(); err != nil {
+			record(id, nil, err)
+			continue
+		}
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			record(id, nil, ctx.Err())
+			continue
+		}
+
+		wg.Add(1)
+		go func(id string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			item, err := c.GetContentItem(ctx, id)
+			record(id, item, err)
+		}(id)
+	}
+	wg.Wait()
+
+	return items, errs
+}
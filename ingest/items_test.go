@@ -0,0 +1,99 @@
+package ingest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestClient_GetContentItems(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		id := strings.TrimPrefix(r.URL.Path, "/content/")
+		w.Header().Set("Content-Type", "application/json")
+		if id == "missing" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":"not_found","error_description":"Content item not found"}`))
+			return
+		}
+		_, _ = fmt.Fprintf(w, `{"id":%q,"status":"COMPLETED"}`, id)
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(server.URL)
+	items, errs := client.GetContentItems(context.Background(), []string{"a", "missing", "b", "a"})
+
+	if len(items) != 2 || items["a"] == nil || items["b"] == nil {
+		t.Fatalf("GetContentItems items = %v, want a and b", items)
+	}
+	if items["a"].ID != "a" || items["b"].Status != "COMPLETED" {
+		t.Errorf("GetContentItems items = %+v, %+v, want decoded items", items["a"], items["b"])
+	}
+	if len(errs) != 1 {
+		t.Fatalf("GetContentItems errors = %v, want one error for missing", errs)
+	}
+	if !errors.Is(errs["missing"], ErrNotFound) {
+		t.Errorf("GetContentItems errors[missing] = %v, want ErrNotFound", errs["missing"])
+	}
+	if got := atomic.LoadInt32(&requests); got != 3 {
+		t.Errorf("requests = %d, want 3 (duplicate IDs fetched once)", got)
+	}
+}
+
+func TestClient_GetContentItems_BoundedConcurrency(t *testing.T) {
+	var inFlight, maxInFlight int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			max := atomic.LoadInt32(&maxInFlight)
+			if n <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"item"}`))
+	}))
+	defer server.Close()
+
+	client, _ := NewClientWithOptions(server.URL, WithBatchConcurrency(3))
+	ids := []string{"1", "2", "3", "4", "5", "6", "7", "8", "9", "10"}
+
+	items, errs := client.GetContentItems(context.Background(), ids)
+	if len(errs) != 0 {
+		t.Fatalf("GetContentItems returned errors: %v", errs)
+	}
+	if len(items) != len(ids) {
+		t.Errorf("GetContentItems returned %d items, want %d", len(items), len(ids))
+	}
+	if got := atomic.LoadInt32(&maxInFlight); got != 3 {
+		t.Errorf("max concurrent fetches = %d, want 3", got)
+	}
+}
+
+func TestClient_GetContentItems_Canceled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("GetContentItems sent a request after the context was canceled")
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(server.URL)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	items, errs := client.GetContentItems(ctx, []string{"a", "b"})
+	if len(items) != 0 {
+		t.Errorf("GetContentItems items = %v, want none", items)
+	}
+	if errs["a"] != context.Canceled || errs["b"] != context.Canceled {
+		t.Errorf("GetContentItems errors = %v, want context.Canceled for each ID", errs)
+	}
+}
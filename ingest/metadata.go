@@ -0,0 +1,150 @@
+package ingest
+
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+	"time"
+)
+
+// MetadataString returns the metadata value key as a string. A string value
+// in TypedMetadata takes precedence over the same key in Metadata.
+//
+// Parameters:
+//   - key: The metadata key
+//
+// Returns:
+//   - string: The metadata value
+//   - bool: False if the key is absent or its typed value is not a string
+func (i *ContentItem) MetadataString(key string) (string, bool) {
+	if value, ok := i.TypedMetadata[key]; ok {
+		s, ok := value.(string)
+		return s, ok
+	}
+	s, ok := i.Metadata[key]
+	return s, ok
+}
+
+// MetadataInt returns the metadata value key as an integer: a whole number in
+// TypedMetadata, or a base 10 integer string in Metadata.
+//
+// Parameters:
+//   - key: The metadata key
+//
+// Returns:
+//   - int64: The metadata value
+//   - bool: False if the key is absent or its value is not an integer
+func (i *ContentItem) MetadataInt(key string) (int64, bool) {
+	if value, ok := i.TypedMetadata[key]; ok {
+		switch v := value.(type) {
+		case float64:
+			// JSON numbers decode as float64; only whole numbers in range are integers
+			if v != math.Trunc(v) || v < math.MinInt64 || v >= math.MaxInt64 {
+				return 0, false
+			}
+			return int64(v), true
+		case int:
+			return int64(v), true
+		case int64:
+			return v, true
+		case json.Number:
+			n, err := v.Int64()
+			return n, err == nil
+		}
+		return 0, false
+	}
+	value, ok := i.Metadata[key]
+	if !ok {
+		return 0, false
+	}
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return n, true
+}
+
+// MetadataFloat returns the metadata value key as a number: a number in
+// TypedMetadata, or a decimal number string in Metadata.
+//
+// Parameters:
+//   - key: The metadata key
+//
+// Returns:
+//   - float64: The metadata value
+//   - bool: False if the key is absent or its value is not a number
+func (i *ContentItem) MetadataFloat(key string) (float64, bool) {
+	if value, ok := i.TypedMetadata[key]; ok {
+		switch v := value.(type) {
+		case float64:
+			return v, true
+		case int:
+			return float64(v), true
+		case int64:
+			return float64(v), true
+		case json.Number:
+			f, err := v.Float64()
+			return f, err == nil
+		}
+		return 0, false
+	}
+	value, ok := i.Metadata[key]
+	if !ok {
+		return 0, false
+	}
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, false
+	}
+	return f, true
+}
+
+// MetadataBool returns the metadata value key as a boolean: a boolean in
+// TypedMetadata, or a string such as "true" or "false" in Metadata.
+//
+// Parameters:
+//   - key: The metadata key
+//
+// Returns:
+//   - bool: The metadata value
+//   - bool: False if the key is absent or its value is not a boolean
+func (i *ContentItem) MetadataBool(key string) (bool, bool) {
+	if value, ok := i.TypedMetadata[key]; ok {
+		b, ok := value.(bool)
+		return b, ok
+	}
+	value, ok := i.Metadata[key]
+	if !ok {
+		return false, false
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, false
+	}
+	return b, true
+}
+
+// MetadataTime returns the metadata value key as a time, parsed from an
+// RFC 3339 string in TypedMetadata or Metadata. A time.Time set in
+// TypedMetadata is sent as such a string.
+//
+// Parameters:
+//   - key: The metadata key
+//
+// Returns:
+//   - time.Time: The metadata value
+//   - bool: False if the key is absent or its value is not an RFC 3339 time
+func (i *ContentItem) MetadataTime(key string) (time.Time, bool) {
+	if t, ok := i.TypedMetadata[key].(time.Time); ok {
+		return t, true
+	}
+	value, ok := i.MetadataString(key)
+	if !ok {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
@@ -0,0 +1,135 @@
+package ingest
+
+import (
+	"container/list"
+	"maps"
+	"sync"
+	"time"
+)
+
+// DefaultMetadataCacheEntries is the number of content items WithMetadataCache
+// keeps when given a maxEntries below 1.
+const DefaultMetadataCacheEntries = 1000
+
+// WithNoCache makes GetContentItemWithOptions fetch the content item from the
+// service even if the client's metadata cache holds it. The fetched item
+// still replaces the cached one.
+func WithNoCache() CallOption {
+	return func(o *callOptions) {
+		o.noCache = true
+	}
+}
+
+// metadataCache holds content items keyed by ID for a fixed time, evicting the
+// least recently used item when full. It is safe for concurrent use.
+type metadataCache struct {
+	mu         sync.Mutex
+	ttl        time.Duration
+	maxEntries int
+	entries    map[string]*list.Element
+	order      *list.List // most recently used at the front
+
+	// generation counts invalidations, so a fetch that raced with a write
+	// does not store the item as it was before the write
+	generation uint64
+}
+
+// metadataEntry is a cached content item and the time it stops being served.
+type metadataEntry struct {
+	id      string
+	item    ContentItem
+	expires time.Time
+}
+
+// newMetadataCache creates a cache that serves items for ttl and holds at most
+// maxEntries of them.
+func newMetadataCache(ttl time.Duration, maxEntries int) *metadataCache {
+	if maxEntries < 1 {
+		maxEntries = DefaultMetadataCacheEntries
+	}
+	return &metadataCache{
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		entries:    make(map[string]*list.Element),
+		order:      list.New(),
+	}
+}
+
+// get returns a copy of the cached item for id, or nil if there is none or it
+// has expired at now.
+func (c *metadataCache) get(id string, now time.Time) *ContentItem {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[id]
+	if !ok {
+		return nil
+	}
+	entry := elem.Value.(*metadataEntry)
+	if !now.Before(entry.expires) {
+		c.remove(elem)
+		return nil
+	}
+	c.order.MoveToFront(elem)
+	return copyContentItem(&entry.item)
+}
+
+// currentGeneration returns the value to pass to put for an item fetched from now on.
+func (c *metadataCache) currentGeneration() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.generation
+}
+
+// put stores a copy of item under id as fetched at now, unless the cache was
+// invalidated since generation was read.
+func (c *metadataCache) put(id string, item *ContentItem, generation uint64, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if generation != c.generation {
+		return
+	}
+	entry := &metadataEntry{id: id, item: *copyContentItem(item), expires: now.Add(c.ttl)}
+	if elem, ok := c.entries[id]; ok {
+		elem.Value = entry
+		c.order.MoveToFront(elem)
+		return
+	}
+	c.entries[id] = c.order.PushFront(entry)
+	for c.order.Len() > c.maxEntries {
+		c.remove(c.order.Back())
+	}
+}
+
+// invalidate drops the cached item for id.
+func (c *metadataCache) invalidate(id string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generation++
+	if elem, ok := c.entries[id]; ok {
+		c.remove(elem)
+	}
+}
+
+// remove drops elem from the cache. The caller must hold c.mu.
+func (c *metadataCache) remove(elem *list.Element) {
+	c.order.Remove(elem)
+	delete(c.entries, elem.Value.(*metadataEntry).id)
+}
+
+// copyContentItem returns a copy of item that shares no map with it.
+func copyContentItem(item *ContentItem) *ContentItem {
+	cp := *item
+	cp.Metadata = maps.Clone(item.Metadata)
+	cp.TypedMetadata = maps.Clone(item.TypedMetadata)
+	return &cp
+}
+
+// invalidateMetadata drops the cached item for id, if the client caches items.
+func (c *Client) invalidateMetadata(id string) {
+	if c.metadataCache != nil {
+		c.metadataCache.invalidate(id)
+	}
+}
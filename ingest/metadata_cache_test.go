@@ -0,0 +1,256 @@
+package ingest
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/atriumn/atriumn-sdk-go/internal/clientutil"
+)
+
+// countingContentServer serves any content item with its ID and the status
+// "COMPLETED", counting GET requests per ID, and accepts PATCH and DELETE
+func countingContentServer(t *testing.T) (*httptest.Server, func(id string) int32) {
+	var mu sync.Mutex
+	gets := make(map[string]*int32)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := strings.TrimPrefix(r.URL.Path, "/content/")
+		if r.Method == http.MethodDelete {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		if r.Method == http.MethodGet {
+			mu.Lock()
+			if gets[id] == nil {
+				gets[id] = new(int32)
+			}
+			n := gets[id]
+			mu.Unlock()
+			atomic.AddInt32(n, 1)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"id":%q,"status":"COMPLETED","metadata":{"k":"v"}}`, id)
+	}))
+	count := func(id string) int32 {
+		mu.Lock()
+		defer mu.Unlock()
+		if gets[id] == nil {
+			return 0
+		}
+		return atomic.LoadInt32(gets[id])
+	}
+	return server, count
+}
+
+func newCachingClient(t *testing.T, serverURL string, clock Clock, maxEntries int) *Client {
+	client, err := NewClientWithOptions(serverURL, WithClock(clock), WithMetadataCache(time.Minute, maxEntries))
+	if err != nil {
+		t.Fatalf("NewClientWithOptions() error = %v", err)
+	}
+	return client
+}
+
+func TestClient_WithMetadataCache_HitWithinTTL(t *testing.T) {
+	server, gets := countingContentServer(t)
+	defer server.Close()
+
+	clock := clientutil.NewFakeClock(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	client := newCachingClient(t, server.URL, clock, 10)
+
+	first, err := client.GetContentItem(context.Background(), "content-1")
+	if err != nil {
+		t.Fatalf("GetContentItem() error = %v", err)
+	}
+	// Changes to a returned item must not reach the cache
+	first.Metadata["k"] = "changed"
+
+	clock.Advance(59 * time.Second)
+	second, err := client.GetContentItem(context.Background(), "content-1")
+	if err != nil {
+		t.Fatalf("GetContentItem() error = %v", err)
+	}
+	if n := gets("content-1"); n != 1 {
+		t.Errorf("server received %d requests, want 1", n)
+	}
+	if second.ID != "content-1" || second.Metadata["k"] != "v" {
+		t.Errorf("cached item = %+v, want ID content-1 with metadata k=v", second)
+	}
+}
+
+func TestClient_WithMetadataCache_ExpiresAfterTTL(t *testing.T) {
+	server, gets := countingContentServer(t)
+	defer server.Close()
+
+	clock := clientutil.NewFakeClock(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	client := newCachingClient(t, server.URL, clock, 10)
+
+	if _, err := client.GetContentItem(context.Background(), "content-1"); err != nil {
+		t.Fatalf("GetContentItem() error = %v", err)
+	}
+	clock.Advance(time.Minute)
+	if _, err := client.GetContentItem(context.Background(), "content-1"); err != nil {
+		t.Fatalf("GetContentItem() error = %v", err)
+	}
+	if n := gets("content-1"); n != 2 {
+		t.Errorf("server received %d requests, want 2", n)
+	}
+}
+
+func TestClient_WithMetadataCache_Invalidation(t *testing.T) {
+	tests := []struct {
+		name  string
+		write func(c *Client) error
+	}{
+		{"UpdateContentItem", func(c *Client) error {
+			_, err := c.UpdateContentItem(context.Background(), "content-1", &UpdateContentItemRequest{})
+			return err
+		}},
+		{"SetContentMetadata", func(c *Client) error {
+			_, err := c.SetContentMetadata(context.Background(), "content-1", map[string]string{"k": "w"}, nil)
+			return err
+		}},
+		{"DeleteContentItem", func(c *Client) error {
+			return c.DeleteContentItem(context.Background(), "content-1")
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, gets := countingContentServer(t)
+			defer server.Close()
+
+			clock := clientutil.NewFakeClock(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+			client := newCachingClient(t, server.URL, clock, 10)
+
+			for _, id := range []string{"content-1", "content-2"} {
+				if _, err := client.GetContentItem(context.Background(), id); err != nil {
+					t.Fatalf("GetContentItem(%s) error = %v", id, err)
+				}
+			}
+			if err := tt.write(client); err != nil {
+				t.Fatalf("%s error = %v", tt.name, err)
+			}
+			for _, id := range []string{"content-1", "content-2"} {
+				if _, err := client.GetContentItem(context.Background(), id); err != nil {
+					t.Fatalf("GetContentItem(%s) error = %v", id, err)
+				}
+			}
+
+			if n := gets("content-1"); n != 2 {
+				t.Errorf("server received %d requests for the changed item, want 2", n)
+			}
+			if n := gets("content-2"); n != 1 {
+				t.Errorf("server received %d requests for the other item, want 1", n)
+			}
+		})
+	}
+}
+
+func TestClient_WithMetadataCache_NoCacheAndFields(t *testing.T) {
+	server, gets := countingContentServer(t)
+	defer server.Close()
+
+	clock := clientutil.NewFakeClock(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	client := newCachingClient(t, server.URL, clock, 10)
+	ctx := context.Background()
+
+	if _, err := client.GetContentItem(ctx, "content-1"); err != nil {
+		t.Fatalf("GetContentItem() error = %v", err)
+	}
+	if _, err := client.GetContentItemWithOptions(ctx, "content-1", nil, WithNoCache()); err != nil {
+		t.Fatalf("GetContentItemWithOptions() error = %v", err)
+	}
+	if _, err := client.GetContentItem(ctx, "content-1", "id", "status"); err != nil {
+		t.Fatalf("GetContentItem() with fields error = %v", err)
+	}
+	if n := gets("content-1"); n != 3 {
+		t.Errorf("server received %d requests, want 3", n)
+	}
+
+	// The full item fetched with WithNoCache is served from the cache
+	if _, err := client.GetContentItem(ctx, "content-1"); err != nil {
+		t.Fatalf("GetContentItem() error = %v", err)
+	}
+	if n := gets("content-1"); n != 3 {
+		t.Errorf("server received %d requests, want 3", n)
+	}
+}
+
+func TestClient_WithMetadataCache_BypassedForContextToken(t *testing.T) {
+	server, gets := countingContentServer(t)
+	defer server.Close()
+
+	clock := clientutil.NewFakeClock(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	client := newCachingClient(t, server.URL, clock, 10)
+
+	// An item fetched for one user must not be served to another
+	for _, token := range []string{"user-a", "user-b"} {
+		ctx := WithAuthToken(context.Background(), token)
+		if _, err := client.GetContentItem(ctx, "content-1"); err != nil {
+			t.Fatalf("GetContentItem() error = %v", err)
+		}
+	}
+	if _, err := client.GetContentItem(context.Background(), "content-1"); err != nil {
+		t.Fatalf("GetContentItem() error = %v", err)
+	}
+	if n := gets("content-1"); n != 3 {
+		t.Errorf("server received %d requests, want 3", n)
+	}
+}
+
+func TestClient_WithMetadataCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	server, gets := countingContentServer(t)
+	defer server.Close()
+
+	clock := clientutil.NewFakeClock(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	client := newCachingClient(t, server.URL, clock, 2)
+	ctx := context.Background()
+
+	for _, id := range []string{"content-1", "content-2", "content-1", "content-3", "content-1", "content-2"} {
+		if _, err := client.GetContentItem(ctx, id); err != nil {
+			t.Fatalf("GetContentItem(%s) error = %v", id, err)
+		}
+	}
+
+	want := map[string]int32{"content-1": 1, "content-2": 2, "content-3": 1}
+	for id, n := range want {
+		if got := gets(id); got != n {
+			t.Errorf("server received %d requests for %s, want %d", got, id, n)
+		}
+	}
+}
+
+func TestClient_WithMetadataCache_ConcurrentUse(t *testing.T) {
+	server, _ := countingContentServer(t)
+	defer server.Close()
+
+	client := newCachingClient(t, server.URL, clientutil.SystemClock, 4)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			id := fmt.Sprintf("content-%d", i%6)
+			for j := 0; j < 20; j++ {
+				if _, err := client.GetContentItem(context.Background(), id); err != nil {
+					t.Errorf("GetContentItem(%s) error = %v", id, err)
+					return
+				}
+				if j%5 == 0 {
+					if _, err := client.UpdateContentItem(context.Background(), id, &UpdateContentItemRequest{}); err != nil {
+						t.Errorf("UpdateContentItem(%s) error = %v", id, err)
+						return
+					}
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+}
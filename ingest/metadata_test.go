@@ -0,0 +1,148 @@
+package ingest
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRequestFileUploadRequest_TypedMetadataJSON(t *testing.T) {
+	req := RequestFileUploadRequest{
+		Filename:    "report.pdf",
+		ContentType: "application/pdf",
+		Metadata:    map[string]string{"source": "scanner"},
+		TypedMetadata: map[string]interface{}{
+			"pages":    12,
+			"reviewed": true,
+			"author":   "ada",
+			"scanned":  time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC),
+		},
+	}
+
+	data, err := json.Marshal(req)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	var body map[string]interface{}
+	if err := json.Unmarshal(data, &body); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+
+	if got := body["metadata"].(map[string]interface{})["source"]; got != "scanner" {
+		t.Errorf("metadata.source = %v, want scanner", got)
+	}
+	typed, ok := body["typedMetadata"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("typedMetadata missing from %s", data)
+	}
+	if typed["pages"] != float64(12) || typed["reviewed"] != true || typed["author"] != "ada" || typed["scanned"] != "2026-03-01T09:30:00Z" {
+		t.Errorf("typedMetadata = %v, want pages 12, reviewed true, author ada, scanned 2026-03-01T09:30:00Z", typed)
+	}
+
+	// Without typed metadata the key is left out entirely
+	data, _ = json.Marshal(IngestTextRequest{Content: "hello"})
+	var plain map[string]interface{}
+	if err := json.Unmarshal(data, &plain); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if _, ok := plain["typedMetadata"]; ok {
+		t.Errorf("empty TypedMetadata was sent: %s", data)
+	}
+}
+
+func TestContentItem_TypedMetadataRoundTrip(t *testing.T) {
+	var sent map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodPost {
+			var body struct {
+				TypedMetadata map[string]interface{} `json:"typedMetadata"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			sent = body.TypedMetadata
+			_, _ = w.Write([]byte(`{"id":"content-1","status":"UPLOADING","uploadUrl":"https://upload.example.com"}`))
+			return
+		}
+		item := ContentItem{ID: "content-1", TypedMetadata: sent, Metadata: map[string]string{"legacy_count": "7"}}
+		_ = json.NewEncoder(w).Encode(item)
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(server.URL)
+	ctx := context.Background()
+
+	_, err := client.RequestFileUpload(ctx, &RequestFileUploadRequest{
+		Filename:    "report.pdf",
+		ContentType: "application/pdf",
+		TypedMetadata: map[string]interface{}{
+			"pages":    12,
+			"ratio":    0.5,
+			"reviewed": true,
+			"author":   "ada",
+			"scanned":  time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC),
+		},
+	})
+	if err != nil {
+		t.Fatalf("RequestFileUpload() error = %v", err)
+	}
+
+	item, err := client.GetContentItem(ctx, "content-1")
+	if err != nil {
+		t.Fatalf("GetContentItem() error = %v", err)
+	}
+
+	if n, ok := item.MetadataInt("pages"); !ok || n != 12 {
+		t.Errorf("MetadataInt(pages) = %d, %v, want 12, true", n, ok)
+	}
+	if f, ok := item.MetadataFloat("ratio"); !ok || f != 0.5 {
+		t.Errorf("MetadataFloat(ratio) = %v, %v, want 0.5, true", f, ok)
+	}
+	if b, ok := item.MetadataBool("reviewed"); !ok || !b {
+		t.Errorf("MetadataBool(reviewed) = %v, %v, want true, true", b, ok)
+	}
+	if s, ok := item.MetadataString("author"); !ok || s != "ada" {
+		t.Errorf("MetadataString(author) = %q, %v, want ada, true", s, ok)
+	}
+	if tm, ok := item.MetadataTime("scanned"); !ok || !tm.Equal(time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)) {
+		t.Errorf("MetadataTime(scanned) = %v, %v, want 2026-03-01T09:30:00Z, true", tm, ok)
+	}
+
+	// String metadata is parsed when the key has no typed value
+	if n, ok := item.MetadataInt("legacy_count"); !ok || n != 7 {
+		t.Errorf("MetadataInt(legacy_count) = %d, %v, want 7, true", n, ok)
+	}
+}
+
+func TestContentItem_MetadataTypeMismatch(t *testing.T) {
+	item := &ContentItem{
+		Metadata: map[string]string{"flag": "maybe"},
+		TypedMetadata: map[string]interface{}{
+			"ratio":  0.5,
+			"author": "ada",
+			"count":  "12",
+		},
+	}
+
+	if _, ok := item.MetadataInt("ratio"); ok {
+		t.Error("MetadataInt(ratio) reported a fraction as an integer")
+	}
+	if _, ok := item.MetadataBool("author"); ok {
+		t.Error("MetadataBool(author) reported a string as a boolean")
+	}
+	// A typed string is not reinterpreted as a number
+	if _, ok := item.MetadataInt("count"); ok {
+		t.Error("MetadataInt(count) reported a typed string as an integer")
+	}
+	if _, ok := item.MetadataString("ratio"); ok {
+		t.Error("MetadataString(ratio) reported a number as a string")
+	}
+	if _, ok := item.MetadataBool("flag"); ok {
+		t.Error("MetadataBool(flag) parsed an invalid boolean")
+	}
+	if _, ok := item.MetadataString("missing"); ok {
+		t.Error("MetadataString(missing) reported an absent key")
+	}
+}
@@ -3,6 +3,16 @@
 // through a simple, idiomatic Go interface.
 package ingest
 
+import (
+	"time"
+
+	"github.com/atriumn/atriumn-sdk-go/internal/health"
+)
+
+// HealthResponse represents the response from the health endpoint.
+// It indicates the current operational status of the Ingest service.
+type HealthResponse = health.Response
+
 // IngestTextRequest represents a request to ingest text content.
 // It contains the text content to be ingested along with optional
 // tenant ID, user ID, and metadata.
@@ -15,6 +25,10 @@ type IngestTextRequest struct {
 	Content string `json:"content"`
 	// Metadata is an optional map of key-value pairs with additional information about the content
 	Metadata map[string]string `json:"metadata,omitempty"`
+	// TypedMetadata is optional metadata whose values keep their JSON types,
+	// such as numbers, booleans, and time.Time values (sent as RFC 3339
+	// strings). It is sent alongside Metadata, which remains string-only
+	TypedMetadata map[string]interface{} `json:"typedMetadata,omitempty"`
 }
 
 // IngestURLRequest represents a request to ingest content from a URL.
@@ -55,7 +69,7 @@ type IngestFileRequest struct {
 type RequestFileUploadRequest struct {
 	// Filename is the name of the file to be uploaded (required)
 	Filename string `json:"filename"`
-	// ContentType is the MIME type of the file (required)
+	// ContentType is the MIME type of the file (required; UploadFile detects it when empty)
 	ContentType string `json:"contentType"`
 	// TenantID is an optional identifier for multi-tenant applications
 	TenantID string `json:"tenantId,omitempty"`
@@ -63,6 +77,10 @@ type RequestFileUploadRequest struct {
 	UserID string `json:"userId,omitempty"`
 	// Metadata is an optional map of key-value pairs with additional information about the file
 	Metadata map[string]string `json:"metadata,omitempty"`
+	// TypedMetadata is optional metadata whose values keep their JSON types,
+	// such as numbers, booleans, and time.Time values (sent as RFC 3339
+	// strings). It is sent alongside Metadata, which remains string-only
+	TypedMetadata map[string]interface{} `json:"typedMetadata,omitempty"`
 }
 
 // RequestFileUploadResponse defines the successful response body after requesting a file upload.
@@ -93,6 +111,10 @@ type RequestTextUploadRequest struct {
 	UserID string `json:"userId,omitempty"`
 	// Metadata is an optional map of key-value pairs with additional information
 	Metadata map[string]string `json:"metadata,omitempty"`
+	// TypedMetadata is optional metadata whose values keep their JSON types,
+	// such as numbers, booleans, and time.Time values (sent as RFC 3339
+	// strings). It is sent alongside Metadata, which remains string-only
+	TypedMetadata map[string]interface{} `json:"typedMetadata,omitempty"`
 	// CallbackURL is an optional URL to be notified when processing completes
 	CallbackURL string `json:"callbackUrl,omitempty"`
 }
@@ -160,12 +182,36 @@ type ContentItem struct {
 	Size int64 `json:"size,omitempty"`
 	// Metadata is a map of custom metadata associated with this content
 	Metadata map[string]string `json:"metadata,omitempty"`
+	// TypedMetadata is the custom metadata sent with its JSON types; read it
+	// with MetadataInt, MetadataBool, and the other Metadata methods
+	TypedMetadata map[string]interface{} `json:"typedMetadata,omitempty"`
 	// CreatedAt is the UTC timestamp when the content was created
 	CreatedAt string `json:"createdAt"`
 	// UpdatedAt is the UTC timestamp when the content was last updated
 	UpdatedAt string `json:"updatedAt"`
 }
 
+// ListContentItemsOptions represents optional filters and pagination for
+// ListContentItemsWithOptions. Zero values are omitted from the request.
+type ListContentItemsOptions struct {
+	// Status matches content items with a specific status
+	Status ContentStatus
+	// SourceType matches content items with a specific source type
+	SourceType SourceType
+	// Limit is the maximum number of items to return
+	Limit int
+	// NextToken is the pagination token from a previous list response
+	NextToken string
+	// TenantID matches content items belonging to a specific tenant
+	TenantID string
+	// UserID matches content items created by a specific user
+	UserID string
+	// CreatedAfter matches content items created after this time
+	CreatedAfter time.Time
+	// CreatedBefore matches content items created before this time
+	CreatedBefore time.Time
+}
+
 // ListContentResponse represents the response from the GET /content endpoint.
 // It contains a list of content items and an optional token for pagination.
 type ListContentResponse struct {
@@ -185,6 +231,12 @@ type IngestURLResponse struct {
 	ID string `json:"id"`
 	// Status should be PENDING/QUEUED, indicating asynchronous processing
 	Status string `json:"status"`
+	// TenantID is the tenant identifier for multi-tenant applications
+	TenantID string `json:"tenantId,omitempty"`
+	// UserID is the identifier for the user who owns this content
+	UserID string `json:"userId,omitempty"`
+	// Timestamp is when the URL was queued for ingestion
+	Timestamp string `json:"timestamp,omitempty"`
 }
 
 // DownloadURLResponse represents the response from the GET /content/{id}/download-url endpoint.
@@ -192,6 +244,13 @@ type IngestURLResponse struct {
 type DownloadURLResponse struct {
 	// DownloadURL is the pre-signed URL that can be used to download the content
 	DownloadURL string `json:"downloadUrl"`
+	// ExpiresAt is the RFC3339 time at which the URL stops working, if reported
+	ExpiresAt string `json:"expiresAt,omitempty"`
+	// ExpiresIn is the number of seconds the URL is valid for after it was issued, if reported
+	ExpiresIn int64 `json:"expiresIn,omitempty"`
+
+	// retrievedAt is when the client received the response; ExpiresIn counts from it
+	retrievedAt time.Time
 }
 
 // UpdateContentItemRequest represents the payload for updating a content item.
@@ -217,3 +276,46 @@ type UpdateTextContentRequest struct {
 	// Content is the new text content to store
 	Content string `json:"content"`
 }
+
+// PollOptions configures how WaitForProcessing polls a content item's status.
+// Delays between polls grow exponentially from Interval up to MaxInterval.
+// Zero values fall back to the defaults documented on each field.
+type PollOptions struct {
+	// Interval is the delay before the second poll (default 1s); the first poll is immediate
+	Interval time.Duration
+	// MaxInterval caps the delay between polls (default 30s)
+	MaxInterval time.Duration
+	// Multiplier is the factor by which the delay grows after each poll (default 2)
+	Multiplier float64
+	// Jitter randomizes each delay by up to this fraction (0 to 1) to spread out concurrent pollers
+	Jitter float64
+}
+
+// UploadFileOptions configures the behavior of UploadFile.
+type UploadFileOptions struct {
+	// VerifySize fetches the content item after the upload and fails with a
+	// "size_mismatch" error if its reported Size differs from the bytes sent
+	VerifySize bool
+}
+
+// UploadFileResponse describes the outcome of a completed UploadFile call.
+type UploadFileResponse struct {
+	// ContentID is the unique ID assigned to the content item
+	ContentID string
+	// Status is the status reported when the upload was requested
+	Status string
+	// BytesUploaded is the number of bytes sent to the pre-signed URL
+	BytesUploaded int64
+	// ContentItem is the content item fetched after upload, populated only when VerifySize is set
+	ContentItem *ContentItem
+}
+
+// UploadResult describes the outcome of an UploadToURLWithResult call.
+type UploadResult struct {
+	// BytesWritten is the number of bytes sent to the pre-signed URL
+	BytesWritten int64
+	// StatusCode is the HTTP status code returned by the storage service
+	StatusCode int
+	// ETag is the entity tag returned by the storage service, exactly as sent (S3 quotes it)
+	ETag string
+}
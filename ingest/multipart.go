@@ -0,0 +1,269 @@
+package ingest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/atriumn/atriumn-sdk-go/internal/clientutil"
+)
+
+const (
+	// DefaultPartSize is the default size of each part in a multipart upload (8 MiB)
+	DefaultPartSize = 8 * 1024 * 1024
+
+	// DefaultPartConcurrency is the default number of parts uploaded in parallel
+	DefaultPartConcurrency = 4
+
+	// DefaultPartRetries is the default number of times a failed part is retried
+	DefaultPartRetries = 3
+)
+
+// PartURLFunc returns the pre-signed URL to which the given part should be PUT.
+// Part numbers start at 1. It is called again for every retry so that
+// implementations can hand out a fresh URL if the previous one expired.
+type PartURLFunc func(ctx context.Context, partNumber int) (string, error)
+
+// UploadedPart describes a single part that was successfully uploaded.
+type UploadedPart struct {
+	// PartNumber is the 1-based position of the part within the upload
+	PartNumber int
+	// ETag is the entity tag returned by the storage service for the part
+	ETag string
+	// Size is the number of bytes in the part
+	Size int64
+}
+
+// MultipartUploader splits content into fixed-size parts and uploads them
+// concurrently to pre-signed part URLs. It is intended for files too large
+// for a single PUT via UploadToURL.
+//
+// The uploader does not initiate or complete the multipart upload itself;
+// callers supply the part URLs through GetPartURL and use the returned
+// UploadedPart values to complete the upload with the service.
+type MultipartUploader struct {
+	// GetPartURL provides the pre-signed URL for each part (required)
+	GetPartURL PartURLFunc
+
+	// HTTPClient is the HTTP client used to PUT parts
+	HTTPClient *http.Client
+
+	// PartSize is the size in bytes of every part except possibly the last
+	PartSize int64
+
+	// Concurrency is the maximum number of parts uploaded at the same time
+	Concurrency int
+
+	// MaxRetries is the number of additional attempts made for a failed part
+	MaxRetries int
+
+	// RetryBackoff controls the delay between attempts for a failed part
+	RetryBackoff clientutil.Backoff
+
+	// Clock waits out the retry delays; nil uses the system clock
+	Clock Clock
+
+	// ContentType is sent as the Content-Type header of every part, if set
+	ContentType string
+
+	// CompletedParts lists part numbers that were uploaded by a previous run.
+	// Their bytes are read and discarded so that an interrupted upload can resume.
+	CompletedParts []int
+}
+
+// NewMultipartUploader creates a MultipartUploader with default part size,
+// concurrency, and retry settings.
+//
+// Parameters:
+//   - getPartURL: Callback returning the pre-signed URL for each part number (required)
+//
+// Returns:
+//   - *MultipartUploader: An uploader ready to use or further customize
+func NewMultipartUploader(getPartURL PartURLFunc) *MultipartUploader {
+	return &MultipartUploader{
+		GetPartURL:  getPartURL,
+		HTTPClient:  &http.Client{Timeout: 60 * time.Second},
+		PartSize:    DefaultPartSize,
+		Concurrency: DefaultPartConcurrency,
+		MaxRetries:  DefaultPartRetries,
+	}
+}
+
+// Upload reads r to the end, uploading each part as it is read. At most
+// Concurrency parts are buffered and in flight at any time. If any part
+// fails after exhausting its retries, the remaining uploads are canceled.
+//
+// Parameters:
+//   - ctx: Context for the upload; canceling it aborts all in-flight parts
+//   - r: The content to upload (required)
+//
+// Returns:
+//   - []UploadedPart: The parts uploaded by this call, ordered by part number
+//   - error: An error if reading the content or uploading any part fails
+func (u *MultipartUploader) Upload(ctx context.Context, r io.Reader) ([]UploadedPart, error) {
+	if u.GetPartURL == nil {
+		return nil, errors.New("multipart uploader requires a GetPartURL callback")
+	}
+
+	partSize := u.PartSize
+	if partSize <= 0 {
+		partSize = DefaultPartSize
+	}
+	concurrency := u.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultPartConcurrency
+	}
+
+	skip := make(map[int]bool, len(u.CompletedParts))
+	for _, n := range u.CompletedParts {
+		skip[n] = true
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		parts    []UploadedPart
+		firstErr error
+	)
+	fail := func(err error) {
+		mu.Lock()
+		if firstErr == nil {
+			firstErr = err
+			cancel()
+		}
+		mu.Unlock()
+	}
+
+	sem := make(chan struct{}, concurrency)
+
+	for partNumber := 1; ; partNumber++ {
+		if skip[partNumber] {
+			n, err := io.CopyN(io.Discard, r, partSize)
+			if err != nil && err != io.EOF {
+				fail(fmt.Errorf("failed to skip part %d: %w", partNumber, err))
+				break
+			}
+			if n < partSize {
+				break
+			}
+			continue
+		}
+
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		buf := make([]byte, partSize)
+		n, err := io.ReadFull(r, buf)
+		if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+			<-sem
+			fail(fmt.Errorf("failed to read part %d: %w", partNumber, err))
+			break
+		}
+		if n == 0 {
+			<-sem
+			break
+		}
+
+		wg.Add(1)
+		go func(partNumber int, data []byte) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			part, err := u.uploadPart(ctx, partNumber, data)
+			if err != nil {
+				fail(err)
+				return
+			}
+			mu.Lock()
+			parts = append(parts, *part)
+			mu.Unlock()
+		}(partNumber, buf[:n])
+
+		if n < len(buf) {
+			break
+		}
+	}
+
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	sort.Slice(parts, func(i, j int) bool { return parts[i].PartNumber < parts[j].PartNumber })
+	return parts, nil
+}
+
+// uploadPart uploads a single part, retrying failed attempts with backoff.
+func (u *MultipartUploader) uploadPart(ctx context.Context, partNumber int, data []byte) (*UploadedPart, error) {
+	var lastErr error
+	for attempt := 0; attempt <= u.MaxRetries; attempt++ {
+		if attempt > 0 {
+			if err := clientutil.ClockOrSystem(u.Clock).Sleep(ctx, u.RetryBackoff.Duration(attempt-1)); err != nil {
+				return nil, err
+			}
+		}
+
+		etag, err := u.putPart(ctx, partNumber, data)
+		if err == nil {
+			return &UploadedPart{PartNumber: partNumber, ETag: etag, Size: int64(len(data))}, nil
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		lastErr = err
+	}
+
+	return nil, fmt.Errorf("failed to upload part %d after %d attempts: %w", partNumber, u.MaxRetries+1, lastErr)
+}
+
+// putPart performs a single PUT of part data and returns the part's ETag.
+func (u *MultipartUploader) putPart(ctx context.Context, partNumber int, data []byte) (string, error) {
+	partURL, err := u.GetPartURL(ctx, partNumber)
+	if err != nil {
+		return "", fmt.Errorf("failed to get URL for part %d: %w", partNumber, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "PUT", partURL, bytes.NewReader(data))
+	if err != nil {
+		return "", fmt.Errorf("failed to create upload request: %w", err)
+	}
+	if u.ContentType != "" {
+		req.Header.Set("Content-Type", u.ContentType)
+	}
+
+	httpClient := u.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to upload to URL: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return "", fmt.Errorf("upload failed with status %d: %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	return resp.Header.Get("ETag"), nil
+}
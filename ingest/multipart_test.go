@@ -0,0 +1,231 @@
+package ingest
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/atriumn/atriumn-sdk-go/internal/clientutil"
+)
+
+// partServer records the body of every part PUT to /parts/{n}
+type partServer struct {
+	*httptest.Server
+	mu       sync.Mutex
+	bodies   map[int]string
+	attempts map[int]int
+}
+
+func newPartServer(t *testing.T, handle func(partNumber, attempt int, w http.ResponseWriter) bool) *partServer {
+	ps := &partServer{bodies: map[int]string{}, attempts: map[int]int{}}
+	ps.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" {
+			t.Errorf("part upload method = %q, want PUT", r.Method)
+		}
+		partNumber, err := strconv.Atoi(strings.TrimPrefix(r.URL.Path, "/parts/"))
+		if err != nil {
+			t.Errorf("unexpected part path %q", r.URL.Path)
+		}
+		body, _ := io.ReadAll(r.Body)
+
+		ps.mu.Lock()
+		ps.attempts[partNumber]++
+		attempt := ps.attempts[partNumber]
+		ps.mu.Unlock()
+
+		if handle != nil && !handle(partNumber, attempt, w) {
+			return
+		}
+
+		ps.mu.Lock()
+		ps.bodies[partNumber] = string(body)
+		ps.mu.Unlock()
+		w.Header().Set("ETag", fmt.Sprintf(`"etag-%d"`, partNumber))
+		w.WriteHeader(http.StatusOK)
+	}))
+	return ps
+}
+
+func (ps *partServer) partURL(ctx context.Context, partNumber int) (string, error) {
+	return fmt.Sprintf("%s/parts/%d", ps.URL, partNumber), nil
+}
+
+func TestMultipartUploader_PartBoundaries(t *testing.T) {
+	server := newPartServer(t, nil)
+	defer server.Close()
+
+	uploader := NewMultipartUploader(server.partURL)
+	uploader.PartSize = 4
+
+	parts, err := uploader.Upload(context.Background(), strings.NewReader("abcdefghij"))
+	if err != nil {
+		t.Fatalf("Upload returned unexpected error: %v", err)
+	}
+
+	wantBodies := map[int]string{1: "abcd", 2: "efgh", 3: "ij"}
+	if len(parts) != len(wantBodies) {
+		t.Fatalf("Upload returned %d parts, want %d", len(parts), len(wantBodies))
+	}
+	for i, part := range parts {
+		if part.PartNumber != i+1 {
+			t.Errorf("parts[%d].PartNumber = %d, want %d", i, part.PartNumber, i+1)
+		}
+		if part.Size != int64(len(wantBodies[part.PartNumber])) {
+			t.Errorf("parts[%d].Size = %d, want %d", i, part.Size, len(wantBodies[part.PartNumber]))
+		}
+		if part.ETag != fmt.Sprintf(`"etag-%d"`, part.PartNumber) {
+			t.Errorf("parts[%d].ETag = %q", i, part.ETag)
+		}
+		if server.bodies[part.PartNumber] != wantBodies[part.PartNumber] {
+			t.Errorf("part %d body = %q, want %q", part.PartNumber, server.bodies[part.PartNumber], wantBodies[part.PartNumber])
+		}
+	}
+}
+
+func TestMultipartUploader_ExactMultipleOfPartSize(t *testing.T) {
+	server := newPartServer(t, nil)
+	defer server.Close()
+
+	uploader := NewMultipartUploader(server.partURL)
+	uploader.PartSize = 5
+
+	parts, err := uploader.Upload(context.Background(), strings.NewReader("0123456789"))
+	if err != nil {
+		t.Fatalf("Upload returned unexpected error: %v", err)
+	}
+	if len(parts) != 2 {
+		t.Errorf("Upload returned %d parts, want 2", len(parts))
+	}
+}
+
+func TestMultipartUploader_ConcurrencyLimit(t *testing.T) {
+	var inFlight, maxInFlight int32
+	server := newPartServer(t, func(partNumber, attempt int, w http.ResponseWriter) bool {
+		current := atomic.AddInt32(&inFlight, 1)
+		for {
+			seen := atomic.LoadInt32(&maxInFlight)
+			if current <= seen || atomic.CompareAndSwapInt32(&maxInFlight, seen, current) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		atomic.AddInt32(&inFlight, -1)
+		return true
+	})
+	defer server.Close()
+
+	uploader := NewMultipartUploader(server.partURL)
+	uploader.PartSize = 1
+	uploader.Concurrency = 2
+
+	parts, err := uploader.Upload(context.Background(), bytes.NewReader(make([]byte, 8)))
+	if err != nil {
+		t.Fatalf("Upload returned unexpected error: %v", err)
+	}
+	if len(parts) != 8 {
+		t.Errorf("Upload returned %d parts, want 8", len(parts))
+	}
+	if got := atomic.LoadInt32(&maxInFlight); got > 2 {
+		t.Errorf("max in-flight parts = %d, want at most 2", got)
+	}
+}
+
+func TestMultipartUploader_RetriesFailedPart(t *testing.T) {
+	server := newPartServer(t, func(partNumber, attempt int, w http.ResponseWriter) bool {
+		if partNumber == 2 && attempt == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return false
+		}
+		return true
+	})
+	defer server.Close()
+
+	uploader := NewMultipartUploader(server.partURL)
+	uploader.PartSize = 3
+	uploader.RetryBackoff = clientutil.Backoff{Initial: time.Millisecond}
+
+	parts, err := uploader.Upload(context.Background(), strings.NewReader("aaabbbccc"))
+	if err != nil {
+		t.Fatalf("Upload returned unexpected error: %v", err)
+	}
+	if len(parts) != 3 {
+		t.Fatalf("Upload returned %d parts, want 3", len(parts))
+	}
+	if server.attempts[2] != 2 {
+		t.Errorf("part 2 attempts = %d, want 2", server.attempts[2])
+	}
+	if server.attempts[1] != 1 || server.attempts[3] != 1 {
+		t.Errorf("unexpected attempts for healthy parts: %v", server.attempts)
+	}
+	if server.bodies[2] != "bbb" {
+		t.Errorf("part 2 body = %q, want %q", server.bodies[2], "bbb")
+	}
+}
+
+func TestMultipartUploader_RetriesExhausted(t *testing.T) {
+	server := newPartServer(t, func(partNumber, attempt int, w http.ResponseWriter) bool {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return false
+	})
+	defer server.Close()
+
+	uploader := NewMultipartUploader(server.partURL)
+	uploader.PartSize = 3
+	uploader.MaxRetries = 2
+	uploader.RetryBackoff = clientutil.Backoff{Initial: time.Second, Multiplier: 2}
+	clock := clientutil.NewFakeClock(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	uploader.Clock = clock
+
+	_, err := uploader.Upload(context.Background(), strings.NewReader("abc"))
+	if err == nil {
+		t.Fatal("Upload should fail when a part never succeeds")
+	}
+	if !strings.Contains(err.Error(), "after 3 attempts") {
+		t.Errorf("Upload error = %v, want attempt count", err)
+	}
+	if server.attempts[1] != 3 {
+		t.Errorf("part 1 attempts = %d, want 3", server.attempts[1])
+	}
+	if delays := clock.Sleeps(); len(delays) != 2 || delays[0] != time.Second || delays[1] != 2*time.Second {
+		t.Errorf("retry delays = %v, want [1s 2s]", delays)
+	}
+}
+
+func TestMultipartUploader_ResumeSkipsCompletedParts(t *testing.T) {
+	server := newPartServer(t, nil)
+	defer server.Close()
+
+	uploader := NewMultipartUploader(server.partURL)
+	uploader.PartSize = 2
+	uploader.CompletedParts = []int{1, 3}
+
+	parts, err := uploader.Upload(context.Background(), strings.NewReader("aabbccdd"))
+	if err != nil {
+		t.Fatalf("Upload returned unexpected error: %v", err)
+	}
+	if len(parts) != 2 || parts[0].PartNumber != 2 || parts[1].PartNumber != 4 {
+		t.Fatalf("Upload returned parts %+v, want parts 2 and 4", parts)
+	}
+	if server.bodies[2] != "bb" || server.bodies[4] != "dd" {
+		t.Errorf("unexpected part bodies: %v", server.bodies)
+	}
+	if _, ok := server.attempts[1]; ok {
+		t.Error("completed part 1 was uploaded again")
+	}
+}
+
+func TestMultipartUploader_GetPartURLRequired(t *testing.T) {
+	uploader := NewMultipartUploader(nil)
+	if _, err := uploader.Upload(context.Background(), strings.NewReader("abc")); err == nil {
+		t.Error("Upload without GetPartURL should return error")
+	}
+}
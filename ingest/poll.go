@@ -0,0 +1,64 @@
+package ingest
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/atriumn/atriumn-sdk-go/internal/clientutil"
+)
+
+const (
+	// DefaultPollInterval is the delay before the second status poll in WaitForProcessing
+	DefaultPollInterval = 1 * time.Second
+
+	// DefaultPollMaxInterval caps the delay between status polls in WaitForProcessing
+	DefaultPollMaxInterval = 30 * time.Second
+)
+
+// WaitForProcessing polls a content item until it reaches a terminal status
+// ("COMPLETED" or "FAILED") or the context is done. The first poll happens
+// immediately; subsequent polls back off exponentially as configured by opts.
+//
+// Parameters:
+//   - ctx: Context for the polling loop; use a deadline to bound the total wait
+//   - id: The unique identifier of the content item to wait for (required)
+//   - opts: Optional PollOptions controlling the delay between polls (nil uses defaults)
+//
+// Returns:
+//   - *ContentItem: The content item in its terminal state
+//   - error: An error if polling fails, which can be:
+//   - apierror.ErrorResponse returned by GetContentItem
+//   - the context error if ctx is canceled or its deadline passes
+func (c *Client) WaitForProcessing(ctx context.Context, id string, opts *PollOptions) (*ContentItem, error) {
+	if opts == nil {
+		opts = &PollOptions{}
+	}
+
+	backoff := clientutil.Backoff{
+		Initial:    opts.Interval,
+		Max:        opts.MaxInterval,
+		Multiplier: opts.Multiplier,
+		Jitter:     opts.Jitter,
+	}
+	if backoff.Initial <= 0 {
+		backoff.Initial = DefaultPollInterval
+	}
+	if backoff.Max <= 0 {
+		backoff.Max = DefaultPollMaxInterval
+	}
+
+	for attempt := 0; ; attempt++ {
+		item, err := c.GetContentItemWithOptions(ctx, id, nil, WithNoCache())
+		if err != nil {
+			return nil, err
+		}
+		if ContentStatus(item.Status).IsTerminal() {
+			return item, nil
+		}
+
+		if err := clientutil.ClockOrSystem(c.base.Clock).Sleep(ctx, backoff.Duration(attempt)); err != nil {
+			return nil, fmt.Errorf("waiting for content item %s: %w", id, err)
+		}
+	}
+}
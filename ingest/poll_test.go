@@ -0,0 +1,135 @@
+package ingest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/atriumn/atriumn-sdk-go/internal/clientutil"
+)
+
+// cancelingClock cancels the context of the call that first sleeps on it
+type cancelingClock struct {
+	clientutil.Clock
+	cancel context.CancelFunc
+}
+
+func (c cancelingClock) Sleep(ctx context.Context, d time.Duration) error {
+	c.cancel()
+	return ctx.Err()
+}
+
+// statusSequenceServer serves the given statuses for successive GET /content/{id} calls
+func statusSequenceServer(t *testing.T, statuses []string, calls *int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/content/content-123" {
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
+		status := statuses[len(statuses)-1]
+		if *calls < len(statuses) {
+			status = statuses[*calls]
+		}
+		*calls++
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"id":"content-123","status":%q}`, status)
+	}))
+}
+
+func TestClient_WaitForProcessing(t *testing.T) {
+	calls := 0
+	server := statusSequenceServer(t, []string{"PENDING", "PROCESSING", "PROCESSING", "PROCESSING", "PROCESSING", "COMPLETED"}, &calls)
+	defer server.Close()
+
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := clientutil.NewFakeClock(start)
+	client, _ := NewClientWithOptions(server.URL, WithClock(clock))
+
+	item, err := client.WaitForProcessing(context.Background(), "content-123", &PollOptions{
+		Interval:    100 * time.Millisecond,
+		MaxInterval: 500 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("WaitForProcessing returned unexpected error: %v", err)
+	}
+	if item.Status != "COMPLETED" {
+		t.Errorf("WaitForProcessing Status = %q, want %q", item.Status, "COMPLETED")
+	}
+	if calls != 6 {
+		t.Errorf("WaitForProcessing polled %d times, want 6", calls)
+	}
+
+	want := []time.Duration{
+		100 * time.Millisecond,
+		200 * time.Millisecond,
+		400 * time.Millisecond,
+		500 * time.Millisecond,
+		500 * time.Millisecond,
+	}
+	delays := clock.Sleeps()
+	if len(delays) != len(want) {
+		t.Fatalf("WaitForProcessing slept %d times, want %d", len(delays), len(want))
+	}
+	for i := range want {
+		if delays[i] != want[i] {
+			t.Errorf("delay[%d] = %v, want %v", i, delays[i], want[i])
+		}
+	}
+	if elapsed := clock.Now().Sub(start); elapsed != 1700*time.Millisecond {
+		t.Errorf("WaitForProcessing advanced the clock by %v, want 1.7s", elapsed)
+	}
+}
+
+func TestClient_WaitForProcessing_FirstPollImmediate(t *testing.T) {
+	calls := 0
+	server := statusSequenceServer(t, []string{"FAILED"}, &calls)
+	defer server.Close()
+
+	clock := clientutil.NewFakeClock(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	client, _ := NewClientWithOptions(server.URL, WithClock(clock))
+
+	item, err := client.WaitForProcessing(context.Background(), "content-123", nil)
+	if err != nil {
+		t.Fatalf("WaitForProcessing returned unexpected error: %v", err)
+	}
+	if item.Status != "FAILED" {
+		t.Errorf("WaitForProcessing Status = %q, want %q", item.Status, "FAILED")
+	}
+	if delays := clock.Sleeps(); len(delays) != 0 {
+		t.Errorf("WaitForProcessing slept before first poll: %v", delays)
+	}
+}
+
+func TestClient_WaitForProcessing_NilClock(t *testing.T) {
+	calls := 0
+	server := statusSequenceServer(t, []string{"PENDING", "COMPLETED"}, &calls)
+	defer server.Close()
+
+	client, _ := NewClient(server.URL)
+	client.base.Clock = nil
+
+	item, err := client.WaitForProcessing(context.Background(), "content-123", &PollOptions{Interval: time.Millisecond})
+	if err != nil {
+		t.Fatalf("WaitForProcessing returned unexpected error: %v", err)
+	}
+	if item.Status != "COMPLETED" {
+		t.Errorf("WaitForProcessing Status = %q, want %q", item.Status, "COMPLETED")
+	}
+}
+
+func TestClient_WaitForProcessing_ContextCanceled(t *testing.T) {
+	calls := 0
+	server := statusSequenceServer(t, []string{"PROCESSING"}, &calls)
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	client, _ := NewClientWithOptions(server.URL, WithClock(cancelingClock{Clock: clientutil.SystemClock, cancel: cancel}))
+
+	_, err := client.WaitForProcessing(ctx, "content-123", nil)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("WaitForProcessing error = %v, want context.Canceled", err)
+	}
+}
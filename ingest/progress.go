@@ -0,0 +1,145 @@
+package ingest
+
+import "io"
+
+// ProgressFunc receives transfer progress updates. bytesTransferred is the
+// running total so far; totalBytes is the expected size, or -1 if unknown.
+type ProgressFunc func(bytesTransferred, totalBytes int64)
+
+// ProgressReader wraps an io.Reader and reports progress after every chunk
+// read, plus once more when the underlying reader reaches io.EOF.
+type ProgressReader struct {
+	// Reader is the underlying source of data
+	Reader io.Reader
+	// Total is the expected number of bytes, or -1 if unknown
+	Total int64
+	// OnProgress is called with the running byte count
+	OnProgress ProgressFunc
+
+	transferred int64
+	completed   bool
+}
+
+// NewProgressReader creates a ProgressReader reporting to fn. If total is
+// negative, the size is inferred from r where possible (e.g. an *os.File).
+func NewProgressReader(r io.Reader, total int64, fn ProgressFunc) *ProgressReader {
+	if total < 0 {
+		total = contentLength(r)
+	}
+	return &ProgressReader{Reader: r, Total: total, OnProgress: fn}
+}
+
+// Read reads from the underlying reader and reports progress.
+func (pr *ProgressReader) Read(p []byte) (int, error) {
+	n, err := pr.Reader.Read(p)
+	if n > 0 {
+		pr.transferred += int64(n)
+		pr.report()
+	}
+	if err == io.EOF && !pr.completed {
+		pr.completed = true
+		pr.report()
+	}
+	return n, err
+}
+
+// BytesTransferred returns the number of bytes read so far.
+func (pr *ProgressReader) BytesTransferred() int64 {
+	return pr.transferred
+}
+
+func (pr *ProgressReader) report() {
+	if pr.OnProgress != nil {
+		pr.OnProgress(pr.transferred, pr.Total)
+	}
+}
+
+// ProgressWriter wraps an io.Writer and reports progress after every chunk
+// written. Because a writer cannot detect the end of a transfer, callers
+// should invoke Complete once all data has been written.
+type ProgressWriter struct {
+	// Writer is the underlying destination of data
+	Writer io.Writer
+	// Total is the expected number of bytes, or -1 if unknown
+	Total int64
+	// OnProgress is called with the running byte count
+	OnProgress ProgressFunc
+
+	transferred int64
+}
+
+// NewProgressWriter creates a ProgressWriter reporting to fn.
+func NewProgressWriter(w io.Writer, total int64, fn ProgressFunc) *ProgressWriter {
+	return &ProgressWriter{Writer: w, Total: total, OnProgress: fn}
+}
+
+// Write writes to the underlying writer and reports progress.
+func (pw *ProgressWriter) Write(p []byte) (int, error) {
+	n, err := pw.Writer.Write(p)
+	if n > 0 {
+		pw.transferred += int64(n)
+		if pw.OnProgress != nil {
+			pw.OnProgress(pw.transferred, pw.Total)
+		}
+	}
+	return n, err
+}
+
+// Complete reports the final byte count once the transfer has finished.
+func (pw *ProgressWriter) Complete() {
+	if pw.OnProgress != nil {
+		pw.OnProgress(pw.transferred, pw.Total)
+	}
+}
+
+// BytesTransferred returns the number of bytes written so far.
+func (pw *ProgressWriter) BytesTransferred() int64 {
+	return pw.transferred
+}
+
+// TransferOption configures a single upload or download call.
+type TransferOption func(*transferOptions)
+
+// transferOptions holds the settings applied by TransferOption functions.
+type transferOptions struct {
+	progress  ProgressFunc
+	totalSize int64
+	checksums []uploadChecksum
+}
+
+// newTransferOptions applies opts over the defaults.
+func newTransferOptions(opts []TransferOption) *transferOptions {
+	o := &transferOptions{totalSize: -1}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// WithProgress reports transfer progress to fn periodically and once at completion.
+//
+// Parameters:
+//   - fn: Callback receiving the bytes transferred so far and the total size (-1 if unknown)
+//
+// Returns:
+//   - TransferOption: An option for UploadToURL or DownloadContent
+func WithProgress(fn ProgressFunc) TransferOption {
+	return func(o *transferOptions) {
+		o.progress = fn
+	}
+}
+
+// WithTotalSize provides the size of the content when it cannot be inferred
+// from the reader. It is used for progress reporting and, for uploads, as the
+// request Content-Length.
+//
+// Parameters:
+//   - size: The total number of bytes to be transferred
+//
+// Returns:
+//   - TransferOption: An option for UploadToURL or DownloadContent
+func WithTotalSize(size int64) TransferOption {
+	return func(o *transferOptions) {
+		o.totalSize = size
+	}
+}
@@ -0,0 +1,228 @@
+package ingest
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// progressRecorder collects progress callbacks
+type progressRecorder struct {
+	transferred []int64
+	totals      []int64
+}
+
+func (p *progressRecorder) record(transferred, total int64) {
+	p.transferred = append(p.transferred, transferred)
+	p.totals = append(p.totals, total)
+}
+
+// assertProgress checks the callbacks grow monotonically and finish at want
+func (p *progressRecorder) assertProgress(t *testing.T, want int64) {
+	t.Helper()
+	if len(p.transferred) < 2 {
+		t.Fatalf("expected several progress callbacks, got %v", p.transferred)
+	}
+	for i := 1; i < len(p.transferred); i++ {
+		if p.transferred[i] < p.transferred[i-1] {
+			t.Errorf("progress went backwards: %v", p.transferred)
+		}
+	}
+	if last := p.transferred[len(p.transferred)-1]; last != want {
+		t.Errorf("final progress = %d, want %d", last, want)
+	}
+}
+
+// smallChunkReader returns at most n bytes per Read to force several callbacks
+type smallChunkReader struct {
+	r io.Reader
+	n int
+}
+
+func (s *smallChunkReader) Read(p []byte) (int, error) {
+	if len(p) > s.n {
+		p = p[:s.n]
+	}
+	return s.r.Read(p)
+}
+
+func TestProgressReader(t *testing.T) {
+	rec := &progressRecorder{}
+	pr := NewProgressReader(&smallChunkReader{r: strings.NewReader("0123456789"), n: 3}, 10, rec.record)
+
+	data, err := io.ReadAll(pr)
+	if err != nil {
+		t.Fatalf("ReadAll returned unexpected error: %v", err)
+	}
+	if string(data) != "0123456789" {
+		t.Errorf("ReadAll = %q", data)
+	}
+
+	rec.assertProgress(t, 10)
+	want := []int64{3, 6, 9, 10, 10}
+	if fmt.Sprint(rec.transferred) != fmt.Sprint(want) {
+		t.Errorf("progress = %v, want %v", rec.transferred, want)
+	}
+	for _, total := range rec.totals {
+		if total != 10 {
+			t.Errorf("total = %d, want 10", total)
+		}
+	}
+}
+
+func TestProgressWriter(t *testing.T) {
+	rec := &progressRecorder{}
+	var buf bytes.Buffer
+	pw := NewProgressWriter(&buf, -1, rec.record)
+
+	for _, chunk := range []string{"abc", "def", "g"} {
+		if _, err := pw.Write([]byte(chunk)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	pw.Complete()
+
+	rec.assertProgress(t, 7)
+	if pw.BytesTransferred() != 7 || buf.String() != "abcdefg" {
+		t.Errorf("ProgressWriter wrote %d bytes %q", pw.BytesTransferred(), buf.String())
+	}
+}
+
+func TestClient_UploadToURL_WithProgress(t *testing.T) {
+	payload := strings.Repeat("x", 64*1024)
+	path := filepath.Join(t.TempDir(), "payload.txt")
+	if err := os.WriteFile(path, []byte(payload), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ContentLength != int64(len(payload)) {
+			t.Errorf("Content-Length = %d, want %d", r.ContentLength, len(payload))
+		}
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != payload {
+			t.Errorf("uploaded %d bytes, want %d", len(body), len(payload))
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = file.Close() }()
+
+	client, _ := NewClient("http://api.example.com")
+	rec := &progressRecorder{}
+	resp, err := client.UploadToURL(context.Background(), server.URL, "text/plain", file, WithProgress(rec.record))
+	if err != nil {
+		t.Fatalf("UploadToURL returned unexpected error: %v", err)
+	}
+	_ = resp.Body.Close()
+
+	rec.assertProgress(t, int64(len(payload)))
+	if rec.totals[0] != int64(len(payload)) {
+		t.Errorf("total = %d, want size inferred from file", rec.totals[0])
+	}
+}
+
+func TestClient_UploadToURL_WithTotalSize(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ContentLength != 5 {
+			t.Errorf("Content-Length = %d, want 5", r.ContentLength)
+		}
+		_, _ = io.Copy(io.Discard, r.Body)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client, _ := NewClient("http://api.example.com")
+	rec := &progressRecorder{}
+	// io.MultiReader hides the size, so it must come from WithTotalSize
+	body := io.MultiReader(strings.NewReader("ab"), strings.NewReader("cde"))
+	resp, err := client.UploadToURL(context.Background(), server.URL, "text/plain", body, WithTotalSize(5), WithProgress(rec.record))
+	if err != nil {
+		t.Fatalf("UploadToURL returned unexpected error: %v", err)
+	}
+	_ = resp.Body.Close()
+
+	rec.assertProgress(t, 5)
+	if rec.totals[0] != 5 {
+		t.Errorf("total = %d, want 5", rec.totals[0])
+	}
+}
+
+func TestClient_DownloadContent(t *testing.T) {
+	payload := strings.Repeat("y", 100*1024)
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/content/content-123/download-url":
+			if r.Header.Get("Authorization") != "Bearer test-token" {
+				t.Errorf("download-url request missing Authorization header")
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = fmt.Fprintf(w, `{"downloadUrl":"%s/object"}`, server.URL)
+		case "/object":
+			if r.Header.Get("Authorization") != "" {
+				t.Errorf("pre-signed download should not carry Authorization header")
+			}
+			w.Header().Set("Content-Length", fmt.Sprint(len(payload)))
+			_, _ = w.Write([]byte(payload))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, _ := NewClientWithOptions(server.URL, WithTokenProvider(&MockTokenProvider{token: "test-token"}))
+	rec := &progressRecorder{}
+	var buf bytes.Buffer
+	n, err := client.DownloadContent(context.Background(), "content-123", &buf, WithProgress(rec.record))
+	if err != nil {
+		t.Fatalf("DownloadContent returned unexpected error: %v", err)
+	}
+	if n != int64(len(payload)) || buf.String() != payload {
+		t.Errorf("DownloadContent wrote %d bytes, want %d", n, len(payload))
+	}
+
+	rec.assertProgress(t, int64(len(payload)))
+	if rec.totals[0] != int64(len(payload)) {
+		t.Errorf("total = %d, want Content-Length", rec.totals[0])
+	}
+}
+
+func TestClient_DownloadContent_Errors(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/content/missing/download-url":
+			w.WriteHeader(http.StatusNotFound)
+		case "/content/expired/download-url":
+			_, _ = fmt.Fprintf(w, `{"downloadUrl":"%s/object"}`, server.URL)
+		default:
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte("Request has expired"))
+		}
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(server.URL)
+
+	if _, err := client.DownloadContent(context.Background(), "missing", io.Discard); err == nil {
+		t.Error("DownloadContent should fail when the download URL cannot be obtained")
+	}
+
+	_, err := client.DownloadContent(context.Background(), "expired", io.Discard)
+	if err == nil || !strings.Contains(err.Error(), "403") {
+		t.Errorf("DownloadContent error = %v, want status 403", err)
+	}
+}
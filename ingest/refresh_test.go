@@ -0,0 +1,128 @@
+package ingest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+// rotatingTokenProvider hands out a stale token until it is invalidated
+type rotatingTokenProvider struct {
+	mu          sync.Mutex
+	token       string
+	invalidated int
+}
+
+func (p *rotatingTokenProvider) GetToken(ctx context.Context) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.token, nil
+}
+
+func (p *rotatingTokenProvider) Invalidate() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.invalidated++
+	p.token = "valid-token"
+}
+
+// tokenCheckingServer accepts only "Bearer valid-token" and records the tokens it sees
+func tokenCheckingServer(t *testing.T, tokens *[]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth := r.Header.Get("Authorization")
+		*tokens = append(*tokens, auth)
+		if auth != "Bearer valid-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"content-123","status":"COMPLETED"}`))
+	}))
+}
+
+func TestClient_WithRefreshOn401(t *testing.T) {
+	var tokens []string
+	server := tokenCheckingServer(t, &tokens)
+	defer server.Close()
+
+	provider := &rotatingTokenProvider{token: "stale-token"}
+	client, _ := NewClientWithOptions(server.URL, WithTokenProvider(provider), WithRefreshOn401())
+
+	item, err := client.GetContentItem(context.Background(), "content-123")
+	if err != nil {
+		t.Fatalf("GetContentItem returned unexpected error: %v", err)
+	}
+	if item.ID != "content-123" {
+		t.Errorf("GetContentItem ID = %q, want %q", item.ID, "content-123")
+	}
+	if len(tokens) != 2 || tokens[0] != "Bearer stale-token" || tokens[1] != "Bearer valid-token" {
+		t.Errorf("server saw tokens %q, want the stale token and then the valid one", tokens)
+	}
+	if provider.invalidated != 1 {
+		t.Errorf("Invalidate called %d times, want 1", provider.invalidated)
+	}
+}
+
+func TestClient_WithRefreshOn401_ReplaysBody(t *testing.T) {
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req IngestURLRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		bodies = append(bodies, req.URL)
+		if r.Header.Get("Authorization") != "Bearer valid-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"content-123","status":"QUEUED"}`))
+	}))
+	defer server.Close()
+
+	provider := &rotatingTokenProvider{token: "stale-token"}
+	client, _ := NewClientWithOptions(server.URL, WithTokenProvider(provider), WithRefreshOn401())
+
+	if _, err := client.IngestURL(context.Background(), &IngestURLRequest{URL: "https://example.com"}); err != nil {
+		t.Fatalf("IngestURL returned unexpected error: %v", err)
+	}
+	if len(bodies) != 2 || bodies[0] != "https://example.com" || bodies[1] != "https://example.com" {
+		t.Errorf("server saw bodies with URLs %q, want the same body twice", bodies)
+	}
+}
+
+func TestClient_WithRefreshOn401_SecondUnauthorized(t *testing.T) {
+	var tokens []string
+	server := tokenCheckingServer(t, &tokens)
+	defer server.Close()
+
+	// A provider without Invalidate keeps returning the rejected token
+	client, _ := NewClientWithOptions(server.URL, WithTokenProvider(&MockTokenProvider{token: "revoked-token"}), WithRefreshOn401())
+
+	_, err := client.GetContentItem(context.Background(), "content-123")
+	var apiErr *ErrorResponse
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("GetContentItem error = %v, want a 401 error", err)
+	}
+	if len(tokens) != 2 {
+		t.Errorf("server received %d requests, want exactly 2", len(tokens))
+	}
+}
+
+func TestClient_WithoutRefreshOn401(t *testing.T) {
+	var tokens []string
+	server := tokenCheckingServer(t, &tokens)
+	defer server.Close()
+
+	provider := &rotatingTokenProvider{token: "stale-token"}
+	client, _ := NewClientWithOptions(server.URL, WithTokenProvider(provider))
+
+	if _, err := client.GetContentItem(context.Background(), "content-123"); err == nil {
+		t.Fatal("GetContentItem should fail without WithRefreshOn401")
+	}
+	if len(tokens) != 1 || provider.invalidated != 0 {
+		t.Errorf("server received %d requests and Invalidate was called %d times, want 1 and 0", len(tokens), provider.invalidated)
+	}
+}
@@ -0,0 +1,165 @@
+package ingest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/atriumn/atriumn-sdk-go/internal/clientutil"
+)
+
+// flakyContentServer answers the first request for a content item with a 200
+// response carrying an application error, and later requests with the item
+func flakyContentServer(t *testing.T, calls *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if atomic.AddInt32(calls, 1) == 1 {
+			_, _ = w.Write([]byte(`{"error":"temporary_processing_failure"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":"content-123","status":"COMPLETED"}`))
+	}))
+}
+
+// retryOnTemporaryFailure retries responses whose body reports a temporary failure
+func retryOnTemporaryFailure(resp *http.Response, err error) bool {
+	if err != nil {
+		return false
+	}
+	var body struct {
+		Error string `json:"error"`
+	}
+	return json.NewDecoder(resp.Body).Decode(&body) == nil && body.Error == "temporary_processing_failure"
+}
+
+func TestClient_WithRetryPolicy_CustomPredicate(t *testing.T) {
+	var calls int32
+	server := flakyContentServer(t, &calls)
+	defer server.Close()
+
+	clock := clientutil.NewFakeClock(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	client, _ := NewClientWithOptions(server.URL,
+		WithClock(clock),
+		WithRetryPolicy(RetryPolicy{
+			MaxRetries: 2,
+			Backoff:    Backoff{Initial: 500 * time.Millisecond},
+			Retryable:  retryOnTemporaryFailure,
+		}),
+	)
+
+	item, err := client.GetContentItem(context.Background(), "content-123")
+	if err != nil {
+		t.Fatalf("GetContentItem returned unexpected error: %v", err)
+	}
+	if item.ID != "content-123" || item.Status != "COMPLETED" {
+		t.Errorf("GetContentItem = %+v, want the item from the retried request", item)
+	}
+	if got := atomic.LoadInt32(&calls); got != 2 {
+		t.Errorf("server received %d requests, want 2", got)
+	}
+	if sleeps := clock.Sleeps(); len(sleeps) != 1 || sleeps[0] != 500*time.Millisecond {
+		t.Errorf("clock sleeps = %v, want [500ms]", sleeps)
+	}
+}
+
+func TestClient_WithRetryPolicy_DefaultIgnoresApplicationErrors(t *testing.T) {
+	var calls int32
+	server := flakyContentServer(t, &calls)
+	defer server.Close()
+
+	clock := clientutil.NewFakeClock(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	client, _ := NewClientWithOptions(server.URL, WithClock(clock), WithRetryPolicy(RetryPolicy{MaxRetries: 2}))
+
+	item, err := client.GetContentItem(context.Background(), "content-123")
+	if err != nil {
+		t.Fatalf("GetContentItem returned unexpected error: %v", err)
+	}
+	if item.ID != "" {
+		t.Errorf("GetContentItem ID = %q, want the empty item from the first response", item.ID)
+	}
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Errorf("server received %d requests, want 1", got)
+	}
+}
+
+// replayServer answers the first request with a 503 and later requests with
+// an accepted ingest response, recording the body of every request it receives
+func replayServer(t *testing.T, bodies *[][]byte) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("failed to read request body: %v", err)
+		}
+		*bodies = append(*bodies, body)
+		if len(*bodies) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte(`{"id":"content-1","contentId":"content-1","uploadUrl":"https://example.com/upload","status":"PENDING"}`))
+	}))
+}
+
+func TestClient_WithRetryPolicy_ReplaysRequestBody(t *testing.T) {
+	content := strings.Repeat("retry me ", CompressionThreshold)
+	calls := map[string]func(c *Client) error{
+		"IngestURL": func(c *Client) error {
+			_, err := c.IngestURL(context.Background(), &IngestURLRequest{URL: "https://example.com"})
+			return err
+		},
+		"RequestFileUpload": func(c *Client) error {
+			_, err := c.RequestFileUpload(context.Background(), &RequestFileUploadRequest{Filename: "a.txt", ContentType: "text/plain"})
+			return err
+		},
+		"IngestText compressed": func(c *Client) error {
+			_, err := c.IngestText(context.Background(), &IngestTextRequest{Content: content})
+			return err
+		},
+		"IngestFile": func(c *Client) error {
+			_, err := c.IngestFile(context.Background(), "tenant-1", "a.txt", "text/plain", "user-1", strings.NewReader(content))
+			return err
+		},
+	}
+
+	for name, call := range calls {
+		t.Run(name, func(t *testing.T) {
+			var bodies [][]byte
+			server := replayServer(t, &bodies)
+			defer server.Close()
+
+			clock := clientutil.NewFakeClock(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+			client, _ := NewClientWithOptions(server.URL,
+				WithClock(clock),
+				WithRequestCompression(),
+				WithRetryPolicy(RetryPolicy{
+					MaxRetries: 1,
+					// The POST calls without an idempotency key are not retried by default
+					Retryable: func(resp *http.Response, err error) bool {
+						return resp != nil && resp.StatusCode == http.StatusServiceUnavailable
+					},
+				}),
+			)
+
+			if err := call(client); err != nil {
+				t.Fatalf("%s returned unexpected error: %v", name, err)
+			}
+			if len(bodies) != 2 {
+				t.Fatalf("server received %d requests, want 2", len(bodies))
+			}
+			if len(bodies[0]) == 0 {
+				t.Fatal("first attempt sent an empty body")
+			}
+			if !bytes.Equal(bodies[1], bodies[0]) {
+				t.Errorf("retried body = %q, want the original %q", bodies[1], bodies[0])
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package ingest
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/atriumn/atriumn-sdk-go/internal/clientutil"
+)
+
+func TestClient_Stats(t *testing.T) {
+	clock := clientutil.NewFakeClock(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	// The nth request takes n milliseconds; every fifth fails
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&calls, 1)
+		clock.Advance(time.Duration(n) * time.Millisecond)
+		w.Header().Set("Content-Type", "application/json")
+		if n%5 == 0 {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":"not_found"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":"content-1","status":"COMPLETED"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithOptions(server.URL, WithClock(clock), WithStatsCollection())
+	if err != nil {
+		t.Fatalf("NewClientWithOptions() error = %v", err)
+	}
+
+	for i := 0; i < 200; i++ {
+		_, _ = client.GetContentItem(context.Background(), "content-1")
+	}
+
+	stats := client.Stats()
+	if stats.Requests != 200 || stats.Errors != 40 {
+		t.Errorf("Stats() counted %d requests and %d errors, want 200 and 40", stats.Requests, stats.Errors)
+	}
+	want := map[string][2]time.Duration{
+		"P50": {stats.P50, 100 * time.Millisecond},
+		"P95": {stats.P95, 190 * time.Millisecond},
+		"P99": {stats.P99, 198 * time.Millisecond},
+	}
+	for name, v := range want {
+		if diff := v[0] - v[1]; diff < -2*time.Millisecond || diff > 2*time.Millisecond {
+			t.Errorf("Stats().%s = %v, want %v within 2ms", name, v[0], v[1])
+		}
+	}
+}
+
+func TestClient_Stats_Disabled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id":"content-1"}`))
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(server.URL)
+	if _, err := client.GetContentItem(context.Background(), "content-1"); err != nil {
+		t.Fatalf("GetContentItem() error = %v", err)
+	}
+	if stats := client.Stats(); stats != (Stats{}) {
+		t.Errorf("Stats() = %+v, want zero Stats", stats)
+	}
+}
@@ -0,0 +1,35 @@
+package ingest
+
+// ContentStatus is the processing status of a content item.
+type ContentStatus string
+
+const (
+	// ContentStatusPending indicates the content item is queued for processing
+	ContentStatusPending ContentStatus = "PENDING"
+	// ContentStatusUploading indicates the service is waiting for the content upload
+	ContentStatusUploading ContentStatus = "UPLOADING"
+	// ContentStatusProcessing indicates the content is being processed
+	ContentStatusProcessing ContentStatus = "PROCESSING"
+	// ContentStatusCompleted indicates processing finished successfully
+	ContentStatusCompleted ContentStatus = "COMPLETED"
+	// ContentStatusFailed indicates processing failed
+	ContentStatusFailed ContentStatus = "FAILED"
+)
+
+// IsTerminal reports whether the status will no longer change, i.e. whether
+// processing has either completed or failed.
+func (s ContentStatus) IsTerminal() bool {
+	return s == ContentStatusCompleted || s == ContentStatusFailed
+}
+
+// SourceType describes how a content item was ingested.
+type SourceType string
+
+const (
+	// SourceTypeText identifies content ingested as text
+	SourceTypeText SourceType = "TEXT"
+	// SourceTypeURL identifies content ingested from a URL
+	SourceTypeURL SourceType = "URL"
+	// SourceTypeFile identifies content ingested as a file upload
+	SourceTypeFile SourceType = "FILE"
+)
@@ -0,0 +1,151 @@
+package ingest
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestContentStatus_IsTerminal(t *testing.T) {
+	tests := []struct {
+		status ContentStatus
+		want   bool
+	}{
+		{ContentStatusPending, false},
+		{ContentStatusUploading, false},
+		{ContentStatusProcessing, false},
+		{ContentStatusCompleted, true},
+		{ContentStatusFailed, true},
+		{ContentStatus("UNKNOWN"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.status), func(t *testing.T) {
+			if got := tt.status.IsTerminal(); got != tt.want {
+				t.Errorf("%s.IsTerminal() = %v, want %v", tt.status, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_ListContentItemsWithOptions(t *testing.T) {
+	tests := []struct {
+		name string
+		opts *ListContentItemsOptions
+		want string
+	}{
+		{
+			name: "typed filters",
+			opts: &ListContentItemsOptions{
+				Status:     ContentStatusCompleted,
+				SourceType: SourceTypeFile,
+				Limit:      25,
+				NextToken:  "token-1",
+			},
+			want: "limit=25&nextToken=token-1&sourceType=FILE&status=COMPLETED",
+		},
+		{
+			name: "tenant and user",
+			opts: &ListContentItemsOptions{TenantID: "tenant-1", UserID: "user-1"},
+			want: "tenantId=tenant-1&userId=user-1",
+		},
+		{
+			name: "created window",
+			opts: &ListContentItemsOptions{
+				CreatedAfter:  time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+				CreatedBefore: time.Date(2024, 2, 1, 12, 30, 0, 0, time.FixedZone("EST", -5*60*60)),
+			},
+			want: "createdAfter=2024-01-01T00%3A00%3A00Z&createdBefore=2024-02-01T17%3A30%3A00Z",
+		},
+		{
+			name: "empty options",
+			opts: &ListContentItemsOptions{},
+			want: "",
+		},
+		{
+			name: "zero values omitted",
+			opts: &ListContentItemsOptions{SourceType: SourceTypeURL},
+			want: "sourceType=URL",
+		},
+		{
+			name: "nil options",
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var query string
+			server := setupTestServer(t, http.StatusOK, `{"items":[]}`, func(r *http.Request) {
+				query = r.URL.RawQuery
+			})
+			defer server.Close()
+
+			client, _ := NewClient(server.URL)
+			if _, err := client.ListContentItemsWithOptions(context.Background(), tt.opts); err != nil {
+				t.Fatalf("ListContentItemsWithOptions returned unexpected error: %v", err)
+			}
+			if query != tt.want {
+				t.Errorf("query = %q, want %q", query, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_ListContentItems_MatchesOptions(t *testing.T) {
+	status, sourceType, limit, token := "FAILED", "TEXT", 10, "token-2"
+
+	tests := []struct {
+		name       string
+		status     *string
+		sourceType *string
+		limit      *int
+		nextToken  *string
+		opts       *ListContentItemsOptions
+	}{
+		{
+			name:       "all filters",
+			status:     &status,
+			sourceType: &sourceType,
+			limit:      &limit,
+			nextToken:  &token,
+			opts: &ListContentItemsOptions{
+				Status:     ContentStatusFailed,
+				SourceType: SourceTypeText,
+				Limit:      10,
+				NextToken:  "token-2",
+			},
+		},
+		{
+			name:  "limit only",
+			limit: &limit,
+			opts:  &ListContentItemsOptions{Limit: 10},
+		},
+		{
+			name: "no filters",
+			opts: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var queries []string
+			server := setupTestServer(t, http.StatusOK, `{"items":[]}`, func(r *http.Request) {
+				queries = append(queries, r.URL.RawQuery)
+			})
+			defer server.Close()
+
+			client, _ := NewClient(server.URL)
+			if _, err := client.ListContentItems(context.Background(), tt.status, tt.sourceType, tt.limit, tt.nextToken); err != nil {
+				t.Fatalf("ListContentItems returned unexpected error: %v", err)
+			}
+			if _, err := client.ListContentItemsWithOptions(context.Background(), tt.opts); err != nil {
+				t.Fatalf("ListContentItemsWithOptions returned unexpected error: %v", err)
+			}
+			if queries[0] != queries[1] {
+				t.Errorf("ListContentItems query = %q, ListContentItemsWithOptions query = %q", queries[0], queries[1])
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package ingest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/atriumn/atriumn-sdk-go/internal/apierror"
+)
+
+// slowServer responds with body after delay.
+func slowServer(delay time.Duration, body string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(delay):
+		case <-r.Context().Done():
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(body))
+	}))
+}
+
+func TestWithRequestTimeout_SlowRequestTimesOut(t *testing.T) {
+	server := slowServer(500*time.Millisecond, `{"id":"content-123"}`)
+	defer server.Close()
+
+	client, _ := NewClientWithOptions(server.URL, WithRequestTimeout(50*time.Millisecond))
+
+	start := time.Now()
+	_, err := client.GetContentItem(context.Background(), "content-123")
+	var apiErr *apierror.ErrorResponse
+	if !errors.As(err, &apiErr) || apiErr.ErrorCode != "request_timeout" {
+		t.Fatalf("GetContentItem error = %v, want request_timeout", err)
+	}
+	if elapsed := time.Since(start); elapsed > 400*time.Millisecond {
+		t.Errorf("GetContentItem took %v, want it to stop near the 50ms timeout", elapsed)
+	}
+}
+
+func TestWithRequestTimeout_LongUploadSucceeds(t *testing.T) {
+	server := slowServer(150*time.Millisecond, `{"id":"content-123","status":"PENDING"}`)
+	defer server.Close()
+
+	// The HTTP client's own timeout would cut the upload off; the per-request
+	// timeout replaces it.
+	httpClient := &http.Client{Timeout: 50 * time.Millisecond}
+	client, _ := NewClientWithOptions(server.URL,
+		WithHTTPClient(httpClient),
+		WithRequestTimeout(2*time.Second))
+
+	resp, err := client.IngestFile(context.Background(), "tenant", "big.bin", "application/octet-stream", "",
+		strings.NewReader(strings.Repeat("x", 1<<20)))
+	if err != nil {
+		t.Fatalf("IngestFile returned unexpected error: %v", err)
+	}
+	if resp.ID != "content-123" {
+		t.Errorf("IngestFile ID = %q, want content-123", resp.ID)
+	}
+	if httpClient.Timeout != 50*time.Millisecond {
+		t.Errorf("WithRequestTimeout modified the caller's HTTP client")
+	}
+	if client.HTTPClient.Timeout != 0 {
+		t.Errorf("client HTTP timeout = %v, want 0", client.HTTPClient.Timeout)
+	}
+}
+
+func TestWithRequestTimeout_CallerDeadlineTakesPrecedence(t *testing.T) {
+	server := slowServer(500*time.Millisecond, `{"id":"content-123"}`)
+	defer server.Close()
+
+	client, _ := NewClientWithOptions(server.URL, WithRequestTimeout(5*time.Second))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	if _, err := client.GetContentItem(ctx, "content-123"); err == nil {
+		t.Fatal("GetContentItem should fail when the caller's deadline expires")
+	}
+	if elapsed := time.Since(start); elapsed > 400*time.Millisecond {
+		t.Errorf("GetContentItem took %v, want it to stop at the caller's 50ms deadline", elapsed)
+	}
+}
@@ -0,0 +1,179 @@
+package ingest
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestClient_WithTLSConfig(t *testing.T) {
+	config := &tls.Config{ServerName: "ingest.atriumn.internal"}
+	pool := x509.NewCertPool()
+
+	client, err := NewClientWithOptions("https://api.example.com", WithTLSConfig(config), WithRootCAs(pool))
+	if err != nil {
+		t.Fatalf("NewClientWithOptions() error = %v", err)
+	}
+
+	transport, ok := client.HTTPClient.Transport.(*http.Transport)
+	if !ok {
+		t.Fatalf("Transport = %T, want *http.Transport", client.HTTPClient.Transport)
+	}
+	if transport.TLSClientConfig == nil {
+		t.Fatal("TLSClientConfig = nil, want the provided config")
+	}
+	if transport.TLSClientConfig.ServerName != "ingest.atriumn.internal" {
+		t.Errorf("ServerName = %q, want %q", transport.TLSClientConfig.ServerName, "ingest.atriumn.internal")
+	}
+	if transport.TLSClientConfig.RootCAs != pool {
+		t.Error("RootCAs is not the pool passed to WithRootCAs")
+	}
+	if transport.TLSClientConfig.InsecureSkipVerify {
+		t.Error("InsecureSkipVerify = true without WithInsecureSkipVerify")
+	}
+}
+
+func TestClient_WithTLSConfig_HTTPClientTakesPrecedence(t *testing.T) {
+	custom := &http.Client{}
+	client, err := NewClientWithOptions("https://api.example.com",
+		WithRootCAs(x509.NewCertPool()), WithHTTPClient(custom), WithTLSConfig(&tls.Config{}))
+	if err != nil {
+		t.Fatalf("NewClientWithOptions() error = %v", err)
+	}
+	if client.HTTPClient != custom {
+		t.Errorf("HTTPClient was replaced, want the client passed to WithHTTPClient")
+	}
+	if custom.Transport != nil {
+		t.Errorf("TLS options modified the client passed to WithHTTPClient")
+	}
+}
+
+func TestClient_WithTLSConfig_InsecureSkipVerifyRequiresOptIn(t *testing.T) {
+	insecure := &tls.Config{InsecureSkipVerify: true}
+
+	_, err := NewClientWithOptions("https://api.example.com", WithTLSConfig(insecure))
+	if !errors.Is(err, ErrInsecureTLSConfig) {
+		t.Fatalf("NewClientWithOptions() error = %v, want ErrInsecureTLSConfig", err)
+	}
+
+	client, err := NewClientWithOptions("https://api.example.com", WithTLSConfig(insecure), WithInsecureSkipVerify())
+	if err != nil {
+		t.Fatalf("NewClientWithOptions() error = %v", err)
+	}
+	if !client.HTTPClient.Transport.(*http.Transport).TLSClientConfig.InsecureSkipVerify {
+		t.Error("InsecureSkipVerify = false, want true after WithInsecureSkipVerify")
+	}
+}
+
+func TestClient_WithRootCAs_PrivateCA(t *testing.T) {
+	var uploaded bool
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/content/content-123":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"id":"content-123","status":"COMPLETED"}`))
+		case "/upload":
+			uploaded = true
+		}
+	}))
+	defer server.Close()
+
+	pool := x509.NewCertPool()
+	pool.AddCert(server.Certificate())
+
+	// Without the server's CA, the certificate is rejected
+	untrusting, _ := NewClient(server.URL)
+	if _, err := untrusting.GetContentItem(context.Background(), "content-123"); err == nil {
+		t.Fatal("GetContentItem succeeded against an untrusted certificate")
+	}
+
+	client, err := NewClientWithOptions(server.URL, WithRootCAs(pool))
+	if err != nil {
+		t.Fatalf("NewClientWithOptions() error = %v", err)
+	}
+	if _, err := client.GetContentItem(context.Background(), "content-123"); err != nil {
+		t.Fatalf("GetContentItem returned unexpected error: %v", err)
+	}
+
+	// Pre-signed URLs served behind the same CA are trusted too
+	resp, err := client.UploadToURL(context.Background(), server.URL+"/upload", "text/plain", strings.NewReader("hello"))
+	if err != nil {
+		t.Fatalf("UploadToURL returned unexpected error: %v", err)
+	}
+	_ = resp.Body.Close()
+	if !uploaded {
+		t.Error("upload did not reach the server")
+	}
+}
+
+func TestClient_WithForceHTTP1(t *testing.T) {
+	client, err := NewClientWithOptions("https://api.example.com", WithForceHTTP1())
+	if err != nil {
+		t.Fatalf("NewClientWithOptions() error = %v", err)
+	}
+	transport, ok := client.HTTPClient.Transport.(*http.Transport)
+	if !ok {
+		t.Fatalf("Transport = %T, want *http.Transport", client.HTTPClient.Transport)
+	}
+	if transport.ForceAttemptHTTP2 {
+		t.Error("ForceAttemptHTTP2 = true, want false")
+	}
+	if transport.TLSNextProto == nil || len(transport.TLSNextProto) != 0 {
+		t.Errorf("TLSNextProto = %v, want an empty non-nil map", transport.TLSNextProto)
+	}
+	if client.transferTransport != transport {
+		t.Error("pre-signed URL transport does not force HTTP/1.1")
+	}
+
+	// Without the option, the default transport is left alone
+	plain, _ := NewClient("https://api.example.com")
+	if plain.HTTPClient.Transport != nil {
+		t.Errorf("Transport = %T, want the default transport", plain.HTTPClient.Transport)
+	}
+}
+
+func TestClient_WithForceHTTP1_Protocol(t *testing.T) {
+	var proto string
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proto = r.Proto
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"content-123","status":"COMPLETED"}`))
+	}))
+	server.EnableHTTP2 = true
+	server.StartTLS()
+	defer server.Close()
+
+	pool := x509.NewCertPool()
+	pool.AddCert(server.Certificate())
+
+	tests := []struct {
+		name      string
+		options   []ClientOption
+		wantProto string
+	}{
+		{"default", []ClientOption{WithRootCAs(pool)}, "HTTP/2.0"},
+		{"WithForceHTTP1", []ClientOption{WithRootCAs(pool), WithForceHTTP1()}, "HTTP/1.1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := NewClientWithOptions(server.URL, tt.options...)
+			if err != nil {
+				t.Fatalf("NewClientWithOptions() error = %v", err)
+			}
+			defer client.Close()
+
+			if _, err := client.GetContentItem(context.Background(), "content-123"); err != nil {
+				t.Fatalf("GetContentItem returned unexpected error: %v", err)
+			}
+			if proto != tt.wantProto {
+				t.Errorf("server saw %s, want %s", proto, tt.wantProto)
+			}
+		})
+	}
+}
@@ -0,0 +1,241 @@
+package ingest
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/atriumn/atriumn-sdk-go/internal/apierror"
+	"github.com/atriumn/atriumn-sdk-go/internal/clientutil"
+)
+
+// UploadFile performs the complete two-step file upload: it requests a
+// pre-signed URL with RequestFileUpload and then uploads content to it with
+// UploadToURL. When opts.VerifySize is set, the content item is fetched
+// afterwards and its reported Size is compared with the bytes actually sent.
+// If the request's ContentType is empty, it is determined with
+// DetectContentType from the content and Filename.
+//
+// Parameters:
+//   - ctx: Context for the API requests
+//   - request: RequestFileUploadRequest containing file metadata (required field: Filename)
+//   - content: An io.Reader providing the file content (required)
+//   - opts: Optional UploadFileOptions (nil disables verification)
+//
+// Returns:
+//   - *UploadFileResponse: The content ID and number of bytes uploaded
+//   - error: An error if the operation fails, which can be:
+//   - apierror.ErrorResponse with codes like:
+//   - "size_mismatch" if the service reports a different size than was uploaded
+//   - any error returned by RequestFileUpload, UploadToURL, or GetContentItem
+func (c *Client) UploadFile(ctx context.Context, request *RequestFileUploadRequest, content io.Reader, opts *UploadFileOptions) (*UploadFileResponse, error) {
+	size := contentLength(content)
+	if request != nil && request.ContentType == "" && content != nil {
+		contentType, detected, err := detectUploadContentType(request.Filename, content)
+		if err != nil {
+			return nil, err
+		}
+		r := *request
+		r.ContentType = contentType
+		request, content = &r, detected
+	}
+
+	uploadResp, err := c.RequestFileUpload(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	counter := &countingReader{r: content, size: size}
+	resp, err := c.UploadToURL(ctx, uploadResp.UploadURL, request.ContentType, counter)
+	if err != nil {
+		return nil, err
+	}
+	_ = resp.Body.Close()
+
+	result := &UploadFileResponse{
+		ContentID:     uploadResp.ContentID,
+		Status:        uploadResp.Status,
+		BytesUploaded: counter.n,
+	}
+
+	if opts != nil && opts.VerifySize {
+		item, err := c.GetContentItemWithOptions(ctx, uploadResp.ContentID, nil, WithNoCache())
+		if err != nil {
+			return nil, err
+		}
+		result.ContentItem = item
+
+		if item.Size != counter.n {
+			return result, &apierror.ErrorResponse{
+				ErrorCode:   "size_mismatch",
+				Description: fmt.Sprintf("uploaded %d bytes but content item %s reports %d bytes", counter.n, item.ID, item.Size),
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// UploadText performs the complete two-step text upload that replaces the
+// deprecated IngestText: it requests a pre-signed URL with RequestTextUpload
+// and then PUTs content to it with UploadToURL. The content is sent with the
+// request's ContentType, or "text/plain" if none is set.
+//
+// Parameters:
+//   - ctx: Context for the API requests
+//   - request: RequestTextUploadRequest containing text metadata (nil uses the defaults)
+//   - content: The text to upload
+//
+// Returns:
+//   - *RequestTextUploadResponse: The content ID and status reported when the upload was requested
+//   - error: Any error returned by RequestTextUpload or UploadToURL
+func (c *Client) UploadText(ctx context.Context, request *RequestTextUploadRequest, content string) (*RequestTextUploadResponse, error) {
+	r := RequestTextUploadRequest{}
+	if request != nil {
+		r = *request
+	}
+	if r.ContentType == "" {
+		r.ContentType = "text/plain"
+	}
+
+	uploadResp, err := c.RequestTextUpload(ctx, &r)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.UploadToURL(ctx, uploadResp.UploadURL, r.ContentType, strings.NewReader(content))
+	if err != nil {
+		return nil, err
+	}
+	_ = resp.Body.Close()
+
+	return uploadResp, nil
+}
+
+// UploadToURLWithResult uploads content directly to a pre-signed URL like
+// UploadToURL, and reports how many bytes were sent along with the status code
+// and ETag of the storage service's response, for reconciling uploads.
+//
+// Parameters:
+//   - ctx: Context for the API request
+//   - uploadURL: The pre-signed S3 URL to upload to (required)
+//   - contentType: The MIME type of the content being uploaded (required)
+//   - content: An io.Reader providing the content to upload (required)
+//   - opts: Optional TransferOption values such as WithProgress or WithTotalSize
+//
+// Returns:
+//   - *UploadResult: The number of bytes written, the response status code, and the ETag
+//   - error: Any error returned by UploadToURL
+func (c *Client) UploadToURLWithResult(ctx context.Context, uploadURL string, contentType string, content io.Reader, opts ...TransferOption) (*UploadResult, error) {
+	counter := &countingReader{r: content, size: contentLength(content)}
+	resp, err := c.UploadToURL(ctx, uploadURL, contentType, counter, opts...)
+	if err != nil {
+		return nil, err
+	}
+	_ = resp.Body.Close()
+
+	return &UploadResult{
+		BytesWritten: counter.n,
+		StatusCode:   resp.StatusCode,
+		ETag:         resp.Header.Get("ETag"),
+	}, nil
+}
+
+// countingReader wraps an io.Reader and counts the bytes read through it.
+type countingReader struct {
+	r    io.Reader
+	n    int64
+	size int64
+}
+
+func (cr *countingReader) Read(p []byte) (int, error) {
+	n, err := cr.r.Read(p)
+	cr.n += int64(n)
+	return n, err
+}
+
+// contentLength returns the number of bytes r will yield, or -1 if unknown.
+// Wrapping readers defined in this package report the size of what they wrap
+// so that Content-Length detection survives wrapping; other readers are sized
+// by clientutil.ContentLength.
+func contentLength(r io.Reader) int64 {
+	switch v := r.(type) {
+	case *countingReader:
+		return v.size
+	case *ProgressReader:
+		if v.Total >= 0 {
+			return v.Total
+		}
+		return contentLength(v.Reader)
+	}
+	return clientutil.ContentLength(r)
+}
+
+// cancelOnCloseBody is a response body that releases its request's context
+// when closed, so a per-upload deadline does not cut off reading the body.
+type cancelOnCloseBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+// Close closes the body and cancels its request's context.
+func (b *cancelOnCloseBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
+// contextBody is a request body that yields r's content and fails with ctx's
+// error as soon as ctx is done. An http.Transport waits for a body Read in
+// progress before returning from a canceled request, so on the first Read, r
+// starts being copied into a pipe by a separate goroutine, and the pipe is
+// closed when ctx ends. The goroutine exits once r's pending Read returns or
+// the transport closes the body.
+type contextBody struct {
+	ctx    context.Context
+	r      io.Reader
+	start  sync.Once
+	copied chan struct{}
+	pr     *io.PipeReader
+	pw     *io.PipeWriter
+}
+
+// newContextBody returns a contextBody reading r until ctx is done.
+func newContextBody(ctx context.Context, r io.Reader) *contextBody {
+	pr, pw := io.Pipe()
+	return &contextBody{ctx: ctx, r: r, copied: make(chan struct{}), pr: pr, pw: pw}
+}
+
+// Read reads from the pipe, starting the copy from r on the first call.
+func (b *contextBody) Read(p []byte) (int, error) {
+	b.start.Do(func() {
+		go func() {
+			_, err := io.Copy(b.pw, b.r)
+			_ = b.pw.CloseWithError(err)
+			close(b.copied)
+		}()
+		go func() {
+			select {
+			case <-b.ctx.Done():
+				_ = b.pw.CloseWithError(b.ctx.Err())
+			case <-b.copied:
+			}
+		}()
+	})
+	return b.pr.Read(p)
+}
+
+// wait blocks until the copy from r has stopped, and keeps it from starting
+// if it has not, so r can be reused. Call it after Close, which makes a copy
+// in progress stop once r's pending Read returns.
+func (b *contextBody) wait() {
+	b.start.Do(func() { close(b.copied) })
+	<-b.copied
+}
+
+// Close closes the pipe, stopping any copy in progress.
+func (b *contextBody) Close() error {
+	return b.pr.Close()
+}
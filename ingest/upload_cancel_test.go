@@ -0,0 +1,180 @@
+package ingest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// stallingServer accepts uploads but never answers them, like a storage
+// service that has stopped responding, until the client gives up or the test ends
+func stallingServer(t *testing.T) *httptest.Server {
+	done := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-done:
+		}
+	}))
+	t.Cleanup(func() {
+		close(done)
+		server.Close()
+	})
+	return server
+}
+
+// stalledBody returns a body that yields a few bytes and then blocks, like a
+// slow upload, until the test ends
+func stalledBody(t *testing.T) io.Reader {
+	pr, pw := io.Pipe()
+	go func() {
+		_, _ = pw.Write([]byte("part"))
+	}()
+	t.Cleanup(func() { _ = pw.Close() })
+	return pr
+}
+
+func TestClient_UploadToURL_CancelMidUpload(t *testing.T) {
+	server := stallingServer(t)
+
+	client, err := NewClient(server.URL)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(100*time.Millisecond, cancel)
+
+	errc := make(chan error, 1)
+	go func() {
+		_, err := client.UploadToURL(ctx, server.URL, "application/octet-stream", stalledBody(t))
+		errc <- err
+	}()
+
+	select {
+	case err := <-errc:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("UploadToURL() error = %v, want context.Canceled", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("UploadToURL() did not return after its context was canceled")
+	}
+}
+
+func TestClient_WithUploadTimeout(t *testing.T) {
+	server := stallingServer(t)
+
+	client, err := NewClientWithOptions(server.URL, WithUploadTimeout(50*time.Millisecond))
+	if err != nil {
+		t.Fatalf("NewClientWithOptions() error = %v", err)
+	}
+
+	begin := time.Now()
+	_, err = client.UploadToURL(context.Background(), server.URL, "application/octet-stream", stalledBody(t))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("UploadToURL() error = %v, want context.DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(begin); elapsed > 5*time.Second {
+		t.Errorf("UploadToURL() took %v, want it to stop after the upload timeout", elapsed)
+	}
+}
+
+func TestClient_WithUploadTimeout_CallerDeadlineGoverns(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		time.Sleep(100 * time.Millisecond)
+		_, _ = w.Write([]byte("stored"))
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithOptions(server.URL, WithUploadTimeout(20*time.Millisecond))
+	if err != nil {
+		t.Fatalf("NewClientWithOptions() error = %v", err)
+	}
+
+	// The caller's longer deadline replaces the upload timeout
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := client.UploadToURL(ctx, server.URL, "text/plain", strings.NewReader("content"))
+	if err != nil {
+		t.Fatalf("UploadToURL() error = %v", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	// The body remains readable after UploadToURL returns
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("reading response body: %v", err)
+	}
+	if string(body) != "stored" {
+		t.Errorf("response body = %q, want %q", body, "stored")
+	}
+}
+
+func TestClient_UploadToURL_ResendsBodyOnRedirect(t *testing.T) {
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		if r.URL.Path == "/upload" {
+			http.Redirect(w, r, "/moved", http.StatusTemporaryRedirect)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(server.URL)
+
+	// Part of the content has already been consumed; only the rest is uploaded
+	content := strings.NewReader("header:content")
+	_, _ = content.Seek(int64(len("header:")), io.SeekStart)
+
+	resp, err := client.UploadToURL(context.Background(), server.URL+"/upload", "text/plain", content)
+	if err != nil {
+		t.Fatalf("UploadToURL() error = %v", err)
+	}
+	_ = resp.Body.Close()
+
+	if len(bodies) != 2 {
+		t.Fatalf("server received %d requests, want 2", len(bodies))
+	}
+	for i, body := range bodies {
+		if body != "content" {
+			t.Errorf("request %d body = %q, want %q", i, body, "content")
+		}
+	}
+}
+
+func TestClient_UploadToURL_RedirectBeforeBodySent(t *testing.T) {
+	content := bytes.Repeat([]byte("0123456789abcdef"), 1<<18) // 4 MiB
+	var resent []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/upload" {
+			// Answer before reading the body, while the client is still sending it
+			http.Redirect(w, r, "/moved", http.StatusTemporaryRedirect)
+			return
+		}
+		resent, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(server.URL)
+	resp, err := client.UploadToURL(context.Background(), server.URL+"/upload", "application/octet-stream", bytes.NewReader(content))
+	if err != nil {
+		t.Fatalf("UploadToURL() error = %v", err)
+	}
+	_ = resp.Body.Close()
+
+	if !bytes.Equal(resent, content) {
+		t.Errorf("redirected request sent %d bytes that differ from the %d byte content", len(resent), len(content))
+	}
+}
@@ -0,0 +1,251 @@
+package ingest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/atriumn/atriumn-sdk-go/internal/apierror"
+)
+
+// uploadFileServer serves the ingest API and the pre-signed upload URL from one server
+func uploadFileServer(t *testing.T, reportedSize int64, uploaded *string) *httptest.Server {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/ingest/file":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = fmt.Fprintf(w, `{"id":"content-123","status":"UPLOADING","uploadUrl":"%s/upload"}`, server.URL)
+		case r.Method == "PUT" && r.URL.Path == "/upload":
+			if r.ContentLength <= 0 {
+				t.Errorf("upload Content-Length = %d, want known length", r.ContentLength)
+			}
+			body, _ := io.ReadAll(r.Body)
+			*uploaded = string(body)
+			w.WriteHeader(http.StatusOK)
+		case r.Method == "GET" && r.URL.Path == "/content/content-123":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = fmt.Fprintf(w, `{"id":"content-123","status":"COMPLETED","size":%d}`, reportedSize)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	return server
+}
+
+func TestClient_UploadFile(t *testing.T) {
+	var uploaded string
+	server := uploadFileServer(t, 0, &uploaded)
+	defer server.Close()
+
+	client, _ := NewClient(server.URL)
+	resp, err := client.UploadFile(context.Background(), &RequestFileUploadRequest{
+		Filename:    "notes.txt",
+		ContentType: "text/plain",
+	}, strings.NewReader("hello world"), nil)
+	if err != nil {
+		t.Fatalf("UploadFile returned unexpected error: %v", err)
+	}
+	if resp.ContentID != "content-123" {
+		t.Errorf("UploadFile ContentID = %q, want %q", resp.ContentID, "content-123")
+	}
+	if resp.BytesUploaded != 11 {
+		t.Errorf("UploadFile BytesUploaded = %d, want 11", resp.BytesUploaded)
+	}
+	if resp.ContentItem != nil {
+		t.Error("UploadFile should not fetch the content item without VerifySize")
+	}
+	if uploaded != "hello world" {
+		t.Errorf("uploaded body = %q, want %q", uploaded, "hello world")
+	}
+}
+
+func TestClient_UploadFile_VerifySize(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data.bin")
+	if err := os.WriteFile(path, []byte("0123456789"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name         string
+		reportedSize int64
+		wantErrCode  string
+	}{
+		{name: "matching size", reportedSize: 10},
+		{name: "smaller reported size", reportedSize: 4, wantErrCode: "size_mismatch"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var uploaded string
+			server := uploadFileServer(t, tt.reportedSize, &uploaded)
+			defer server.Close()
+
+			file, err := os.Open(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer func() { _ = file.Close() }()
+
+			client, _ := NewClient(server.URL)
+			resp, err := client.UploadFile(context.Background(), &RequestFileUploadRequest{
+				Filename:    "data.bin",
+				ContentType: "application/octet-stream",
+			}, file, &UploadFileOptions{VerifySize: true})
+
+			if tt.wantErrCode == "" {
+				if err != nil {
+					t.Fatalf("UploadFile returned unexpected error: %v", err)
+				}
+				if resp.ContentItem == nil || resp.ContentItem.Size != 10 {
+					t.Errorf("UploadFile ContentItem = %+v, want size 10", resp.ContentItem)
+				}
+				return
+			}
+
+			apiErr, ok := err.(*apierror.ErrorResponse)
+			if !ok {
+				t.Fatalf("UploadFile error = %v, want *apierror.ErrorResponse", err)
+			}
+			if apiErr.ErrorCode != tt.wantErrCode {
+				t.Errorf("UploadFile ErrorCode = %q, want %q", apiErr.ErrorCode, tt.wantErrCode)
+			}
+			if resp == nil || resp.BytesUploaded != 10 {
+				t.Errorf("UploadFile should report bytes uploaded alongside a size mismatch, got %+v", resp)
+			}
+		})
+	}
+}
+
+func TestClient_UploadToURLWithResult(t *testing.T) {
+	payload := strings.Repeat("reconcile me ", 100)
+	var received int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" {
+			t.Errorf("UploadToURLWithResult method = %s, want PUT", r.Method)
+		}
+		body, _ := io.ReadAll(r.Body)
+		received = len(body)
+		w.Header().Set("ETag", `"9b2cf535f27731c974343645a3985328"`)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client, _ := NewClient("https://api.example.com")
+	result, err := client.UploadToURLWithResult(context.Background(), server.URL+"/upload", "text/plain", strings.NewReader(payload))
+	if err != nil {
+		t.Fatalf("UploadToURLWithResult returned unexpected error: %v", err)
+	}
+	if result.BytesWritten != int64(len(payload)) {
+		t.Errorf("UploadToURLWithResult BytesWritten = %d, want %d", result.BytesWritten, len(payload))
+	}
+	if result.BytesWritten != int64(received) {
+		t.Errorf("UploadToURLWithResult BytesWritten = %d, but the server received %d bytes", result.BytesWritten, received)
+	}
+	if result.StatusCode != http.StatusOK {
+		t.Errorf("UploadToURLWithResult StatusCode = %d, want %d", result.StatusCode, http.StatusOK)
+	}
+	if want := `"9b2cf535f27731c974343645a3985328"`; result.ETag != want {
+		t.Errorf("UploadToURLWithResult ETag = %s, want %s", result.ETag, want)
+	}
+}
+
+func TestClient_UploadToURLWithResult_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte("<Error><Code>AccessDenied</Code></Error>"))
+	}))
+	defer server.Close()
+
+	client, _ := NewClient("https://api.example.com")
+	result, err := client.UploadToURLWithResult(context.Background(), server.URL, "text/plain", strings.NewReader("data"))
+	if err == nil || !strings.Contains(err.Error(), "upload failed with status 403") {
+		t.Errorf("UploadToURLWithResult error = %v, want upload failed with status 403", err)
+	}
+	if result != nil {
+		t.Errorf("UploadToURLWithResult result = %+v, want nil", result)
+	}
+}
+
+// textUploadServers starts a mock S3 server recording the uploaded body and
+// content type, and a mock ingest API handing out its URL
+func textUploadServers(t *testing.T, wantRequestType string, uploaded, uploadedType *string) (api, s3 *httptest.Server) {
+	s3 = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" || r.URL.Path != "/bucket/content-123" {
+			t.Errorf("unexpected S3 request %s %s", r.Method, r.URL.Path)
+		}
+		if r.Header.Get("Authorization") != "" {
+			t.Error("the pre-signed upload must not carry an Authorization header")
+		}
+		body, _ := io.ReadAll(r.Body)
+		*uploaded = string(body)
+		*uploadedType = r.Header.Get("Content-Type")
+		w.WriteHeader(http.StatusOK)
+	}))
+	api = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/ingest/text" {
+			t.Errorf("unexpected API request %s %s", r.Method, r.URL.Path)
+		}
+		var req RequestTextUploadRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+		}
+		if req.ContentType != wantRequestType {
+			t.Errorf("RequestTextUpload ContentType = %q, want %q", req.ContentType, wantRequestType)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"id":"content-123","status":"UPLOADING","uploadUrl":"%s/bucket/content-123"}`, s3.URL)
+	}))
+	return api, s3
+}
+
+func TestClient_UploadText(t *testing.T) {
+	var uploaded, uploadedType string
+	api, s3 := textUploadServers(t, "text/markdown", &uploaded, &uploadedType)
+	defer api.Close()
+	defer s3.Close()
+
+	client, _ := NewClientWithOptions(api.URL, WithTokenProvider(&MockTokenProvider{token: "test-token"}))
+	resp, err := client.UploadText(context.Background(), &RequestTextUploadRequest{
+		ContentType: "text/markdown",
+		TenantID:    "tenant-1",
+	}, "# Notes")
+	if err != nil {
+		t.Fatalf("UploadText returned unexpected error: %v", err)
+	}
+	if resp.ContentID != "content-123" || resp.Status != "UPLOADING" {
+		t.Errorf("UploadText response = %+v, want content-123 UPLOADING", resp)
+	}
+	if uploaded != "# Notes" {
+		t.Errorf("uploaded body = %q, want %q", uploaded, "# Notes")
+	}
+	if uploadedType != "text/markdown" {
+		t.Errorf("uploaded Content-Type = %q, want %q", uploadedType, "text/markdown")
+	}
+}
+
+func TestClient_UploadText_DefaultContentType(t *testing.T) {
+	var uploaded, uploadedType string
+	api, s3 := textUploadServers(t, "text/plain", &uploaded, &uploadedType)
+	defer api.Close()
+	defer s3.Close()
+
+	client, _ := NewClient(api.URL)
+	if _, err := client.UploadText(context.Background(), nil, "hello"); err != nil {
+		t.Fatalf("UploadText returned unexpected error: %v", err)
+	}
+	if uploadedType != "text/plain" {
+		t.Errorf("uploaded Content-Type = %q, want %q", uploadedType, "text/plain")
+	}
+	if uploaded != "hello" {
+		t.Errorf("uploaded body = %q, want %q", uploaded, "hello")
+	}
+}
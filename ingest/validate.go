@@ -0,0 +1,40 @@
+package ingest
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+)
+
+// Validate checks that URL is an absolute http or https URL with a host, so
+// that a malformed URL is reported precisely instead of as a "bad_request"
+// from the service. Whether the URL is reachable is not checked.
+//
+// Returns:
+//   - error: nil if the request's URL can be ingested, otherwise an error
+//     naming the problem
+func (r *IngestURLRequest) Validate() error {
+	if r == nil {
+		return errors.New("request is required")
+	}
+	if r.URL == "" {
+		return errors.New("url is required")
+	}
+
+	u, err := url.Parse(r.URL)
+	if err != nil {
+		return fmt.Errorf("url %q cannot be parsed: %w", r.URL, err)
+	}
+	switch u.Scheme {
+	case "http", "https":
+	case "":
+		return fmt.Errorf("url %q has no scheme; it must start with http:// or https://", r.URL)
+	default:
+		return fmt.Errorf("url %q has scheme %q; only http and https are supported", r.URL, u.Scheme)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("url %q has no host", r.URL)
+	}
+
+	return nil
+}
@@ -0,0 +1,86 @@
+package ingest
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestIngestURLRequest_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		url     string
+		wantErr string
+	}{
+		{name: "valid https", url: "https://example.com/article"},
+		{name: "valid http", url: "http://example.com"},
+		{name: "empty", url: "", wantErr: "url is required"},
+		{name: "file scheme", url: "file:///etc/passwd", wantErr: `has scheme "file"; only http and https are supported`},
+		{name: "no scheme", url: "example.com/article", wantErr: "has no scheme"},
+		{name: "no host", url: "https:///article", wantErr: "has no host"},
+		{name: "unparseable", url: "https://example.com/%zz", wantErr: "cannot be parsed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := (&IngestURLRequest{URL: tt.url}).Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Validate() error = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("Validate() error = nil, want error containing %q", tt.wantErr)
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() error = %q, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	var nilRequest *IngestURLRequest
+	if err := nilRequest.Validate(); err == nil {
+		t.Error("Validate() on nil request error = nil, want error")
+	}
+}
+
+func TestClient_IngestURL_ClientSideValidation(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte(`{"id":"content-123","status":"PENDING"}`))
+	}))
+	defer server.Close()
+
+	client, _ := NewClientWithOptions(server.URL, WithClientSideValidation())
+
+	_, err := client.IngestURL(context.Background(), &IngestURLRequest{URL: "file:///etc/passwd"})
+	if err == nil || !strings.Contains(err.Error(), "invalid ingest URL request") {
+		t.Fatalf("IngestURL() error = %v, want a validation error", err)
+	}
+	if requests != 0 {
+		t.Fatalf("IngestURL() sent %d requests for an invalid URL, want 0", requests)
+	}
+
+	resp, err := client.IngestURL(context.Background(), &IngestURLRequest{URL: "https://example.com/article"})
+	if err != nil {
+		t.Fatalf("IngestURL() error = %v", err)
+	}
+	if resp.ID != "content-123" || requests != 1 {
+		t.Errorf("IngestURL() = %+v after %d requests, want content-123 after 1", resp, requests)
+	}
+
+	// Without the option, the URL is sent to the service as is
+	unvalidated, _ := NewClient(server.URL)
+	if _, err := unvalidated.IngestURL(context.Background(), &IngestURLRequest{URL: "example.com"}); err != nil {
+		t.Fatalf("IngestURL() without validation error = %v", err)
+	}
+	if requests != 2 {
+		t.Errorf("requests = %d, want 2", requests)
+	}
+}
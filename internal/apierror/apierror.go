@@ -2,13 +2,57 @@
 // It defines the standard error response structure used across different Atriumn APIs.
 package apierror
 
-import "fmt"
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// ErrConflict is matched by errors.Is for any API error returned with HTTP 409
+// Conflict, such as a duplicate credential or a prompt name clash.
+var ErrConflict = &ErrorResponse{
+	ErrorCode:   "conflict",
+	Description: "The request conflicts with the current state of the resource.",
+	StatusCode:  http.StatusConflict,
+}
+
+// ErrNotFound is matched by errors.Is for any API error returned with HTTP 404
+// Not Found.
+var ErrNotFound = &ErrorResponse{
+	ErrorCode:   "not_found",
+	Description: "The requested resource was not found.",
+	StatusCode:  http.StatusNotFound,
+}
 
 // ErrorResponse represents a standard error response from Atriumn APIs.
 // It contains the error code and an optional description returned by the API.
 type ErrorResponse struct {
 	ErrorCode   string `json:"error"`
 	Description string `json:"error_description,omitempty"`
+	// StatusCode is the HTTP status of the response, or 0 for client-side errors
+	StatusCode int `json:"-"`
+	// RawBody is the full response body. It is only populated when raw bodies
+	// are explicitly enabled, since error responses may contain sensitive data.
+	RawBody []byte `json:"-"`
+	// RequestID is the X-Request-ID sent with the failed request. Quote it when
+	// reporting a problem so the request can be found in the service logs.
+	RequestID string `json:"-"`
+	// RetryAfter is how long the server asked the client to wait before
+	// retrying, from the Retry-After or X-RateLimit-Reset header of a 429 or
+	// 503 response. It is zero if the server gave no hint.
+	RetryAfter time.Duration `json:"-"`
+
+	// ValidationErrors lists the fields the server rejected, from the details
+	// array of a validation error response. It is nil if the response gave none.
+	ValidationErrors []FieldError `json:"-"`
+}
+
+// FieldError is the server's message about one invalid field of a request.
+type FieldError struct {
+	// Field is the name of the invalid field, such as "filename"
+	Field string `json:"field"`
+	// Message describes what is wrong with the field
+	Message string `json:"message"`
 }
 
 // Error satisfies the error interface by returning a formatted error message.
@@ -19,3 +63,17 @@ func (e *ErrorResponse) Error() string {
 	}
 	return e.ErrorCode
 }
+
+// Is reports whether target is an *ErrorResponse with the same HTTP status code
+// or, if target carries no status code, the same error code. This lets errors.Is
+// match sentinel errors like ErrConflict regardless of the code in the response body.
+func (e *ErrorResponse) Is(target error) bool {
+	t, ok := target.(*ErrorResponse)
+	if !ok {
+		return false
+	}
+	if t.StatusCode != 0 {
+		return e.StatusCode == t.StatusCode
+	}
+	return e.ErrorCode == t.ErrorCode
+}
@@ -1,6 +1,11 @@
 package apierror
 
-import "testing"
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
 
 func TestErrorResponse_Error(t *testing.T) {
 	tests := []struct {
@@ -35,3 +40,23 @@ func TestErrorResponse_Error(t *testing.T) {
 		})
 	}
 }
+
+func TestErrorResponse_Is(t *testing.T) {
+	conflict := &ErrorResponse{ErrorCode: "duplicate_name", StatusCode: http.StatusConflict}
+	if !errors.Is(conflict, ErrConflict) {
+		t.Error("errors.Is(409 response, ErrConflict) = false, want true")
+	}
+	if !errors.Is(fmt.Errorf("wrapped: %w", conflict), ErrConflict) {
+		t.Error("errors.Is(wrapped 409 response, ErrConflict) = false, want true")
+	}
+
+	notFound := &ErrorResponse{ErrorCode: "not_found", StatusCode: http.StatusNotFound}
+	if errors.Is(notFound, ErrConflict) {
+		t.Error("errors.Is(404 response, ErrConflict) = true, want false")
+	}
+
+	// Targets without a status code match on the error code
+	if !errors.Is(notFound, &ErrorResponse{ErrorCode: "not_found"}) {
+		t.Error("errors.Is() by error code = false, want true")
+	}
+}
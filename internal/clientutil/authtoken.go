@@ -0,0 +1,20 @@
+package clientutil
+
+import "context"
+
+// authTokenKey is the context key for a caller-provided bearer token.
+type authTokenKey struct{}
+
+// WithAuthToken returns a copy of ctx that makes requests created with it send
+// token as their bearer token instead of one from the client's TokenProvider,
+// so a client shared between users can act for each of them.
+func WithAuthToken(ctx context.Context, token string) context.Context {
+	return context.WithValue(ctx, authTokenKey{}, token)
+}
+
+// AuthTokenFromContext returns the bearer token stored in ctx by
+// WithAuthToken, or "" if there is none.
+func AuthTokenFromContext(ctx context.Context) string {
+	token, _ := ctx.Value(authTokenKey{}).(string)
+	return token
+}
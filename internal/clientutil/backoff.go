@@ -0,0 +1,97 @@
+package clientutil
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"time"
+)
+
+const (
+	// DefaultBackoffInitial is the delay used before the first retry when none is configured
+	DefaultBackoffInitial = 500 * time.Millisecond
+
+	// DefaultBackoffMax is the upper bound for a single delay when none is configured
+	DefaultBackoffMax = 30 * time.Second
+
+	// DefaultBackoffMultiplier is the growth factor applied between attempts when none is configured
+	DefaultBackoffMultiplier = 2.0
+)
+
+// Backoff computes exponentially growing delays for retry and polling loops.
+// The zero value is usable and falls back to the package defaults.
+type Backoff struct {
+	// Initial is the delay returned for the first attempt
+	Initial time.Duration
+
+	// Max caps the delay returned for any attempt
+	Max time.Duration
+
+	// Multiplier is the factor by which the delay grows after each attempt
+	Multiplier float64
+
+	// Jitter randomizes each delay by up to the given fraction (0 to 1) in
+	// either direction. The jittered delay never exceeds Max.
+	Jitter float64
+
+	// Rand returns a pseudo-random number in [0, 1). It defaults to math/rand
+	// and can be replaced for deterministic tests.
+	Rand func() float64
+}
+
+// Duration returns the delay to wait before the given attempt, where attempt 0
+// is the first retry.
+func (b Backoff) Duration(attempt int) time.Duration {
+	initial := b.Initial
+	if initial <= 0 {
+		initial = DefaultBackoffInitial
+	}
+	maxDelay := b.Max
+	if maxDelay <= 0 {
+		maxDelay = DefaultBackoffMax
+	}
+	multiplier := b.Multiplier
+	if multiplier < 1 {
+		multiplier = DefaultBackoffMultiplier
+	}
+	if attempt < 0 {
+		attempt = 0
+	}
+
+	delay := float64(initial) * math.Pow(multiplier, float64(attempt))
+	if delay > float64(maxDelay) {
+		delay = float64(maxDelay)
+	}
+
+	if b.Jitter > 0 {
+		jitter := math.Min(b.Jitter, 1)
+		random := b.Rand
+		if random == nil {
+			random = rand.Float64
+		}
+		delay += delay * jitter * (2*random() - 1)
+		if delay > float64(maxDelay) {
+			delay = float64(maxDelay)
+		}
+	}
+
+	return time.Duration(delay)
+}
+
+// Sleep pauses for d or until ctx is done, whichever happens first.
+// It returns ctx.Err() if the context ends before the delay elapses.
+func Sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,64 @@
+package clientutil
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackoff_Duration(t *testing.T) {
+	b := Backoff{Initial: 100 * time.Millisecond, Max: time.Second, Multiplier: 2}
+
+	assert.Equal(t, 100*time.Millisecond, b.Duration(0))
+	assert.Equal(t, 200*time.Millisecond, b.Duration(1))
+	assert.Equal(t, 400*time.Millisecond, b.Duration(2))
+	assert.Equal(t, 800*time.Millisecond, b.Duration(3))
+	assert.Equal(t, time.Second, b.Duration(4))
+	assert.Equal(t, time.Second, b.Duration(50))
+}
+
+func TestBackoff_Defaults(t *testing.T) {
+	var b Backoff
+
+	assert.Equal(t, DefaultBackoffInitial, b.Duration(0))
+	assert.Equal(t, 2*DefaultBackoffInitial, b.Duration(1))
+	assert.Equal(t, DefaultBackoffMax, b.Duration(100))
+}
+
+func TestBackoff_Jitter(t *testing.T) {
+	tests := []struct {
+		name   string
+		random float64
+		want   time.Duration
+	}{
+		{name: "lowest", random: 0, want: 50 * time.Millisecond},
+		{name: "middle", random: 0.5, want: 100 * time.Millisecond},
+		{name: "highest", random: 0.999999, want: 149999900 * time.Nanosecond},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := Backoff{
+				Initial: 100 * time.Millisecond,
+				Max:     time.Second,
+				Jitter:  0.5,
+				Rand:    func() float64 { return tt.random },
+			}
+			assert.InDelta(t, float64(tt.want), float64(b.Duration(0)), float64(time.Microsecond))
+		})
+	}
+
+	// Jitter never pushes a delay past the cap
+	b := Backoff{Initial: time.Second, Max: time.Second, Jitter: 1, Rand: func() float64 { return 0.99 }}
+	assert.Equal(t, time.Second, b.Duration(3))
+}
+
+func TestSleep(t *testing.T) {
+	assert.NoError(t, Sleep(context.Background(), time.Millisecond))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, Sleep(ctx, time.Hour), context.Canceled)
+}
@@ -0,0 +1,301 @@
+package clientutil
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/atriumn/atriumn-sdk-go/internal/apierror"
+)
+
+// TokenProvider supplies bearer tokens for authenticated requests. The
+// service packages define identical interfaces, so their implementations can
+// be assigned to BaseClient.TokenProvider directly.
+type TokenProvider interface {
+	GetToken(ctx context.Context) (string, error)
+}
+
+// TokenInvalidator is implemented by token providers that cache tokens. With
+// RefreshOn401, BaseClient calls Invalidate when the service rejects a token,
+// so the next GetToken returns a fresh one.
+type TokenInvalidator interface {
+	Invalidate()
+}
+
+// BaseClient holds the connection settings shared by the service clients and
+// builds and sends their requests. Service clients hold one in an unexported
+// field, so its methods and settings are not part of their public API.
+type BaseClient struct {
+	// BaseURL is the base URL of the service API
+	BaseURL *url.URL
+
+	// HTTPClient is the HTTP client used for making requests
+	HTTPClient *http.Client
+
+	// UserAgent is the user agent sent with each request
+	UserAgent string
+
+	// TokenProvider, if set, supplies the bearer token sent with each request
+	TokenProvider TokenProvider
+
+	// RateLimiter, if set, throttles the requests sent by Do
+	RateLimiter *RateLimiter
+
+	// ConcurrencyLimiter, if set, bounds the requests of Do and Stream in flight at once
+	ConcurrencyLimiter *ConcurrencyLimiter
+
+	// Clock, if set, replaces SystemClock for rate limiting and request timing
+	Clock Clock
+
+	// ResponseCache, if set, revalidates and caches the GET responses of Do
+	ResponseCache *ResponseCache
+
+	// RefreshOn401 makes Do and Stream retry a request rejected as unauthorized
+	// once, with a fresh token from TokenProvider
+	RefreshOn401 bool
+
+	// RetryPolicy, if set, retries the requests of Do that fail transiently
+	RetryPolicy *RetryPolicy
+
+	// BodyLogger, if set, receives the JSON bodies of the POST, PUT, and PATCH
+	// requests created by NewRequest, with secrets redacted
+	BodyLogger Logger
+
+	// Service names the service, such as "ai", in the metrics and deprecation
+	// warnings of RequestOptions
+	Service string
+
+	// RequestTimeout, if positive, bounds each call through a per-call context deadline
+	RequestTimeout time.Duration
+
+	// MaxResponseBytes limits the size of API response bodies; zero uses the default
+	MaxResponseBytes int64
+
+	// RawErrorBody attaches full response bodies to returned API errors
+	RawErrorBody bool
+
+	// Metrics, if set, receives the outcome and latency of every call
+	Metrics MetricsRecorder
+
+	// Stats, if set, counts calls and samples their latencies
+	Stats *StatsCollector
+
+	// Logger receives diagnostic messages such as deprecation warnings
+	Logger Logger
+
+	// DeprecationCallback is called when a response announces a deprecation
+	DeprecationCallback func(DeprecationNotice)
+
+	// LogRequestBodies makes Configure set BodyLogger to Logger, or to the
+	// standard library's default logger
+	LogRequestBodies bool
+
+	// ConnectionPool, if set, tunes the transport Configure gives the default HTTP client
+	ConnectionPool *ConnectionPool
+
+	// TLS holds the TLS settings Configure gives the default HTTP client
+	TLS TLSOptions
+
+	// ForceHTTP1 makes Configure limit the default HTTP client to HTTP/1.1
+	ForceHTTP1 bool
+}
+
+// NewRequest creates an API request for path, relative to BaseURL. A non-nil
+// body is encoded as JSON.
+func (b *BaseClient) NewRequest(ctx context.Context, method, path string, body interface{}) (*http.Request, error) {
+	return b.NewRequestWithBase(ctx, b.BaseURL, method, path, body)
+}
+
+// NewRequestWithBase creates an API request for path, relative to base instead
+// of BaseURL. A non-nil body is encoded as JSON. The Accept, User-Agent,
+// X-Request-ID, Content-Type (for JSON bodies), and Authorization headers are set.
+// JSON bodies of POST, PUT, and PATCH requests are logged to BodyLogger, if set.
+func (b *BaseClient) NewRequestWithBase(ctx context.Context, base *url.URL, method, path string, body interface{}) (*http.Request, error) {
+	u := base.JoinPath(path)
+
+	// A nil *bytes.Buffer must not be passed as a non-nil io.Reader
+	var req *http.Request
+	var err error
+	if body != nil {
+		buf := new(bytes.Buffer)
+		if err := json.NewEncoder(buf).Encode(body); err != nil {
+			return nil, err
+		}
+		req, err = http.NewRequestWithContext(ctx, method, u.String(), buf)
+		if err == nil && b.BodyLogger != nil {
+			logRequestBody(b.BodyLogger, req, buf.Bytes())
+		}
+	} else {
+		req, err = http.NewRequestWithContext(ctx, method, u.String(), nil)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", b.UserAgent)
+	if err := SetRequestID(req); err != nil {
+		return nil, err
+	}
+
+	if err := b.Authorize(req); err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// Authorize sets the Authorization header on req to the token carried by its
+// context through WithAuthToken, or otherwise to one from TokenProvider. It
+// does nothing if neither supplies a token.
+func (b *BaseClient) Authorize(req *http.Request) error {
+	if token := AuthTokenFromContext(req.Context()); token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+		return nil
+	}
+	if b.TokenProvider == nil {
+		return nil
+	}
+
+	token, err := b.TokenProvider.GetToken(req.Context())
+	if err != nil {
+		return fmt.Errorf("failed to get token from provider: %w", err)
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	return nil
+}
+
+// Do sends req with HTTPClient through ExecuteRequest, decoding a successful
+// response into v. If ConcurrencyLimiter is set, Do first takes a slot from
+// it, holding the slot until the response is decoded, including any retries.
+// If RateLimiter is set, Do then waits for it. If ResponseCache is set, GET
+// responses are revalidated against it. If RetryPolicy is set, failed
+// attempts are retried under it, each retry also waiting for RateLimiter. If
+// RefreshOn401 is set, a 401 response is retried once with a fresh token.
+func (b *BaseClient) Do(req *http.Request, v interface{}, opts ...RequestOption) (*http.Response, error) {
+	resp, err := b.do(req, v, opts...)
+	if retry := b.refreshedRequest(req, err); retry != nil {
+		return b.do(retry, v, opts...)
+	}
+	return resp, err
+}
+
+// do implements Do, apart from retrying unauthorized requests
+func (b *BaseClient) do(req *http.Request, v interface{}, opts ...RequestOption) (*http.Response, error) {
+	if err := b.ConcurrencyLimiter.acquire(req.Context()); err != nil {
+		return nil, err
+	}
+	defer b.ConcurrencyLimiter.release()
+
+	clock := ClockOrSystem(b.Clock)
+	if err := b.RateLimiter.wait(req.Context(), clock); err != nil {
+		return nil, err
+	}
+	defaults := []RequestOption{WithClock(clock), WithResponseCache(b.ResponseCache)}
+	httpClient := withRetries(b.HTTPClient, b.RetryPolicy, clock, b.RateLimiter)
+	return ExecuteRequest(req.Context(), httpClient, req, v, append(defaults, opts...)...)
+}
+
+// Stream sends req with HTTPClient through OpenStream and returns the unread
+// body of a successful response. If ConcurrencyLimiter is set, Stream first
+// takes a slot from it, holding the slot until the returned body is closed.
+// If RateLimiter is set, Stream then waits for it. If RefreshOn401 is set, a
+// 401 response is retried once with a fresh token. The caller must close the
+// returned body.
+func (b *BaseClient) Stream(req *http.Request, opts ...RequestOption) (io.ReadCloser, error) {
+	body, err := b.stream(req, opts...)
+	if retry := b.refreshedRequest(req, err); retry != nil {
+		return b.stream(retry, opts...)
+	}
+	return body, err
+}
+
+// stream implements Stream, apart from retrying unauthorized requests
+func (b *BaseClient) stream(req *http.Request, opts ...RequestOption) (io.ReadCloser, error) {
+	if err := b.ConcurrencyLimiter.acquire(req.Context()); err != nil {
+		return nil, err
+	}
+
+	clock := ClockOrSystem(b.Clock)
+	if err := b.RateLimiter.wait(req.Context(), clock); err != nil {
+		b.ConcurrencyLimiter.release()
+		return nil, err
+	}
+	body, err := OpenStream(req.Context(), b.HTTPClient, req, append([]RequestOption{WithClock(clock)}, opts...)...)
+	if err != nil {
+		b.ConcurrencyLimiter.release()
+		return nil, err
+	}
+	if b.ConcurrencyLimiter == nil {
+		return body, nil
+	}
+	return &releasingBody{ReadCloser: body, limiter: b.ConcurrencyLimiter}, nil
+}
+
+// refreshedRequest returns a copy of req carrying a fresh token if RefreshOn401
+// is set and err rejected req's token, or nil if req should not be retried.
+// A request whose body cannot be replayed, or whose token came from its
+// context, is not retried.
+func (b *BaseClient) refreshedRequest(req *http.Request, err error) *http.Request {
+	var apiErr *apierror.ErrorResponse
+	if !b.RefreshOn401 || b.TokenProvider == nil || !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusUnauthorized {
+		return nil
+	}
+	// A token from the context did not come from TokenProvider, so a fresh
+	// one would be the same
+	if AuthTokenFromContext(req.Context()) != "" {
+		return nil
+	}
+
+	retry := req.Clone(req.Context())
+	if req.Body != nil && req.Body != http.NoBody {
+		if req.GetBody == nil {
+			return nil
+		}
+		body, err := req.GetBody()
+		if err != nil {
+			return nil
+		}
+		retry.Body = body
+	}
+
+	if invalidator, ok := b.TokenProvider.(TokenInvalidator); ok {
+		invalidator.Invalidate()
+	}
+	retry.Header.Del("Authorization")
+	if err := b.Authorize(retry); err != nil {
+		return nil
+	}
+	return retry
+}
+
+// Close closes any idle keep-alive connections held by HTTPClient's transport.
+// Connections in use are not interrupted, and the client remains usable
+// afterwards; new requests simply open new connections. Transports that do
+// not pool connections are left alone, as is http.DefaultTransport, which is
+// shared with the rest of the process. Close always returns nil and is safe
+// to call more than once.
+func (b *BaseClient) Close() error {
+	if b.HTTPClient == nil {
+		return nil
+	}
+	// A nil Transport means http.DefaultTransport
+	if transport := b.HTTPClient.Transport; transport == nil || transport == http.DefaultTransport {
+		return nil
+	}
+	// http.Client.CloseIdleConnections only calls through to transports
+	// that implement CloseIdleConnections
+	b.HTTPClient.CloseIdleConnections()
+	return nil
+}
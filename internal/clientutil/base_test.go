@@ -0,0 +1,343 @@
+package clientutil
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type staticTokenProvider struct {
+	token string
+	err   error
+}
+
+func (p staticTokenProvider) GetToken(ctx context.Context) (string, error) {
+	return p.token, p.err
+}
+
+func newBaseClient(t *testing.T, rawURL string) *BaseClient {
+	t.Helper()
+	u, err := url.Parse(rawURL)
+	require.NoError(t, err)
+	return &BaseClient{BaseURL: u, HTTPClient: http.DefaultClient, UserAgent: "test-agent/1.0"}
+}
+
+func TestBaseClient_NewRequest(t *testing.T) {
+	b := newBaseClient(t, "https://api.example.com")
+
+	req, err := b.NewRequest(context.Background(), "POST", "/items", map[string]string{"name": "x"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "POST", req.Method)
+	assert.Equal(t, "https://api.example.com/items", req.URL.String())
+	assert.Equal(t, "application/json", req.Header.Get("Content-Type"))
+	assert.Equal(t, "application/json", req.Header.Get("Accept"))
+	assert.Equal(t, "test-agent/1.0", req.Header.Get("User-Agent"))
+	assert.Empty(t, req.Header.Get("Authorization"))
+
+	var body map[string]string
+	require.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+	assert.Equal(t, "x", body["name"])
+}
+
+func TestBaseClient_NewRequest_GetBody(t *testing.T) {
+	b := newBaseClient(t, "https://api.example.com")
+
+	req, err := b.NewRequest(context.Background(), "POST", "/items", map[string]string{"name": "x"})
+	require.NoError(t, err)
+	sent, err := io.ReadAll(req.Body)
+	require.NoError(t, err)
+	require.NotNil(t, req.GetBody)
+
+	// Each call returns the full body, however much was read before
+	for i := 0; i < 2; i++ {
+		body, err := req.GetBody()
+		require.NoError(t, err)
+		replayed, err := io.ReadAll(body)
+		require.NoError(t, err)
+		assert.Equal(t, sent, replayed)
+	}
+	assert.Equal(t, int64(len(sent)), req.ContentLength)
+}
+
+func TestBaseClient_NewRequest_NoBody(t *testing.T) {
+	b := newBaseClient(t, "https://api.example.com")
+
+	req, err := b.NewRequest(context.Background(), "GET", "/items", nil)
+	require.NoError(t, err)
+	assert.Empty(t, req.Header.Get("Content-Type"))
+	assert.Nil(t, req.Body)
+}
+
+func TestBaseClient_NewRequest_URLJoining(t *testing.T) {
+	tests := []struct {
+		base string
+		path string
+		want string
+	}{
+		{"https://api.example.com", "/items", "https://api.example.com/items"},
+		{"https://api.example.com/", "/items", "https://api.example.com/items"},
+		{"https://api.example.com/v1", "/items/1", "https://api.example.com/v1/items/1"},
+		{"https://api.example.com/v1/", "items", "https://api.example.com/v1/items"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.base+tt.path, func(t *testing.T) {
+			b := newBaseClient(t, tt.base)
+			req, err := b.NewRequest(context.Background(), "GET", tt.path, nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, req.URL.String())
+		})
+	}
+}
+
+func TestBaseClient_NewRequestWithBase(t *testing.T) {
+	b := newBaseClient(t, "https://api.example.com")
+	other, _ := url.Parse("https://admin.example.com/v2")
+
+	req, err := b.NewRequestWithBase(context.Background(), other, "GET", "/items", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "https://admin.example.com/v2/items", req.URL.String())
+	assert.Equal(t, "test-agent/1.0", req.Header.Get("User-Agent"))
+}
+
+func TestBaseClient_Authorize(t *testing.T) {
+	t.Run("token", func(t *testing.T) {
+		b := newBaseClient(t, "https://api.example.com")
+		b.TokenProvider = staticTokenProvider{token: "abc"}
+
+		req, err := b.NewRequest(context.Background(), "GET", "/items", nil)
+		require.NoError(t, err)
+		assert.Equal(t, "Bearer abc", req.Header.Get("Authorization"))
+	})
+
+	t.Run("empty token", func(t *testing.T) {
+		b := newBaseClient(t, "https://api.example.com")
+		b.TokenProvider = staticTokenProvider{}
+
+		req, err := b.NewRequest(context.Background(), "GET", "/items", nil)
+		require.NoError(t, err)
+		assert.Empty(t, req.Header.Get("Authorization"))
+	})
+
+	t.Run("provider error", func(t *testing.T) {
+		b := newBaseClient(t, "https://api.example.com")
+		providerErr := errors.New("token unavailable")
+		b.TokenProvider = staticTokenProvider{err: providerErr}
+
+		req, err := b.NewRequest(context.Background(), "GET", "/items", nil)
+		assert.ErrorIs(t, err, providerErr)
+		assert.Nil(t, req)
+	})
+
+	t.Run("context token", func(t *testing.T) {
+		b := newBaseClient(t, "https://api.example.com")
+		b.TokenProvider = staticTokenProvider{err: errors.New("provider must not be called")}
+
+		ctx := WithAuthToken(context.Background(), "user-token")
+		req, err := b.NewRequest(ctx, "GET", "/items", nil)
+		require.NoError(t, err)
+		assert.Equal(t, "Bearer user-token", req.Header.Get("Authorization"))
+	})
+
+	t.Run("context token without provider", func(t *testing.T) {
+		b := newBaseClient(t, "https://api.example.com")
+
+		ctx := WithAuthToken(context.Background(), "user-token")
+		req, err := b.NewRequest(ctx, "GET", "/items", nil)
+		require.NoError(t, err)
+		assert.Equal(t, "Bearer user-token", req.Header.Get("Authorization"))
+	})
+
+	t.Run("empty context token", func(t *testing.T) {
+		b := newBaseClient(t, "https://api.example.com")
+		b.TokenProvider = staticTokenProvider{token: "abc"}
+
+		ctx := WithAuthToken(context.Background(), "")
+		req, err := b.NewRequest(ctx, "GET", "/items", nil)
+		require.NoError(t, err)
+		assert.Equal(t, "Bearer abc", req.Header.Get("Authorization"))
+	})
+}
+
+func TestBaseClient_Do(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(body)
+	}))
+	defer server.Close()
+
+	b := newBaseClient(t, server.URL)
+	req, err := b.NewRequest(context.Background(), "POST", "/echo", map[string]string{"name": "x"})
+	require.NoError(t, err)
+
+	var result map[string]string
+	resp, err := b.Do(req, &result)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "x", result["name"])
+}
+
+func TestExecuteRequest_WithTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(time.Second):
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+
+	req, _ := http.NewRequestWithContext(context.Background(), "GET", server.URL, nil)
+	_, err := ExecuteRequest(context.Background(), http.DefaultClient, req, nil, WithTimeout(20*time.Millisecond))
+	require.Error(t, err)
+}
+
+func TestBaseClient_DoWaitsForRateLimiter(t *testing.T) {
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+	}))
+	defer server.Close()
+
+	b := newBaseClient(t, server.URL)
+	b.RateLimiter = NewRateLimiter(0.5, 1)
+
+	req, err := b.NewRequest(context.Background(), "GET", "/items", nil)
+	require.NoError(t, err)
+	_, err = b.Do(req, nil)
+	require.NoError(t, err)
+
+	// The bucket is empty, so the next request waits past its deadline
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	req, err = b.NewRequest(ctx, "GET", "/items", nil)
+	require.NoError(t, err)
+	_, err = b.Do(req, nil)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&hits))
+}
+
+// idleCloserTransport is a non-standard transport that counts CloseIdleConnections calls
+type idleCloserTransport struct {
+	closes int32
+}
+
+func (t *idleCloserTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (t *idleCloserTransport) CloseIdleConnections() {
+	atomic.AddInt32(&t.closes, 1)
+}
+
+// plainTransport is a non-standard transport without CloseIdleConnections
+type plainTransport struct{}
+
+func (plainTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return nil, errors.New("not implemented")
+}
+
+func TestBaseClient_Close(t *testing.T) {
+	t.Run("fresh client", func(t *testing.T) {
+		b := &BaseClient{HTTPClient: &http.Client{}}
+		assert.NoError(t, b.Close())
+		assert.NoError(t, b.Close())
+	})
+
+	t.Run("nil HTTP client", func(t *testing.T) {
+		assert.NoError(t, (&BaseClient{}).Close())
+	})
+
+	t.Run("idle closer transport", func(t *testing.T) {
+		transport := &idleCloserTransport{}
+		b := &BaseClient{HTTPClient: &http.Client{Transport: transport}}
+		assert.NoError(t, b.Close())
+		assert.Equal(t, int32(1), atomic.LoadInt32(&transport.closes))
+	})
+
+	t.Run("default transport", func(t *testing.T) {
+		// Another user of http.DefaultTransport holds an idle connection
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		defer server.Close()
+		var newConns int32
+		server.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+			if state == http.StateNew {
+				atomic.AddInt32(&newConns, 1)
+			}
+		}
+		get := func() {
+			resp, err := http.Get(server.URL)
+			require.NoError(t, err)
+			_, _ = io.Copy(io.Discard, resp.Body)
+			require.NoError(t, resp.Body.Close())
+		}
+		get()
+
+		for _, transport := range []http.RoundTripper{nil, http.DefaultTransport} {
+			b := &BaseClient{HTTPClient: &http.Client{Transport: transport, Timeout: time.Second}}
+			assert.NoError(t, b.Close())
+		}
+
+		get()
+		assert.Equal(t, int32(1), atomic.LoadInt32(&newConns), "Close dropped a connection of http.DefaultTransport")
+	})
+
+	t.Run("transport without idle closer", func(t *testing.T) {
+		b := &BaseClient{HTTPClient: &http.Client{Transport: plainTransport{}}}
+		assert.NoError(t, b.Close())
+	})
+}
+
+func TestBaseClient_CloseKeepsClientUsable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"ok":"yes"}`))
+	}))
+	defer server.Close()
+
+	b := newBaseClient(t, server.URL)
+	b.HTTPClient = &http.Client{Transport: &http.Transport{}}
+	for i := 0; i < 2; i++ {
+		req, err := b.NewRequest(context.Background(), "GET", "/", nil)
+		require.NoError(t, err)
+		var result map[string]string
+		_, err = b.Do(req, &result)
+		require.NoError(t, err)
+		assert.Equal(t, "yes", result["ok"])
+		require.NoError(t, b.Close())
+	}
+}
+
+func TestBaseClient_RefreshOn401SkipsUnreplayableBody(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	b := newBaseClient(t, server.URL)
+	b.TokenProvider = staticTokenProvider{token: "stale"}
+	b.RefreshOn401 = true
+
+	// A body without GetBody cannot be sent twice
+	req, err := http.NewRequest("POST", server.URL, io.MultiReader(strings.NewReader("payload")))
+	require.NoError(t, err)
+	require.NoError(t, b.Authorize(req))
+
+	_, err = b.Do(req, nil)
+	require.Error(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
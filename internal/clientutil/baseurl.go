@@ -0,0 +1,25 @@
+package clientutil
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+)
+
+// ParseBaseURL parses a service base URL, rejecting URLs that lack a scheme or
+// host. Without this check a URL such as "example.com" parses as a bare path
+// and every request made with it fails at send time with an opaque error.
+// URLs with paths and trailing slashes are accepted.
+func ParseBaseURL(raw string) (*url.URL, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid base URL: %w", err)
+	}
+	if u.Scheme == "" {
+		return nil, errors.New("invalid base URL: missing scheme")
+	}
+	if u.Host == "" {
+		return nil, errors.New("invalid base URL: missing host")
+	}
+	return u, nil
+}
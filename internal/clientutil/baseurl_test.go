@@ -0,0 +1,37 @@
+package clientutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseBaseURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		wantErr string
+	}{
+		{name: "valid", raw: "https://api.example.com"},
+		{name: "path and trailing slash", raw: "https://api.example.com/v1/"},
+		{name: "port", raw: "http://localhost:8080"},
+		{name: "unparsable", raw: ":", wantErr: "invalid base URL: parse"},
+		{name: "missing scheme", raw: "api.example.com", wantErr: "invalid base URL: missing scheme"},
+		{name: "missing host", raw: "https:///v1", wantErr: "invalid base URL: missing host"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, err := ParseBaseURL(tt.raw)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				assert.Nil(t, u)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.raw, u.String())
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package clientutil
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// RedactedValue replaces the values of sensitive fields in logged request bodies.
+const RedactedValue = "***"
+
+// sensitiveFields are the JSON field names, compared case-insensitively, whose
+// values are never logged
+var sensitiveFields = map[string]bool{
+	"password":      true,
+	"new_password":  true,
+	"client_secret": true,
+	"access_token":  true,
+	"refresh_token": true,
+}
+
+// logRequestBody writes the JSON body of a POST, PUT, or PATCH request to
+// logger, with the values of sensitive fields replaced by RedactedValue
+func logRequestBody(logger Logger, req *http.Request, body []byte) {
+	if logger == nil {
+		return
+	}
+	switch req.Method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch:
+	default:
+		return
+	}
+	logger.Printf("atriumn: %s %s request body: %s", req.Method, req.URL.Path, RedactJSON(body))
+}
+
+// RedactJSON returns body with the values of fields named password,
+// new_password, client_secret, access_token, or refresh_token replaced by
+// RedactedValue, at any depth. A body that is not valid JSON is not returned,
+// since it cannot be redacted; a placeholder is returned instead.
+func RedactJSON(body []byte) string {
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return "<unparsable body omitted>"
+	}
+	redacted, err := json.Marshal(redact(v))
+	if err != nil {
+		return "<unparsable body omitted>"
+	}
+	return string(redacted)
+}
+
+// redact replaces the values of sensitive fields in v, which holds decoded JSON
+func redact(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			if sensitiveFields[strings.ToLower(key)] {
+				v[key] = RedactedValue
+			} else {
+				v[key] = redact(value)
+			}
+		}
+	case []interface{}:
+		for i, value := range v {
+			v[i] = redact(value)
+		}
+	}
+	return v
+}
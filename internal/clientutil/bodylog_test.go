@@ -0,0 +1,75 @@
+package clientutil
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedactJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{
+			"top level",
+			`{"username":"ada","password":"hunter2"}`,
+			`{"password":"***","username":"ada"}`,
+		},
+		{
+			"all sensitive fields",
+			`{"password":"a","new_password":"b","client_secret":"c","access_token":"d","refresh_token":"e","token_type":"Bearer"}`,
+			`{"access_token":"***","client_secret":"***","new_password":"***","password":"***","refresh_token":"***","token_type":"Bearer"}`,
+		},
+		{
+			"nested and in arrays",
+			`{"items":[{"Password":"x","n":1}],"auth":{"refresh_token":{"v":"y"}}}`,
+			`{"auth":{"refresh_token":"***"},"items":[{"Password":"***","n":1}]}`,
+		},
+		{
+			"large numbers kept exact",
+			`{"size":12345678901234567890}`,
+			`{"size":12345678901234567890}`,
+		},
+		{
+			"not JSON",
+			`password=hunter2`,
+			`<unparsable body omitted>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, RedactJSON([]byte(tt.body)))
+		})
+	}
+}
+
+func TestNewRequest_BodyLogger(t *testing.T) {
+	logger := &capturingLogger{}
+	base, err := url.Parse("https://api.example.com")
+	require.NoError(t, err)
+	b := &BaseClient{BaseURL: base, BodyLogger: logger}
+
+	body := map[string]string{"username": "ada", "password": "hunter2"}
+	for _, method := range []string{"GET", "DELETE", "POST", "PUT", "PATCH"} {
+		_, err := b.NewRequest(context.Background(), method, "/auth/login", body)
+		require.NoError(t, err)
+	}
+
+	require.Len(t, logger.messages, 3)
+	for i, method := range []string{"POST", "PUT", "PATCH"} {
+		assert.Equal(t, "atriumn: "+method+` /auth/login request body: {"password":"***","username":"ada"}`, logger.messages[i])
+		assert.False(t, strings.Contains(logger.messages[i], "hunter2"))
+	}
+
+	// Requests without a body are not logged
+	_, err = b.NewRequest(context.Background(), "POST", "/auth/logout", nil)
+	require.NoError(t, err)
+	assert.Len(t, logger.messages, 3)
+}
@@ -3,29 +3,214 @@
 package clientutil
 
 import (
+	"bufio"
 	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/atriumn/atriumn-sdk-go/internal/apierror"
 )
 
+const (
+	// MaxBodySnippet is the maximum number of response body bytes quoted in a parse_error description
+	MaxBodySnippet = 512
+
+	// DefaultMaxResponseBytes is the default limit on a response body read by ExecuteRequest (10 MiB)
+	DefaultMaxResponseBytes = 10 * 1024 * 1024
+)
+
+// RequestOption configures a single ExecuteRequest call.
+type RequestOption func(*requestOptions)
+
+// requestOptions holds the settings applied by RequestOption functions.
+type requestOptions struct {
+	rawBody  bool
+	maxBytes int64
+	timeout  time.Duration
+	metrics  *metrics
+	stats    *StatsCollector
+	clock    Clock
+	cache    *ResponseCache
+
+	deprecation *deprecation
+}
+
+// newRequestOptions applies opts over the defaults
+func newRequestOptions(opts []RequestOption) *requestOptions {
+	options := &requestOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+	if options.maxBytes <= 0 {
+		options.maxBytes = DefaultMaxResponseBytes
+	}
+	options.clock = ClockOrSystem(options.clock)
+	return options
+}
+
+// WithRawBody attaches the full response body to the RawBody field of errors
+// returned for unparsable successful responses and for error-status responses.
+// It is off by default because error bodies may contain sensitive data.
+func WithRawBody() RequestOption {
+	return func(o *requestOptions) {
+		o.rawBody = true
+	}
+}
+
+// WithMaxResponseBytes limits how many bytes of the (decoded) response body are
+// read. A value of zero or less uses DefaultMaxResponseBytes.
+func WithMaxResponseBytes(n int64) RequestOption {
+	return func(o *requestOptions) {
+		o.maxBytes = n
+	}
+}
+
+// WithTimeout bounds the request, including reading the response body, with a
+// context deadline. A shorter deadline on the request's context still applies.
+// A value of zero or less adds no deadline.
+func WithTimeout(d time.Duration) RequestOption {
+	return func(o *requestOptions) {
+		o.timeout = d
+	}
+}
+
+// WithClock sets the clock used to time the request for WithMetrics and to
+// interpret Retry-After dates. A nil clock uses SystemClock.
+func WithClock(clock Clock) RequestOption {
+	return func(o *requestOptions) {
+		o.clock = clock
+	}
+}
+
 // ExecuteRequest sends an API request and returns the API response.
 // It handles:
-// - Sending the request using httpClient.Do(req)
-// - Network error handling and wrapping into apierror.ErrorResponse
-// - Reading the response body exactly once
-// - Closing the response body
-// - Status code checking
-// - Parsing error responses into apierror.ErrorResponse
-// - Generating fallback error messages for empty/unparsable error responses
-// - Unmarshalling successful responses into the provided value
-func ExecuteRequest(ctx context.Context, httpClient *http.Client, req *http.Request, v interface{}) (*http.Response, error) {
+//   - Sending the request using httpClient.Do(req)
+//   - Bounding the request with the WithTimeout deadline, if set
+//   - Network error handling and wrapping into apierror.ErrorResponse
+//   - Reading the response body exactly once, decoding gzip and deflate Content-Encoding
+//   - Rejecting bodies larger than the response size limit with a response_too_large error
+//   - Closing the response body
+//   - Status code checking
+//   - Parsing error responses into apierror.ErrorResponse, recording the HTTP status code
+//   - Generating fallback error messages for empty/unparsable error responses
+//   - Unmarshalling successful responses into the provided value, quoting the start
+//     of the body in the parse_error description if that fails
+//   - Recording the request's X-Request-ID on returned apierror.ErrorResponse values
+//   - Reporting the outcome and latency to the WithMetrics recorder and
+//     WithStats collector, if set
+//   - Reporting Deprecation and Sunset response headers as WithDeprecationWarnings asks
+//   - Revalidating GET requests against the WithResponseCache cache, if set, and
+//     decoding the cached body when the service answers 304 Not Modified; the
+//     returned response keeps its 304 status
+func ExecuteRequest(ctx context.Context, httpClient *http.Client, req *http.Request, v interface{}, opts ...RequestOption) (*http.Response, error) {
+	options := newRequestOptions(opts)
+	start := options.clock.Now()
+	resp, err := executeRequest(ctx, httpClient, req, v, options)
+	if apiErr, ok := err.(*apierror.ErrorResponse); ok {
+		apiErr.RequestID = req.Header.Get(RequestIDHeader)
+	}
+	latency := options.clock.Now().Sub(start)
+	options.metrics.record(latency, responseStatus(resp), err)
+	options.stats.record(latency, err)
+	return resp, err
+}
+
+// executeRequest implements ExecuteRequest, apart from recording the request ID on errors
+func executeRequest(ctx context.Context, httpClient *http.Client, req *http.Request, v interface{}, options *requestOptions) (*http.Response, error) {
+	if options.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(req.Context(), options.timeout)
+		defer cancel()
+		req = req.WithContext(ctx)
+	}
+
+	// Revalidate a cached response rather than fetching it again
+	key := cacheKey(req)
+	cached := options.cache.get(key)
+	if cached != nil {
+		req.Header.Set("If-None-Match", cached.etag)
+	}
+
 	// Send the request
+	resp, err := sendRequest(httpClient, req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	options.deprecation.check(req, resp)
+
+	// Read the response body, decoding it if the server compressed it
+	body, err := decodeBody(resp)
+	if err != nil {
+		return resp, &apierror.ErrorResponse{
+			ErrorCode:   "read_error",
+			Description: fmt.Sprintf("Failed to decode %s response body: %v", resp.Header.Get("Content-Encoding"), err),
+		}
+	}
+	bodyBytes, err := io.ReadAll(io.LimitReader(body, options.maxBytes+1))
+	if err != nil {
+		return resp, &apierror.ErrorResponse{
+			ErrorCode:   "read_error",
+			Description: fmt.Sprintf("Failed to read response body: %v", err),
+		}
+	}
+	if int64(len(bodyBytes)) > options.maxBytes {
+		return nil, &apierror.ErrorResponse{
+			ErrorCode:   "response_too_large",
+			Description: fmt.Sprintf("The response body exceeds the maximum of %d bytes", options.maxBytes),
+			StatusCode:  resp.StatusCode,
+		}
+	}
+
+	// A 304 confirms the cached body is still current
+	notModified := resp.StatusCode == http.StatusNotModified && cached != nil
+	if notModified {
+		bodyBytes = cached.body
+	}
+
+	// Reset the body with a new ReadCloser for further processing if needed
+	resp.Body = io.NopCloser(bytes.NewBuffer(bodyBytes))
+
+	// Handle non-success status codes
+	if (resp.StatusCode < 200 || resp.StatusCode >= 300) && !notModified {
+		return nil, statusError(resp, bodyBytes, options)
+	}
+
+	// Handle successful response
+	if v != nil && len(bodyBytes) > 0 {
+		err = json.Unmarshal(bodyBytes, v)
+		if err != nil {
+			parseErr := &apierror.ErrorResponse{
+				ErrorCode:   "parse_error",
+				Description: fmt.Sprintf("Failed to parse the successful response: %v. Body: %s", err, bodySnippet(bodyBytes)),
+				StatusCode:  resp.StatusCode,
+			}
+			if options.rawBody {
+				parseErr.RawBody = bodyBytes
+			}
+			return nil, parseErr
+		}
+	}
+
+	if !notModified {
+		options.cache.put(key, resp.Header.Get("ETag"), bodyBytes)
+	}
+
+	return resp, nil
+}
+
+// sendRequest sends req, turning transport failures into API errors
+func sendRequest(httpClient *http.Client, req *http.Request) (*http.Response, error) {
 	resp, err := httpClient.Do(req)
 	if err != nil {
 		// Handle network-level errors
@@ -47,77 +232,166 @@ func ExecuteRequest(ctx context.Context, httpClient *http.Client, req *http.Requ
 			Description: fmt.Sprintf("Failed to connect to the service: %v", err),
 		}
 	}
-	defer func() { _ = resp.Body.Close() }()
+	return resp, nil
+}
 
-	// Read the response body
-	bodyBytes, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return resp, &apierror.ErrorResponse{
-			ErrorCode:   "read_error",
-			Description: fmt.Sprintf("Failed to read response body: %v", err),
-		}
+// statusError builds the API error for a non-2xx response with the given body
+func statusError(resp *http.Response, bodyBytes []byte, options *requestOptions) error {
+	var errResp apierror.ErrorResponse
+	if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable {
+		errResp.RetryAfter = retryAfter(resp.Header, options.clock.Now())
 	}
+	errResp.ValidationErrors = validationErrors(bodyBytes)
 
-	// Reset the body with a new ReadCloser for further processing if needed
-	resp.Body = io.NopCloser(bytes.NewBuffer(bodyBytes))
-
-	// Handle non-success status codes
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		var errResp apierror.ErrorResponse
-
-		// Try to unmarshal the error response
-		if len(bodyBytes) > 0 {
-			if jsonErr := json.Unmarshal(bodyBytes, &errResp); jsonErr == nil &&
-				(errResp.ErrorCode != "" || errResp.Description != "") {
-				// Successfully parsed error with at least some data
-				return nil, &errResp
+	// Try to unmarshal the error response
+	if len(bodyBytes) > 0 {
+		if jsonErr := json.Unmarshal(bodyBytes, &errResp); jsonErr == nil &&
+			(errResp.ErrorCode != "" || errResp.Description != "") {
+			// Successfully parsed error with at least some data
+			errResp.StatusCode = resp.StatusCode
+			if options.rawBody {
+				errResp.RawBody = bodyBytes
 			}
+			return &errResp
 		}
+	}
 
-		// Create a user-friendly error based on status code if parsing failed
-		// or the error response was empty
-		switch resp.StatusCode {
-		case http.StatusBadRequest:
-			errResp.ErrorCode = "bad_request"
-			errResp.Description = "The request was invalid. Please check your input and try again."
-		case http.StatusUnauthorized:
-			errResp.ErrorCode = "unauthorized"
-			errResp.Description = "Authentication failed. Please check your credentials or login again."
-		case http.StatusForbidden:
-			errResp.ErrorCode = "forbidden"
-			errResp.Description = "You don't have permission to access this resource."
-		case http.StatusNotFound:
-			errResp.ErrorCode = "not_found"
-			errResp.Description = "The requested resource was not found."
-		case http.StatusTooManyRequests:
-			errResp.ErrorCode = "rate_limited"
-			errResp.Description = "Too many requests. Please try again later."
-		case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
-			errResp.ErrorCode = "server_error"
-			errResp.Description = "The service is currently unavailable. Please try again later."
-		default:
-			errResp.ErrorCode = "unknown_error"
-			errResp.Description = fmt.Sprintf("Unexpected HTTP status: %d", resp.StatusCode)
-		}
+	// Create a user-friendly error based on status code if parsing failed
+	// or the error response was empty
+	errResp.StatusCode = resp.StatusCode
+	switch resp.StatusCode {
+	case http.StatusBadRequest:
+		errResp.ErrorCode = "bad_request"
+		errResp.Description = "The request was invalid. Please check your input and try again."
+	case http.StatusUnauthorized:
+		errResp.ErrorCode = "unauthorized"
+		errResp.Description = "Authentication failed. Please check your credentials or login again."
+	case http.StatusForbidden:
+		errResp.ErrorCode = "forbidden"
+		errResp.Description = "You don't have permission to access this resource."
+	case http.StatusNotFound:
+		errResp.ErrorCode = "not_found"
+		errResp.Description = "The requested resource was not found."
+	case http.StatusConflict:
+		errResp.ErrorCode = "conflict"
+		errResp.Description = "The request conflicts with the current state of the resource."
+	case http.StatusTooManyRequests:
+		errResp.ErrorCode = "rate_limited"
+		errResp.Description = "Too many requests. Please try again later."
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		errResp.ErrorCode = "server_error"
+		errResp.Description = "The service is currently unavailable. Please try again later."
+	default:
+		errResp.ErrorCode = "unknown_error"
+		errResp.Description = fmt.Sprintf("Unexpected HTTP status: %d", resp.StatusCode)
+	}
 
-		// Include response body for unknown errors if available
-		if errResp.ErrorCode == "unknown_error" && len(bodyBytes) > 0 {
-			errResp.Description += fmt.Sprintf(" Body: %s", string(bodyBytes))
-		}
+	// Include response body for unknown errors if available
+	if errResp.ErrorCode == "unknown_error" && len(bodyBytes) > 0 {
+		errResp.Description += fmt.Sprintf(" Body: %s", string(bodyBytes))
+	}
+	if options.rawBody {
+		errResp.RawBody = bodyBytes
+	}
+
+	return &errResp
+}
 
-		return nil, &errResp
+// validationErrors returns the field errors in the details array of an error
+// response body, or nil if the body has none. Details of any other shape are
+// ignored so they do not hide the rest of the error.
+func validationErrors(bodyBytes []byte) []apierror.FieldError {
+	var body struct {
+		Details []apierror.FieldError `json:"details"`
 	}
+	if len(bodyBytes) == 0 || json.Unmarshal(bodyBytes, &body) != nil || len(body.Details) == 0 {
+		return nil
+	}
+	return body.Details
+}
 
-	// Handle successful response
-	if v != nil && len(bodyBytes) > 0 {
-		err = json.Unmarshal(bodyBytes, v)
+// decodeBody returns a reader over resp.Body that undoes a gzip or deflate
+// Content-Encoding. Other encodings, and responses without a body, are
+// returned unchanged. Once a body is decoded, the Content-Encoding and
+// Content-Length headers no longer describe it, so they are removed.
+func decodeBody(resp *http.Response) (io.Reader, error) {
+	encoding := strings.ToLower(strings.TrimSpace(resp.Header.Get("Content-Encoding")))
+	switch encoding {
+	case "gzip", "x-gzip", "deflate":
+	default:
+		return resp.Body, nil
+	}
+
+	// Responses to HEAD, 204 and 304 responses, and empty bodies may still
+	// carry the Content-Encoding of the representation, with nothing to decode
+	if !hasBody(resp) {
+		return resp.Body, nil
+	}
+	br := bufio.NewReader(resp.Body)
+	if _, err := br.Peek(1); err == io.EOF {
+		return br, nil
+	}
+
+	var body io.Reader
+	switch encoding {
+	case "gzip", "x-gzip":
+		zr, err := gzip.NewReader(br)
 		if err != nil {
-			return nil, &apierror.ErrorResponse{
-				ErrorCode:   "parse_error",
-				Description: fmt.Sprintf("Failed to parse the successful response: %v", err),
+			return nil, err
+		}
+		body = zr
+	case "deflate":
+		// Servers disagree on whether "deflate" means zlib-wrapped (as the
+		// HTTP spec says) or raw DEFLATE data, so check for a zlib header
+		header, err := br.Peek(2)
+		if err != nil {
+			return nil, err
+		}
+		if isZlibHeader(header) {
+			zr, err := zlib.NewReader(br)
+			if err != nil {
+				return nil, err
 			}
+			body = zr
+		} else {
+			body = flate.NewReader(br)
 		}
 	}
 
-	return resp, nil
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = -1
+	resp.Uncompressed = true
+	return body, nil
+}
+
+// hasBody reports whether resp can carry a body: it is not the response to a
+// HEAD request, not a 204 No Content or 304 Not Modified, and not declared empty
+func hasBody(resp *http.Response) bool {
+	if resp.Request != nil && resp.Request.Method == http.MethodHead {
+		return false
+	}
+	if resp.StatusCode == http.StatusNoContent || resp.StatusCode == http.StatusNotModified {
+		return false
+	}
+	return resp.ContentLength != 0
+}
+
+// isZlibHeader reports whether b starts with a zlib stream header using the
+// DEFLATE compression method.
+func isZlibHeader(b []byte) bool {
+	return len(b) >= 2 && b[0]&0x0f == 8 && (uint16(b[0])<<8|uint16(b[1]))%31 == 0
+}
+
+// bodySnippet returns at most MaxBodySnippet bytes of body, cut on a UTF-8
+// boundary and marked with "..." when truncated.
+func bodySnippet(body []byte) string {
+	if len(body) <= MaxBodySnippet {
+		return string(body)
+	}
+	cut := MaxBodySnippet
+	for cut > 0 && !utf8.RuneStart(body[cut]) {
+		cut--
+	}
+	return string(body[:cut]) + "..."
 }
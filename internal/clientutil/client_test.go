@@ -2,10 +2,16 @@
 package clientutil
 
 import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
 	"context"
 	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -111,6 +117,13 @@ func TestExecuteRequest_ResponseErrors(t *testing.T) {
 			wantCode:     "not_found",
 			wantContain:  "not found",
 		},
+		{
+			name:         "conflict with empty response",
+			statusCode:   409,
+			responseBody: `{}`,
+			wantCode:     "conflict",
+			wantContain:  "conflicts with the current state",
+		},
 		{
 			name:         "rate limited with empty response",
 			statusCode:   429,
@@ -154,6 +167,8 @@ func TestExecuteRequest_ResponseErrors(t *testing.T) {
 			assert.True(t, ok, "Expected error to be *apierror.ErrorResponse")
 			assert.Equal(t, tt.wantCode, errorResp.ErrorCode)
 			assert.Contains(t, errorResp.Description, tt.wantContain)
+			assert.Equal(t, tt.statusCode, errorResp.StatusCode)
+			assert.Equal(t, tt.statusCode == http.StatusConflict, errors.Is(err, apierror.ErrConflict))
 		})
 	}
 }
@@ -225,6 +240,145 @@ func TestExecuteRequest_ResponseProcessing(t *testing.T) {
 }
 
 // Test for handling read errors from response body
+func TestExecuteRequest_ValidationErrors(t *testing.T) {
+	tests := []struct {
+		name         string
+		responseBody string
+		wantCode     string
+		wantErrors   []apierror.FieldError
+	}{
+		{
+			name:         "two field errors",
+			responseBody: `{"error":"validation_failed","error_description":"The request is invalid","details":[{"field":"filename","message":"is required"},{"field":"contentType","message":"must be a MIME type"}]}`,
+			wantCode:     "validation_failed",
+			wantErrors: []apierror.FieldError{
+				{Field: "filename", Message: "is required"},
+				{Field: "contentType", Message: "must be a MIME type"},
+			},
+		},
+		{
+			name:         "details without an error code",
+			responseBody: `{"details":[{"field":"url","message":"is not a valid URL"}]}`,
+			wantCode:     "bad_request",
+			wantErrors:   []apierror.FieldError{{Field: "url", Message: "is not a valid URL"}},
+		},
+		{
+			name:         "details of another shape",
+			responseBody: `{"error":"invalid_request","error_description":"Bad input","details":"see docs"}`,
+			wantCode:     "invalid_request",
+		},
+		{
+			name:         "no details",
+			responseBody: `{"error":"invalid_request","error_description":"Bad input"}`,
+			wantCode:     "invalid_request",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(tt.responseBody))
+			}))
+			defer server.Close()
+
+			req, _ := http.NewRequestWithContext(context.Background(), "POST", server.URL, nil)
+			_, err := ExecuteRequest(context.Background(), http.DefaultClient, req, nil)
+			require.Error(t, err)
+
+			var errorResp *apierror.ErrorResponse
+			require.ErrorAs(t, err, &errorResp)
+			assert.Equal(t, tt.wantCode, errorResp.ErrorCode)
+			assert.Equal(t, tt.wantErrors, errorResp.ValidationErrors)
+			// Error() is unchanged by the details
+			plain := &apierror.ErrorResponse{ErrorCode: errorResp.ErrorCode, Description: errorResp.Description}
+			assert.Equal(t, plain.Error(), err.Error())
+		})
+	}
+}
+
+func TestExecuteRequest_ParseErrorSnippet(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        string
+		wantSnippet string
+	}{
+		{
+			name:        "short body quoted in full",
+			body:        `["unexpected","shape"]`,
+			wantSnippet: `Body: ["unexpected","shape"]`,
+		},
+		{
+			name:        "long body truncated",
+			body:        `[` + strings.Repeat(`"x",`, 1000) + `"x"]`,
+			wantSnippet: `Body: [` + strings.Repeat(`"x",`, 127) + `"x"...`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			req, _ := http.NewRequestWithContext(context.Background(), "GET", server.URL, nil)
+			var result map[string]string
+			_, err := ExecuteRequest(context.Background(), http.DefaultClient, req, &result)
+			require.Error(t, err)
+
+			errorResp, ok := err.(*apierror.ErrorResponse)
+			require.True(t, ok, "Expected error to be *apierror.ErrorResponse")
+			assert.Equal(t, "parse_error", errorResp.ErrorCode)
+			assert.True(t, strings.HasSuffix(errorResp.Description, tt.wantSnippet), errorResp.Description)
+			assert.LessOrEqual(t, len(errorResp.Description), MaxBodySnippet+200)
+			assert.Nil(t, errorResp.RawBody, "raw body should not be attached by default")
+		})
+	}
+}
+
+func TestExecuteRequest_RawBody(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		body       string
+	}{
+		{name: "parse error", statusCode: 200, body: `not json`},
+		{name: "parsed error response", statusCode: 400, body: `{"error":"invalid_request","secret":"s3cr3t"}`},
+		{name: "fallback error response", statusCode: 500, body: `internal details`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.statusCode)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			var result map[string]string
+
+			req, _ := http.NewRequestWithContext(context.Background(), "GET", server.URL, nil)
+			_, err := ExecuteRequest(context.Background(), http.DefaultClient, req, &result)
+			errorResp, ok := err.(*apierror.ErrorResponse)
+			require.True(t, ok, "Expected error to be *apierror.ErrorResponse")
+			assert.Nil(t, errorResp.RawBody)
+
+			req, _ = http.NewRequestWithContext(context.Background(), "GET", server.URL, nil)
+			_, err = ExecuteRequest(context.Background(), http.DefaultClient, req, &result, WithRawBody())
+			errorResp, ok = err.(*apierror.ErrorResponse)
+			require.True(t, ok, "Expected error to be *apierror.ErrorResponse")
+			assert.Equal(t, tt.body, string(errorResp.RawBody))
+		})
+	}
+}
+
+func TestBodySnippet_UTF8Boundary(t *testing.T) {
+	body := []byte(strings.Repeat("a", MaxBodySnippet-1) + "é")
+	snippet := bodySnippet(body)
+	assert.Equal(t, strings.Repeat("a", MaxBodySnippet-1)+"...", snippet)
+}
+
 func TestExecuteRequest_ReadBodyError(t *testing.T) {
 	// Create a test server
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -271,4 +425,210 @@ func (r *errorReader) Read(p []byte) (n int, err error) {
 
 func (r *errorReader) Close() error {
 	return nil
-}
\ No newline at end of file
+}
+
+// compressedServer serves body with the given status, compressed with encoding
+func compressedServer(t *testing.T, status int, encoding, body string) *httptest.Server {
+	var buf bytes.Buffer
+	var w io.WriteCloser
+	switch encoding {
+	case "gzip":
+		w = gzip.NewWriter(&buf)
+	case "deflate":
+		w = zlib.NewWriter(&buf)
+	case "raw-deflate":
+		fw, err := flate.NewWriter(&buf, flate.DefaultCompression)
+		require.NoError(t, err)
+		w = fw
+		encoding = "deflate"
+	}
+	_, err := w.Write([]byte(body))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	return httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.Header().Set("Content-Type", "application/json")
+		rw.Header().Set("Content-Encoding", encoding)
+		rw.WriteHeader(status)
+		_, _ = rw.Write(buf.Bytes())
+	}))
+}
+
+// noDecompressClient returns a client whose transport leaves compressed bodies untouched
+func noDecompressClient() *http.Client {
+	return &http.Client{Transport: &http.Transport{DisableCompression: true}}
+}
+
+func TestExecuteRequest_CompressedSuccess(t *testing.T) {
+	for _, encoding := range []string{"gzip", "deflate", "raw-deflate"} {
+		t.Run(encoding, func(t *testing.T) {
+			server := compressedServer(t, http.StatusOK, encoding, `{"name":"test","value":123}`)
+			defer server.Close()
+
+			req, _ := http.NewRequestWithContext(context.Background(), "GET", server.URL, nil)
+			var result struct {
+				Name  string `json:"name"`
+				Value int    `json:"value"`
+			}
+			resp, err := ExecuteRequest(context.Background(), noDecompressClient(), req, &result)
+			require.NoError(t, err)
+			assert.Equal(t, "test", result.Name)
+			assert.Equal(t, 123, result.Value)
+			assert.Empty(t, resp.Header.Get("Content-Encoding"))
+		})
+	}
+}
+
+func TestExecuteRequest_CompressedError(t *testing.T) {
+	server := compressedServer(t, http.StatusBadRequest, "gzip", `{"error":"invalid_request","error_description":"Bad input"}`)
+	defer server.Close()
+
+	req, _ := http.NewRequestWithContext(context.Background(), "GET", server.URL, nil)
+	_, err := ExecuteRequest(context.Background(), noDecompressClient(), req, nil)
+	require.Error(t, err)
+
+	errorResp, ok := err.(*apierror.ErrorResponse)
+	require.True(t, ok, "Expected error to be *apierror.ErrorResponse")
+	assert.Equal(t, "invalid_request", errorResp.ErrorCode)
+	assert.Equal(t, "Bad input", errorResp.Description)
+	assert.Equal(t, http.StatusBadRequest, errorResp.StatusCode)
+}
+
+func TestExecuteRequest_EncodedEmptyBody(t *testing.T) {
+	var compressed bytes.Buffer
+	zw := gzip.NewWriter(&compressed)
+	_, _ = zw.Write([]byte(`{"name":"greeting"}`))
+	require.NoError(t, zw.Close())
+
+	// Every response claims a gzip encoding, whether or not it has a body
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "gzip")
+		switch r.URL.Path {
+		case "/no-content":
+			w.WriteHeader(http.StatusNoContent)
+		case "/empty":
+			w.Header().Set("Content-Length", "0")
+		case "/cached":
+			w.Header().Set("ETag", `"v1"`)
+			if r.Header.Get("If-None-Match") == `"v1"` {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			_, _ = w.Write(compressed.Bytes())
+		default:
+			w.Header().Set("Content-Length", "128")
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		wantStatus int
+	}{
+		{"HEAD", http.MethodHead, "/item", http.StatusOK},
+		{"204 No Content", http.MethodDelete, "/no-content", http.StatusNoContent},
+		{"Content-Length 0", http.MethodGet, "/empty", http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequestWithContext(context.Background(), tt.method, server.URL+tt.path, nil)
+			resp, err := ExecuteRequest(context.Background(), noDecompressClient(), req, nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, resp.StatusCode)
+		})
+	}
+
+	t.Run("304 Not Modified", func(t *testing.T) {
+		cache := NewResponseCache(10)
+		for _, wantStatus := range []int{http.StatusOK, http.StatusNotModified} {
+			req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL+"/cached", nil)
+			var result map[string]string
+			resp, err := ExecuteRequest(context.Background(), noDecompressClient(), req, &result, WithResponseCache(cache))
+			require.NoError(t, err)
+			assert.Equal(t, wantStatus, resp.StatusCode)
+			assert.Equal(t, "greeting", result["name"])
+		}
+	})
+
+	t.Run("unknown length", func(t *testing.T) {
+		client := &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode:    http.StatusOK,
+				Header:        http.Header{"Content-Encoding": {"gzip"}},
+				Body:          io.NopCloser(strings.NewReader("")),
+				ContentLength: -1,
+				Request:       r,
+			}, nil
+		})}
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "https://api.example.com/item", nil)
+		_, err := ExecuteRequest(context.Background(), client, req, nil)
+		assert.NoError(t, err)
+	})
+}
+
+func TestExecuteRequest_InvalidGzipBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "gzip")
+		_, _ = w.Write([]byte(`{"name":"not actually gzip"}`))
+	}))
+	defer server.Close()
+
+	req, _ := http.NewRequestWithContext(context.Background(), "GET", server.URL, nil)
+	_, err := ExecuteRequest(context.Background(), noDecompressClient(), req, &struct{}{})
+	require.Error(t, err)
+
+	errorResp, ok := err.(*apierror.ErrorResponse)
+	require.True(t, ok, "Expected error to be *apierror.ErrorResponse")
+	assert.Equal(t, "read_error", errorResp.ErrorCode)
+}
+
+func TestExecuteRequest_MaxResponseBytes(t *testing.T) {
+	body := `{"name":"` + strings.Repeat("x", 100) + `"}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	t.Run("under the limit", func(t *testing.T) {
+		req, _ := http.NewRequestWithContext(context.Background(), "GET", server.URL, nil)
+		var result map[string]string
+		_, err := ExecuteRequest(context.Background(), http.DefaultClient, req, &result, WithMaxResponseBytes(int64(len(body))))
+		require.NoError(t, err)
+		assert.Equal(t, strings.Repeat("x", 100), result["name"])
+	})
+
+	t.Run("over the limit", func(t *testing.T) {
+		req, _ := http.NewRequestWithContext(context.Background(), "GET", server.URL, nil)
+		var result map[string]string
+		_, err := ExecuteRequest(context.Background(), http.DefaultClient, req, &result, WithMaxResponseBytes(int64(len(body)-1)))
+		require.Error(t, err)
+
+		errorResp, ok := err.(*apierror.ErrorResponse)
+		require.True(t, ok, "Expected error to be *apierror.ErrorResponse")
+		assert.Equal(t, "response_too_large", errorResp.ErrorCode)
+		assert.Equal(t, http.StatusOK, errorResp.StatusCode)
+	})
+
+	t.Run("default limit", func(t *testing.T) {
+		req, _ := http.NewRequestWithContext(context.Background(), "GET", server.URL, nil)
+		var result map[string]string
+		_, err := ExecuteRequest(context.Background(), http.DefaultClient, req, &result, WithMaxResponseBytes(0))
+		require.NoError(t, err)
+	})
+}
+
+func TestExecuteRequest_MaxResponseBytesAppliesToDecodedBody(t *testing.T) {
+	server := compressedServer(t, http.StatusOK, "gzip", `{"name":"`+strings.Repeat("x", 10000)+`"}`)
+	defer server.Close()
+
+	req, _ := http.NewRequestWithContext(context.Background(), "GET", server.URL, nil)
+	var result map[string]string
+	_, err := ExecuteRequest(context.Background(), noDecompressClient(), req, &result, WithMaxResponseBytes(1000))
+	require.Error(t, err)
+
+	errorResp, ok := err.(*apierror.ErrorResponse)
+	require.True(t, ok, "Expected error to be *apierror.ErrorResponse")
+	assert.Equal(t, "response_too_large", errorResp.ErrorCode)
+}
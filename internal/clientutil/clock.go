@@ -0,0 +1,89 @@
+package clientutil
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Clock tells the time and waits for durations to pass. Time-dependent code
+// such as polling, rate limiting, and expiry checks uses a Clock rather than
+// the time package directly, so tests can substitute a FakeClock.
+type Clock interface {
+	// Now returns the current time
+	Now() time.Time
+
+	// Sleep pauses for d or until ctx is done, returning ctx.Err() in the latter case
+	Sleep(ctx context.Context, d time.Duration) error
+}
+
+// SystemClock is the Clock backed by the time package. It is used whenever no
+// other Clock is configured.
+var SystemClock Clock = systemClock{}
+
+// systemClock implements Clock with time.Now and Sleep
+type systemClock struct{}
+
+// Now returns time.Now().
+func (systemClock) Now() time.Time { return time.Now() }
+
+// Sleep waits with the package-level Sleep function.
+func (systemClock) Sleep(ctx context.Context, d time.Duration) error { return Sleep(ctx, d) }
+
+// ClockOrSystem returns clock, or SystemClock if clock is nil.
+func ClockOrSystem(clock Clock) Clock {
+	if clock == nil {
+		return SystemClock
+	}
+	return clock
+}
+
+// FakeClock is a Clock for tests whose time only moves when Advance or Sleep
+// is called. Sleep returns immediately after advancing the time by the
+// requested duration and records it. FakeClock is safe for concurrent use.
+type FakeClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	sleeps []time.Duration
+}
+
+// NewFakeClock returns a FakeClock whose current time is now.
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now}
+}
+
+// Now returns the fake current time.
+func (f *FakeClock) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+// Sleep records d and advances the fake time by it without blocking. Like the
+// real Sleep, it returns ctx.Err() if the context is already done.
+func (f *FakeClock) Sleep(ctx context.Context, d time.Duration) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.sleeps = append(f.sleeps, d)
+	if d > 0 {
+		f.now = f.now.Add(d)
+	}
+	return nil
+}
+
+// Advance moves the fake time forward by d.
+func (f *FakeClock) Advance(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = f.now.Add(d)
+}
+
+// Sleeps returns the durations passed to Sleep, in order.
+func (f *FakeClock) Sleeps() []time.Duration {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]time.Duration(nil), f.sleeps...)
+}
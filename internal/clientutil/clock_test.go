@@ -0,0 +1,80 @@
+package clientutil
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/atriumn/atriumn-sdk-go/internal/apierror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var clockStart = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func TestFakeClock(t *testing.T) {
+	clock := NewFakeClock(clockStart)
+	assert.Equal(t, clockStart, clock.Now())
+
+	clock.Advance(time.Minute)
+	require.NoError(t, clock.Sleep(context.Background(), 2*time.Second))
+	assert.Equal(t, clockStart.Add(time.Minute+2*time.Second), clock.Now())
+	assert.Equal(t, []time.Duration{2 * time.Second}, clock.Sleeps())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, clock.Sleep(ctx, time.Second), context.Canceled)
+	assert.Equal(t, clockStart.Add(time.Minute+2*time.Second), clock.Now(), "a canceled Sleep must not advance time")
+}
+
+func TestClockOrSystem(t *testing.T) {
+	assert.Equal(t, SystemClock, ClockOrSystem(nil))
+
+	clock := NewFakeClock(clockStart)
+	assert.Equal(t, Clock(clock), ClockOrSystem(clock))
+}
+
+func TestRateLimiter_FakeClock(t *testing.T) {
+	clock := NewFakeClock(clockStart)
+	l := NewRateLimiter(10, 2)
+
+	// The burst is free, then each call waits for the next token
+	for i := 0; i < 4; i++ {
+		require.NoError(t, l.wait(context.Background(), clock))
+	}
+	assert.Equal(t, []time.Duration{0, 0, 100 * time.Millisecond, 100 * time.Millisecond}, clock.Sleeps())
+
+	// Idle time refills the bucket, but never beyond the burst
+	clock.Advance(time.Hour)
+	for i := 0; i < 3; i++ {
+		require.NoError(t, l.wait(context.Background(), clock))
+	}
+	assert.Equal(t, []time.Duration{0, 0, 100 * time.Millisecond}, clock.Sleeps()[4:])
+}
+
+func TestBaseClient_DoUsesClock(t *testing.T) {
+	clock := NewFakeClock(clockStart)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The request takes 2s of fake time, and the service asks for a
+		// retry at an HTTP date one minute past the fake time
+		clock.Advance(2 * time.Second)
+		w.Header().Set("Retry-After", clock.Now().Add(time.Minute).Format(http.TimeFormat))
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	base := newBaseClient(t, server.URL)
+	base.Clock = clock
+	recorder := &fakeRecorder{}
+
+	req, err := base.NewRequest(context.Background(), http.MethodGet, "/items", nil)
+	require.NoError(t, err)
+	_, err = base.Do(req, nil, WithMetrics(recorder, "ingest", "ingest.ListContentItems"))
+
+	apiErr, ok := err.(*apierror.ErrorResponse)
+	require.True(t, ok, "error = %v, want *apierror.ErrorResponse", err)
+	assert.Equal(t, time.Minute, apiErr.RetryAfter)
+	assert.Equal(t, []time.Duration{2 * time.Second}, recorder.latencies)
+}
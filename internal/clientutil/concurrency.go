@@ -0,0 +1,102 @@
+package clientutil
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// ErrTooManyConcurrentRequests is returned, instead of waiting, for a request
+// made while a fail-fast ConcurrencyLimiter has no free slot.
+var ErrTooManyConcurrentRequests = errors.New("too many concurrent requests")
+
+// ConcurrencyLimiter is a semaphore that bounds how many requests are in
+// flight at once. It is safe for concurrent use, so one limiter bounds every
+// goroutine sharing a client. A nil *ConcurrencyLimiter never blocks.
+type ConcurrencyLimiter struct {
+	slots    chan struct{}
+	failFast bool
+}
+
+// ConcurrencyOption configures a ConcurrencyLimiter.
+type ConcurrencyOption func(*ConcurrencyLimiter)
+
+// FailFast makes a ConcurrencyLimiter return ErrTooManyConcurrentRequests
+// when no slot is free, instead of waiting for one.
+func FailFast() ConcurrencyOption {
+	return func(l *ConcurrencyLimiter) {
+		l.failFast = true
+	}
+}
+
+// NewConcurrencyLimiter returns a limiter that allows up to n requests in
+// flight at once. It returns nil, meaning no limit, if n is zero or less.
+func NewConcurrencyLimiter(n int, opts ...ConcurrencyOption) *ConcurrencyLimiter {
+	if n <= 0 {
+		return nil
+	}
+	l := &ConcurrencyLimiter{slots: make(chan struct{}, n)}
+	for _, opt := range opts {
+		opt(l)
+	}
+	return l
+}
+
+// acquire takes a slot, waiting until one is free or ctx is done. It returns
+// an error wrapping ctx.Err() if the context ends first, or
+// ErrTooManyConcurrentRequests if the limiter fails fast and no slot is free.
+// Each successful acquire must be matched by a release.
+func (l *ConcurrencyLimiter) acquire(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("waiting for a request slot: %w", err)
+	}
+	if l.failFast {
+		select {
+		case l.slots <- struct{}{}:
+			return nil
+		default:
+			return ErrTooManyConcurrentRequests
+		}
+	}
+	select {
+	case l.slots <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("waiting for a request slot: %w", ctx.Err())
+	}
+}
+
+// release frees a slot taken by acquire.
+func (l *ConcurrencyLimiter) release() {
+	if l != nil {
+		<-l.slots
+	}
+}
+
+// InFlight returns the number of requests currently holding a slot.
+func (l *ConcurrencyLimiter) InFlight() int {
+	if l == nil {
+		return 0
+	}
+	return len(l.slots)
+}
+
+// releasingBody is a response body that frees its request's slot when closed,
+// so a stream counts as in flight until the caller is done reading it
+type releasingBody struct {
+	io.ReadCloser
+	once    sync.Once
+	limiter *ConcurrencyLimiter
+}
+
+// Close closes the body and releases its slot, once
+func (b *releasingBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.limiter.release)
+	return err
+}
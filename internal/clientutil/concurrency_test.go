@@ -0,0 +1,76 @@
+package clientutil
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewConcurrencyLimiter_NoLimit(t *testing.T) {
+	assert.Nil(t, NewConcurrencyLimiter(0))
+	assert.Nil(t, NewConcurrencyLimiter(-1))
+
+	var l *ConcurrencyLimiter
+	require.NoError(t, l.acquire(context.Background()))
+	l.release()
+	assert.Zero(t, l.InFlight())
+}
+
+func TestConcurrencyLimiter_Acquire(t *testing.T) {
+	l := NewConcurrencyLimiter(2)
+	ctx := context.Background()
+
+	require.NoError(t, l.acquire(ctx))
+	require.NoError(t, l.acquire(ctx))
+	assert.Equal(t, 2, l.InFlight())
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	assert.ErrorIs(t, l.acquire(canceled), context.Canceled)
+
+	l.release()
+	require.NoError(t, l.acquire(ctx))
+	assert.Equal(t, 2, l.InFlight())
+}
+
+func TestConcurrencyLimiter_FailFast(t *testing.T) {
+	l := NewConcurrencyLimiter(1, FailFast())
+
+	require.NoError(t, l.acquire(context.Background()))
+	assert.ErrorIs(t, l.acquire(context.Background()), ErrTooManyConcurrentRequests)
+	l.release()
+	assert.NoError(t, l.acquire(context.Background()))
+}
+
+func TestBaseClient_StreamHoldsSlotUntilClosed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("data"))
+	}))
+	defer server.Close()
+
+	b := newBaseClient(t, server.URL)
+	b.ConcurrencyLimiter = NewConcurrencyLimiter(1, FailFast())
+
+	req, err := b.NewRequest(context.Background(), "GET", "/file", nil)
+	require.NoError(t, err)
+	body, err := b.Stream(req)
+	require.NoError(t, err)
+	assert.Equal(t, 1, b.ConcurrencyLimiter.InFlight())
+
+	req, err = b.NewRequest(context.Background(), "GET", "/file", nil)
+	require.NoError(t, err)
+	_, err = b.Do(req, nil)
+	assert.ErrorIs(t, err, ErrTooManyConcurrentRequests)
+
+	require.NoError(t, body.Close())
+	require.NoError(t, body.Close())
+	assert.Zero(t, b.ConcurrencyLimiter.InFlight())
+
+	_, err = b.Do(req, nil)
+	assert.NoError(t, err)
+	assert.Zero(t, b.ConcurrencyLimiter.InFlight())
+}
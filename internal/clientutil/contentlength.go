@@ -0,0 +1,29 @@
+package clientutil
+
+import (
+	"io"
+	"os"
+)
+
+// ContentLength returns the number of bytes left to read from r, or -1 if
+// it cannot tell. It knows the size of regular files, counting from their
+// current offset so a partly read file is not over-reported, and of readers
+// with a Len method, such as *bytes.Buffer, *bytes.Reader and *strings.Reader,
+// which report their unread bytes.
+func ContentLength(r io.Reader) int64 {
+	switch v := r.(type) {
+	case *os.File:
+		fileInfo, err := v.Stat()
+		if err != nil || !fileInfo.Mode().IsRegular() {
+			return -1
+		}
+		offset, err := v.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return -1
+		}
+		return fileInfo.Size() - offset
+	case interface{ Len() int }:
+		return int64(v.Len())
+	}
+	return -1
+}
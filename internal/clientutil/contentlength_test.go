@@ -0,0 +1,37 @@
+package clientutil
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContentLength(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data.txt")
+	require.NoError(t, os.WriteFile(path, []byte("hello world"), 0o600))
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer func() { _ = file.Close() }()
+
+	assert.EqualValues(t, 11, ContentLength(file))
+	_, err = file.Seek(6, io.SeekStart)
+	require.NoError(t, err)
+	assert.EqualValues(t, 5, ContentLength(file), "a partly read file reports the bytes left")
+
+	reader := strings.NewReader("hello world")
+	_, _ = reader.Read(make([]byte, 6))
+	assert.EqualValues(t, 5, ContentLength(reader))
+	assert.EqualValues(t, 3, ContentLength(bytes.NewBufferString("abc")))
+
+	pr, pw, err := os.Pipe()
+	require.NoError(t, err)
+	defer func() { _ = pr.Close(); _ = pw.Close() }()
+	assert.EqualValues(t, -1, ContentLength(pr), "a pipe has no known size")
+	assert.EqualValues(t, -1, ContentLength(io.MultiReader(reader)))
+}
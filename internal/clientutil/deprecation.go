@@ -0,0 +1,141 @@
+package clientutil
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Logger receives diagnostic messages from the clients, such as warnings that
+// an endpoint is deprecated. *log.Logger satisfies it. Implementations must
+// be safe for concurrent use.
+type Logger interface {
+	Printf(format string, v ...interface{})
+}
+
+// DeprecationNotice describes a response whose Deprecation or Sunset header
+// announced that its endpoint is going away.
+type DeprecationNotice struct {
+	// Service is the service that sent the response, such as "ingest"
+	Service string
+
+	// Operation is the client method that called the endpoint, such as
+	// "ingest.ListContentItemsWithOptions"
+	Operation string
+
+	// Method and Path identify the request that received the response
+	Method string
+	Path   string
+
+	// Deprecation is the raw Deprecation header, empty if it was not sent
+	Deprecation string
+
+	// Sunset is the raw Sunset header, empty if it was not sent
+	Sunset string
+
+	// DeprecatedAt is the time parsed from a Deprecation header of the form
+	// "@<unix seconds>", or the zero time
+	DeprecatedAt time.Time
+
+	// SunsetAt is the time parsed from the Sunset header, or the zero time
+	SunsetAt time.Time
+}
+
+// String describes the notice in a single line.
+func (n DeprecationNotice) String() string {
+	var b strings.Builder
+	if n.Operation != "" {
+		fmt.Fprintf(&b, "atriumn: %s (%s %s) is deprecated", n.Operation, n.Method, n.Path)
+	} else {
+		fmt.Fprintf(&b, "atriumn: %s %s is deprecated", n.Method, n.Path)
+	}
+	if !n.DeprecatedAt.IsZero() {
+		fmt.Fprintf(&b, " since %s", n.DeprecatedAt.UTC().Format(time.RFC3339))
+	}
+	switch {
+	case !n.SunsetAt.IsZero():
+		fmt.Fprintf(&b, " and will be removed after %s", n.SunsetAt.UTC().Format(time.RFC3339))
+	case n.Sunset != "":
+		fmt.Fprintf(&b, " and will be removed after %s", n.Sunset)
+	}
+	return b.String()
+}
+
+// endpoint returns the name a notice is deduplicated under: the operation if
+// known, otherwise the request's method and path
+func (n DeprecationNotice) endpoint() string {
+	if n.Operation != "" {
+		return n.Operation
+	}
+	return n.Method + " " + n.Path
+}
+
+// deprecation holds the settings applied by WithDeprecationWarnings.
+type deprecation struct {
+	logger    Logger
+	callback  func(DeprecationNotice)
+	service   string
+	operation string
+}
+
+// warnedEndpoints records the endpoints a deprecation has been reported for,
+// so each is reported once per process
+var warnedEndpoints sync.Map
+
+// WithDeprecationWarnings reports a response carrying a Deprecation or Sunset
+// header to logger and callback, once per endpoint per process. Endpoints are
+// identified by operation, such as "ingest.ListContentItemsWithOptions", or
+// by the request's method and path if operation is empty. With a nil logger
+// and callback, nothing is reported.
+func WithDeprecationWarnings(logger Logger, callback func(DeprecationNotice), service, operation string) RequestOption {
+	return func(o *requestOptions) {
+		if logger == nil && callback == nil {
+			o.deprecation = nil
+			return
+		}
+		o.deprecation = &deprecation{logger: logger, callback: callback, service: service, operation: operation}
+	}
+}
+
+// check reports resp if it announces a deprecation that has not been reported
+// for its endpoint yet
+func (d *deprecation) check(req *http.Request, resp *http.Response) {
+	if d == nil || resp == nil {
+		return
+	}
+	notice := DeprecationNotice{
+		Service:     d.service,
+		Operation:   d.operation,
+		Method:      req.Method,
+		Path:        req.URL.Path,
+		Deprecation: resp.Header.Get("Deprecation"),
+		Sunset:      resp.Header.Get("Sunset"),
+	}
+	if notice.Deprecation == "" && notice.Sunset == "" {
+		return
+	}
+	if _, warned := warnedEndpoints.LoadOrStore(notice.endpoint(), true); warned {
+		return
+	}
+
+	if seconds, ok := strings.CutPrefix(notice.Deprecation, "@"); ok {
+		if n, err := strconv.ParseInt(seconds, 10, 64); err == nil {
+			notice.DeprecatedAt = time.Unix(n, 0).UTC()
+		}
+	}
+	if notice.Sunset != "" {
+		if t, err := http.ParseTime(notice.Sunset); err == nil {
+			notice.SunsetAt = t
+		}
+	}
+
+	if d.logger != nil {
+		d.logger.Printf("%s", notice)
+	}
+	if d.callback != nil {
+		d.callback(notice)
+	}
+}
@@ -0,0 +1,153 @@
+package clientutil
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// capturingLogger records the messages logged through it
+type capturingLogger struct {
+	mu       sync.Mutex
+	messages []string
+}
+
+func (l *capturingLogger) Printf(format string, v ...interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.messages = append(l.messages, fmt.Sprintf(format, v...))
+}
+
+func (l *capturingLogger) Messages() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]string(nil), l.messages...)
+}
+
+// newDeprecatedServer returns a server that marks /old as deprecated and
+// /current as not
+func newDeprecatedServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/current" {
+			w.Header().Set("Deprecation", "@1767225600")
+			w.Header().Set("Sunset", "Wed, 30 Dec 2026 23:59:59 GMT")
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(server.Close)
+	t.Cleanup(warnedEndpoints.Clear)
+	warnedEndpoints.Clear()
+	return server
+}
+
+func executeDeprecationRequest(t *testing.T, url string, opts ...RequestOption) {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	require.NoError(t, err)
+	_, err = ExecuteRequest(context.Background(), http.DefaultClient, req, nil, opts...)
+	require.NoError(t, err)
+}
+
+func TestWithDeprecationWarnings_OncePerEndpoint(t *testing.T) {
+	server := newDeprecatedServer(t)
+	logger := &capturingLogger{}
+	var notices []DeprecationNotice
+	var mu sync.Mutex
+	callback := func(n DeprecationNotice) {
+		mu.Lock()
+		defer mu.Unlock()
+		notices = append(notices, n)
+	}
+
+	for i := 0; i < 3; i++ {
+		executeDeprecationRequest(t, server.URL+"/old/"+fmt.Sprint(i),
+			WithDeprecationWarnings(logger, callback, "ingest", "ingest.ListOld"))
+	}
+	executeDeprecationRequest(t, server.URL+"/other",
+		WithDeprecationWarnings(logger, callback, "ingest", "ingest.ListOther"))
+	executeDeprecationRequest(t, server.URL+"/current",
+		WithDeprecationWarnings(logger, callback, "ingest", "ingest.ListCurrent"))
+
+	messages := logger.Messages()
+	require.Len(t, messages, 2, "one warning per deprecated endpoint")
+	assert.Equal(t, "atriumn: ingest.ListOld (GET /old/0) is deprecated since 2026-01-01T00:00:00Z and will be removed after 2026-12-30T23:59:59Z", messages[0])
+	assert.Contains(t, messages[1], "ingest.ListOther")
+
+	require.Len(t, notices, 2)
+	notice := notices[0]
+	assert.Equal(t, "ingest", notice.Service)
+	assert.Equal(t, "ingest.ListOld", notice.Operation)
+	assert.Equal(t, http.MethodGet, notice.Method)
+	assert.Equal(t, "/old/0", notice.Path)
+	assert.Equal(t, "@1767225600", notice.Deprecation)
+	assert.Equal(t, "Wed, 30 Dec 2026 23:59:59 GMT", notice.Sunset)
+	assert.True(t, notice.DeprecatedAt.Equal(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)))
+	assert.True(t, notice.SunsetAt.Equal(time.Date(2026, 12, 30, 23, 59, 59, 0, time.UTC)))
+}
+
+func TestWithDeprecationWarnings_OncePerProcess(t *testing.T) {
+	server := newDeprecatedServer(t)
+	first, second := &capturingLogger{}, &capturingLogger{}
+
+	executeDeprecationRequest(t, server.URL+"/old", WithDeprecationWarnings(first, nil, "ai", "ai.ListOld"))
+	executeDeprecationRequest(t, server.URL+"/old", WithDeprecationWarnings(second, nil, "ai", "ai.ListOld"))
+
+	assert.Len(t, first.Messages(), 1)
+	assert.Empty(t, second.Messages(), "a deprecation is reported once per process, not once per client")
+}
+
+func TestWithDeprecationWarnings_Concurrent(t *testing.T) {
+	server := newDeprecatedServer(t)
+	logger := &capturingLogger{}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			executeDeprecationRequest(t, server.URL+"/old", WithDeprecationWarnings(logger, nil, "ai", "ai.ListOld"))
+		}()
+	}
+	wg.Wait()
+
+	assert.Len(t, logger.Messages(), 1)
+}
+
+func TestWithDeprecationWarnings_NothingConfigured(t *testing.T) {
+	server := newDeprecatedServer(t)
+
+	executeDeprecationRequest(t, server.URL+"/old", WithDeprecationWarnings(nil, nil, "ai", "ai.ListOld"))
+
+	// A client without a logger or callback must not use up the warning
+	logger := &capturingLogger{}
+	executeDeprecationRequest(t, server.URL+"/old", WithDeprecationWarnings(logger, nil, "ai", "ai.ListOld"))
+	assert.Len(t, logger.Messages(), 1)
+}
+
+func TestWithDeprecationWarnings_Stream(t *testing.T) {
+	server := newDeprecatedServer(t)
+	logger := &capturingLogger{}
+
+	req, err := http.NewRequest(http.MethodGet, server.URL+"/old", nil)
+	require.NoError(t, err)
+	body, err := OpenStream(context.Background(), http.DefaultClient, req,
+		WithDeprecationWarnings(logger, nil, "ingest", "ingest.GetContentStream"))
+	require.NoError(t, err)
+	_ = body.Close()
+
+	assert.Len(t, logger.Messages(), 1)
+}
+
+func TestDeprecationNotice_String(t *testing.T) {
+	notice := DeprecationNotice{Method: http.MethodDelete, Path: "/items/1", Deprecation: "true", Sunset: "soon"}
+	assert.Equal(t, "atriumn: DELETE /items/1 is deprecated and will be removed after soon", notice.String())
+}
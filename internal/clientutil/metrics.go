@@ -0,0 +1,79 @@
+package clientutil
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/atriumn/atriumn-sdk-go/internal/apierror"
+)
+
+// MetricsRecorder receives a measurement for every API call, for export to a
+// metrics system such as Prometheus. Implementations must be safe for
+// concurrent use.
+type MetricsRecorder interface {
+	// IncRequest counts one call of operation on service that ended with code:
+	// the HTTP status code (such as "200" or "404") if the service responded,
+	// or otherwise the error code (such as "network_error").
+	IncRequest(service, operation, code string)
+
+	// ObserveLatency records how long a call of operation on service took,
+	// including reading the response.
+	ObserveLatency(service, operation string, d time.Duration)
+}
+
+// metrics holds the settings applied by WithMetrics.
+type metrics struct {
+	recorder  MetricsRecorder
+	service   string
+	operation string
+}
+
+// WithMetrics reports the call's outcome and latency to recorder under the
+// given service and operation names, such as "ingest" and
+// "ingest.RequestFileUpload". A nil recorder records nothing.
+func WithMetrics(recorder MetricsRecorder, service, operation string) RequestOption {
+	return func(o *requestOptions) {
+		if recorder == nil {
+			o.metrics = nil
+			return
+		}
+		o.metrics = &metrics{recorder: recorder, service: service, operation: operation}
+	}
+}
+
+// record reports a call that took latency and ended with status (0 if no
+// response arrived) and err.
+func (m *metrics) record(latency time.Duration, status int, err error) {
+	if m == nil {
+		return
+	}
+	m.recorder.ObserveLatency(m.service, m.operation, latency)
+	m.recorder.IncRequest(m.service, m.operation, outcomeCode(status, err))
+}
+
+// outcomeCode returns the code a call is counted under: the HTTP status for
+// successful calls and error responses, and the error code for failures
+// without an error status, such as network errors or unparsable responses
+func outcomeCode(status int, err error) string {
+	if err == nil {
+		return strconv.Itoa(status)
+	}
+	var apiErr *apierror.ErrorResponse
+	if !errors.As(err, &apiErr) {
+		return "error"
+	}
+	if apiErr.StatusCode != 0 && (apiErr.StatusCode < 200 || apiErr.StatusCode >= 300) {
+		return strconv.Itoa(apiErr.StatusCode)
+	}
+	return apiErr.ErrorCode
+}
+
+// responseStatus returns resp's status code, or 0 for a nil response
+func responseStatus(resp *http.Response) int {
+	if resp == nil {
+		return 0
+	}
+	return resp.StatusCode
+}
@@ -0,0 +1,121 @@
+package clientutil
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/atriumn/atriumn-sdk-go/internal/apierror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRecorder records the metrics it receives
+type fakeRecorder struct {
+	mu        sync.Mutex
+	requests  []string
+	latencies []time.Duration
+}
+
+func (r *fakeRecorder) IncRequest(service, operation, code string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.requests = append(r.requests, service+" "+operation+" "+code)
+}
+
+func (r *fakeRecorder) ObserveLatency(service, operation string, d time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.latencies = append(r.latencies, d)
+}
+
+func TestExecuteRequest_Metrics(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ok":
+			_, _ = w.Write([]byte(`{"id":"1"}`))
+		case "/bad-json":
+			_, _ = w.Write([]byte(`not json`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name string
+		url  string
+		want string
+	}{
+		{"success", server.URL + "/ok", "ingest ingest.GetContentItem 200"},
+		{"error status", server.URL + "/missing", "ingest ingest.GetContentItem 404"},
+		{"parse error", server.URL + "/bad-json", "ingest ingest.GetContentItem parse_error"},
+		{"network error", "http://127.0.0.1:1/ok", "ingest ingest.GetContentItem network_error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := &fakeRecorder{}
+			req, err := http.NewRequest("GET", tt.url, nil)
+			require.NoError(t, err)
+
+			var v map[string]string
+			_, _ = ExecuteRequest(context.Background(), http.DefaultClient, req, &v,
+				WithMetrics(recorder, "ingest", "ingest.GetContentItem"))
+
+			assert.Equal(t, []string{tt.want}, recorder.requests)
+			require.Len(t, recorder.latencies, 1)
+			assert.Greater(t, recorder.latencies[0], time.Duration(0))
+		})
+	}
+}
+
+func TestOutcomeCode(t *testing.T) {
+	assert.Equal(t, "204", outcomeCode(http.StatusNoContent, nil))
+	assert.Equal(t, "429", outcomeCode(0, &apierror.ErrorResponse{ErrorCode: "rate_limited", StatusCode: http.StatusTooManyRequests}))
+	assert.Equal(t, "request_timeout", outcomeCode(0, &apierror.ErrorResponse{ErrorCode: "request_timeout"}))
+	assert.Equal(t, "error", outcomeCode(0, errors.New("boom")))
+}
+
+func TestWithMetrics_NilRecorder(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	req, err := http.NewRequest("GET", server.URL, nil)
+	require.NoError(t, err)
+
+	_, err = ExecuteRequest(context.Background(), http.DefaultClient, req, nil, WithMetrics(nil, "ai", "ai.Health"))
+	assert.NoError(t, err)
+}
+
+func TestOpenStream_Metrics(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte("text"))
+	}))
+	defer server.Close()
+
+	recorder := &fakeRecorder{}
+	for _, path := range []string{"/text", "/missing"} {
+		req, err := http.NewRequest("GET", server.URL+path, nil)
+		require.NoError(t, err)
+		body, err := OpenStream(context.Background(), http.DefaultClient, req,
+			WithMetrics(recorder, "ingest", "ingest.StreamTextContent"))
+		if err == nil {
+			_ = body.Close()
+		}
+	}
+
+	assert.Equal(t, []string{
+		"ingest ingest.StreamTextContent 200",
+		"ingest ingest.StreamTextContent 404",
+	}, recorder.requests)
+	assert.Len(t, recorder.latencies, 2)
+}
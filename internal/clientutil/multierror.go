@@ -0,0 +1,90 @@
+package clientutil
+
+import (
+	"fmt"
+	"strings"
+)
+
+// maxListedErrors is how many item errors MultiError.Error lists before
+// summarizing the rest, to keep log lines readable for large batches
+const maxListedErrors = 5
+
+// ItemError is the error for one item of a batch operation.
+type ItemError struct {
+	// Index is the position of the item in the batch input
+	Index int
+	// ID identifies the item, such as a content ID; it may be empty
+	ID string
+	// Err is the error the item failed with
+	Err error
+}
+
+// Error returns the item's ID, or its index if it has no ID, followed by the error.
+func (e *ItemError) Error() string {
+	if e.ID != "" {
+		return fmt.Sprintf("%s: %v", e.ID, e.Err)
+	}
+	return fmt.Sprintf("item %d: %v", e.Index, e.Err)
+}
+
+// Unwrap returns the item's error, so errors.Is and errors.As see through it.
+func (e *ItemError) Unwrap() error {
+	return e.Err
+}
+
+// MultiError aggregates the failures of a batch operation. errors.Is and
+// errors.As match it against any of the item errors, so for example
+// errors.Is(err, ErrNotFound) reports whether any item was not found.
+type MultiError struct {
+	// Errors holds the failed items in input order
+	Errors []*ItemError
+	// Total is the number of items in the batch, including the successful ones
+	Total int
+}
+
+// Add records that the item at index, identified by id, failed with err. A
+// nil err is ignored.
+func (m *MultiError) Add(index int, id string, err error) {
+	if err == nil {
+		return
+	}
+	m.Errors = append(m.Errors, &ItemError{Index: index, ID: id, Err: err})
+}
+
+// ErrorOrNil returns m, or nil if no item failed. Batch methods return its
+// result so that an all-success batch yields a nil error rather than an empty
+// *MultiError.
+func (m *MultiError) ErrorOrNil() error {
+	if m == nil || len(m.Errors) == 0 {
+		return nil
+	}
+	return m
+}
+
+// Error summarizes how many items failed and lists the first few errors.
+func (m *MultiError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d of %d operations failed", len(m.Errors), m.Total)
+	for i, err := range m.Errors {
+		if i == maxListedErrors {
+			fmt.Fprintf(&b, "; and %d more", len(m.Errors)-maxListedErrors)
+			break
+		}
+		sep := "; "
+		if i == 0 {
+			sep = ": "
+		}
+		b.WriteString(sep)
+		b.WriteString(err.Error())
+	}
+	return b.String()
+}
+
+// Unwrap returns the item errors, so errors.Is and errors.As check each of them.
+func (m *MultiError) Unwrap() []error {
+	errs := make([]error, len(m.Errors))
+	for i, err := range m.Errors {
+		errs[i] = err
+	}
+	return errs
+}
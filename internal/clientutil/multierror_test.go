@@ -0,0 +1,62 @@
+package clientutil
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/atriumn/atriumn-sdk-go/internal/apierror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMultiError_Aggregates(t *testing.T) {
+	notFound := &apierror.ErrorResponse{ErrorCode: "not_found", StatusCode: http.StatusNotFound}
+
+	multi := &MultiError{Total: 4}
+	multi.Add(0, "a", nil)
+	multi.Add(1, "b", notFound)
+	multi.Add(2, "", context.Canceled)
+	multi.Add(3, "d", nil)
+
+	err := multi.ErrorOrNil()
+	require.Error(t, err)
+	require.Len(t, multi.Errors, 2)
+	assert.Equal(t, "2 of 4 operations failed: b: not_found; item 2: context canceled", err.Error())
+
+	assert.True(t, errors.Is(err, apierror.ErrNotFound))
+	assert.True(t, errors.Is(err, context.Canceled))
+	assert.False(t, errors.Is(err, apierror.ErrConflict))
+
+	var apiErr *apierror.ErrorResponse
+	require.True(t, errors.As(err, &apiErr))
+	assert.Same(t, notFound, apiErr)
+
+	// Still matches when wrapped
+	assert.True(t, errors.Is(fmt.Errorf("cleanup: %w", err), apierror.ErrNotFound))
+}
+
+func TestMultiError_AllSucceeded(t *testing.T) {
+	multi := &MultiError{Total: 3}
+	for i := 0; i < 3; i++ {
+		multi.Add(i, "", nil)
+	}
+
+	assert.Nil(t, multi.ErrorOrNil())
+
+	var nilMulti *MultiError
+	assert.Nil(t, nilMulti.ErrorOrNil())
+}
+
+func TestMultiError_SummarizesLongLists(t *testing.T) {
+	multi := &MultiError{Total: 8}
+	for i := 0; i < 8; i++ {
+		multi.Add(i, fmt.Sprintf("id-%d", i), errors.New("boom"))
+	}
+
+	assert.Equal(t,
+		"8 of 8 operations failed: id-0: boom; id-1: boom; id-2: boom; id-3: boom; id-4: boom; and 3 more",
+		multi.Error())
+}
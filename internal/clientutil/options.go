@@ -0,0 +1,231 @@
+package clientutil
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"log"
+	"net/http"
+	"time"
+)
+
+// Option configures a BaseClient. The service packages wrap these options
+// in their own ClientOption type, so that the settings they share are defined once.
+type Option func(*BaseClient)
+
+// SetTokenProvider sets the provider of the bearer tokens sent with each request.
+func SetTokenProvider(tp TokenProvider) Option {
+	return func(b *BaseClient) {
+		b.TokenProvider = tp
+	}
+}
+
+// SetRefreshOn401 retries a request once when the service rejects its token as
+// unauthorized, after invalidating the token if TokenProvider implements
+// TokenInvalidator. A second 401 is returned as is.
+func SetRefreshOn401() Option {
+	return func(b *BaseClient) {
+		b.RefreshOn401 = true
+	}
+}
+
+// SetMaxResponseBytes limits how many bytes of an API response body are read.
+// Larger responses fail with a "response_too_large" error. A value of zero or
+// less restores DefaultMaxResponseBytes.
+func SetMaxResponseBytes(n int64) Option {
+	return func(b *BaseClient) {
+		b.MaxResponseBytes = n
+	}
+}
+
+// SetRateLimit throttles requests to rps per second on average, allowing
+// bursts of up to burst requests. A rate of zero or less removes the limit.
+func SetRateLimit(rps float64, burst int) Option {
+	return func(b *BaseClient) {
+		b.RateLimiter = NewRateLimiter(rps, burst)
+	}
+}
+
+// SetMaxConcurrentRequests allows at most n calls to be in flight at once. An
+// n of zero or less removes the limit.
+func SetMaxConcurrentRequests(n int, opts ...ConcurrencyOption) Option {
+	return func(b *BaseClient) {
+		b.ConcurrencyLimiter = NewConcurrencyLimiter(n, opts...)
+	}
+}
+
+// SetResponseCache caches up to size GET responses that carry an ETag, and
+// revalidates them with If-None-Match. A size of zero or less disables the cache.
+func SetResponseCache(size int) Option {
+	return func(b *BaseClient) {
+		b.ResponseCache = NewResponseCache(size)
+	}
+}
+
+// SetConnectionPool tunes the connection pool Configure gives the default HTTP client.
+func SetConnectionPool(maxIdle, maxIdlePerHost, maxConnsPerHost int, idleTimeout time.Duration) Option {
+	return func(b *BaseClient) {
+		b.ConnectionPool = &ConnectionPool{
+			MaxIdleConns:        maxIdle,
+			MaxIdleConnsPerHost: maxIdlePerHost,
+			MaxConnsPerHost:     maxConnsPerHost,
+			IdleConnTimeout:     idleTimeout,
+		}
+	}
+}
+
+// SetTLSConfig sets the TLS configuration Configure gives the default HTTP
+// client. A config with InsecureSkipVerify set is rejected by Configure unless
+// SetInsecureSkipVerify is also applied.
+func SetTLSConfig(config *tls.Config) Option {
+	return func(b *BaseClient) {
+		b.TLS.Config = config
+	}
+}
+
+// SetRootCAs makes the default HTTP client trust the certificates in pool
+// instead of the system roots, taking precedence over the RootCAs of SetTLSConfig.
+func SetRootCAs(pool *x509.CertPool) Option {
+	return func(b *BaseClient) {
+		b.TLS.RootCAs = pool
+	}
+}
+
+// SetInsecureSkipVerify disables TLS certificate verification on the default HTTP client.
+func SetInsecureSkipVerify() Option {
+	return func(b *BaseClient) {
+		b.TLS.InsecureSkipVerify = true
+	}
+}
+
+// SetForceHTTP1 limits the default HTTP client to HTTP/1.1.
+func SetForceHTTP1() Option {
+	return func(b *BaseClient) {
+		b.ForceHTTP1 = true
+	}
+}
+
+// SetMetricsRecorder reports every call to recorder, labeled with Service.
+func SetMetricsRecorder(recorder MetricsRecorder) Option {
+	return func(b *BaseClient) {
+		b.Metrics = recorder
+	}
+}
+
+// SetStatsCollection counts calls and samples DefaultStatsReservoirSize of
+// their latencies for Stats.
+func SetStatsCollection() Option {
+	return func(b *BaseClient) {
+		b.Stats = NewStatsCollector(DefaultStatsReservoirSize)
+	}
+}
+
+// SetLogger sets the logger that receives diagnostic messages, such as a
+// warning the first time an endpoint is reported deprecated.
+func SetLogger(logger Logger) Option {
+	return func(b *BaseClient) {
+		b.Logger = logger
+	}
+}
+
+// SetRequestBodyLogging logs the JSON bodies of POST, PUT, and PATCH requests,
+// with secrets redacted, once Configure has run.
+func SetRequestBodyLogging() Option {
+	return func(b *BaseClient) {
+		b.LogRequestBodies = true
+	}
+}
+
+// SetDeprecationCallback calls fn, once per endpoint per process, when a
+// response carries a Deprecation or Sunset header.
+func SetDeprecationCallback(fn func(DeprecationNotice)) Option {
+	return func(b *BaseClient) {
+		b.DeprecationCallback = fn
+	}
+}
+
+// SetClock replaces the system clock. A nil clock restores the system clock.
+func SetClock(clock Clock) Option {
+	return func(b *BaseClient) {
+		b.Clock = ClockOrSystem(clock)
+	}
+}
+
+// SetRetryPolicy retries requests that fail transiently under policy.
+func SetRetryPolicy(policy RetryPolicy) Option {
+	return func(b *BaseClient) {
+		b.RetryPolicy = &policy
+	}
+}
+
+// SetRawErrorBody attaches full response bodies to returned API errors.
+func SetRawErrorBody() Option {
+	return func(b *BaseClient) {
+		b.RawErrorBody = true
+	}
+}
+
+// SetRequestTimeout bounds each call with a per-call context deadline, and
+// makes Configure clear the HTTP client's overall Timeout.
+func SetRequestTimeout(d time.Duration) Option {
+	return func(b *BaseClient) {
+		b.RequestTimeout = d
+	}
+}
+
+// Configure completes the settings of b once all options have been applied,
+// and returns the HTTP client to use in place of httpClient. It validates the
+// TLS settings and sets BodyLogger for SetRequestBodyLogging. If httpClient is
+// defaultHTTPClient, the one created by the service package rather than
+// supplied by the caller, the connection pool, TLS, and HTTP/1.1 settings are
+// applied to its transport, which is also returned when it carries TLS or
+// HTTP/1.1 settings, for requests to pre-signed URLs to share; otherwise the
+// returned transport is nil. With RequestTimeout set, a copy of the HTTP
+// client without a Timeout is returned.
+func (b *BaseClient) Configure(httpClient, defaultHTTPClient *http.Client) (*http.Client, http.RoundTripper, error) {
+	if err := b.TLS.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	if b.LogRequestBodies {
+		b.BodyLogger = b.Logger
+		if b.BodyLogger == nil {
+			b.BodyLogger = log.Default()
+		}
+	}
+
+	var transferTransport http.RoundTripper
+	if (b.ConnectionPool != nil || b.TLS.IsSet() || b.ForceHTTP1) && httpClient == defaultHTTPClient {
+		transport := NewTransport(b.ConnectionPool, b.TLS.ClientConfig())
+		if b.ForceHTTP1 {
+			ForceHTTP1(transport)
+		}
+		httpClient.Transport = transport
+		if b.TLS.IsSet() || b.ForceHTTP1 {
+			transferTransport = transport
+		}
+	}
+
+	if b.RequestTimeout > 0 && httpClient != nil && httpClient.Timeout != 0 {
+		c := *httpClient
+		c.Timeout = 0
+		httpClient = &c
+	}
+
+	return httpClient, transferTransport, nil
+}
+
+// RequestOptions returns the options for a call of the named client method
+// implied by b's settings, for Do and Stream
+func (b *BaseClient) RequestOptions(operation string) []RequestOption {
+	opts := []RequestOption{
+		WithTimeout(b.RequestTimeout),
+		WithMaxResponseBytes(b.MaxResponseBytes),
+		WithMetrics(b.Metrics, b.Service, b.Service+"."+operation),
+		WithStats(b.Stats),
+		WithDeprecationWarnings(b.Logger, b.DeprecationCallback, b.Service, b.Service+"."+operation),
+	}
+	if b.RawErrorBody {
+		opts = append(opts, WithRawBody())
+	}
+	return opts
+}
@@ -0,0 +1,138 @@
+package clientutil
+
+import (
+	"bytes"
+	"crypto/tls"
+	"log"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBaseClient_Configure(t *testing.T) {
+	t.Run("no settings", func(t *testing.T) {
+		defaultClient := &http.Client{Timeout: 10 * time.Second}
+		b := &BaseClient{}
+
+		httpClient, transfer, err := b.Configure(defaultClient, defaultClient)
+		require.NoError(t, err)
+		assert.Same(t, defaultClient, httpClient)
+		assert.Nil(t, httpClient.Transport)
+		assert.Nil(t, transfer)
+		assert.Nil(t, b.BodyLogger)
+	})
+
+	t.Run("connection pool only", func(t *testing.T) {
+		defaultClient := &http.Client{}
+		b := &BaseClient{}
+		SetConnectionPool(64, 16, 32, time.Minute)(b)
+
+		httpClient, transfer, err := b.Configure(defaultClient, defaultClient)
+		require.NoError(t, err)
+		transport, ok := httpClient.Transport.(*http.Transport)
+		require.True(t, ok)
+		assert.Equal(t, 16, transport.MaxIdleConnsPerHost)
+		assert.Nil(t, transfer, "a connection pool alone is not shared with pre-signed URL transfers")
+	})
+
+	t.Run("TLS settings", func(t *testing.T) {
+		defaultClient := &http.Client{}
+		b := &BaseClient{}
+		SetTLSConfig(&tls.Config{MinVersion: tls.VersionTLS13})(b)
+		SetForceHTTP1()(b)
+
+		httpClient, transfer, err := b.Configure(defaultClient, defaultClient)
+		require.NoError(t, err)
+		transport := httpClient.Transport.(*http.Transport)
+		assert.Equal(t, uint16(tls.VersionTLS13), transport.TLSClientConfig.MinVersion)
+		assert.False(t, transport.ForceAttemptHTTP2)
+		assert.Same(t, transport, transfer)
+	})
+
+	t.Run("supplied HTTP client is left alone", func(t *testing.T) {
+		supplied := &http.Client{}
+		b := &BaseClient{}
+		SetForceHTTP1()(b)
+
+		httpClient, transfer, err := b.Configure(supplied, &http.Client{})
+		require.NoError(t, err)
+		assert.Same(t, supplied, httpClient)
+		assert.Nil(t, httpClient.Transport)
+		assert.Nil(t, transfer)
+	})
+
+	t.Run("insecure TLS config without SetInsecureSkipVerify", func(t *testing.T) {
+		defaultClient := &http.Client{}
+		b := &BaseClient{}
+		SetTLSConfig(&tls.Config{InsecureSkipVerify: true})(b)
+
+		_, _, err := b.Configure(defaultClient, defaultClient)
+		assert.Error(t, err)
+
+		SetInsecureSkipVerify()(b)
+		_, _, err = b.Configure(defaultClient, defaultClient)
+		assert.NoError(t, err)
+	})
+
+	t.Run("request timeout clears the client timeout on a copy", func(t *testing.T) {
+		supplied := &http.Client{Timeout: 10 * time.Second}
+		b := &BaseClient{}
+		SetRequestTimeout(time.Minute)(b)
+
+		httpClient, _, err := b.Configure(supplied, supplied)
+		require.NoError(t, err)
+		assert.NotSame(t, supplied, httpClient)
+		assert.Zero(t, httpClient.Timeout)
+		assert.Equal(t, 10*time.Second, supplied.Timeout)
+	})
+
+	t.Run("request body logging", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := log.New(&buf, "", 0)
+		b := &BaseClient{}
+		SetLogger(logger)(b)
+		SetRequestBodyLogging()(b)
+
+		_, _, err := b.Configure(&http.Client{}, nil)
+		require.NoError(t, err)
+		assert.Same(t, logger, b.BodyLogger)
+
+		b = &BaseClient{}
+		SetRequestBodyLogging()(b)
+		_, _, err = b.Configure(&http.Client{}, nil)
+		require.NoError(t, err)
+		assert.Same(t, log.Default(), b.BodyLogger)
+	})
+}
+
+func TestBaseClient_RequestOptions(t *testing.T) {
+	recorder := &fakeRecorder{}
+	callback := func(DeprecationNotice) {}
+	b := &BaseClient{Service: "ai"}
+	SetMetricsRecorder(recorder)(b)
+	SetStatsCollection()(b)
+	SetDeprecationCallback(callback)(b)
+	SetMaxResponseBytes(5)(b)
+	SetRawErrorBody()(b)
+	SetRequestTimeout(time.Minute)(b)
+
+	options := newRequestOptions(b.RequestOptions("CreatePrompt"))
+	assert.Equal(t, int64(5), options.maxBytes)
+	assert.True(t, options.rawBody)
+	assert.Equal(t, time.Minute, options.timeout)
+	assert.Same(t, b.Stats, options.stats)
+	require.NotNil(t, options.metrics)
+	assert.Equal(t, "ai", options.metrics.service)
+	assert.Equal(t, "ai.CreatePrompt", options.metrics.operation)
+	require.NotNil(t, options.deprecation)
+	assert.Equal(t, "ai.CreatePrompt", options.deprecation.operation)
+
+	options = newRequestOptions((&BaseClient{Service: "ai"}).RequestOptions("CreatePrompt"))
+	assert.Equal(t, int64(DefaultMaxResponseBytes), options.maxBytes)
+	assert.False(t, options.rawBody)
+	assert.Nil(t, options.metrics)
+	assert.Nil(t, options.deprecation)
+}
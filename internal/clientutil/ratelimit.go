@@ -0,0 +1,77 @@
+package clientutil
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+// RateLimiter is a token bucket that limits how often requests are sent. It is
+// safe for concurrent use, so one limiter throttles every goroutine sharing a
+// client. A nil *RateLimiter never blocks.
+type RateLimiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// NewRateLimiter returns a limiter that allows rps requests per second on
+// average and bursts of up to burst requests. It returns nil, meaning no limit,
+// if rps is zero or less. A burst below 1 is treated as 1.
+func NewRateLimiter(rps float64, burst int) *RateLimiter {
+	if rps <= 0 {
+		return nil
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	return &RateLimiter{
+		rate:   rps,
+		burst:  float64(burst),
+		tokens: float64(burst),
+	}
+}
+
+// Wait blocks until a request may be sent or ctx is done. It returns an error
+// wrapping ctx.Err() if the context ends first.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	return l.wait(ctx, SystemClock)
+}
+
+// wait implements Wait, reading the time from and sleeping on clock
+func (l *RateLimiter) wait(ctx context.Context, clock Clock) error {
+	if l == nil {
+		return nil
+	}
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("waiting for rate limiter: %w", err)
+	}
+
+	// Take a token now, going into debt if necessary, and wait out the debt
+	l.mu.Lock()
+	now := clock.Now()
+	if l.last.IsZero() {
+		l.last = now
+	}
+	l.tokens = math.Min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	l.last = now
+	l.tokens--
+	var delay time.Duration
+	if l.tokens < 0 {
+		delay = time.Duration(-l.tokens / l.rate * float64(time.Second))
+	}
+	l.mu.Unlock()
+
+	if err := clock.Sleep(ctx, delay); err != nil {
+		// Give the token back so a canceled call doesn't delay later ones
+		l.mu.Lock()
+		l.tokens = math.Min(l.burst, l.tokens+1)
+		l.mu.Unlock()
+		return fmt.Errorf("waiting for rate limiter: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package clientutil
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRateLimiter_Disabled(t *testing.T) {
+	assert.Nil(t, NewRateLimiter(0, 5))
+
+	var l *RateLimiter
+	assert.NoError(t, l.Wait(context.Background()))
+}
+
+func TestRateLimiter_Throttles(t *testing.T) {
+	l := NewRateLimiter(20, 2)
+
+	// The burst is free; each further call waits 1/20s
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, l.Wait(context.Background()))
+		}()
+	}
+	wg.Wait()
+
+	assert.GreaterOrEqual(t, time.Since(start), 190*time.Millisecond)
+}
+
+func TestRateLimiter_ContextCanceled(t *testing.T) {
+	l := NewRateLimiter(0.5, 1)
+	require.NoError(t, l.Wait(context.Background()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := l.Wait(ctx)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.Less(t, time.Since(start), time.Second)
+}
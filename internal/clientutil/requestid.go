@@ -0,0 +1,54 @@
+package clientutil
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"net/http"
+)
+
+// RequestIDHeader is the header carrying the ID that identifies a request in
+// client and service logs.
+const RequestIDHeader = "X-Request-ID"
+
+// requestIDKey is the context key for a caller-provided request ID.
+type requestIDKey struct{}
+
+// WithRequestID returns a copy of ctx that makes requests created with it use
+// id as their request ID instead of a generated one.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestIDFromContext returns the request ID stored in ctx by WithRequestID,
+// or "" if there is none.
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// SetRequestID sets the X-Request-ID header on req to the ID carried by its
+// context, or to a newly generated UUID.
+func SetRequestID(req *http.Request) error {
+	id := RequestIDFromContext(req.Context())
+	if id == "" {
+		var err error
+		id, err = NewUUID()
+		if err != nil {
+			return fmt.Errorf("failed to generate request ID: %w", err)
+		}
+	}
+	req.Header.Set(RequestIDHeader, id)
+	return nil
+}
+
+// NewUUID returns a random version 4 UUID.
+func NewUUID() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", err
+	}
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16]), nil
+}
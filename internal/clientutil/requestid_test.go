@@ -0,0 +1,70 @@
+package clientutil
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/atriumn/atriumn-sdk-go/internal/apierror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+func TestNewRequest_GeneratesRequestID(t *testing.T) {
+	b := newBaseClient(t, "https://api.example.com")
+
+	first, err := b.NewRequest(context.Background(), "GET", "/items", nil)
+	require.NoError(t, err)
+	second, err := b.NewRequest(context.Background(), "GET", "/items", nil)
+	require.NoError(t, err)
+
+	id := first.Header.Get(RequestIDHeader)
+	assert.Regexp(t, uuidPattern, id)
+	assert.NotEqual(t, id, second.Header.Get(RequestIDHeader), "each request should get its own ID")
+}
+
+func TestNewRequest_ContextRequestID(t *testing.T) {
+	b := newBaseClient(t, "https://api.example.com")
+	ctx := WithRequestID(context.Background(), "req-123")
+
+	req, err := b.NewRequest(ctx, "GET", "/items", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "req-123", req.Header.Get(RequestIDHeader))
+	assert.Equal(t, "req-123", RequestIDFromContext(ctx))
+	assert.Empty(t, RequestIDFromContext(context.Background()))
+}
+
+func TestExecuteRequest_RequestIDOnError(t *testing.T) {
+	var received string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header.Get(RequestIDHeader)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	b := newBaseClient(t, server.URL)
+	req, err := b.NewRequest(WithRequestID(context.Background(), "req-456"), "GET", "/items", nil)
+	require.NoError(t, err)
+
+	_, err = b.Do(req, nil)
+	var apiErr *apierror.ErrorResponse
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, "req-456", received)
+	assert.Equal(t, "req-456", apiErr.RequestID)
+}
+
+func TestExecuteRequest_RequestIDOnNetworkError(t *testing.T) {
+	b := newBaseClient(t, "http://127.0.0.1:1")
+	req, err := b.NewRequest(context.Background(), "GET", "/items", nil)
+	require.NoError(t, err)
+
+	_, err = b.Do(req, nil)
+	var apiErr *apierror.ErrorResponse
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, req.Header.Get(RequestIDHeader), apiErr.RequestID)
+	assert.NotEmpty(t, apiErr.RequestID)
+}
@@ -0,0 +1,112 @@
+package clientutil
+
+import (
+	"container/list"
+	"net/http"
+	"sync"
+)
+
+// ResponseCache holds the bodies of successful GET responses that carried an
+// ETag, keyed by request URL, and evicts the least recently used entry once
+// full. ExecuteRequest uses it to revalidate repeated GETs with If-None-Match
+// and to serve the cached body when the service answers 304 Not Modified. It
+// is safe for concurrent use. A nil *ResponseCache caches nothing.
+type ResponseCache struct {
+	mu      sync.Mutex
+	size    int
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+// cachedResponse is a ResponseCache entry
+type cachedResponse struct {
+	key  string
+	etag string
+	body []byte
+}
+
+// NewResponseCache returns a cache holding up to size responses. It returns
+// nil, meaning no caching, if size is zero or less.
+func NewResponseCache(size int) *ResponseCache {
+	if size <= 0 {
+		return nil
+	}
+	return &ResponseCache{
+		size:    size,
+		order:   list.New(),
+		entries: make(map[string]*list.Element),
+	}
+}
+
+// WithResponseCache revalidates GET requests against cache, and stores
+// successful GET responses that carry an ETag in it. A nil cache disables
+// caching. Other methods are never cached.
+func WithResponseCache(cache *ResponseCache) RequestOption {
+	return func(o *requestOptions) {
+		o.cache = cache
+	}
+}
+
+// cacheKey returns the key req's response is cached under, or "" if it must
+// not be cached. Requests made with a token from WithAuthToken are not cached,
+// since the key does not tell apart the users such a shared client serves.
+func cacheKey(req *http.Request) string {
+	if req.Method != http.MethodGet || AuthTokenFromContext(req.Context()) != "" {
+		return ""
+	}
+	return req.URL.String()
+}
+
+// get returns the entry cached under key, or nil
+func (c *ResponseCache) get(key string) *cachedResponse {
+	if c == nil || key == "" {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil
+	}
+	c.order.MoveToFront(elem)
+	return elem.Value.(*cachedResponse)
+}
+
+// put caches body under key with etag, or removes the entry for key if etag
+// is empty, since the response can then no longer be revalidated
+func (c *ResponseCache) put(key, etag string, body []byte) {
+	if c == nil || key == "" {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if elem, ok := c.entries[key]; ok {
+		if etag == "" {
+			c.order.Remove(elem)
+			delete(c.entries, key)
+			return
+		}
+		elem.Value = &cachedResponse{key: key, etag: etag, body: body}
+		c.order.MoveToFront(elem)
+		return
+	}
+	if etag == "" {
+		return
+	}
+	c.entries[key] = c.order.PushFront(&cachedResponse{key: key, etag: etag, body: body})
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cachedResponse).key)
+	}
+}
+
+// Len returns the number of cached responses.
+func (c *ResponseCache) Len() int {
+	if c == nil {
+		return 0
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
@@ -0,0 +1,121 @@
+package clientutil
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewResponseCache_Disabled(t *testing.T) {
+	assert.Nil(t, NewResponseCache(0))
+
+	var c *ResponseCache
+	c.put("key", `"v1"`, []byte("body"))
+	assert.Nil(t, c.get("key"))
+	assert.Equal(t, 0, c.Len())
+}
+
+func TestResponseCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	c := NewResponseCache(2)
+	c.put("a", `"a1"`, []byte("a"))
+	c.put("b", `"b1"`, []byte("b"))
+	require.NotNil(t, c.get("a"))
+	c.put("c", `"c1"`, []byte("c"))
+
+	assert.Equal(t, 2, c.Len())
+	assert.NotNil(t, c.get("a"))
+	assert.Nil(t, c.get("b"), "b was least recently used and should be evicted")
+	assert.NotNil(t, c.get("c"))
+
+	// A response without an ETag replaces nothing and drops the stale entry
+	c.put("a", "", []byte("a2"))
+	assert.Nil(t, c.get("a"))
+	assert.Equal(t, 1, c.Len())
+}
+
+func TestExecuteRequest_ResponseCache(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&calls, 1)
+		if n > 1 {
+			assert.Equal(t, `"v1"`, r.Header.Get("If-None-Match"))
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		assert.Empty(t, r.Header.Get("If-None-Match"))
+		w.Header().Set("ETag", `"v1"`)
+		_, _ = w.Write([]byte(`{"name":"greeting"}`))
+	}))
+	defer server.Close()
+
+	cache := NewResponseCache(10)
+	for i := 0; i < 3; i++ {
+		req, err := http.NewRequest(http.MethodGet, server.URL+"/prompts/1", nil)
+		require.NoError(t, err)
+
+		var result map[string]string
+		resp, err := ExecuteRequest(context.Background(), http.DefaultClient, req, &result, WithResponseCache(cache))
+		require.NoError(t, err)
+		assert.Equal(t, "greeting", result["name"])
+		if i == 0 {
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+		} else {
+			assert.Equal(t, http.StatusNotModified, resp.StatusCode)
+		}
+	}
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+	assert.Equal(t, 1, cache.Len())
+}
+
+func TestExecuteRequest_ResponseCacheSkipsUnsafeMethods(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Empty(t, r.Header.Get("If-None-Match"))
+		w.Header().Set("ETag", `"v1"`)
+		_, _ = w.Write([]byte(`{"name":"greeting"}`))
+	}))
+	defer server.Close()
+
+	cache := NewResponseCache(10)
+	for i := 0; i < 2; i++ {
+		req, err := http.NewRequest(http.MethodPut, server.URL+"/prompts/1", nil)
+		require.NoError(t, err)
+		_, err = ExecuteRequest(context.Background(), http.DefaultClient, req, nil, WithResponseCache(cache))
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 0, cache.Len())
+}
+
+func TestExecuteRequest_ResponseCacheSkipsContextTokens(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Empty(t, r.Header.Get("If-None-Match"))
+		w.Header().Set("ETag", `"v1"`)
+		_, _ = w.Write([]byte(`{"name":"greeting"}`))
+	}))
+	defer server.Close()
+
+	cache := NewResponseCache(10)
+	for _, token := range []string{"user-a", "user-b"} {
+		req, err := http.NewRequestWithContext(WithAuthToken(context.Background(), token), http.MethodGet, server.URL+"/prompts/1", nil)
+		require.NoError(t, err)
+		_, err = ExecuteRequest(context.Background(), http.DefaultClient, req, nil, WithResponseCache(cache))
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 0, cache.Len(), "one user's response must not be served to another")
+}
+
+func TestExecuteRequest_NotModifiedWithoutCache(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotModified)
+	}))
+	defer server.Close()
+
+	req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+	_, err = ExecuteRequest(context.Background(), http.DefaultClient, req, nil, WithResponseCache(NewResponseCache(10)))
+	assert.Error(t, err, "a 304 with nothing cached is unexpected")
+}
@@ -0,0 +1,182 @@
+package clientutil
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+)
+
+// RetryPredicate reports whether a request should be retried given the outcome
+// of an attempt: either resp, whose body can be read in full and whose Request
+// field is the request sent, or err, the transport error the attempt failed with.
+type RetryPredicate func(resp *http.Response, err error) bool
+
+// RetryPolicy configures how BaseClient.Do retries failed requests. By default,
+// transport errors and 429, 502, 503, and 504 responses are retried, for
+// idempotent requests only: those with a GET, HEAD, OPTIONS, PUT, or DELETE
+// method, or that carry an Idempotency-Key header.
+type RetryPolicy struct {
+	// MaxRetries is the number of retries made after the first attempt
+	MaxRetries int
+
+	// Backoff computes the delay before each retry. A Retry-After or
+	// X-RateLimit-Reset header on the response takes precedence.
+	Backoff Backoff
+
+	// Retryable, if set, is consulted when the default rules decline to retry,
+	// so it can add retries, for example on an application error code in a
+	// 200 response, but not remove the default ones. It sees the response body,
+	// buffered up to DefaultMaxResponseBytes, and must itself decide whether a
+	// request that is not idempotent is safe to repeat.
+	Retryable RetryPredicate
+}
+
+// retries reports whether p makes any retries
+func (p *RetryPolicy) retries() bool {
+	return p != nil && p.MaxRetries > 0
+}
+
+// shouldRetry reports whether req should be retried after an attempt that
+// returned resp, whose body began with body, or failed with err
+func (p *RetryPolicy) shouldRetry(req *http.Request, resp *http.Response, body []byte, err error) bool {
+	if req.Context().Err() != nil {
+		return false
+	}
+	if defaultRetryable(req, resp, err) {
+		return true
+	}
+	if p.Retryable == nil {
+		return false
+	}
+	if resp == nil {
+		return p.Retryable(nil, err)
+	}
+
+	// The predicate reads its own copy of the body, leaving resp's for decoding
+	view := *resp
+	view.Body = io.NopCloser(bytes.NewReader(body))
+	return p.Retryable(&view, nil)
+}
+
+// defaultRetryable implements the default retry rules described on RetryPolicy
+func defaultRetryable(req *http.Request, resp *http.Response, err error) bool {
+	if !idempotent(req) {
+		return false
+	}
+	if err != nil {
+		return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// idempotent reports whether sending req more than once has the same effect
+// as sending it once
+func idempotent(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return req.Header.Get("Idempotency-Key") != ""
+}
+
+// retryTransport is an http.RoundTripper that retries requests under policy,
+// waiting on clock between attempts and for limiter before each retry
+type retryTransport struct {
+	base    http.RoundTripper
+	policy  *RetryPolicy
+	clock   Clock
+	limiter *RateLimiter
+}
+
+// withRetries returns a copy of client that retries requests under policy, or
+// client itself if policy makes no retries
+func withRetries(client *http.Client, policy *RetryPolicy, clock Clock, limiter *RateLimiter) *http.Client {
+	if !policy.retries() {
+		return client
+	}
+	base := client.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	retrying := *client
+	retrying.Transport = &retryTransport{base: base, policy: policy, clock: clock, limiter: limiter}
+	return &retrying
+}
+
+// RoundTrip sends req, retrying it while the policy allows. A request whose
+// body cannot be replayed is sent once.
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	for attempt := 0; ; attempt++ {
+		resp, err := t.base.RoundTrip(req)
+		var body []byte
+		if err == nil {
+			if body, err = bufferBody(resp); err != nil {
+				return nil, err
+			}
+		}
+
+		if attempt >= t.policy.MaxRetries || !t.policy.shouldRetry(req, resp, body, err) {
+			return resp, err
+		}
+		next, ok := replay(req)
+		if !ok {
+			return resp, err
+		}
+
+		delay := t.policy.Backoff.Duration(attempt)
+		if resp != nil {
+			if hint := retryAfter(resp.Header, t.clock.Now()); hint > 0 {
+				delay = hint
+			}
+			_ = resp.Body.Close()
+		}
+		if err := t.clock.Sleep(ctx, delay); err != nil {
+			return nil, err
+		}
+		if err := t.limiter.wait(ctx, t.clock); err != nil {
+			return nil, err
+		}
+		req = next
+	}
+}
+
+// bufferBody reads up to DefaultMaxResponseBytes of resp's body into memory, so
+// a retry predicate can read it, and replaces the body with one that yields
+// the buffered bytes followed by any remainder. It returns the buffered bytes.
+func bufferBody(resp *http.Response) ([]byte, error) {
+	buf, err := io.ReadAll(io.LimitReader(resp.Body, DefaultMaxResponseBytes))
+	if err != nil {
+		_ = resp.Body.Close()
+		return nil, err
+	}
+	resp.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(buf), resp.Body), resp.Body}
+	return buf, nil
+}
+
+// replay returns a copy of req with a fresh body, or false if its body cannot
+// be read again
+func replay(req *http.Request) (*http.Request, bool) {
+	next := req.Clone(req.Context())
+	if req.Body == nil || req.Body == http.NoBody {
+		return next, true
+	}
+	if req.GetBody == nil {
+		return nil, false
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, false
+	}
+	next.Body = body
+	return next, true
+}
@@ -0,0 +1,261 @@
+package clientutil
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newRetryServer returns a server that answers the first len(statuses)
+// requests with the given status codes and bodies, and later requests with
+// 200 and final. It counts the requests it receives in calls.
+func newRetryServer(t *testing.T, calls *int32, statuses []int, bodies []string, final string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(atomic.AddInt32(calls, 1)) - 1
+		w.Header().Set("Content-Type", "application/json")
+		if n < len(statuses) {
+			w.WriteHeader(statuses[n])
+			_, _ = io.WriteString(w, bodies[n])
+			return
+		}
+		_, _ = io.WriteString(w, final)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+func newRetryingBaseClient(t *testing.T, rawURL string, policy *RetryPolicy) (*BaseClient, *FakeClock) {
+	t.Helper()
+	clock := NewFakeClock(clockStart)
+	b := newBaseClient(t, rawURL)
+	b.Clock = clock
+	b.RetryPolicy = policy
+	return b, clock
+}
+
+func TestBaseClient_Do_RetriesTransientStatus(t *testing.T) {
+	var calls int32
+	server := newRetryServer(t, &calls,
+		[]int{http.StatusServiceUnavailable, http.StatusBadGateway},
+		[]string{`{"error":"unavailable"}`, `{"error":"bad_gateway"}`},
+		`{"name":"done"}`)
+	b, clock := newRetryingBaseClient(t, server.URL, &RetryPolicy{
+		MaxRetries: 3,
+		Backoff:    Backoff{Initial: time.Second, Multiplier: 2},
+	})
+
+	req, err := b.NewRequest(context.Background(), http.MethodGet, "/items/1", nil)
+	require.NoError(t, err)
+	var out struct{ Name string }
+	_, err = b.Do(req, &out)
+
+	require.NoError(t, err)
+	assert.Equal(t, "done", out.Name)
+	assert.EqualValues(t, 3, atomic.LoadInt32(&calls))
+	assert.Equal(t, []time.Duration{time.Second, 2 * time.Second}, clock.Sleeps())
+}
+
+func TestBaseClient_Do_RetryGivesUp(t *testing.T) {
+	var calls int32
+	server := newRetryServer(t, &calls,
+		[]int{http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusServiceUnavailable},
+		[]string{`{"error":"unavailable"}`, `{"error":"unavailable"}`, `{"error":"still_unavailable"}`},
+		`{}`)
+	b, _ := newRetryingBaseClient(t, server.URL, &RetryPolicy{MaxRetries: 2})
+
+	req, err := b.NewRequest(context.Background(), http.MethodGet, "/items/1", nil)
+	require.NoError(t, err)
+	_, err = b.Do(req, nil)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "still_unavailable")
+	assert.EqualValues(t, 3, atomic.LoadInt32(&calls))
+}
+
+func TestBaseClient_Do_NoRetryForNonIdempotent(t *testing.T) {
+	var calls int32
+	server := newRetryServer(t, &calls,
+		[]int{http.StatusServiceUnavailable},
+		[]string{`{"error":"unavailable"}`},
+		`{}`)
+	b, _ := newRetryingBaseClient(t, server.URL, &RetryPolicy{MaxRetries: 3})
+
+	req, err := b.NewRequest(context.Background(), http.MethodPost, "/items", map[string]string{"name": "x"})
+	require.NoError(t, err)
+	_, err = b.Do(req, nil)
+
+	require.Error(t, err)
+	assert.EqualValues(t, 1, atomic.LoadInt32(&calls))
+}
+
+func TestBaseClient_Do_RetriesPostWithIdempotencyKey(t *testing.T) {
+	var calls int32
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = io.WriteString(w, `{}`)
+	}))
+	defer server.Close()
+	b, _ := newRetryingBaseClient(t, server.URL, &RetryPolicy{MaxRetries: 1})
+
+	req, err := b.NewRequest(context.Background(), http.MethodPost, "/items", map[string]string{"name": "x"})
+	require.NoError(t, err)
+	req.Header.Set("Idempotency-Key", "key-1")
+	_, err = b.Do(req, nil)
+
+	require.NoError(t, err)
+	require.Len(t, bodies, 2)
+	assert.Equal(t, bodies[0], bodies[1], "the retry must resend the same body")
+}
+
+func TestBaseClient_Do_RetryPredicate(t *testing.T) {
+	var calls int32
+	server := newRetryServer(t, &calls,
+		[]int{http.StatusOK},
+		[]string{`{"error":"temporary_processing_failure"}`},
+		`{"name":"done"}`)
+
+	var seen []string
+	b, _ := newRetryingBaseClient(t, server.URL, &RetryPolicy{
+		MaxRetries: 2,
+		Retryable: func(resp *http.Response, err error) bool {
+			if err != nil {
+				return false
+			}
+			var body struct{ Error string }
+			_ = json.NewDecoder(resp.Body).Decode(&body)
+			seen = append(seen, body.Error)
+			return body.Error == "temporary_processing_failure"
+		},
+	})
+
+	req, err := b.NewRequest(context.Background(), http.MethodGet, "/items/1", nil)
+	require.NoError(t, err)
+	var out struct{ Name string }
+	_, err = b.Do(req, &out)
+
+	require.NoError(t, err)
+	assert.Equal(t, "done", out.Name, "the predicate must not consume the body returned to the caller")
+	assert.EqualValues(t, 2, atomic.LoadInt32(&calls))
+	assert.Equal(t, []string{"temporary_processing_failure", ""}, seen)
+}
+
+func TestBaseClient_Do_RetryPredicateCannotRemoveDefaults(t *testing.T) {
+	var calls int32
+	server := newRetryServer(t, &calls,
+		[]int{http.StatusServiceUnavailable},
+		[]string{`{"error":"unavailable"}`},
+		`{}`)
+	b, _ := newRetryingBaseClient(t, server.URL, &RetryPolicy{
+		MaxRetries: 1,
+		Retryable:  func(resp *http.Response, err error) bool { return false },
+	})
+
+	req, err := b.NewRequest(context.Background(), http.MethodGet, "/items/1", nil)
+	require.NoError(t, err)
+	_, err = b.Do(req, nil)
+
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, atomic.LoadInt32(&calls))
+}
+
+func TestBaseClient_Do_RetryHonorsRetryAfter(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.Header().Set("Retry-After", "7")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_, _ = io.WriteString(w, `{}`)
+	}))
+	defer server.Close()
+	b, clock := newRetryingBaseClient(t, server.URL, &RetryPolicy{
+		MaxRetries: 1,
+		Backoff:    Backoff{Initial: time.Second},
+	})
+
+	req, err := b.NewRequest(context.Background(), http.MethodGet, "/items/1", nil)
+	require.NoError(t, err)
+	_, err = b.Do(req, nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, []time.Duration{7 * time.Second}, clock.Sleeps())
+}
+
+func TestBaseClient_Do_NoRetryForUnreplayableBody(t *testing.T) {
+	var calls int32
+	server := newRetryServer(t, &calls,
+		[]int{http.StatusServiceUnavailable},
+		[]string{`{"error":"unavailable"}`},
+		`{}`)
+	b, _ := newRetryingBaseClient(t, server.URL, &RetryPolicy{MaxRetries: 3})
+
+	req, err := http.NewRequest(http.MethodPut, server.URL+"/items/1", io.NopCloser(strings.NewReader("data")))
+	require.NoError(t, err)
+	_, err = b.Do(req, nil)
+
+	require.Error(t, err)
+	assert.EqualValues(t, 1, atomic.LoadInt32(&calls))
+}
+
+func TestBaseClient_Do_RetryTransportError(t *testing.T) {
+	var calls int32
+	b, _ := newRetryingBaseClient(t, "https://api.example.com", &RetryPolicy{MaxRetries: 2})
+	b.HTTPClient = &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			return nil, io.ErrUnexpectedEOF
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(`{}`)),
+			Request:    r,
+		}, nil
+	})}
+
+	req, err := b.NewRequest(context.Background(), http.MethodGet, "/items/1", nil)
+	require.NoError(t, err)
+	_, err = b.Do(req, nil)
+
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, atomic.LoadInt32(&calls))
+}
+
+func TestBaseClient_Do_RetryStopsOnCancel(t *testing.T) {
+	var calls int32
+	ctx, cancel := context.WithCancel(context.Background())
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		cancel()
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+	b, _ := newRetryingBaseClient(t, server.URL, &RetryPolicy{MaxRetries: 3})
+
+	req, err := b.NewRequest(ctx, http.MethodGet, "/items/1", nil)
+	require.NoError(t, err)
+	_, err = b.Do(req, nil)
+
+	require.Error(t, err)
+	assert.EqualValues(t, 1, atomic.LoadInt32(&calls))
+}
@@ -0,0 +1,51 @@
+package clientutil
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// rateLimitResetHeader is the non-standard header some gateways send instead
+// of, or alongside, Retry-After
+const rateLimitResetHeader = "X-RateLimit-Reset"
+
+// unixTimeThreshold separates X-RateLimit-Reset values that are Unix
+// timestamps from ones that count seconds from now
+const unixTimeThreshold = 1_000_000_000
+
+// retryAfter returns how long the server asked the client to wait before
+// retrying, from the Retry-After header or, failing that, X-RateLimit-Reset.
+// It returns zero if neither header is present or parsable.
+func retryAfter(h http.Header, now time.Time) time.Duration {
+	if v := strings.TrimSpace(h.Get("Retry-After")); v != "" {
+		// Retry-After is either delta-seconds or an HTTP-date
+		if secs, err := strconv.ParseInt(v, 10, 64); err == nil {
+			return nonNegative(time.Duration(secs) * time.Second)
+		}
+		if t, err := http.ParseTime(v); err == nil {
+			return nonNegative(t.Sub(now))
+		}
+	}
+
+	if v := strings.TrimSpace(h.Get(rateLimitResetHeader)); v != "" {
+		// X-RateLimit-Reset is either a Unix timestamp or seconds from now
+		if secs, err := strconv.ParseInt(v, 10, 64); err == nil {
+			if secs >= unixTimeThreshold {
+				return nonNegative(time.Unix(secs, 0).Sub(now))
+			}
+			return nonNegative(time.Duration(secs) * time.Second)
+		}
+	}
+
+	return 0
+}
+
+// nonNegative clamps d to zero, since a time in the past means "retry now"
+func nonNegative(d time.Duration) time.Duration {
+	if d < 0 {
+		return 0
+	}
+	return d
+}
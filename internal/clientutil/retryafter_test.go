@@ -0,0 +1,81 @@
+package clientutil
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/atriumn/atriumn-sdk-go/internal/apierror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		headers map[string]string
+		want    time.Duration
+	}{
+		{"missing", nil, 0},
+		{"delta seconds", map[string]string{"Retry-After": "120"}, 2 * time.Minute},
+		{"http date", map[string]string{"Retry-After": "Fri, 01 Mar 2024 12:00:30 GMT"}, 30 * time.Second},
+		{"http date in the past", map[string]string{"Retry-After": "Fri, 01 Mar 2024 11:00:00 GMT"}, 0},
+		{"unparsable", map[string]string{"Retry-After": "soon"}, 0},
+		{"reset seconds", map[string]string{"X-RateLimit-Reset": "15"}, 15 * time.Second},
+		{"reset timestamp", map[string]string{"X-RateLimit-Reset": strconv.FormatInt(now.Add(45*time.Second).Unix(), 10)}, 45 * time.Second},
+		{"retry-after wins", map[string]string{"Retry-After": "5", "X-RateLimit-Reset": "60"}, 5 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := http.Header{}
+			for k, v := range tt.headers {
+				h.Set(k, v)
+			}
+			assert.Equal(t, tt.want, retryAfter(h, now))
+		})
+	}
+}
+
+func TestExecuteRequest_RetryAfter(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		retryAfter string
+		body       string
+		want       time.Duration
+	}{
+		{"429 delta seconds", http.StatusTooManyRequests, "3", "", 3 * time.Second},
+		{"429 with error body", http.StatusTooManyRequests, "3", `{"error":"slow_down"}`, 3 * time.Second},
+		{"503 http date", http.StatusServiceUnavailable, time.Now().Add(time.Hour).UTC().Format(http.TimeFormat), "", time.Hour},
+		{"429 missing header", http.StatusTooManyRequests, "", "", 0},
+		{"500 ignores header", http.StatusInternalServerError, "3", "", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tt.retryAfter != "" {
+					w.Header().Set("Retry-After", tt.retryAfter)
+				}
+				w.WriteHeader(tt.statusCode)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			req, err := http.NewRequest("GET", server.URL, nil)
+			require.NoError(t, err)
+
+			_, err = ExecuteRequest(context.Background(), http.DefaultClient, req, nil)
+			apiErr, ok := err.(*apierror.ErrorResponse)
+			require.True(t, ok, "expected *apierror.ErrorResponse, got %T", err)
+			// HTTP dates have one-second resolution
+			assert.InDelta(t, tt.want, apiErr.RetryAfter, float64(time.Second))
+		})
+	}
+}
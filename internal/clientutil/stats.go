@@ -0,0 +1,116 @@
+package clientutil
+
+import (
+	"math/rand/v2"
+	"slices"
+	"sync"
+	"time"
+)
+
+// DefaultStatsReservoirSize is the number of latencies a StatsCollector keeps
+// to estimate percentiles from.
+const DefaultStatsReservoirSize = 1024
+
+// Stats summarizes the API calls made by a client since it was created.
+type Stats struct {
+	// Requests is the number of calls made
+	Requests int64
+
+	// Errors is the number of calls that returned an error, including error
+	// responses from the service
+	Errors int64
+
+	// P50, P95, and P99 are the median, 95th, and 99th percentile call
+	// latencies, estimated from a uniform sample of the calls; zero if no
+	// call has been made
+	P50 time.Duration
+	P95 time.Duration
+	P99 time.Duration
+}
+
+// StatsCollector counts API calls and samples their latencies, keeping memory
+// bounded however many calls are made. It is safe for concurrent use.
+type StatsCollector struct {
+	mu        sync.Mutex
+	requests  int64
+	errors    int64
+	reservoir []time.Duration
+	size      int
+	rand      *rand.Rand
+}
+
+// NewStatsCollector creates a collector that estimates percentiles from a
+// sample of at most size latencies; below 1, DefaultStatsReservoirSize is used.
+func NewStatsCollector(size int) *StatsCollector {
+	if size < 1 {
+		size = DefaultStatsReservoirSize
+	}
+	return &StatsCollector{
+		size:      size,
+		reservoir: make([]time.Duration, 0, size),
+		rand:      rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64())),
+	}
+}
+
+// WithStats records the call's outcome and latency in collector. A nil
+// collector records nothing.
+func WithStats(collector *StatsCollector) RequestOption {
+	return func(o *requestOptions) {
+		o.stats = collector
+	}
+}
+
+// record counts a call that took latency and ended with err. Once the
+// reservoir is full, the latency replaces a random sample with the
+// probability that keeps the reservoir a uniform sample of all calls.
+func (s *StatsCollector) record(latency time.Duration, err error) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests++
+	if err != nil {
+		s.errors++
+	}
+	if len(s.reservoir) < s.size {
+		s.reservoir = append(s.reservoir, latency)
+		return
+	}
+	if i := s.rand.Int64N(s.requests); i < int64(s.size) {
+		s.reservoir[i] = latency
+	}
+}
+
+// Stats returns the counts and latency percentiles recorded so far. A nil
+// collector returns zero Stats.
+func (s *StatsCollector) Stats() Stats {
+	if s == nil {
+		return Stats{}
+	}
+	s.mu.Lock()
+	stats := Stats{Requests: s.requests, Errors: s.errors}
+	sample := slices.Clone(s.reservoir)
+	s.mu.Unlock()
+
+	slices.Sort(sample)
+	stats.P50 = percentile(sample, 50)
+	stats.P95 = percentile(sample, 95)
+	stats.P99 = percentile(sample, 99)
+	return stats
+}
+
+// percentile returns the nearest-rank p-th percentile of sorted, or zero if
+// it is empty
+func percentile(sorted []time.Duration, p int) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	// The smallest rank covering p percent of the samples, counted from 1
+	rank := (p*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
@@ -0,0 +1,106 @@
+package clientutil
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatsCollector_KnownLatencies(t *testing.T) {
+	s := NewStatsCollector(1000)
+
+	// 1ms to 100ms, recorded in a shuffled order
+	for _, i := range rand.Perm(100) {
+		var err error
+		if i%10 == 0 {
+			err = errors.New("failed")
+		}
+		s.record(time.Duration(i+1)*time.Millisecond, err)
+	}
+
+	stats := s.Stats()
+	assert.Equal(t, int64(100), stats.Requests)
+	assert.Equal(t, int64(10), stats.Errors)
+	assert.Equal(t, 50*time.Millisecond, stats.P50)
+	assert.Equal(t, 95*time.Millisecond, stats.P95)
+	assert.Equal(t, 99*time.Millisecond, stats.P99)
+}
+
+func TestStatsCollector_ReservoirEstimatesPercentiles(t *testing.T) {
+	s := NewStatsCollector(DefaultStatsReservoirSize)
+
+	// 100,000 latencies spread evenly over 1ms to 10s, so the reservoir
+	// holds only a sample of them
+	const n = 100000
+	for _, i := range rand.Perm(n) {
+		s.record(time.Duration(i+1)*100*time.Microsecond, nil)
+	}
+
+	stats := s.Stats()
+	assert.Equal(t, int64(n), stats.Requests)
+	assert.Len(t, s.reservoir, DefaultStatsReservoirSize)
+	assert.InDelta(t, float64(5*time.Second), float64(stats.P50), float64(800*time.Millisecond))
+	assert.InDelta(t, float64(9500*time.Millisecond), float64(stats.P95), float64(300*time.Millisecond))
+	assert.InDelta(t, float64(9900*time.Millisecond), float64(stats.P99), float64(200*time.Millisecond))
+}
+
+func TestStatsCollector_Empty(t *testing.T) {
+	assert.Equal(t, Stats{}, NewStatsCollector(0).Stats())
+
+	var s *StatsCollector
+	s.record(time.Second, nil)
+	assert.Equal(t, Stats{}, s.Stats())
+}
+
+func TestStatsCollector_ConcurrentUse(t *testing.T) {
+	s := NewStatsCollector(16)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				s.record(time.Millisecond, nil)
+				_ = s.Stats()
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int64(800), s.Stats().Requests)
+}
+
+func TestExecuteRequest_Stats(t *testing.T) {
+	clock := NewFakeClock(clockStart)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		clock.Advance(20 * time.Millisecond)
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	s := NewStatsCollector(0)
+	for _, path := range []string{"/ok", "/missing", "/ok"} {
+		req, err := http.NewRequest("GET", server.URL+path, nil)
+		require.NoError(t, err)
+		_, _ = ExecuteRequest(context.Background(), server.Client(), req, nil, WithStats(s), WithClock(clock))
+	}
+
+	stats := s.Stats()
+	assert.Equal(t, int64(3), stats.Requests)
+	assert.Equal(t, int64(1), stats.Errors)
+	assert.Equal(t, 20*time.Millisecond, stats.P50)
+	assert.Equal(t, 20*time.Millisecond, stats.P99)
+}
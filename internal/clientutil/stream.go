@@ -0,0 +1,76 @@
+package clientutil
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/atriumn/atriumn-sdk-go/internal/apierror"
+)
+
+// OpenStream sends an API request whose successful response body is returned
+// unread, for responses too large to buffer. Error responses are read and
+// turned into apierror.ErrorResponse values exactly as ExecuteRequest does.
+// A gzip or deflate Content-Encoding is decoded as the stream is read.
+//
+// The caller must close the returned body. The request is bounded by its
+// context only; WithTimeout does not apply, since the body is read after
+// OpenStream returns. The latency reported to a WithMetrics recorder covers
+// the call up to the response headers, not reading the stream.
+func OpenStream(ctx context.Context, httpClient *http.Client, req *http.Request, opts ...RequestOption) (io.ReadCloser, error) {
+	options := newRequestOptions(opts)
+	start := options.clock.Now()
+	body, err := openStream(httpClient, req, options)
+	if apiErr, ok := err.(*apierror.ErrorResponse); ok {
+		apiErr.RequestID = req.Header.Get(RequestIDHeader)
+	}
+	status := 0
+	if sb, ok := body.(*streamBody); ok {
+		status = sb.status
+	}
+	latency := options.clock.Now().Sub(start)
+	options.metrics.record(latency, status, err)
+	options.stats.record(latency, err)
+	return body, err
+}
+
+// openStream implements OpenStream, apart from recording the request ID on errors
+func openStream(httpClient *http.Client, req *http.Request, options *requestOptions) (io.ReadCloser, error) {
+	resp, err := sendRequest(httpClient, req)
+	if err != nil {
+		return nil, err
+	}
+	options.deprecation.check(req, resp)
+
+	body, err := decodeBody(resp)
+	if err != nil {
+		_ = resp.Body.Close()
+		return nil, &apierror.ErrorResponse{
+			ErrorCode:   "read_error",
+			Description: fmt.Sprintf("Failed to decode %s response body: %v", resp.Header.Get("Content-Encoding"), err),
+		}
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		defer func() { _ = resp.Body.Close() }()
+		// Error bodies are small; a truncated one still yields a usable error
+		bodyBytes, _ := io.ReadAll(io.LimitReader(body, options.maxBytes))
+		return nil, statusError(resp, bodyBytes, options)
+	}
+
+	return &streamBody{Reader: body, closer: resp.Body, status: resp.StatusCode}, nil
+}
+
+// streamBody reads a possibly decoded response body and closes the
+// underlying connection body
+type streamBody struct {
+	io.Reader
+	closer io.Closer
+	status int
+}
+
+// Close closes the underlying response body.
+func (b *streamBody) Close() error {
+	return b.closer.Close()
+}
@@ -0,0 +1,50 @@
+package clientutil
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/atriumn/atriumn-sdk-go/internal/apierror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenStream_Success(t *testing.T) {
+	server := compressedServer(t, http.StatusOK, "gzip", "streamed body")
+	defer server.Close()
+
+	req, err := http.NewRequest("GET", server.URL, nil)
+	require.NoError(t, err)
+
+	body, err := OpenStream(context.Background(), noDecompressClient(), req)
+	require.NoError(t, err)
+	defer func() { _ = body.Close() }()
+
+	data, err := io.ReadAll(body)
+	require.NoError(t, err)
+	assert.Equal(t, "streamed body", string(data))
+}
+
+func TestOpenStream_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error":"not_found","error_description":"no such item"}`))
+	}))
+	defer server.Close()
+
+	req, err := http.NewRequest("GET", server.URL, nil)
+	require.NoError(t, err)
+	req.Header.Set(RequestIDHeader, "req-1")
+
+	body, err := OpenStream(context.Background(), http.DefaultClient, req)
+	assert.Nil(t, body)
+	apiErr, ok := err.(*apierror.ErrorResponse)
+	require.True(t, ok, "expected *apierror.ErrorResponse, got %T", err)
+	assert.Equal(t, "not_found", apiErr.ErrorCode)
+	assert.Equal(t, "no such item", apiErr.Description)
+	assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+	assert.Equal(t, "req-1", apiErr.RequestID)
+}
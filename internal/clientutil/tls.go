@@ -0,0 +1,57 @@
+package clientutil
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+)
+
+// ErrInsecureTLSConfig is returned when a TLS configuration disables
+// certificate verification without the client opting in to it explicitly
+var ErrInsecureTLSConfig = errors.New("tls.Config has InsecureSkipVerify set; use WithInsecureSkipVerify to disable certificate verification")
+
+// TLSOptions collects the TLS settings of a client's default transport, as set
+// by the WithTLSConfig, WithRootCAs, and WithInsecureSkipVerify options
+type TLSOptions struct {
+	// Config is the base TLS configuration; nil starts from the defaults
+	Config *tls.Config
+
+	// RootCAs, if set, replaces the root certificates of Config
+	RootCAs *x509.CertPool
+
+	// InsecureSkipVerify disables certificate verification
+	InsecureSkipVerify bool
+}
+
+// IsSet reports whether any TLS setting was configured
+func (o *TLSOptions) IsSet() bool {
+	return o.Config != nil || o.RootCAs != nil || o.InsecureSkipVerify
+}
+
+// Validate returns ErrInsecureTLSConfig if Config disables certificate
+// verification but InsecureSkipVerify was not set
+func (o *TLSOptions) Validate() error {
+	if o.Config != nil && o.Config.InsecureSkipVerify && !o.InsecureSkipVerify {
+		return ErrInsecureTLSConfig
+	}
+	return nil
+}
+
+// ClientConfig returns the TLS configuration the options describe, or nil if
+// none were set. Config is cloned, so later changes to it have no effect.
+func (o *TLSOptions) ClientConfig() *tls.Config {
+	if !o.IsSet() {
+		return nil
+	}
+	config := &tls.Config{MinVersion: tls.VersionTLS12}
+	if o.Config != nil {
+		config = o.Config.Clone()
+	}
+	if o.RootCAs != nil {
+		config.RootCAs = o.RootCAs
+	}
+	if o.InsecureSkipVerify {
+		config.InsecureSkipVerify = true
+	}
+	return config
+}
@@ -0,0 +1,67 @@
+package clientutil
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTLSOptions_Unset(t *testing.T) {
+	var options TLSOptions
+
+	assert.False(t, options.IsSet())
+	assert.Nil(t, options.ClientConfig())
+	assert.NoError(t, options.Validate())
+}
+
+func TestTLSOptions_ClientConfig(t *testing.T) {
+	base := &tls.Config{MinVersion: tls.VersionTLS13, ServerName: "atriumn.internal"}
+	pool := x509.NewCertPool()
+	options := TLSOptions{Config: base, RootCAs: pool}
+
+	config := options.ClientConfig()
+	require.NotNil(t, config)
+	assert.NotSame(t, base, config, "the config must be cloned")
+	assert.Equal(t, uint16(tls.VersionTLS13), config.MinVersion)
+	assert.Equal(t, "atriumn.internal", config.ServerName)
+	assert.Same(t, pool, config.RootCAs)
+	assert.False(t, config.InsecureSkipVerify)
+	assert.Nil(t, base.RootCAs, "the caller's config must not be modified")
+}
+
+func TestTLSOptions_RootCAsOnly(t *testing.T) {
+	pool := x509.NewCertPool()
+	options := TLSOptions{RootCAs: pool}
+
+	config := options.ClientConfig()
+	require.NotNil(t, config)
+	assert.Same(t, pool, config.RootCAs)
+	assert.Equal(t, uint16(tls.VersionTLS12), config.MinVersion)
+}
+
+func TestTLSOptions_InsecureSkipVerify(t *testing.T) {
+	insecureConfig := &tls.Config{InsecureSkipVerify: true}
+
+	options := TLSOptions{Config: insecureConfig}
+	assert.ErrorIs(t, options.Validate(), ErrInsecureTLSConfig)
+
+	options.InsecureSkipVerify = true
+	assert.NoError(t, options.Validate())
+
+	options = TLSOptions{InsecureSkipVerify: true}
+	assert.NoError(t, options.Validate())
+	assert.True(t, options.ClientConfig().InsecureSkipVerify)
+}
+
+func TestNewTransport_TLSConfig(t *testing.T) {
+	config := &tls.Config{ServerName: "atriumn.internal"}
+
+	transport := NewTransport(nil, config)
+	assert.Same(t, config, transport.TLSClientConfig)
+
+	// Without a pool, the default connection limits are kept
+	assert.Equal(t, 100, transport.MaxIdleConns)
+}
@@ -0,0 +1,62 @@
+package clientutil
+
+import (
+	"crypto/tls"
+	"net/http"
+	"slices"
+	"time"
+)
+
+// ConnectionPool holds the connection limits of a transport built by
+// NewPooledTransport. Zero values mean no limit, except MaxIdleConnsPerHost,
+// where zero keeps http.DefaultMaxIdleConnsPerHost.
+type ConnectionPool struct {
+	// MaxIdleConns limits idle keep-alive connections across all hosts
+	MaxIdleConns int
+
+	// MaxIdleConnsPerHost limits idle keep-alive connections to each host
+	MaxIdleConnsPerHost int
+
+	// MaxConnsPerHost limits the connections to each host, including those in use
+	MaxConnsPerHost int
+
+	// IdleConnTimeout is how long an idle connection is kept before closing
+	IdleConnTimeout time.Duration
+}
+
+// NewPooledTransport returns a copy of http.DefaultTransport, keeping its
+// proxy, dialer, and TLS settings, with the connection limits of pool.
+func NewPooledTransport(pool ConnectionPool) *http.Transport {
+	return NewTransport(&pool, nil)
+}
+
+// NewTransport returns a copy of http.DefaultTransport with the connection
+// limits of pool, if set, and the TLS configuration tlsConfig, if set.
+func NewTransport(pool *ConnectionPool, tlsConfig *tls.Config) *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if pool != nil {
+		transport.MaxIdleConns = pool.MaxIdleConns
+		transport.MaxIdleConnsPerHost = pool.MaxIdleConnsPerHost
+		transport.MaxConnsPerHost = pool.MaxConnsPerHost
+		transport.IdleConnTimeout = pool.IdleConnTimeout
+	}
+	if tlsConfig != nil {
+		transport.TLSClientConfig = tlsConfig
+	}
+	return transport
+}
+
+// ForceHTTP1 makes transport use only HTTP/1.1, for networks whose proxies
+// mishandle HTTP/2. It turns off ForceAttemptHTTP2, sets an empty TLSNextProto
+// so HTTP/2 is never set up, and stops offering "h2" during the TLS handshake.
+func ForceHTTP1(transport *http.Transport) {
+	transport.ForceAttemptHTTP2 = false
+	transport.TLSNextProto = make(map[string]func(string, *tls.Conn) http.RoundTripper)
+	if transport.TLSClientConfig != nil {
+		config := transport.TLSClientConfig.Clone()
+		config.NextProtos = slices.DeleteFunc(slices.Clone(config.NextProtos), func(proto string) bool {
+			return proto == "h2"
+		})
+		transport.TLSClientConfig = config
+	}
+}
@@ -0,0 +1,43 @@
+package clientutil
+
+import (
+	"crypto/tls"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPooledTransport(t *testing.T) {
+	transport := NewPooledTransport(ConnectionPool{
+		MaxIdleConns:        64,
+		MaxIdleConnsPerHost: 16,
+		MaxConnsPerHost:     32,
+		IdleConnTimeout:     45 * time.Second,
+	})
+
+	assert.Equal(t, 64, transport.MaxIdleConns)
+	assert.Equal(t, 16, transport.MaxIdleConnsPerHost)
+	assert.Equal(t, 32, transport.MaxConnsPerHost)
+	assert.Equal(t, 45*time.Second, transport.IdleConnTimeout)
+
+	// The defaults other than the pool limits are kept
+	assert.NotNil(t, transport.Proxy)
+	assert.NotNil(t, transport.DialContext)
+	assert.NotSame(t, http.DefaultTransport, transport)
+}
+
+func TestForceHTTP1(t *testing.T) {
+	transport := NewTransport(nil, &tls.Config{NextProtos: []string{"h2", "http/1.1"}})
+	original := transport.TLSClientConfig
+
+	ForceHTTP1(transport)
+	assert.False(t, transport.ForceAttemptHTTP2)
+	assert.NotNil(t, transport.TLSNextProto)
+	assert.Empty(t, transport.TLSNextProto)
+	assert.Equal(t, []string{"http/1.1"}, transport.TLSClientConfig.NextProtos)
+
+	// The caller's TLS config is not modified
+	assert.Equal(t, []string{"h2", "http/1.1"}, original.NextProtos)
+}
@@ -0,0 +1,48 @@
+package clientutil
+
+import (
+	"runtime/debug"
+	"strings"
+)
+
+// modulePath is the import path of the atriumn-sdk-go module
+const modulePath = "github.com/atriumn/atriumn-sdk-go"
+
+// develVersion is reported when the build does not record a module version,
+// as in the module's own tests or a build from a local checkout
+const develVersion = "devel"
+
+// Version is the version of the atriumn-sdk-go module the program was built
+// with, such as "0.3.1", read from the build information Go embeds in the
+// binary, or "devel" if no release version was recorded. It is included in
+// the default User-Agent of every client so the server can tell SDK releases apart.
+var Version = moduleVersion(debug.ReadBuildInfo())
+
+// VersionToken is the User-Agent product token identifying the SDK release
+var VersionToken = "atriumn-sdk-go/" + Version
+
+// moduleVersion returns the version of this module recorded in info, without
+// its leading "v", or develVersion if there is none
+func moduleVersion(info *debug.BuildInfo, ok bool) string {
+	if !ok || info == nil {
+		return develVersion
+	}
+
+	module := &info.Main
+	if module.Path != modulePath {
+		module = nil
+		for _, dep := range info.Deps {
+			if dep.Path == modulePath {
+				module = dep
+				break
+			}
+		}
+	}
+	if module != nil && module.Replace != nil {
+		module = module.Replace
+	}
+	if module == nil || module.Version == "" || module.Version == "(devel)" {
+		return develVersion
+	}
+	return strings.TrimPrefix(module.Version, "v")
+}
@@ -0,0 +1,41 @@
+package clientutil
+
+import (
+	"runtime/debug"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestModuleVersion(t *testing.T) {
+	dependency := func(version string, replace *debug.Module) *debug.BuildInfo {
+		return &debug.BuildInfo{
+			Main: debug.Module{Path: "example.com/app", Version: "(devel)"},
+			Deps: []*debug.Module{
+				{Path: "github.com/stretchr/testify", Version: "v1.9.0"},
+				{Path: modulePath, Version: version, Replace: replace},
+			},
+		}
+	}
+
+	tests := []struct {
+		name string
+		info *debug.BuildInfo
+		ok   bool
+		want string
+	}{
+		{"no build info", nil, false, "devel"},
+		{"dependency", dependency("v0.3.1", nil), true, "0.3.1"},
+		{"pseudo-version", dependency("v0.3.2-0.20260101000000-abcdef123456", nil), true, "0.3.2-0.20260101000000-abcdef123456"},
+		{"replaced by a release", dependency("v0.3.1", &debug.Module{Path: "example.com/fork", Version: "v0.4.0"}), true, "0.4.0"},
+		{"replaced by a local directory", dependency("v0.3.1", &debug.Module{Path: "../atriumn-sdk-go"}), true, "devel"},
+		{"main module checkout", &debug.BuildInfo{Main: debug.Module{Path: modulePath, Version: "(devel)"}}, true, "devel"},
+		{"main module release", &debug.BuildInfo{Main: debug.Module{Path: modulePath, Version: "v0.3.1"}}, true, "0.3.1"},
+		{"not a dependency", &debug.BuildInfo{Main: debug.Module{Path: "example.com/app", Version: "v1.0.0"}}, true, "devel"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, moduleVersion(tt.info, tt.ok))
+		})
+	}
+}
@@ -0,0 +1,9 @@
+// Package health provides the health check model shared by the Atriumn API clients.
+package health
+
+// Response represents the response from a service's health endpoint.
+// It indicates the current operational status of the service.
+type Response struct {
+	// Status indicates the health of the service (e.g., "ok", "error")
+	Status string `json:"status"`
+}
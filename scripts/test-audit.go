@@ -4,7 +4,10 @@ package main
 
 import (
 	"bufio"
+	"encoding/json"
+	"flag"
 	"fmt"
+	"io"
 	"io/fs"
 	"os"
 	"path/filepath"
@@ -14,26 +17,42 @@ import (
 
 // TestIssue represents a potential test quality issue
 type TestIssue struct {
-	File        string
-	Line        int
-	Type        string
-	Description string
-	Severity    string
-	Code        string
+	File        string `json:"file"`
+	Line        int    `json:"line"`
+	Type        string `json:"type"`
+	Description string `json:"description"`
+	Severity    string `json:"severity"`
+	Code        string `json:"code"`
+	// Subtype refines Type where it has variants, such as "unconditional"
+	// and "short_mode" for skipped tests
+	Subtype string `json:"subtype,omitempty"`
 }
 
 // AuditResult contains the results of a test audit
 type AuditResult struct {
-	TotalFiles    int
-	TotalLines    int
-	Issues        []TestIssue
-	CleanFiles    []string
-	TestFunctions int
+	TotalFiles    int         `json:"totalFiles"`
+	TotalLines    int         `json:"totalLines"`
+	Issues        []TestIssue `json:"issues"`
+	CleanFiles    []string    `json:"cleanFiles"`
+	TestFunctions int         `json:"testFunctions"`
 }
 
 // TestAuditor performs comprehensive test suite audits
 type TestAuditor struct {
 	patterns map[string]*regexp.Regexp
+
+	// IgnorePatterns are filepath.Match globs, relative to the audited
+	// directory, for files and directories to leave out of the audit
+	IgnorePatterns []string
+}
+
+// severityRanks orders the issue severities from least to most severe. Info
+// issues are reported but do not fail the audit unless -fail-on=info is set.
+var severityRanks = map[string]int{
+	"info":   0,
+	"low":    1,
+	"medium": 2,
+	"high":   3,
 }
 
 // NewTestAuditor creates a new test auditor with predefined patterns
@@ -42,6 +61,7 @@ func NewTestAuditor() *TestAuditor {
 		// Skipped tests
 		"skip_call":        regexp.MustCompile(`\.Skip\s*\(`),
 		"skip_annotation":  regexp.MustCompile(`@skip|@Skip|@SKIP`),
+		"short_guard":      regexp.MustCompile(`\bif\s+testing\.Short\(\)`),
 		
 		// Commented tests
 		"commented_func":   regexp.MustCompile(`^[\s]*//.*func\s+Test\w+`),
@@ -78,6 +98,13 @@ func (ta *TestAuditor) AuditDirectory(dir string) (*AuditResult, error) {
 			return err
 		}
 		
+		if ta.ignored(dir, path) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		
 		if !strings.HasSuffix(path, "_test.go") {
 			return nil
 		}
@@ -103,6 +130,39 @@ func (ta *TestAuditor) AuditDirectory(dir string) (*AuditResult, error) {
 	return result, err
 }
 
+// ignored reports whether path, found while walking root, matches one of the
+// ignore patterns. A pattern matches the path relative to root or its base name.
+func (ta *TestAuditor) ignored(root, path string) bool {
+	rel, err := filepath.Rel(root, path)
+	if err != nil || rel == "." {
+		return false
+	}
+	for _, pattern := range ta.IgnorePatterns {
+		if ok, _ := filepath.Match(pattern, rel); ok {
+			return true
+		}
+		if ok, _ := filepath.Match(pattern, filepath.Base(path)); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// HasFailures reports whether result has an issue at or above the failOn
+// severity. An empty failOn counts every issue of low severity or above.
+func HasFailures(result *AuditResult, failOn string) bool {
+	if failOn == "" {
+		failOn = "low"
+	}
+	threshold := severityRanks[failOn]
+	for _, issue := range result.Issues {
+		if severityRanks[issue.Severity] >= threshold {
+			return true
+		}
+	}
+	return false
+}
+
 // fileAuditResult represents the audit result for a single file
 type fileAuditResult struct {
 	Issues        []TestIssue
@@ -124,6 +184,7 @@ func (ta *TestAuditor) auditFile(filename string) (*fileAuditResult, error) {
 	
 	scanner := bufio.NewScanner(file)
 	lineNum := 0
+	inShortGuard := false
 	
 	for scanner.Scan() {
 		lineNum++
@@ -135,16 +196,40 @@ func (ta *TestAuditor) auditFile(filename string) (*fileAuditResult, error) {
 			result.TestFunctions++
 		}
 		
+		// Skips inside an "if testing.Short()" block are the standard way to
+		// leave slow tests out of "go test -short", so they are only noted
+		if ta.patterns["short_guard"].MatchString(line) {
+			inShortGuard = true
+		}
+		
 		// Check for skipped tests
 		if ta.patterns["skip_call"].MatchString(line) {
-			result.Issues = append(result.Issues, TestIssue{
-				File:        filename,
-				Line:        lineNum,
-				Type:        "skipped_test",
-				Description: "Test contains t.Skip() call",
-				Severity:    "medium",
-				Code:        strings.TrimSpace(line),
-			})
+			if inShortGuard {
+				result.Issues = append(result.Issues, TestIssue{
+					File:        filename,
+					Line:        lineNum,
+					Type:        "skipped_test",
+					Subtype:     "short_mode",
+					Description: "Test is skipped in short mode",
+					Severity:    "info",
+					Code:        strings.TrimSpace(line),
+				})
+			} else {
+				result.Issues = append(result.Issues, TestIssue{
+					File:        filename,
+					Line:        lineNum,
+					Type:        "skipped_test",
+					Subtype:     "unconditional",
+					Description: "Test contains t.Skip() call",
+					Severity:    "medium",
+					Code:        strings.TrimSpace(line),
+				})
+			}
+		}
+		
+		// The guard ends with its block
+		if inShortGuard && strings.Contains(line, "}") {
+			inShortGuard = false
 		}
 		
 		if ta.patterns["skip_annotation"].MatchString(line) {
@@ -254,22 +339,27 @@ func (ta *TestAuditor) auditFile(filename string) (*fileAuditResult, error) {
 	return result, scanner.Err()
 }
 
-// PrintReport generates a human-readable audit report
+// PrintReport writes a human-readable audit report to stdout
 func (ta *TestAuditor) PrintReport(result *AuditResult) {
-	fmt.Println("=== TEST AUDIT REPORT ===")
-	fmt.Printf("Total test files examined: %d\n", result.TotalFiles)
-	fmt.Printf("Total lines of test code: %d\n", result.TotalLines)
-	fmt.Printf("Total test functions: %d\n", result.TestFunctions)
-	fmt.Printf("Issues found: %d\n", len(result.Issues))
-	fmt.Printf("Clean files: %d\n", len(result.CleanFiles))
-	fmt.Println()
+	ta.WriteReport(os.Stdout, result)
+}
+
+// WriteReport writes a human-readable audit report to w
+func (ta *TestAuditor) WriteReport(w io.Writer, result *AuditResult) {
+	fmt.Fprintln(w, "=== TEST AUDIT REPORT ===")
+	fmt.Fprintf(w, "Total test files examined: %d\n", result.TotalFiles)
+	fmt.Fprintf(w, "Total lines of test code: %d\n", result.TotalLines)
+	fmt.Fprintf(w, "Total test functions: %d\n", result.TestFunctions)
+	fmt.Fprintf(w, "Issues found: %d\n", len(result.Issues))
+	fmt.Fprintf(w, "Clean files: %d\n", len(result.CleanFiles))
+	fmt.Fprintln(w)
 	
 	if len(result.Issues) == 0 {
-		fmt.Println("✅ NO ISSUES FOUND - Test suite is clean!")
-		fmt.Println()
-		fmt.Println("Clean files:")
+		fmt.Fprintln(w, "✅ NO ISSUES FOUND - Test suite is clean!")
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "Clean files:")
 		for _, file := range result.CleanFiles {
-			fmt.Printf("  ✅ %s\n", file)
+			fmt.Fprintf(w, "  ✅ %s\n", file)
 		}
 		return
 	}
@@ -281,51 +371,110 @@ func (ta *TestAuditor) PrintReport(result *AuditResult) {
 	}
 	
 	// Print issues by severity
-	severities := []string{"high", "medium", "low"}
+	severities := []string{"high", "medium", "low", "info"}
 	for _, severity := range severities {
 		issues := severityGroups[severity]
 		if len(issues) == 0 {
 			continue
 		}
 		
-		fmt.Printf("🚨 %s SEVERITY ISSUES (%d):\n", strings.ToUpper(severity), len(issues))
+		fmt.Fprintf(w, "🚨 %s SEVERITY ISSUES (%d):\n", strings.ToUpper(severity), len(issues))
 		for _, issue := range issues {
-			fmt.Printf("  %s:%d - %s\n", issue.File, issue.Line, issue.Description)
-			fmt.Printf("    Type: %s\n", issue.Type)
-			fmt.Printf("    Code: %s\n", issue.Code)
-			fmt.Println()
+			fmt.Fprintf(w, "  %s:%d - %s\n", issue.File, issue.Line, issue.Description)
+			fmt.Fprintf(w, "    Type: %s\n", issue.Type)
+			fmt.Fprintf(w, "    Code: %s\n", issue.Code)
+			fmt.Fprintln(w)
 		}
 	}
 	
 	// Print clean files
 	if len(result.CleanFiles) > 0 {
-		fmt.Println("✅ CLEAN FILES:")
+		fmt.Fprintln(w, "✅ CLEAN FILES:")
 		for _, file := range result.CleanFiles {
-			fmt.Printf("  %s\n", file)
+			fmt.Fprintf(w, "  %s\n", file)
 		}
 	}
 }
 
+// WriteJSON writes the audit result to w as indented JSON
+func (ta *TestAuditor) WriteJSON(w io.Writer, result *AuditResult) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(result)
+}
+
 func main() {
-	if len(os.Args) < 2 {
-		fmt.Println("Usage: go run test-audit.go <directory>")
-		fmt.Println("Example: go run test-audit.go .")
-		os.Exit(1)
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run executes the audit with the given command-line arguments and returns
+// the process exit code
+func run(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("test-audit", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	format := flags.String("format", "text", "report format: text or json")
+	output := flags.String("output", "", "write the report to this file instead of stdout")
+	failOn := flags.String("fail-on", "", "exit non-zero only for issues at or above this severity: info, low, medium, or high (default: low)")
+	ignore := flags.String("ignore", "", "comma-separated globs of paths to skip, relative to the directory")
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: go run test-audit.go [flags] <directory>")
+		fmt.Fprintln(stderr, "Example: go run test-audit.go -format=json -output=audit.json .")
+		flags.PrintDefaults()
 	}
-	
-	directory := os.Args[1]
-	
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if flags.NArg() < 1 {
+		flags.Usage()
+		return 1
+	}
+	if *format != "text" && *format != "json" {
+		fmt.Fprintf(stderr, "Unknown format %q: use text or json\n", *format)
+		return 2
+	}
+
+	if _, ok := severityRanks[*failOn]; *failOn != "" && !ok {
+		fmt.Fprintf(stderr, "Unknown severity %q: use info, low, medium, or high\n", *failOn)
+		return 2
+	}
+
+	directory := flags.Arg(0)
+
 	auditor := NewTestAuditor()
+	for _, pattern := range strings.Split(*ignore, ",") {
+		if pattern = strings.TrimSpace(pattern); pattern != "" {
+			auditor.IgnorePatterns = append(auditor.IgnorePatterns, pattern)
+		}
+	}
 	result, err := auditor.AuditDirectory(directory)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error performing audit: %v\n", err)
-		os.Exit(1)
+		fmt.Fprintf(stderr, "Error performing audit: %v\n", err)
+		return 1
 	}
-	
-	auditor.PrintReport(result)
-	
-	// Exit with non-zero code if issues found
-	if len(result.Issues) > 0 {
-		os.Exit(1)
+
+	w := stdout
+	if *output != "" {
+		file, err := os.Create(*output)
+		if err != nil {
+			fmt.Fprintf(stderr, "Error creating output file: %v\n", err)
+			return 1
+		}
+		defer file.Close()
+		w = file
+	}
+
+	if *format == "json" {
+		if err := auditor.WriteJSON(w, result); err != nil {
+			fmt.Fprintf(stderr, "Error writing report: %v\n", err)
+			return 1
+		}
+	} else {
+		auditor.WriteReport(w, result)
 	}
-}
\ No newline at end of file
+
+	// Exit with non-zero code if issues at the failing severity were found
+	if HasFailures(result, *failOn) {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,238 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// fixture expands placeholders in src so that this file does not itself
+// contain the patterns the auditor reports when it audits the repository
+var fixture = strings.NewReplacer(
+	"SKIP", "t."+"Skip(",
+	"NOTE", "// "+"TODO",
+	"EMPTY", "{"+"}",
+)
+
+// writeFiles creates the named files, with placeholders expanded, under dir
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, src := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(fixture.Replace(src)), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// auditFixture is a repository with one clean file and one medium and one
+// low severity issue
+func auditFixture(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"clean_test.go": "package x\n\nfunc TestClean(t *testing.T) {\n\tcheck(t)\n}\n",
+		"pkg/issues_test.go": "package x\n\n" +
+			"func TestSkipped(t *testing.T) {\n\tSKIP\"later\")\n}\n\n" +
+			"NOTE: finish this test\nfunc TestOther(t *testing.T) {\n\tcheck(t)\n}\n",
+		"helper.go": "package x\n\nfunc TestLooksLikeOne() EMPTY\n",
+	})
+	return dir
+}
+
+func TestRun_JSONFormat(t *testing.T) {
+	dir := auditFixture(t)
+
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"-format=json", dir}, &stdout, &stderr)
+	if code != 1 {
+		t.Errorf("exit code = %d, want 1 when issues are found (stderr: %s)", code, stderr.String())
+	}
+
+	var result AuditResult
+	if err := json.Unmarshal(stdout.Bytes(), &result); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, stdout.String())
+	}
+	if result.TotalFiles != 2 || result.TestFunctions != 3 || len(result.CleanFiles) != 1 {
+		t.Errorf("result = %+v, want 2 files, 3 test functions, 1 clean file", result)
+	}
+	if len(result.Issues) != 2 {
+		t.Fatalf("issues = %+v, want 2", result.Issues)
+	}
+
+	issue := result.Issues[0]
+	if issue.File != filepath.Join(dir, "pkg", "issues_test.go") || issue.Line != 4 ||
+		issue.Type != "skipped_test" || issue.Severity != "medium" || issue.Description == "" || issue.Code == "" {
+		t.Errorf("issue = %+v, want a medium skipped_test on line 4", issue)
+	}
+
+	// The JSON keys are stable for consumers
+	var raw map[string]interface{}
+	_ = json.Unmarshal(stdout.Bytes(), &raw)
+	for _, key := range []string{"totalFiles", "totalLines", "issues", "cleanFiles", "testFunctions"} {
+		if _, ok := raw[key]; !ok {
+			t.Errorf("JSON output lacks key %q", key)
+		}
+	}
+	first := raw["issues"].([]interface{})[0].(map[string]interface{})
+	for _, key := range []string{"file", "line", "type", "description", "severity", "code"} {
+		if _, ok := first[key]; !ok {
+			t.Errorf("JSON issue lacks key %q", key)
+		}
+	}
+}
+
+func TestRun_OutputFile(t *testing.T) {
+	dir := auditFixture(t)
+	output := filepath.Join(t.TempDir(), "audit.json")
+
+	var stdout, stderr bytes.Buffer
+	run([]string{"-format=json", "-output=" + output, dir}, &stdout, &stderr)
+
+	if stdout.Len() != 0 {
+		t.Errorf("stdout = %q, want nothing when -output is set", stdout.String())
+	}
+	data, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var result AuditResult
+	if err := json.Unmarshal(data, &result); err != nil || len(result.Issues) != 2 {
+		t.Errorf("output file = %s, want JSON with 2 issues (err: %v)", data, err)
+	}
+}
+
+func TestRun_TextFormat(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	clean := t.TempDir()
+	writeFiles(t, clean, map[string]string{"a_test.go": "package x\n"})
+	if code := run([]string{clean}, &stdout, &stderr); code != 0 {
+		t.Errorf("exit code = %d, want 0 for a clean directory", code)
+	}
+	if !strings.Contains(stdout.String(), "=== TEST AUDIT REPORT ===") {
+		t.Errorf("stdout = %q, want the text report", stdout.String())
+	}
+
+	if code := run([]string{"-format=xml", clean}, &stdout, &stderr); code != 2 {
+		t.Errorf("exit code = %d, want 2 for an unknown format", code)
+	}
+}
+
+func TestRun_FailOn(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"low_test.go": "package x\n\nNOTE: cover the edge case in this test\nfunc TestLow(t *testing.T) {\n\tcheck(t)\n}\n",
+	})
+
+	tests := []struct {
+		failOn string
+		want   int
+	}{
+		{"", 1},
+		{"low", 1},
+		{"medium", 0},
+		{"high", 0},
+		{"critical", 2},
+	}
+	for _, tt := range tests {
+		var stdout, stderr bytes.Buffer
+		args := []string{dir}
+		if tt.failOn != "" {
+			args = append([]string{"-fail-on=" + tt.failOn}, args...)
+		}
+		if code := run(args, &stdout, &stderr); code != tt.want {
+			t.Errorf("-fail-on=%q exit code = %d, want %d", tt.failOn, code, tt.want)
+		}
+	}
+}
+
+func TestRun_Ignore(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"ok_test.go":                 "package x\n",
+		"vendor/dep/dep_test.go":     "package dep\n\nfunc TestDep(t *testing.T) {\n\tSKIP\"vendored\")\n}\n",
+		"gen/models_gen_test.go":     "package gen\n\nfunc TestGen(t *testing.T) {\n\tSKIP\"generated\")\n}\n",
+		"gen/handwritten_test.go":    "package gen\n",
+		"other/zz_generated_test.go": "package other\n\nfunc TestZ(t *testing.T) {\n\tSKIP\"generated\")\n}\n",
+	})
+
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"-format=json", "-ignore=vendor, *_gen_test.go,other/zz_*", dir}, &stdout, &stderr)
+	if code != 0 {
+		t.Errorf("exit code = %d, want 0 with the offending files ignored (stderr: %s)", code, stderr.String())
+	}
+
+	var result AuditResult
+	if err := json.Unmarshal(stdout.Bytes(), &result); err != nil {
+		t.Fatal(err)
+	}
+	if result.TotalFiles != 2 || len(result.Issues) != 0 {
+		t.Errorf("result = %+v, want 2 files audited and no issues", result)
+	}
+
+	// Without -ignore the same tree fails
+	stdout.Reset()
+	if code := run([]string{dir}, &stdout, &stderr); code != 1 {
+		t.Errorf("exit code = %d, want 1 without -ignore", code)
+	}
+}
+
+func TestAuditFile_ShortModeSkips(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"skips_test.go": "package x\n\n" +
+			"func TestSlow(t *testing.T) {\n\tif testing.Short() {\n\t\tSKIP\"slow\")\n\t}\n\tcheck(t)\n}\n\n" +
+			"func TestInline(t *testing.T) {\n\tif testing.Short() { SKIP\"slow\") }\n}\n\n" +
+			"func TestBroken(t *testing.T) {\n\tSKIP\"broken\")\n}\n",
+	})
+
+	result, err := NewTestAuditor().AuditDirectory(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type classification struct {
+		line     int
+		subtype  string
+		severity string
+	}
+	var got []classification
+	for _, issue := range result.Issues {
+		if issue.Type != "skipped_test" {
+			t.Errorf("issue type = %q, want skipped_test", issue.Type)
+		}
+		got = append(got, classification{issue.Line, issue.Subtype, issue.Severity})
+	}
+	want := []classification{
+		{5, "short_mode", "info"},
+		{11, "short_mode", "info"},
+		{15, "unconditional", "medium"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("issues = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("issue %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+
+	// Short-mode skips alone do not fail the audit by default
+	onlyShort := &AuditResult{Issues: result.Issues[:2]}
+	if HasFailures(onlyShort, "") {
+		t.Error("HasFailures() = true for short-mode skips only, want false")
+	}
+	if !HasFailures(onlyShort, "info") {
+		t.Error("HasFailures(info) = false, want true")
+	}
+	if !HasFailures(result, "") {
+		t.Error("HasFailures() = false with an unconditional skip, want true")
+	}
+}
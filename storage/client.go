@@ -4,11 +4,9 @@
 package storage
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"fmt"
-	"io"
+	"crypto/tls"
+	"crypto/x509"
 	"net/http"
 	"net/url"
 	"time"
@@ -20,16 +18,24 @@ const (
 	// DefaultTimeout is the default timeout for API requests
 	DefaultTimeout = 10 * time.Second
 
-	// DefaultUserAgent is the user agent sent in requests
-	DefaultUserAgent = "atriumn-storage-client/1.0"
+	// DefaultUploadTimeout is the default limit on an upload to a pre-signed URL
+	DefaultUploadTimeout = 60 * time.Second
 )
 
+// DefaultUserAgent is the user agent sent in requests, naming the client and the SDK version
+var DefaultUserAgent = "atriumn-storage-client/1.0" + " " + clientutil.VersionToken
+
 // TokenProvider defines an interface for retrieving authentication tokens.
 // Implementations should retrieve and return valid bearer tokens for the Atriumn API.
 type TokenProvider interface {
 	GetToken(ctx context.Context) (string, error) // Returns the Bearer token string
 }
 
+// TokenInvalidator is optionally implemented by a TokenProvider that caches
+// tokens. With WithRefreshOn401, the client calls Invalidate when the service
+// rejects a token, so the next GetToken call returns a fresh one.
+type TokenInvalidator = clientutil.TokenInvalidator
+
 // Client is the main API client for Atriumn Storage Service.
 // It handles communication with the API endpoints for generating
 // pre-signed URLs for file uploads and downloads.
@@ -43,29 +49,39 @@ type Client struct {
 	// UserAgent is the user agent sent with each request
 	UserAgent string
 
-	// tokenProvider provides authentication tokens for API requests
-	tokenProvider TokenProvider
+	// base holds the token provider and the other shared request settings;
+	// baseClient combines it with the fields above to build and send requests
+	base clientutil.BaseClient
+
+	// uploadTimeout bounds uploads to pre-signed URLs whose context has no deadline
+	uploadTimeout time.Duration
+
+	// transferTransport, if set, carries requests to pre-signed URLs, so they
+	// share the TLS and protocol settings of the default HTTP client
+	transferTransport http.RoundTripper
 }
 
 // NewClient creates a new Atriumn Storage API client with the specified base URL.
-// It returns an error if the provided URL cannot be parsed.
+// It returns an error if the provided URL cannot be parsed or lacks a scheme or host.
 //
 // Parameters:
 //   - baseURL: The base URL for the Atriumn Storage API (required)
 //
 // Returns:
 //   - *Client: A configured Storage client instance
-//   - error: An error if the URL cannot be parsed
+//   - error: An error if the URL cannot be parsed or is not absolute
 func NewClient(baseURL string) (*Client, error) {
-	parsedURL, err := url.Parse(baseURL)
+	parsedURL, err := clientutil.ParseBaseURL(baseURL)
 	if err != nil {
-		return nil, fmt.Errorf("invalid base URL: %w", err)
+		return nil, err
 	}
 
 	return &Client{
-		BaseURL:    parsedURL,
-		HTTPClient: &http.Client{Timeout: DefaultTimeout},
-		UserAgent:  DefaultUserAgent,
+		BaseURL:       parsedURL,
+		HTTPClient:    &http.Client{Timeout: DefaultTimeout},
+		UserAgent:     DefaultUserAgent,
+		base:          clientutil.BaseClient{Service: "storage", Clock: clientutil.SystemClock},
+		uploadTimeout: DefaultUploadTimeout,
 	}, nil
 }
 
@@ -73,6 +89,13 @@ func NewClient(baseURL string) (*Client, error) {
 // It is used with NewClientWithOptions to customize the client behavior.
 type ClientOption func(*Client)
 
+// shared adapts an option defined once in clientutil for all service clients
+func shared(opt clientutil.Option) ClientOption {
+	return func(c *Client) {
+		opt(&c.base)
+	}
+}
+
 // WithHTTPClient sets the HTTP client for the API client.
 // This can be used to customize timeouts, transport settings, or to inject
 // middleware/interceptors for testing or monitoring.
@@ -102,17 +125,234 @@ func WithUserAgent(userAgent string) ClientOption {
 	}
 }
 
-// WithTokenProvider sets the token provider for the API client.
-// The token provider is used to obtain authentication tokens for API requests.
+// WithUserAgentSuffix appends " suffix" to the user agent, keeping the default
+// client and SDK version tokens so requests remain identifiable. It extends the
+// user agent configured so far, so apply it after WithUserAgent when using both.
 //
 // Parameters:
-//   - tp: The TokenProvider implementation to use for authentication
+//   - suffix: The product token to append, such as "my-app/2.3"
 //
 // Returns:
 //   - ClientOption: A functional option to configure the client
+func WithUserAgentSuffix(suffix string) ClientOption {
+	return func(c *Client) {
+		if suffix != "" {
+			c.UserAgent += " " + suffix
+		}
+	}
+}
+
+// WithTokenProvider sets the provider of the bearer tokens sent with each request.
 func WithTokenProvider(tp TokenProvider) ClientOption {
+	return shared(clientutil.SetTokenProvider(tp))
+}
+
+// WithRefreshOn401 retries a request once with a fresh token when the service
+// rejects its token as unauthorized, calling Invalidate first if the
+// TokenProvider implements TokenInvalidator. A second 401 is returned as is.
+func WithRefreshOn401() ClientOption {
+	return shared(clientutil.SetRefreshOn401())
+}
+
+// WithMaxResponseBytes limits how many bytes of an API response body the
+// client reads. Larger responses fail with a "response_too_large" error. The
+// default is 10 MiB; a value of zero or less restores it.
+func WithMaxResponseBytes(n int64) ClientOption {
+	return shared(clientutil.SetMaxResponseBytes(n))
+}
+
+// WithRateLimit throttles the client to rps requests per second on average,
+// allowing bursts of up to burst requests; calls wait for their turn or their
+// context. A rate of zero or less removes the limit.
+func WithRateLimit(rps float64, burst int) ClientOption {
+	return shared(clientutil.SetRateLimit(rps, burst))
+}
+
+// ConcurrencyOption configures WithMaxConcurrentRequests.
+type ConcurrencyOption = clientutil.ConcurrencyOption
+
+// FailFast makes calls made while WithMaxConcurrentRequests has no free slot
+// return ErrTooManyConcurrentRequests at once, instead of waiting for a slot.
+//
+// Returns:
+//   - ConcurrencyOption: An option for WithMaxConcurrentRequests
+func FailFast() ConcurrencyOption {
+	return clientutil.FailFast()
+}
+
+// WithMaxConcurrentRequests allows at most n API calls of the client to be in
+// flight at once; further calls wait for a slot or their context, unless
+// FailFast is given. An n of zero or less removes the limit.
+func WithMaxConcurrentRequests(n int, opts ...ConcurrencyOption) ClientOption {
+	return shared(clientutil.SetMaxConcurrentRequests(n, opts...))
+}
+
+// WithResponseCache caches up to size GET responses that carry an ETag and
+// revalidates them with If-None-Match. A size of zero or less disables the cache.
+func WithResponseCache(size int) ClientOption {
+	return shared(clientutil.SetResponseCache(size))
+}
+
+// WithConnectionPool tunes the connection pool of the client's default HTTP
+// client. Like the TLS options and WithForceHTTP1, it never changes a client
+// passed to WithHTTPClient; zero maxIdle, maxConnsPerHost, or idleTimeout
+// means no limit.
+func WithConnectionPool(maxIdle, maxIdlePerHost, maxConnsPerHost int, idleTimeout time.Duration) ClientOption {
+	return shared(clientutil.SetConnectionPool(maxIdle, maxIdlePerHost, maxConnsPerHost, idleTimeout))
+}
+
+// WithTLSConfig sets the TLS configuration of the client's default HTTP
+// client, for example to present a client certificate. A config with
+// InsecureSkipVerify set is rejected unless WithInsecureSkipVerify is also given.
+func WithTLSConfig(config *tls.Config) ClientOption {
+	return shared(clientutil.SetTLSConfig(config))
+}
+
+// WithRootCAs makes the client's default HTTP client trust the certificates
+// in pool instead of the system roots, overriding the RootCAs of WithTLSConfig.
+func WithRootCAs(pool *x509.CertPool) ClientOption {
+	return shared(clientutil.SetRootCAs(pool))
+}
+
+// WithInsecureSkipVerify disables TLS certificate verification on the client's
+// default HTTP client. It is only fit for local testing; use WithRootCAs to
+// trust a private certificate authority instead.
+func WithInsecureSkipVerify() ClientOption {
+	return shared(clientutil.SetInsecureSkipVerify())
+}
+
+// WithForceHTTP1 makes the client's default HTTP client use only HTTP/1.1,
+// for proxies or middleboxes where HTTP/2 negotiation fails.
+func WithForceHTTP1() ClientOption {
+	return shared(clientutil.SetForceHTTP1())
+}
+
+// MetricsRecorder receives the outcome and latency of every API call, for
+// export to a metrics system such as Prometheus. Implementations must be safe
+// for concurrent use.
+type MetricsRecorder = clientutil.MetricsRecorder
+
+// WithMetricsRecorder reports every API call made by the client to recorder,
+// labeled with the service "storage" and an operation such as "storage.GenerateUploadURL".
+func WithMetricsRecorder(recorder MetricsRecorder) ClientOption {
+	return shared(clientutil.SetMetricsRecorder(recorder))
+}
+
+// Stats summarizes the API calls made by a client: how many were made, how
+// many failed, and their latency percentiles.
+type Stats = clientutil.Stats
+
+// WithStatsCollection makes the client count its API calls and sample their
+// latencies, so Stats can report them without a metrics system.
+func WithStatsCollection() ClientOption {
+	return shared(clientutil.SetStatsCollection())
+}
+
+// DefaultStatsReservoirSize is the number of call latencies WithStatsCollection
+// samples to estimate percentiles from.
+const DefaultStatsReservoirSize = clientutil.DefaultStatsReservoirSize
+
+// Stats returns the number of API calls made by the client, how many of them
+// returned an error, and their p50, p95, and p99 latencies. Without
+// WithStatsCollection it returns zero Stats.
+func (c *Client) Stats() Stats {
+	return c.base.Stats.Stats()
+}
+
+// Close closes the idle connections of HTTPClient's transport, unless it is
+// the shared http.DefaultTransport. The client remains usable afterwards.
+func (c *Client) Close() error {
+	return c.baseClient().Close()
+}
+
+// Logger receives diagnostic messages from the client, such as warnings that
+// an endpoint is deprecated. *log.Logger satisfies it.
+type Logger = clientutil.Logger
+
+// DeprecationNotice describes a response whose Deprecation or Sunset header
+// announced that its endpoint is going away.
+type DeprecationNotice = clientutil.DeprecationNotice
+
+// WithLogger sets the logger that receives the client's diagnostic messages,
+// such as a warning the first time an endpoint is reported deprecated. The
+// client logs nothing by default.
+func WithLogger(logger Logger) ClientOption {
+	return shared(clientutil.SetLogger(logger))
+}
+
+// WithRequestBodyLogging logs the JSON body of every POST, PUT, and PATCH
+// request to the WithLogger logger, or to the standard library's default
+// logger. Passwords, client secrets, and tokens are replaced with "***".
+func WithRequestBodyLogging() ClientOption {
+	return shared(clientutil.SetRequestBodyLogging())
+}
+
+// WithDeprecationCallback calls fn, once per endpoint per process, when a
+// response carries a Deprecation or Sunset header. fn must be safe for concurrent use.
+func WithDeprecationCallback(fn func(DeprecationNotice)) ClientOption {
+	return shared(clientutil.SetDeprecationCallback(fn))
+}
+
+// Clock tells the time and waits for durations to pass. Supplying a fake
+// implementation with WithClock makes time-dependent behavior testable
+// without real sleeps.
+type Clock = clientutil.Clock
+
+// WithClock replaces the system clock used for rate limiting, retries, and
+// measuring latencies. A nil clock restores the system clock.
+func WithClock(clock Clock) ClientOption {
+	return shared(clientutil.SetClock(clock))
+}
+
+// RetryPolicy configures how the client retries requests that fail
+// transiently. By default, transport errors and 429, 502, 503, and 504
+// responses are retried for idempotent requests only: those with a GET, HEAD,
+// OPTIONS, PUT, or DELETE method, or that carry an Idempotency-Key header.
+// Retryable adds retries on top of those rules.
+type RetryPolicy = clientutil.RetryPolicy
+
+// RetryPredicate reports whether a request should be retried given the response
+// of an attempt, or the error it failed with. The response body can be read in
+// full without affecting the response returned to the caller.
+type RetryPredicate = clientutil.RetryPredicate
+
+// Backoff computes the growing delays between retries
+type Backoff = clientutil.Backoff
+
+// WithRetryPolicy retries requests that fail transiently under policy,
+// waiting for the backoff delay or Retry-After between attempts. Requests
+// whose body cannot be replayed are sent once.
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return shared(clientutil.SetRetryPolicy(policy))
+}
+
+// WithRawErrorBody attaches the full response body to the RawBody field of
+// returned API errors. It is off by default because error bodies may contain
+// sensitive data.
+func WithRawErrorBody() ClientOption {
+	return shared(clientutil.SetRawErrorBody())
+}
+
+// WithRequestTimeout bounds each API call with a per-call context deadline
+// instead of the HTTP client's overall Timeout, which is cleared on a copy of
+// the HTTP client. A shorter deadline on the caller's context still applies.
+func WithRequestTimeout(d time.Duration) ClientOption {
+	return shared(clientutil.SetRequestTimeout(d))
+}
+
+// WithUploadTimeout sets how long an upload sent by UploadToURL may take when
+// its context has no deadline. A deadline on the caller's context always
+// governs instead, so long uploads can be given more time than the default.
+// Zero or less removes the limit, leaving the upload bounded only by its context.
+//
+// Parameters:
+//   - d: The maximum duration of an upload (default DefaultUploadTimeout)
+//
+// Returns:
+//   - ClientOption: A functional option to configure the client
+func WithUploadTimeout(d time.Duration) ClientOption {
 	return func(c *Client) {
-		c.tokenProvider = tp
+		c.uploadTimeout = d
 	}
 }
 
@@ -132,54 +372,59 @@ func NewClientWithOptions(baseURL string, options ...ClientOption) (*Client, err
 		return nil, err
 	}
 
+	defaultHTTPClient := client.HTTPClient
 	for _, option := range options {
 		option(client)
 	}
 
+	client.HTTPClient, client.transferTransport, err = client.base.Configure(client.HTTPClient, defaultHTTPClient)
+	if err != nil {
+		return nil, err
+	}
+
 	return client, nil
 }
 
-// newRequest creates an API request with the specified method, path, and body
-func (c *Client) newRequest(ctx context.Context, method, path string, body interface{}) (*http.Request, error) {
-	u := c.BaseURL.JoinPath(path)
+// baseClient returns the BaseClient that builds and sends c's requests, with
+// c's current BaseURL, HTTPClient, and UserAgent
+func (c *Client) baseClient() *clientutil.BaseClient {
+	b := c.base
+	b.BaseURL = c.BaseURL
+	b.HTTPClient = c.HTTPClient
+	b.UserAgent = c.UserAgent
+	return &b
+}
 
-	var buf io.ReadWriter
-	if body != nil {
-		buf = new(bytes.Buffer)
-		err := json.NewEncoder(buf).Encode(body)
-		if err != nil {
-			return nil, err
-		}
-	}
+// do sends an API request for the named client method and returns the API response
+func (c *Client) do(operation string, req *http.Request, v interface{}) (*http.Response, error) {
+	b := c.baseClient()
+	return b.Do(req, v, b.RequestOptions(operation)...)
+}
 
-	req, err := http.NewRequestWithContext(ctx, method, u.String(), buf)
+// Health checks the health status of the Storage API.
+//
+// Parameters:
+//   - ctx: Context for the API request
+//
+// Returns:
+//   - *HealthResponse: The service health status, typically containing a "status" field
+//   - error: An error if the operation fails, which can be:
+//   - apierror.ErrorResponse with codes like:
+//   - "network_error" if the connection fails
+//   - "server_error" if the API server experiences an error
+func (c *Client) Health(ctx context.Context) (*HealthResponse, error) {
+	req, err := c.baseClient().NewRequest(ctx, "GET", "/health", nil)
 	if err != nil {
 		return nil, err
 	}
 
-	if body != nil {
-		req.Header.Set("Content-Type", "application/json")
-	}
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("User-Agent", c.UserAgent)
-
-	// Add Authorization header if TokenProvider is configured
-	if c.tokenProvider != nil {
-		token, tokenErr := c.tokenProvider.GetToken(ctx)
-		if tokenErr != nil {
-			return nil, fmt.Errorf("failed to get token from provider: %w", tokenErr)
-		}
-		if token != "" {
-			req.Header.Set("Authorization", "Bearer "+token)
-		}
+	var resp HealthResponse
+	_, err = c.do("Health", req, &resp)
+	if err != nil {
+		return nil, err
 	}
 
-	return req, nil
-}
-
-// do sends an API request and returns the API response
-func (c *Client) do(req *http.Request, v interface{}) (*http.Response, error) {
-	return clientutil.ExecuteRequest(req.Context(), c.HTTPClient, req, v)
+	return &resp, nil
 }
 
 // GenerateUploadURL generates a pre-signed URL for uploading a file to storage.
@@ -198,13 +443,13 @@ func (c *Client) do(req *http.Request, v interface{}) (*http.Response, error) {
 //   - "network_error" if the connection fails
 //   - "server_error" if generating the upload URL fails
 func (c *Client) GenerateUploadURL(ctx context.Context, request *GenerateUploadURLRequest) (*GenerateUploadURLResponse, error) {
-	req, err := c.newRequest(ctx, "POST", "/generate-upload-url", request)
+	req, err := c.baseClient().NewRequest(ctx, "POST", "/generate-upload-url", request)
 	if err != nil {
 		return nil, err
 	}
 
 	var resp GenerateUploadURLResponse
-	_, err = c.do(req, &resp)
+	_, err = c.do("GenerateUploadURL", req, &resp)
 	if err != nil {
 		return nil, err
 	}
@@ -229,13 +474,13 @@ func (c *Client) GenerateUploadURL(ctx context.Context, request *GenerateUploadU
 //   - "network_error" if the connection fails
 //   - "server_error" if generating the download URL fails
 func (c *Client) GenerateDownloadURL(ctx context.Context, request *GenerateDownloadURLRequest) (*GenerateDownloadURLResponse, error) {
-	req, err := c.newRequest(ctx, "POST", "/generate-download-url", request)
+	req, err := c.baseClient().NewRequest(ctx, "POST", "/generate-download-url", request)
 	if err != nil {
 		return nil, err
 	}
 
 	var resp GenerateDownloadURLResponse
-	_, err = c.do(req, &resp)
+	_, err = c.do("GenerateDownloadURL", req, &resp)
 	if err != nil {
 		return nil, err
 	}
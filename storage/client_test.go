@@ -2,6 +2,8 @@ package storage
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
 	"fmt"
 	"net"
@@ -52,6 +54,27 @@ func TestNewClient(t *testing.T) {
 				return err != nil && err.Error() != ""
 			},
 		},
+		{
+			name:    "missing scheme",
+			baseURL: "storage.example.com",
+			wantErr: true,
+			errCheck: func(err error) bool {
+				return err.Error() == "invalid base URL: missing scheme"
+			},
+		},
+		{
+			name:    "missing host",
+			baseURL: "https:///v1",
+			wantErr: true,
+			errCheck: func(err error) bool {
+				return err.Error() == "invalid base URL: missing host"
+			},
+		},
+		{
+			name:    "valid URL with path and trailing slash",
+			baseURL: "https://storage.example.com/v1/",
+			wantErr: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -108,11 +131,90 @@ func TestClientOptions(t *testing.T) {
 		t.Errorf("client.UserAgent = %v, want %v", client.UserAgent, customUserAgent)
 	}
 
-	if client.tokenProvider != tokenProvider {
-		t.Errorf("client.tokenProvider not set correctly")
+	if client.base.TokenProvider != tokenProvider {
+		t.Errorf("client.base.TokenProvider not set correctly")
 	}
 }
 
+func TestWithConnectionPool(t *testing.T) {
+	client, err := NewClientWithOptions("https://storage.example.com", WithConnectionPool(64, 16, 32, 45*time.Second))
+	require.NoError(t, err)
+
+	transport, ok := client.HTTPClient.Transport.(*http.Transport)
+	require.True(t, ok, "Transport = %T, want *http.Transport", client.HTTPClient.Transport)
+	assert.Equal(t, 64, transport.MaxIdleConns)
+	assert.Equal(t, 16, transport.MaxIdleConnsPerHost)
+	assert.Equal(t, 32, transport.MaxConnsPerHost)
+	assert.Equal(t, 45*time.Second, transport.IdleConnTimeout)
+	assert.Equal(t, DefaultTimeout, client.HTTPClient.Timeout)
+}
+
+func TestWithConnectionPool_HTTPClientTakesPrecedence(t *testing.T) {
+	for _, order := range []string{"pool first", "client first"} {
+		t.Run(order, func(t *testing.T) {
+			custom := &http.Client{}
+			options := []ClientOption{WithConnectionPool(64, 16, 32, 45*time.Second), WithHTTPClient(custom)}
+			if order == "client first" {
+				options[0], options[1] = options[1], options[0]
+			}
+
+			client, err := NewClientWithOptions("https://storage.example.com", options...)
+			require.NoError(t, err)
+			assert.Same(t, custom, client.HTTPClient)
+			assert.Nil(t, custom.Transport)
+		})
+	}
+}
+
+func TestWithTLSConfig(t *testing.T) {
+	config := &tls.Config{ServerName: "storage.atriumn.internal"}
+	pool := x509.NewCertPool()
+
+	client, err := NewClientWithOptions("https://storage.example.com", WithTLSConfig(config), WithRootCAs(pool))
+	require.NoError(t, err)
+
+	transport, ok := client.HTTPClient.Transport.(*http.Transport)
+	require.True(t, ok, "Transport = %T, want *http.Transport", client.HTTPClient.Transport)
+	require.NotNil(t, transport.TLSClientConfig)
+	assert.Equal(t, "storage.atriumn.internal", transport.TLSClientConfig.ServerName)
+	assert.Same(t, pool, transport.TLSClientConfig.RootCAs)
+	assert.False(t, transport.TLSClientConfig.InsecureSkipVerify)
+}
+
+func TestWithTLSConfig_HTTPClientTakesPrecedence(t *testing.T) {
+	custom := &http.Client{}
+	client, err := NewClientWithOptions("https://storage.example.com",
+		WithRootCAs(x509.NewCertPool()), WithHTTPClient(custom), WithTLSConfig(&tls.Config{}))
+	require.NoError(t, err)
+	assert.Same(t, custom, client.HTTPClient)
+	assert.Nil(t, custom.Transport)
+}
+
+func TestWithInsecureSkipVerify(t *testing.T) {
+	insecure := &tls.Config{InsecureSkipVerify: true}
+
+	_, err := NewClientWithOptions("https://storage.example.com", WithTLSConfig(insecure))
+	assert.ErrorIs(t, err, ErrInsecureTLSConfig)
+
+	client, err := NewClientWithOptions("https://storage.example.com", WithTLSConfig(insecure), WithInsecureSkipVerify())
+	require.NoError(t, err)
+	assert.True(t, client.HTTPClient.Transport.(*http.Transport).TLSClientConfig.InsecureSkipVerify)
+}
+
+func TestWithUserAgentSuffix(t *testing.T) {
+	client, err := NewClientWithOptions("https://storage.example.com", WithUserAgentSuffix("my-app/2.3"))
+	require.NoError(t, err)
+
+	assert.Equal(t, DefaultUserAgent+" my-app/2.3", client.UserAgent)
+	assert.Contains(t, client.UserAgent, "atriumn-storage-client/1.0")
+	assert.Contains(t, client.UserAgent, "atriumn-sdk-go/")
+	assert.Contains(t, client.UserAgent, "my-app/2.3")
+
+	custom, err := NewClientWithOptions("https://storage.example.com", WithUserAgent("custom/1.0"), WithUserAgentSuffix("my-app/2.3"))
+	require.NoError(t, err)
+	assert.Equal(t, "custom/1.0 my-app/2.3", custom.UserAgent)
+}
+
 func TestGenerateUploadURL_Success(t *testing.T) {
 	// Create a test server
 	server, client := setupTestServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -151,6 +253,45 @@ func TestGenerateUploadURL_Success(t *testing.T) {
 	assert.Equal(t, "PUT", resp.HTTPMethod)
 }
 
+func TestGenerateUploadURLRequest_OptionalLimits(t *testing.T) {
+	data, err := json.Marshal(GenerateUploadURLRequest{Filename: "a.txt", ContentType: "text/plain"})
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "expiresIn")
+	assert.NotContains(t, string(data), "maxSizeBytes")
+
+	data, err = json.Marshal(GenerateUploadURLRequest{
+		Filename:     "a.txt",
+		ContentType:  "text/plain",
+		ExpiresIn:    300,
+		MaxSizeBytes: 5 << 20,
+	})
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"expiresIn":300`)
+	assert.Contains(t, string(data), `"maxSizeBytes":5242880`)
+}
+
+func TestGenerateUploadURL_ExpiryAndMaxSize(t *testing.T) {
+	server, client := setupTestServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, float64(300), body["expiresIn"])
+		assert.Equal(t, float64(1048576), body["maxSizeBytes"])
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintln(w, `{"uploadUrl": "https://example-bucket.s3.amazonaws.com/a.txt", "httpMethod": "PUT", "expiresIn": 300}`)
+	}))
+	defer server.Close()
+
+	resp, err := client.GenerateUploadURL(context.Background(), &GenerateUploadURLRequest{
+		Filename:     "a.txt",
+		ContentType:  "text/plain",
+		ExpiresIn:    300,
+		MaxSizeBytes: 1 << 20,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 300, resp.ExpiresIn)
+}
+
 func TestGenerateUploadURL_WithAuth(t *testing.T) {
 	expectedToken := "test-token-12345"
 	// Create a test server
@@ -171,7 +312,7 @@ func TestGenerateUploadURL_WithAuth(t *testing.T) {
 	defer server.Close()
 
 	// Add token provider to client
-	client.tokenProvider = &mockTokenProvider{token: expectedToken}
+	client.base.TokenProvider = &mockTokenProvider{token: expectedToken}
 
 	request := &GenerateUploadURLRequest{
 		Filename:    "test-file.txt",
@@ -225,7 +366,7 @@ func TestGenerateUploadURL_TokenProviderError(t *testing.T) {
 	defer server.Close()
 
 	// Add token provider that returns an error
-	client.tokenProvider = &mockTokenProvider{
+	client.base.TokenProvider = &mockTokenProvider{
 		err: fmt.Errorf("token provider failed"),
 	}
 
@@ -293,7 +434,7 @@ func TestGenerateDownloadURL_WithAuth(t *testing.T) {
 	defer server.Close()
 
 	// Add token provider to client
-	client.tokenProvider = &mockTokenProvider{token: expectedToken}
+	client.base.TokenProvider = &mockTokenProvider{token: expectedToken}
 
 	request := &GenerateDownloadURLRequest{
 		S3Key: "tenant-123/files/document.pdf",
@@ -587,7 +728,7 @@ func TestTokenProviderScenarios(t *testing.T) {
 			}))
 			defer server.Close()
 
-			client.tokenProvider = tt.tokenProvider
+			client.base.TokenProvider = tt.tokenProvider
 
 			_, err := client.GenerateUploadURL(context.Background(), &GenerateUploadURLRequest{
 				Filename:    "test.txt",
@@ -746,7 +887,7 @@ func TestURLConstruction(t *testing.T) {
 				t.Fatalf("Failed to create client: %v", err)
 			}
 
-			req, err := client.newRequest(context.Background(), "GET", tt.path, nil)
+			req, err := client.baseClient().NewRequest(context.Background(), "GET", tt.path, nil)
 			if err != nil {
 				t.Fatalf("Failed to create request: %v", err)
 			}
@@ -757,3 +898,46 @@ func TestURLConstruction(t *testing.T) {
 		})
 	}
 }
+
+func TestHealth(t *testing.T) {
+	server, client := setupTestServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "/health", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"status": "ok"}`))
+	}))
+	defer server.Close()
+
+	health, err := client.Health(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "ok", health.Status)
+}
+
+func TestHealth_ServerError(t *testing.T) {
+	server, client := setupTestServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	health, err := client.Health(context.Background())
+	require.Error(t, err)
+	assert.Nil(t, health)
+	errorResp, ok := err.(*apierror.ErrorResponse)
+	require.True(t, ok, "Expected error to be *apierror.ErrorResponse")
+	assert.Equal(t, "server_error", errorResp.ErrorCode)
+}
+
+func TestClient_UsesBaseClient(t *testing.T) {
+	client, err := NewClientWithOptions("https://storage.example.com",
+		WithUserAgent("custom-agent"),
+		WithTokenProvider(&mockTokenProvider{token: "abc"}),
+	)
+	require.NoError(t, err)
+
+	req, err := client.baseClient().NewRequest(context.Background(), "POST", "/upload-url", &GenerateUploadURLRequest{Filename: "a.txt"})
+	require.NoError(t, err)
+	assert.Equal(t, "https://storage.example.com/upload-url", req.URL.String())
+	assert.Equal(t, "custom-agent", req.Header.Get("User-Agent"))
+	assert.Equal(t, "Bearer abc", req.Header.Get("Authorization"))
+	assert.Equal(t, "application/json", req.Header.Get("Content-Type"))
+}
@@ -0,0 +1,49 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/atriumn/atriumn-sdk-go/internal/apierror"
+	"github.com/atriumn/atriumn-sdk-go/internal/clientutil"
+)
+
+// ErrorResponse is the error returned for failed API calls. Use errors.As to
+// inspect its code, HTTP status, request ID, retry hint, and field-level
+// validation errors.
+type ErrorResponse = apierror.ErrorResponse
+
+// FieldError is one entry of ErrorResponse.ValidationErrors: a request field
+// the service rejected and the reason.
+type FieldError = apierror.FieldError
+
+// ErrConflict matches, via errors.Is, any error returned for an HTTP 409 Conflict
+// response, such as an attempt to create a resource that already exists.
+var ErrConflict error = apierror.ErrConflict
+
+// ErrNotFound matches, via errors.Is, any error returned for an HTTP 404 Not
+// Found response.
+var ErrNotFound error = apierror.ErrNotFound
+
+// ErrInsecureTLSConfig is returned by NewClientWithOptions when the config
+// passed to WithTLSConfig disables certificate verification without
+// WithInsecureSkipVerify.
+var ErrInsecureTLSConfig error = clientutil.ErrInsecureTLSConfig
+
+// ErrTooManyConcurrentRequests is returned by calls made while the limit set
+// with WithMaxConcurrentRequests and FailFast is reached.
+var ErrTooManyConcurrentRequests error = clientutil.ErrTooManyConcurrentRequests
+
+// WithRequestID returns a copy of ctx that makes API calls made with it send id
+// as their X-Request-ID header instead of a generated ID. The ID sent is
+// reported in the RequestID field of returned API errors.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return clientutil.WithRequestID(ctx, id)
+}
+
+// WithAuthToken returns a copy of ctx that makes API calls made with it send
+// token as their bearer token instead of one from the client's TokenProvider,
+// for example the access token returned by the auth client's LoginUser. One
+// client can then make calls on behalf of several users.
+func WithAuthToken(ctx context.Context, token string) context.Context {
+	return clientutil.WithAuthToken(ctx, token)
+}
@@ -3,6 +3,12 @@
 // through a simple, idiomatic Go interface.
 package storage
 
+import "github.com/atriumn/atriumn-sdk-go/internal/health"
+
+// HealthResponse represents the response from the health endpoint.
+// It indicates the current operational status of the Storage service.
+type HealthResponse = health.Response
+
 // GenerateUploadURLRequest defines the request body for generating an upload URL.
 // It specifies the filename and content type of the file to be uploaded.
 type GenerateUploadURLRequest struct {
@@ -12,6 +18,10 @@ type GenerateUploadURLRequest struct {
 	ContentType string `json:"contentType"`
 	// TenantID is an optional identifier for multi-tenant applications
 	TenantID string `json:"tenantId,omitempty"` // Optional tenant ID field
+	// ExpiresIn optionally requests how many seconds the URL stays valid; zero uses the service default
+	ExpiresIn int `json:"expiresIn,omitempty"`
+	// MaxSizeBytes optionally caps the size of the upload the service will accept; zero means no SDK-requested cap
+	MaxSizeBytes int64 `json:"maxSizeBytes,omitempty"`
 }
 
 // GenerateUploadURLResponse defines the successful response body for generating an upload URL.
@@ -21,9 +31,12 @@ type GenerateUploadURLResponse struct {
 	UploadURL string `json:"uploadUrl"`
 	// S3Key is the S3 storage key for the uploaded file
 	S3Key string `json:"s3Key"` // S3 key for the uploaded file
-	// HTTPMethod is the HTTP method to use with the UploadURL (expected to be "PUT")
-	HTTPMethod string `json:"httpMethod"` // Expected: "PUT"
-	// Add other fields if the API returns them, e.g., required headers
+	// HTTPMethod is the HTTP method to use with the UploadURL, either "PUT" or "POST"
+	HTTPMethod string `json:"httpMethod"`
+	// FormFields holds the policy fields that must accompany a "POST" form upload
+	FormFields map[string]string `json:"fields,omitempty"`
+	// ExpiresIn is the number of seconds the URL is valid for, as resolved by the service, if reported
+	ExpiresIn int `json:"expiresIn,omitempty"`
 }
 
 // GenerateDownloadURLRequest defines the request body for generating a download URL.
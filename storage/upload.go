@@ -0,0 +1,164 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"sort"
+	"strings"
+
+	"github.com/atriumn/atriumn-sdk-go/internal/clientutil"
+)
+
+// UploadToURL uploads content to a pre-signed URL returned by GenerateUploadURL,
+// using the HTTP method the service chose. For "PUT" (the default when
+// HTTPMethod is empty) the content is sent as the request body. For "POST" the
+// content is sent as a multipart form upload: the FormFields come first and the
+// content last, in a "file" field, as storage services require.
+//
+// Like other pre-signed requests, it uses a plain HTTP client so that no
+// Authorization header is sent to the storage service. If ctx has no
+// deadline, the upload is bounded by the client's upload timeout (see
+// WithUploadTimeout).
+//
+// Parameters:
+//   - ctx: Context for the upload
+//   - upload: The response from GenerateUploadURL (required)
+//   - filename: The file name sent with a POST form upload
+//   - contentType: The MIME type of the content
+//   - content: The content to upload (required)
+//
+// Returns:
+//   - *http.Response: The response from the storage service, with its body closed
+//   - error: An error if the method is unsupported, the request fails, or a non-2xx status is returned
+func (c *Client) UploadToURL(ctx context.Context, upload *GenerateUploadURLResponse, filename, contentType string, content io.Reader) (*http.Response, error) {
+	// The upload timeout is a context deadline rather than an http.Client
+	// Timeout, so it never outlasts or cuts short a deadline set by the caller
+	if _, ok := ctx.Deadline(); !ok && c.uploadTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.uploadTimeout)
+		defer cancel()
+	}
+
+	var (
+		req *http.Request
+		err error
+	)
+
+	switch method := strings.ToUpper(upload.HTTPMethod); method {
+	case "", http.MethodPut:
+		req, err = http.NewRequestWithContext(ctx, http.MethodPut, upload.UploadURL, content)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create upload request: %w", err)
+		}
+		if contentType != "" {
+			req.Header.Set("Content-Type", contentType)
+		}
+		setUploadBodyLength(req, content)
+	case http.MethodPost:
+		body, formContentType := formUploadBody(upload.FormFields, filename, contentType, content)
+		req, err = http.NewRequestWithContext(ctx, http.MethodPost, upload.UploadURL, body)
+		if err != nil {
+			// Stop the goroutine writing the form, which nothing will read
+			_ = body.CloseWithError(err)
+			return nil, fmt.Errorf("failed to create upload request: %w", err)
+		}
+		req.Header.Set("Content-Type", formContentType)
+	default:
+		return nil, fmt.Errorf("unsupported upload method %q", upload.HTTPMethod)
+	}
+
+	// The request's context bounds the connection, the body write, and the response
+	standardClient := &http.Client{Transport: c.transferTransport}
+
+	resp, err := standardClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload to URL: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("upload failed with status %d: %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	return resp, nil
+}
+
+// setUploadBodyLength sets the Content-Length of a PUT whose content is an
+// *os.File or another reader of known size, so storage services that reject
+// chunked uploads accept it, and lets a seekable body be resent on a redirect.
+// http.NewRequest already does both for *bytes.Buffer, *bytes.Reader and
+// *strings.Reader.
+func setUploadBodyLength(req *http.Request, content io.Reader) {
+	if content == nil || req.GetBody != nil {
+		return
+	}
+
+	size := clientutil.ContentLength(content)
+	if size == 0 {
+		req.Body = http.NoBody
+		req.GetBody = func() (io.ReadCloser, error) { return http.NoBody, nil }
+		return
+	}
+	if size > 0 {
+		req.ContentLength = size
+	}
+
+	// The caller owns content, so the transport must not close it
+	req.Body = io.NopCloser(content)
+
+	if seeker, ok := content.(io.Seeker); ok {
+		if offset, err := seeker.Seek(0, io.SeekCurrent); err == nil {
+			req.GetBody = func() (io.ReadCloser, error) {
+				if _, err := seeker.Seek(offset, io.SeekStart); err != nil {
+					return nil, err
+				}
+				return io.NopCloser(content), nil
+			}
+		}
+	}
+}
+
+// formUploadBody streams a multipart/form-data body containing fields followed
+// by the content, and returns it with the matching Content-Type header value.
+func formUploadBody(fields map[string]string, filename, contentType string, content io.Reader) (*io.PipeReader, string) {
+	pr, pw := io.Pipe()
+	mw := multipart.NewWriter(pw)
+
+	go func() {
+		names := make([]string, 0, len(fields))
+		for name := range fields {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			if err := mw.WriteField(name, fields[name]); err != nil {
+				_ = pw.CloseWithError(err)
+				return
+			}
+		}
+
+		header := make(textproto.MIMEHeader)
+		header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="file"; filename=%q`, filename))
+		if contentType != "" {
+			header.Set("Content-Type", contentType)
+		}
+		part, err := mw.CreatePart(header)
+		if err != nil {
+			_ = pw.CloseWithError(err)
+			return
+		}
+		if _, err := io.Copy(part, content); err != nil {
+			_ = pw.CloseWithError(err)
+			return
+		}
+		_ = pw.CloseWithError(mw.Close())
+	}()
+
+	return pr, mw.FormDataContentType()
+}
@@ -0,0 +1,249 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUploadToURL_Put(t *testing.T) {
+	uploadServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "text/plain", r.Header.Get("Content-Type"))
+		assert.Empty(t, r.Header.Get("Authorization"))
+
+		body, _ := io.ReadAll(r.Body)
+		assert.Equal(t, "hello world", string(body))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer uploadServer.Close()
+
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/generate-upload-url", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"uploadUrl":"` + uploadServer.URL + `/bucket/key","s3Key":"key","httpMethod":"PUT"}`))
+	}))
+	defer apiServer.Close()
+
+	client, err := NewClientWithOptions(apiServer.URL, WithTokenProvider(&mockTokenProvider{token: "token"}))
+	require.NoError(t, err)
+
+	upload, err := client.GenerateUploadURL(context.Background(), &GenerateUploadURLRequest{
+		Filename:    "hello.txt",
+		ContentType: "text/plain",
+	})
+	require.NoError(t, err)
+
+	resp, err := client.UploadToURL(context.Background(), upload, "hello.txt", "text/plain", strings.NewReader("hello world"))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestUploadToURL_Post(t *testing.T) {
+	uploadServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+
+		mr, err := r.MultipartReader()
+		require.NoError(t, err)
+
+		var names []string
+		for {
+			part, err := mr.NextPart()
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+			names = append(names, part.FormName())
+
+			value, _ := io.ReadAll(part)
+			switch part.FormName() {
+			case "key":
+				assert.Equal(t, "uploads/hello.txt", string(value))
+			case "policy":
+				assert.Equal(t, "base64-policy", string(value))
+			case "file":
+				assert.Equal(t, "hello.txt", part.FileName())
+				assert.Equal(t, "text/plain", part.Header.Get("Content-Type"))
+				assert.Equal(t, "hello world", string(value))
+			}
+		}
+
+		// Policy fields must precede the file
+		assert.Equal(t, []string{"key", "policy", "file"}, names)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer uploadServer.Close()
+
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"uploadUrl": "` + uploadServer.URL + `",
+			"s3Key": "uploads/hello.txt",
+			"httpMethod": "POST",
+			"fields": {"key": "uploads/hello.txt", "policy": "base64-policy"}
+		}`))
+	}))
+	defer apiServer.Close()
+
+	client, err := NewClient(apiServer.URL)
+	require.NoError(t, err)
+
+	upload, err := client.GenerateUploadURL(context.Background(), &GenerateUploadURLRequest{
+		Filename:    "hello.txt",
+		ContentType: "text/plain",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "POST", upload.HTTPMethod)
+	assert.Equal(t, "base64-policy", upload.FormFields["policy"])
+
+	resp, err := client.UploadToURL(context.Background(), upload, "hello.txt", "text/plain", strings.NewReader("hello world"))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+}
+
+func TestUploadToURL_BodyLoggingOmitsFileBytes(t *testing.T) {
+	uploadServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer uploadServer.Close()
+
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"uploadUrl":"` + uploadServer.URL + `","s3Key":"k","httpMethod":"POST","fields":{"key":"k"}}`))
+	}))
+	defer apiServer.Close()
+
+	var buf bytes.Buffer
+	client, err := NewClientWithOptions(apiServer.URL, WithRequestBodyLogging(), WithLogger(log.New(&buf, "", 0)))
+	require.NoError(t, err)
+
+	upload, err := client.GenerateUploadURL(context.Background(), &GenerateUploadURLRequest{
+		Filename:    "secret.txt",
+		ContentType: "text/plain",
+	})
+	require.NoError(t, err)
+	_, err = client.UploadToURL(context.Background(), upload, "secret.txt", "text/plain", strings.NewReader("file contents"))
+	require.NoError(t, err)
+
+	// Only the JSON request for the upload URL is logged
+	assert.Equal(t, 1, strings.Count(buf.String(), "request body:"))
+	assert.Contains(t, buf.String(), `"filename":"secret.txt"`)
+	assert.NotContains(t, buf.String(), "file contents")
+}
+
+func TestUploadToURL_PutFileSetsContentLength(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "hello.txt")
+	require.NoError(t, os.WriteFile(path, []byte("hello world"), 0o600))
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer func() { _ = file.Close() }()
+
+	var bodies []string
+	uploadServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.EqualValues(t, 11, r.ContentLength)
+		assert.Empty(t, r.TransferEncoding, "the upload must not be sent chunked")
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		if r.URL.Path == "/bucket/key" {
+			http.Redirect(w, r, "/bucket/moved", http.StatusTemporaryRedirect)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer uploadServer.Close()
+
+	client, err := NewClient("https://storage.example.com")
+	require.NoError(t, err)
+
+	upload := &GenerateUploadURLResponse{UploadURL: uploadServer.URL + "/bucket/key", HTTPMethod: "PUT"}
+	_, err = client.UploadToURL(context.Background(), upload, "hello.txt", "text/plain", file)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"hello world", "hello world"}, bodies, "the redirected request must resend the whole file")
+}
+
+func TestUploadToURL_Errors(t *testing.T) {
+	client, err := NewClient("https://storage.example.com")
+	require.NoError(t, err)
+
+	_, err = client.UploadToURL(context.Background(), &GenerateUploadURLResponse{
+		UploadURL:  "https://bucket.example.com",
+		HTTPMethod: "PATCH",
+	}, "f", "text/plain", strings.NewReader("x"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unsupported upload method")
+
+	uploadServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte("Policy expired"))
+	}))
+	defer uploadServer.Close()
+
+	resp, err := client.UploadToURL(context.Background(), &GenerateUploadURLResponse{
+		UploadURL:  uploadServer.URL,
+		HTTPMethod: "POST",
+		FormFields: map[string]string{"key": "k"},
+	}, "f", "text/plain", strings.NewReader("x"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "403")
+	assert.Nil(t, resp)
+}
+
+func TestUploadToURL_PostBadURLStopsFormWriter(t *testing.T) {
+	client, err := NewClient("https://storage.example.com")
+	require.NoError(t, err)
+
+	before := runtime.NumGoroutine()
+	_, err = client.UploadToURL(context.Background(), &GenerateUploadURLResponse{
+		UploadURL:  "://bad-url",
+		HTTPMethod: "POST",
+		FormFields: map[string]string{"key": "k"},
+	}, "f", "text/plain", strings.NewReader("x"))
+	require.Error(t, err)
+
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.LessOrEqual(t, runtime.NumGoroutine(), before, "the form writer goroutine should exit")
+}
+
+func TestUploadToURL_UploadTimeout(t *testing.T) {
+	done := make(chan struct{})
+	uploadServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		select {
+		case <-time.After(100 * time.Millisecond):
+		case <-done:
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer uploadServer.Close()
+	defer close(done)
+
+	client, err := NewClientWithOptions("https://storage.example.com", WithUploadTimeout(20*time.Millisecond))
+	require.NoError(t, err)
+	upload := &GenerateUploadURLResponse{UploadURL: uploadServer.URL, HTTPMethod: "PUT"}
+
+	_, err = client.UploadToURL(context.Background(), upload, "f", "text/plain", strings.NewReader("x"))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// The caller's longer deadline replaces the upload timeout
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	resp, err := client.UploadToURL(ctx, upload, "f", "text/plain", strings.NewReader("x"))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
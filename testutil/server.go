@@ -0,0 +1,194 @@
+// Package testutil provides a fake Atriumn server, a stub token provider, and
+// failing HTTP transports for testing code built on the Atriumn SDK without
+// running the real services.
+package testutil
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// Service names an Atriumn service served by Server.
+type Service string
+
+const (
+	// ServiceAuth is the Atriumn Auth API, used by the auth client
+	ServiceAuth Service = "auth"
+
+	// ServiceStorage is the Atriumn Storage API, used by the storage client
+	ServiceStorage Service = "storage"
+
+	// ServiceIngest is the Atriumn Ingest API, used by the ingest client
+	ServiceIngest Service = "ingest"
+
+	// ServiceAI is the Atriumn AI API, used by the ai client
+	ServiceAI Service = "ai"
+)
+
+// Services lists every service served by Server.
+var Services = []Service{ServiceAuth, ServiceStorage, ServiceIngest, ServiceAI}
+
+// RecordedRequest is a request received by Server.
+type RecordedRequest struct {
+	// Service is the service the request was sent to
+	Service Service
+	// Method is the request's HTTP method
+	Method string
+	// Path is the request path relative to the service's base URL
+	Path string
+	// Query holds the request's query parameters
+	Query url.Values
+	// Header holds the request's headers
+	Header http.Header
+	// Body is the full request body
+	Body []byte
+}
+
+// Server is a fake Atriumn server. Each service is served under its own base
+// URL (see BaseURL), so one Server can back clients for all of them. Every
+// service answers GET /health with {"status":"ok"}; other endpoints answer
+// 404 Not Found until a handler is registered with Handle. Server records
+// every request it receives and is safe for concurrent use.
+type Server struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	handlers map[string]http.Handler
+	mux      *http.ServeMux
+	requests []RecordedRequest
+}
+
+// NewServer starts a fake Atriumn server that is closed when the test ends.
+//
+// Parameters:
+//   - t: The test using the server
+//
+// Returns:
+//   - *Server: A running server with health endpoints for every service
+func NewServer(t testing.TB) *Server {
+	s := &Server{handlers: make(map[string]http.Handler)}
+	for _, service := range Services {
+		s.Handle(service, "GET /health", JSONHandler(http.StatusOK, map[string]string{"status": "ok"}))
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// BaseURL returns the base URL to create service's client with.
+//
+// Parameters:
+//   - service: The service whose base URL to return
+//
+// Returns:
+//   - string: The URL of the server followed by the service's path prefix
+func (s *Server) BaseURL(service Service) string {
+	return s.URL + "/" + string(service)
+}
+
+// Handle registers handler for requests to service matching pattern, replacing
+// any handler registered for the same service and pattern. Patterns are
+// http.ServeMux patterns relative to the service's base URL, such as
+// "GET /content/{id}"; path wildcards are available from r.PathValue.
+//
+// Parameters:
+//   - service: The service the handler serves
+//   - pattern: The method and path to match, such as "POST /auth/login"
+//   - handler: The handler to call for matching requests
+func (s *Server) Handle(service Service, pattern string, handler http.Handler) {
+	method, path, ok := strings.Cut(pattern, " ")
+	if !ok {
+		method, path = "", pattern
+	}
+	key := strings.TrimSpace(method + " /" + string(service) + path)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.handlers[key] = handler
+	mux := http.NewServeMux()
+	for key, handler := range s.handlers {
+		mux.Handle(key, handler)
+	}
+	s.mux = mux
+}
+
+// Requests returns the requests received so far, in the order they arrived.
+//
+// Returns:
+//   - []RecordedRequest: A copy of the recorded requests
+func (s *Server) Requests() []RecordedRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]RecordedRequest(nil), s.requests...)
+}
+
+// serveHTTP records r and dispatches it to the handler registered for it
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	service, path, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+
+	s.mu.Lock()
+	s.requests = append(s.requests, RecordedRequest{
+		Service: Service(service),
+		Method:  r.Method,
+		Path:    "/" + path,
+		Query:   r.URL.Query(),
+		Header:  r.Header.Clone(),
+		Body:    body,
+	})
+	mux := s.mux
+	s.mu.Unlock()
+
+	if _, pattern := mux.Handler(r); pattern == "" {
+		ErrorHandler(http.StatusNotFound, "not_found", fmt.Sprintf("no handler for %s %s", r.Method, r.URL.Path)).ServeHTTP(w, r)
+		return
+	}
+	mux.ServeHTTP(w, r)
+}
+
+// JSONHandler returns a handler that answers every request with status and v
+// encoded as JSON.
+//
+// Parameters:
+//   - status: The HTTP status code to respond with
+//   - v: The value to encode as the response body (nil sends no body)
+//
+// Returns:
+//   - http.HandlerFunc: The handler
+func JSONHandler(status int, v interface{}) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		if v != nil {
+			_ = json.NewEncoder(w).Encode(v)
+		}
+	}
+}
+
+// ErrorHandler returns a handler that answers every request with status and an
+// Atriumn API error body carrying code and description.
+//
+// Parameters:
+//   - status: The HTTP status code to respond with
+//   - code: The error code, such as "not_found"
+//   - description: The human-readable error description
+//
+// Returns:
+//   - http.HandlerFunc: The handler
+func ErrorHandler(status int, code, description string) http.HandlerFunc {
+	return JSONHandler(status, map[string]string{
+		"error":             code,
+		"error_description": description,
+	})
+}
@@ -0,0 +1,190 @@
+package testutil_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/atriumn/atriumn-sdk-go/ai"
+	"github.com/atriumn/atriumn-sdk-go/auth"
+	"github.com/atriumn/atriumn-sdk-go/ingest"
+	"github.com/atriumn/atriumn-sdk-go/storage"
+	"github.com/atriumn/atriumn-sdk-go/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServer_Health(t *testing.T) {
+	server := testutil.NewServer(t)
+	ctx := context.Background()
+
+	authClient, err := auth.NewClient(server.BaseURL(testutil.ServiceAuth))
+	require.NoError(t, err)
+	storageClient, err := storage.NewClient(server.BaseURL(testutil.ServiceStorage))
+	require.NoError(t, err)
+	ingestClient, err := ingest.NewClient(server.BaseURL(testutil.ServiceIngest))
+	require.NoError(t, err)
+	aiClient, err := ai.NewClient(server.BaseURL(testutil.ServiceAI))
+	require.NoError(t, err)
+
+	authHealth, err := authClient.Health(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "ok", authHealth.Status)
+	storageHealth, err := storageClient.Health(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "ok", storageHealth.Status)
+	ingestHealth, err := ingestClient.Health(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "ok", ingestHealth.Status)
+	aiHealth, err := aiClient.Health(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "ok", aiHealth.Status)
+
+	var services []testutil.Service
+	for _, req := range server.Requests() {
+		assert.Equal(t, "/health", req.Path)
+		services = append(services, req.Service)
+	}
+	assert.Equal(t, testutil.Services, services)
+}
+
+func TestServer_Auth(t *testing.T) {
+	server := testutil.NewServer(t)
+	server.Handle(testutil.ServiceAuth, "POST /auth/login", testutil.JSONHandler(http.StatusOK, auth.TokenResponse{
+		AccessToken: "access-token",
+		TokenType:   "Bearer",
+		ExpiresIn:   3600,
+	}))
+
+	client, err := auth.NewClient(server.BaseURL(testutil.ServiceAuth))
+	require.NoError(t, err)
+
+	token, err := client.LoginUser(context.Background(), "user@example.com", "password123")
+	require.NoError(t, err)
+	assert.Equal(t, "access-token", token.AccessToken)
+
+	requests := server.Requests()
+	require.Len(t, requests, 1)
+	assert.Equal(t, http.MethodPost, requests[0].Method)
+	assert.JSONEq(t, `{"username": "user@example.com", "password": "password123"}`, string(requests[0].Body))
+}
+
+func TestServer_Storage(t *testing.T) {
+	server := testutil.NewServer(t)
+	server.Handle(testutil.ServiceStorage, "POST /generate-upload-url", testutil.JSONHandler(http.StatusOK, storage.GenerateUploadURLResponse{
+		UploadURL:  "https://uploads.example.com/file",
+		S3Key:      "tenant/file.txt",
+		HTTPMethod: http.MethodPut,
+	}))
+
+	tokens := &testutil.StubTokenProvider{Token: "storage-token"}
+	client, err := storage.NewClientWithOptions(server.BaseURL(testutil.ServiceStorage), storage.WithTokenProvider(tokens))
+	require.NoError(t, err)
+
+	resp, err := client.GenerateUploadURL(context.Background(), &storage.GenerateUploadURLRequest{
+		Filename:    "file.txt",
+		ContentType: "text/plain",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "tenant/file.txt", resp.S3Key)
+	assert.Equal(t, 1, tokens.Calls())
+
+	requests := server.Requests()
+	require.Len(t, requests, 1)
+	assert.Equal(t, "Bearer storage-token", requests[0].Header.Get("Authorization"))
+}
+
+func TestServer_Ingest(t *testing.T) {
+	server := testutil.NewServer(t)
+	server.Handle(testutil.ServiceIngest, "GET /content/{id}", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		testutil.JSONHandler(http.StatusOK, ingest.ContentItem{ID: r.PathValue("id"), Status: "COMPLETED"})(w, r)
+	}))
+
+	client, err := ingest.NewClient(server.BaseURL(testutil.ServiceIngest))
+	require.NoError(t, err)
+
+	item, err := client.GetContentItem(context.Background(), "content-123")
+	require.NoError(t, err)
+	assert.Equal(t, "content-123", item.ID)
+	assert.Equal(t, "COMPLETED", item.Status)
+
+	// Unregistered endpoints answer 404 Not Found
+	_, err = client.DeleteContentItemWithResult(context.Background(), "content-123")
+	assert.True(t, errors.Is(err, ingest.ErrNotFound))
+}
+
+func TestServer_AI(t *testing.T) {
+	server := testutil.NewServer(t)
+	server.Handle(testutil.ServiceAI, "GET /prompts/{id}", testutil.JSONHandler(http.StatusOK, ai.PromptResponse{
+		Prompt: ai.Prompt{ID: "prompt-1", Name: "greeting", Template: "Hello {{name}}"},
+	}))
+
+	client, err := ai.NewClient(server.BaseURL(testutil.ServiceAI))
+	require.NoError(t, err)
+
+	prompt, err := client.GetPrompt(context.Background(), "prompt-1")
+	require.NoError(t, err)
+	assert.Equal(t, "greeting", prompt.Name)
+}
+
+func TestServer_HandleReplaces(t *testing.T) {
+	server := testutil.NewServer(t)
+	server.Handle(testutil.ServiceAuth, "GET /health", testutil.ErrorHandler(http.StatusServiceUnavailable, "server_error", "down for maintenance"))
+
+	client, err := auth.NewClient(server.BaseURL(testutil.ServiceAuth))
+	require.NoError(t, err)
+
+	_, err = client.Health(context.Background())
+	var apiErr *auth.ErrorResponse
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, "server_error", apiErr.ErrorCode)
+	assert.Equal(t, "down for maintenance", apiErr.Description)
+}
+
+func TestStubTokenProvider_Error(t *testing.T) {
+	server := testutil.NewServer(t)
+	tokenErr := errors.New("no credentials")
+
+	client, err := ingest.NewClientWithOptions(server.BaseURL(testutil.ServiceIngest),
+		ingest.WithTokenProvider(&testutil.StubTokenProvider{Err: tokenErr}))
+	require.NoError(t, err)
+
+	_, err = client.Health(context.Background())
+	assert.ErrorIs(t, err, tokenErr)
+	assert.Empty(t, server.Requests())
+}
+
+func TestStubTokenProvider_Invalidate(t *testing.T) {
+	server := testutil.NewServer(t)
+	server.Handle(testutil.ServiceAI, "GET /health", testutil.ErrorHandler(http.StatusUnauthorized, "unauthorized", "token expired"))
+
+	tokens := &testutil.StubTokenProvider{Token: "stale"}
+	client, err := ai.NewClientWithOptions(server.BaseURL(testutil.ServiceAI), ai.WithTokenProvider(tokens), ai.WithRefreshOn401())
+	require.NoError(t, err)
+
+	_, err = client.Health(context.Background())
+	assert.Error(t, err)
+	assert.Equal(t, 1, tokens.Invalidations())
+	assert.Equal(t, 2, tokens.Calls())
+}
+
+func TestErrorTransport(t *testing.T) {
+	client, err := storage.NewClientWithOptions("https://storage.example.com",
+		storage.WithHTTPClient(&http.Client{Transport: &testutil.ErrorTransport{}}))
+	require.NoError(t, err)
+
+	_, err = client.Health(context.Background())
+	var apiErr *storage.ErrorResponse
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, "network_error", apiErr.ErrorCode)
+}
+
+func TestBodyReadErrorTransport(t *testing.T) {
+	client, err := ingest.NewClientWithOptions("https://ingest.example.com",
+		ingest.WithHTTPClient(&http.Client{Transport: &testutil.BodyReadErrorTransport{}}))
+	require.NoError(t, err)
+
+	_, err = client.Health(context.Background())
+	assert.Error(t, err)
+}
@@ -0,0 +1,53 @@
+package testutil
+
+import (
+	"context"
+	"sync"
+)
+
+// StubTokenProvider is a token provider that returns a fixed token or error.
+// It satisfies the TokenProvider interfaces of the ingest, storage, and ai
+// packages and is safe for concurrent use.
+type StubTokenProvider struct {
+	// Token is returned by GetToken when Err is nil
+	Token string
+	// Err, if set, is returned by GetToken instead of a token
+	Err error
+
+	mu          sync.Mutex
+	calls       int
+	invalidated int
+}
+
+// GetToken returns Err if it is set, and Token otherwise.
+func (p *StubTokenProvider) GetToken(ctx context.Context) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.calls++
+	if p.Err != nil {
+		return "", p.Err
+	}
+	return p.Token, nil
+}
+
+// Invalidate records that a client discarded the token, as clients created
+// with WithRefreshOn401 do when the service rejects it.
+func (p *StubTokenProvider) Invalidate() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.invalidated++
+}
+
+// Calls returns how many times GetToken has been called.
+func (p *StubTokenProvider) Calls() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.calls
+}
+
+// Invalidations returns how many times Invalidate has been called.
+func (p *StubTokenProvider) Invalidations() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.invalidated
+}
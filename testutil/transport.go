@@ -0,0 +1,75 @@
+package testutil
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// ErrSimulated is the error returned by ErrorTransport and
+// BodyReadErrorTransport when no other error is set.
+var ErrSimulated = errors.New("simulated transport error")
+
+// ErrorTransport is an http.RoundTripper whose requests all fail with Err,
+// simulating a network failure. Use it as the Transport of an HTTP client
+// passed to a client's WithHTTPClient option.
+type ErrorTransport struct {
+	// Err is the error every request fails with (default ErrSimulated)
+	Err error
+}
+
+// RoundTrip returns the transport's error without sending req.
+func (t *ErrorTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		_ = req.Body.Close()
+	}
+	if t.Err != nil {
+		return nil, t.Err
+	}
+	return nil, ErrSimulated
+}
+
+// BodyReadErrorTransport is an http.RoundTripper that answers every request
+// with StatusCode and a body whose reads fail with Err, simulating a
+// connection dropped while the response is read.
+type BodyReadErrorTransport struct {
+	// StatusCode is the status of the responses (default 200 OK)
+	StatusCode int
+	// Err is the error reading the body fails with (default ErrSimulated)
+	Err error
+}
+
+// RoundTrip returns a response with a failing body without sending req.
+func (t *BodyReadErrorTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		_ = req.Body.Close()
+	}
+	status := t.StatusCode
+	if status == 0 {
+		status = http.StatusOK
+	}
+	err := t.Err
+	if err == nil {
+		err = ErrSimulated
+	}
+	header := make(http.Header)
+	header.Set("Content-Type", "application/json")
+	return &http.Response{
+		StatusCode: status,
+		Status:     fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		Header:     header,
+		Body:       io.NopCloser(io.MultiReader(strings.NewReader(`{"`), errReader{err})),
+		Request:    req,
+	}, nil
+}
+
+// errReader is an io.Reader whose reads always fail with err
+type errReader struct {
+	err error
+}
+
+func (r errReader) Read(p []byte) (int, error) {
+	return 0, r.err
+}